@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultBaseURL = "http://localhost:8080"
+
+// Config is the whctl config file, holding a profile per server.
+type Config struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+type Profile struct {
+	BaseURL string `yaml:"base_url"`
+	Token   string `yaml:"token,omitempty"`
+}
+
+// defaultConfigPath is $XDG_CONFIG_HOME/whctl/config.yaml or its equivalent on
+// the platform.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "whctl.yaml"
+	}
+	return filepath.Join(dir, "whctl", "config.yaml")
+}
+
+// loadConfig reads the config file; a missing file is an empty config.
+func loadConfig(path string) (*Config, error) {
+	conf := &Config{Profiles: map[string]Profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return conf, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if conf.Profiles == nil {
+		conf.Profiles = map[string]Profile{}
+	}
+	return conf, nil
+}
+
+// save writes the config readable by the owner only, as it holds tokens.
+func (c *Config) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func newConfigCmd(app *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage server profiles",
+	}
+
+	var profile Profile
+	setProfile := &cobra.Command{
+		Use:   "set-profile NAME",
+		Short: "Create or update a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadConfig(app.configPath)
+			if err != nil {
+				return err
+			}
+			p, ok := conf.Profiles[args[0]]
+			if !ok {
+				p.BaseURL = defaultBaseURL
+			}
+			if cmd.Flags().Changed("base-url") {
+				p.BaseURL = profile.BaseURL
+			}
+			if cmd.Flags().Changed("token") {
+				p.Token = profile.Token
+			}
+			conf.Profiles[args[0]] = p
+			if conf.CurrentProfile == "" {
+				conf.CurrentProfile = args[0]
+			}
+			return conf.save(app.configPath)
+		},
+	}
+	setProfile.Flags().StringVar(&profile.BaseURL, "base-url", defaultBaseURL, "base URL of the API")
+	setProfile.Flags().StringVar(&profile.Token, "token", "", "bearer token sent to the API")
+
+	useProfile := &cobra.Command{
+		Use:               "use-profile NAME",
+		Short:             "Set the profile used by default",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: app.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadConfig(app.configPath)
+			if err != nil {
+				return err
+			}
+			if _, ok := conf.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q does not exist", args[0])
+			}
+			conf.CurrentProfile = args[0]
+			return conf.save(app.configPath)
+		},
+	}
+
+	deleteProfile := &cobra.Command{
+		Use:               "delete-profile NAME",
+		Short:             "Delete a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: app.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadConfig(app.configPath)
+			if err != nil {
+				return err
+			}
+			if _, ok := conf.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q does not exist", args[0])
+			}
+			delete(conf.Profiles, args[0])
+			if conf.CurrentProfile == args[0] {
+				conf.CurrentProfile = ""
+			}
+			return conf.save(app.configPath)
+		},
+	}
+
+	listProfiles := &cobra.Command{
+		Use:   "list",
+		Short: "List profiles; tokens are not shown",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadConfig(app.configPath)
+			if err != nil {
+				return err
+			}
+			type profileRow struct {
+				Name     string `json:"name"`
+				BaseURL  string `json:"base_url"`
+				HasToken bool   `json:"has_token"`
+				Current  bool   `json:"current"`
+			}
+			rows := make([]profileRow, 0, len(conf.Profiles))
+			for name, p := range conf.Profiles {
+				rows = append(rows, profileRow{name, p.BaseURL, p.Token != "", name == conf.CurrentProfile})
+			}
+			sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+
+			return app.print(rows, []string{"CURRENT", "NAME", "BASE URL", "TOKEN"}, func(add func(...any)) {
+				for _, r := range rows {
+					current, token := "", ""
+					if r.Current {
+						current = "*"
+					}
+					if r.HasToken {
+						token = "set"
+					}
+					add(current, r.Name, r.BaseURL, token)
+				}
+			})
+		},
+	}
+
+	cmd.AddCommand(setProfile, useProfile, deleteProfile, listProfiles)
+	return cmd
+}
+
+func (a *app) completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	conf, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, 0, len(conf.Profiles))
+	for name := range conf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+func newHealthCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "health",
+		Short: "Show the health of the server and its dependencies",
+		Long:  "Show the health of the server and its dependencies; exits with status 1 when the server is not up.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			report, err := c.Health(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(report.Checks))
+			for name := range report.Checks {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			err = a.print(report, []string{"CHECK", "STATUS", "LATENCY (MS)", "ERROR"}, func(add func(...any)) {
+				status := report.Status
+				if report.ShuttingDown {
+					status += " (shutting down)"
+				}
+				add("server", status, "", "")
+				for _, name := range names {
+					r := report.Checks[name]
+					add(name, r.Status, r.LatencyMS, r.Error)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if report.Status != "up" {
+				return errors.New("server is " + report.Status)
+			}
+			return nil
+		},
+	}
+}
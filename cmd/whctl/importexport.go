@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Gen1usBruh/warehouse-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// productColumns are the CSV columns of exported products; imports need a
+// header row and may leave out any column but name, description, price and
+// currency.
+var productColumns = []string{"id", "name", "description", "price", "currency", "quantity",
+	"base_unit", "lot_tracked", "serialized", "bin_location", "category"}
+
+func newProductExportCmd(a *app) *cobra.Command {
+	var format, file string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all products as JSON or CSV",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != formatJSON && format != formatCSV {
+				return fmt.Errorf("invalid format %q: must be json or csv", format)
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			products, err := c.ListProducts(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := a.out
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if format == formatCSV {
+				return writeProductsCSV(w, products)
+			}
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(products)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", formatJSON, "export format: json or csv")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write instead of stdout")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{formatJSON, formatCSV}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func newProductImportCmd(a *app) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Create the products of a JSON or CSV file",
+		Long: `Create a product for every entry of a JSON array or CSV file, such as one
+written by "whctl product export". IDs in the file are ignored. The format
+follows the file extension unless --format is given; "-" reads stdin in the
+given format. The import stops at the first product the API rejects.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				format = strings.TrimPrefix(filepath.Ext(args[0]), ".")
+			}
+			if format != formatJSON && format != formatCSV {
+				return fmt.Errorf("unknown format of %s: use --format json or csv", args[0])
+			}
+
+			r := io.Reader(os.Stdin)
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+			var (
+				products []client.ProductInput
+				err      error
+			)
+			if format == formatCSV {
+				products, err = readProductsCSV(r)
+			} else {
+				err = json.NewDecoder(r).Decode(&products)
+			}
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", args[0], err)
+			}
+
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for i, p := range products {
+				id, err := c.CreateProduct(cmd.Context(), p)
+				if err != nil {
+					return fmt.Errorf("product %d (%s): %w; %d of %d imported", i+1, p.Name, err, i, len(products))
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "created %d %s\n", id, p.Name)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d products imported\n", len(products))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", "file format: json or csv")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]string{formatJSON, formatCSV}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func writeProductsCSV(w io.Writer, products []client.Product) error {
+	cw := csv.NewWriter(w)
+	cw.Write(productColumns)
+	for _, p := range products {
+		cw.Write([]string{
+			strconv.Itoa(int(p.ID)), p.Name, p.Description, p.Price.Decimal(), p.Price.Currency,
+			strconv.Itoa(int(p.Quantity)), p.BaseUnit, strconv.FormatBool(p.LotTracked),
+			strconv.FormatBool(p.Serialized), p.BinLocation, p.Category,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readProductsCSV(r io.Reader) ([]client.ProductInput, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, required := range []string{"name", "description", "price", "currency"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	products := make([]client.ProductInput, 0, len(records)-1)
+	for n, record := range records[1:] {
+		line := n + 2
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		parseBool := func(column string) (bool, error) {
+			s := get(column)
+			if s == "" {
+				return false, nil
+			}
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				return false, fmt.Errorf("line %d: invalid %s %q", line, column, s)
+			}
+			return v, nil
+		}
+
+		p := client.ProductInput{
+			Name:        get("name"),
+			Description: get("description"),
+			BaseUnit:    get("base_unit"),
+			BinLocation: get("bin_location"),
+			Category:    get("category"),
+		}
+		if p.Price, err = client.ParseMoney(get("price"), strings.ToUpper(get("currency"))); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if s := get("quantity"); s != "" {
+			q, err := strconv.ParseInt(s, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quantity %q", line, s)
+			}
+			p.Quantity = int32(q)
+		}
+		if p.LotTracked, err = parseBool("lot_tracked"); err != nil {
+			return nil, err
+		}
+		if p.Serialized, err = parseBool("serialized"); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, nil
+}
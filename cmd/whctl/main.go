@@ -0,0 +1,101 @@
+// Command whctl is a command-line client and admin tool for the warehouse API.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Gen1usBruh/warehouse-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// app holds the global flags shared by the subcommands.
+type app struct {
+	configPath string
+	profile    string
+	baseURL    string
+	token      string
+	output     string
+
+	out io.Writer
+}
+
+func main() {
+	if err := newRootCmd(os.Stdout).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCmd(out io.Writer) *cobra.Command {
+	a := &app{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "whctl",
+		Short: "Manage the warehouse from the command line",
+		Long: `whctl calls the warehouse REST API.
+
+The server is taken from --base-url and --token, the WHCTL_BASE_URL and
+WHCTL_TOKEN environment variables, or the selected profile of the config file,
+in that order. Profiles are managed with "whctl config".`,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch a.output {
+			case outputTable, outputJSON, outputYAML:
+				return nil
+			}
+			return fmt.Errorf("invalid output %q: must be one of table, json, yaml", a.output)
+		},
+	}
+	cmd.SetOut(out)
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", defaultConfigPath(), "config file")
+	flags.StringVarP(&a.profile, "profile", "p", "", "profile to use instead of the current one")
+	flags.StringVar(&a.baseURL, "base-url", "", "base URL of the API")
+	flags.StringVar(&a.token, "token", "", "bearer token sent to the API")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("profile", a.completeProfiles)
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+		[]string{outputTable, outputJSON, outputYAML}, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.AddCommand(
+		newProductCmd(a),
+		newStockCmd(a),
+		newRatesCmd(a),
+		newHealthCmd(a),
+		newConfigCmd(a),
+	)
+	return cmd
+}
+
+// client returns an API client for the selected server.
+func (a *app) client() (*client.Client, error) {
+	conf, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name := a.profile
+	if name == "" {
+		name = conf.CurrentProfile
+	}
+	profile, ok := conf.Profiles[name]
+	if a.profile != "" && !ok {
+		return nil, fmt.Errorf("profile %q does not exist", a.profile)
+	}
+
+	baseURL := first(a.baseURL, os.Getenv("WHCTL_BASE_URL"), profile.BaseURL, defaultBaseURL)
+	token := first(a.token, os.Getenv("WHCTL_TOKEN"), profile.Token)
+	return client.New(baseURL, client.WithToken(token), client.WithUserAgent("whctl"))
+}
+
+// first returns the first non-empty value.
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+var testProducts = []client.Product{
+	{ID: 1, Name: "Office chair", Description: "Black", Price: mustMoney("129.90", "USD"), Quantity: 4, BaseUnit: "pcs", Category: "Furniture"},
+	{ID: 2, Name: "Desk", Description: "Oak, 160 cm", Price: mustMoney("350", "EUR"), Quantity: 1, BaseUnit: "pcs", BinLocation: "B-01"},
+}
+
+func mustMoney(amount, currency string) client.Money {
+	m, err := client.ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// run executes whctl against server with a config file in a temporary directory.
+func run(t *testing.T, server *httptest.Server, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := newRootCmd(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"--config", filepath.Join(t.TempDir(), "config.yaml"), "--base-url", server.URL}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestProductList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/products", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{"data": testProducts})
+	}))
+	defer server.Close()
+
+	out, err := run(t, server, "--token", "secret", "product", "list")
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"ID  NAME          PRICE       QUANTITY  UNIT  CATEGORY   BIN\n"+
+		"1   Office chair  129.90 USD  4         pcs   Furniture  \n"+
+		"2   Desk          350.00 EUR  1         pcs              B-01\n", out)
+
+	out, err = run(t, server, "--token", "secret", "product", "list", "-o", "json")
+	assert.NoError(t, err)
+	var products []client.Product
+	assert.NoError(t, json.Unmarshal([]byte(out), &products))
+	assert.Equal(t, testProducts, products)
+
+	out, err = run(t, server, "--token", "secret", "product", "list", "-o", "yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "- id: 1\n  name: Office chair\n  description: Black\n  price:\n    amount: \"129.90\"\n    currency: USD\n")
+}
+
+func TestProductGet_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"error":"Product not found","errorCode":404}`))
+	}))
+	defer server.Close()
+
+	_, err := run(t, server, "product", "get", "7")
+	assert.True(t, client.IsNotFound(err))
+	assert.EqualError(t, err, "warehouse api: 404 Product not found")
+
+	_, err = run(t, server, "product", "get", "x")
+	assert.EqualError(t, err, `invalid ID "x"`)
+}
+
+func TestProductsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, writeProductsCSV(&buf, testProducts))
+
+	products, err := readProductsCSV(&buf)
+	assert.NoError(t, err)
+	if assert.Len(t, products, 2) {
+		assert.Equal(t, productInput(testProducts[0]), products[0])
+		assert.Equal(t, productInput(testProducts[1]), products[1])
+	}
+
+	_, err = readProductsCSV(bytes.NewBufferString("name,price,currency\nDesk,10,USD\n"))
+	assert.EqualError(t, err, `missing column "description"`)
+
+	_, err = readProductsCSV(bytes.NewBufferString("name,description,price,currency\nDesk,Oak,10.123,USD\n"))
+	assert.ErrorContains(t, err, "line 2: invalid amount")
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// print writes v as JSON or YAML, or as a table with the given headers whose
+// rows are added by rows.
+func (a *app) print(v any, headers []string, rows func(add func(...any))) error {
+	switch a.output {
+	case outputJSON:
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case outputYAML:
+		return writeYAML(a.out, v)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	rows(func(cells ...any) {
+		s := make([]string, len(cells))
+		for i, cell := range cells {
+			s[i] = fmt.Sprint(cell)
+		}
+		fmt.Fprintln(w, strings.Join(s, "\t"))
+	})
+	return w.Flush()
+}
+
+// writeYAML converts v through JSON, so the YAML has the same field names and
+// formats (such as money amounts) as the API.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle drops the flow style and quoting the nodes got from the JSON input.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Gen1usBruh/warehouse-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var productHeaders = []string{"ID", "NAME", "PRICE", "QUANTITY", "UNIT", "CATEGORY", "BIN"}
+
+func productRow(add func(...any), p client.Product) {
+	add(p.ID, p.Name, p.Price, p.Quantity, p.BaseUnit, p.Category, p.BinLocation)
+}
+
+func newProductCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "product",
+		Aliases: []string{"products"},
+		Short:   "Manage products",
+	}
+	cmd.AddCommand(
+		newProductListCmd(a),
+		newProductGetCmd(a),
+		newProductCreateCmd(a),
+		newProductUpdateCmd(a),
+		newProductDeleteCmd(a),
+		newProductImportCmd(a),
+		newProductExportCmd(a),
+	)
+	return cmd
+}
+
+func newProductListCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all products",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			products, err := c.ListProducts(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return a.print(products, productHeaders, func(add func(...any)) {
+				for _, p := range products {
+					productRow(add, p)
+				}
+			})
+		},
+	}
+}
+
+func newProductGetCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Show a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			p, err := c.GetProduct(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			return a.print(p, productHeaders, func(add func(...any)) {
+				productRow(add, p)
+			})
+		},
+	}
+}
+
+// productFlags are the product fields set from flags; update only changes the
+// fields whose flag is given.
+type productFlags struct {
+	in       client.ProductInput
+	price    string
+	currency string
+}
+
+func (f *productFlags) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.in.Name, "name", "", "product name")
+	flags.StringVar(&f.in.Description, "description", "", "product description")
+	flags.StringVar(&f.price, "price", "", "price as a decimal, e.g. 12.50")
+	flags.StringVar(&f.currency, "currency", "USD", "currency of the price")
+	flags.Int32Var(&f.in.Quantity, "quantity", 0, "opening stock (create only)")
+	flags.StringVar(&f.in.BaseUnit, "base-unit", "", "unit stock is kept in (create only)")
+	flags.BoolVar(&f.in.LotTracked, "lot-tracked", false, "keep stock per lot")
+	flags.BoolVar(&f.in.Serialized, "serialized", false, "keep stock per serial number")
+	flags.StringVar(&f.in.BinLocation, "bin", "", "storage bin, e.g. A-03-2")
+	flags.StringVar(&f.in.Category, "category", "", "product category")
+}
+
+// apply copies the given flags onto in.
+func (f *productFlags) apply(cmd *cobra.Command, in *client.ProductInput) error {
+	flags := cmd.Flags()
+	set := func(name string, apply func()) {
+		if flags.Changed(name) {
+			apply()
+		}
+	}
+	set("name", func() { in.Name = f.in.Name })
+	set("description", func() { in.Description = f.in.Description })
+	set("quantity", func() { in.Quantity = f.in.Quantity })
+	set("base-unit", func() { in.BaseUnit = f.in.BaseUnit })
+	set("lot-tracked", func() { in.LotTracked = f.in.LotTracked })
+	set("serialized", func() { in.Serialized = f.in.Serialized })
+	set("bin", func() { in.BinLocation = f.in.BinLocation })
+	set("category", func() { in.Category = f.in.Category })
+
+	if flags.Changed("price") || flags.Changed("currency") {
+		amount, currency := f.price, strings.ToUpper(f.currency)
+		if !flags.Changed("price") {
+			amount = in.Price.Decimal()
+		}
+		price, err := client.ParseMoney(amount, currency)
+		if err != nil {
+			return err
+		}
+		in.Price = price
+	}
+	return nil
+}
+
+func newProductCreateCmd(a *app) *cobra.Command {
+	var f productFlags
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a product",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var in client.ProductInput
+			if err := f.apply(cmd, &in); err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			id, err := c.CreateProduct(cmd.Context(), in)
+			if err != nil {
+				return err
+			}
+			return a.print(map[string]int32{"id": id}, []string{"ID"}, func(add func(...any)) {
+				add(id)
+			})
+		},
+	}
+	f.register(cmd)
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("description")
+	cmd.MarkFlagRequired("price")
+	return cmd
+}
+
+func newProductUpdateCmd(a *app) *cobra.Command {
+	var f productFlags
+	cmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Change the given fields of a product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			p, err := c.GetProduct(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			in := productInput(p)
+			if err := f.apply(cmd, &in); err != nil {
+				return err
+			}
+			return c.UpdateProduct(cmd.Context(), id, in)
+		},
+	}
+	f.register(cmd)
+	return cmd
+}
+
+func newProductDeleteCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID...",
+		Short: "Delete products",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for _, arg := range args {
+				id, err := parseID(arg)
+				if err != nil {
+					return err
+				}
+				if err := c.DeleteProduct(cmd.Context(), id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// productInput returns the fields of p that can be sent back to the API.
+func productInput(p client.Product) client.ProductInput {
+	return client.ProductInput{
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Quantity:    p.Quantity,
+		BaseUnit:    p.BaseUnit,
+		LotTracked:  p.LotTracked,
+		Serialized:  p.Serialized,
+		BinLocation: p.BinLocation,
+		Category:    p.Category,
+	}
+}
+
+func parseID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID %q", s)
+	}
+	return int32(id), nil
+}
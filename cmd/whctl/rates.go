@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newRatesCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rates",
+		Aliases: []string{"exchange-rates"},
+		Short:   "List and import exchange rates",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List exchange rates, newest first per currency pair",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			rates, err := c.ListExchangeRates(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return a.print(rates, []string{"BASE", "QUOTE", "RATE", "VALID FROM"}, func(add func(...any)) {
+				for _, r := range rates {
+					add(r.Base, r.Quote, r.Rate, r.ValidFrom.Format(time.RFC3339))
+				}
+			})
+		},
+	}
+
+	importRates := &cobra.Command{
+		Use:   "import FILE",
+		Short: `Import exchange rates from a CSV file with the columns base,quote,rate,valid_from; "-" reads stdin`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := io.Reader(os.Stdin)
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			n, err := c.ImportExchangeRates(cmd.Context(), r)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d exchange rates imported\n", n)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(list, importRates)
+	return cmd
+}
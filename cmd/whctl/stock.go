@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var movementHeaders = []string{"ID", "TYPE", "QUANTITY", "ENTERED", "WAREHOUSE", "REFERENCE", "CREATED"}
+
+func movementRow(add func(...any), m client.StockMovement) {
+	add(m.ID, m.Type, m.Quantity, m.EnteredQuantity+" "+m.EnteredUnit, m.WarehouseID, m.Reference,
+		m.CreatedAt.Local().Format(time.DateTime))
+}
+
+func newStockCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stock",
+		Short: "Receive, issue and adjust stock",
+	}
+	cmd.AddCommand(
+		newMovementCmd(a, "receive PRODUCT_ID QUANTITY", "Receive stock of a product", (*client.Client).ReceiveStock),
+		newMovementCmd(a, "issue PRODUCT_ID QUANTITY", "Issue stock of a product", (*client.Client).IssueStock),
+		newMovementCmd(a, "adjust PRODUCT_ID QUANTITY", "Correct the stock of a product by a signed quantity, e.g. -- -3",
+			(*client.Client).AdjustStock),
+		newMovementsCmd(a),
+	)
+	return cmd
+}
+
+type recordFunc func(c *client.Client, ctx context.Context, productID int32, in client.MovementInput) (client.StockMovement, error)
+
+func newMovementCmd(a *app, use, short string, record recordFunc) *cobra.Command {
+	var (
+		in  client.MovementInput
+		lot client.LotInput
+	)
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			in.Quantity = args[1]
+			if lot.Number != "" {
+				in.Lot = &lot
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			m, err := record(c, cmd.Context(), id, in)
+			if err != nil {
+				return err
+			}
+			return a.print(m, movementHeaders, func(add func(...any)) {
+				movementRow(add, m)
+			})
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&in.Unit, "unit", "", "unit of the quantity; the product base unit by default")
+	flags.StringVar(&in.Reference, "reference", "", "reference such as a delivery note")
+	flags.Int32Var(&in.WarehouseID, "warehouse", 0, "warehouse ID; the default warehouse if omitted")
+	flags.StringSliceVar(&in.Serials, "serial", nil, "serial number of a unit moved; repeat for every unit")
+	flags.StringVar(&lot.Number, "lot", "", "lot number of lot-tracked products")
+	flags.StringVar(&lot.ManufacturedAt, "manufactured", "", "manufacturing date of a new lot (YYYY-MM-DD)")
+	flags.StringVar(&lot.ExpiresAt, "expires", "", "expiry date of a new lot (YYYY-MM-DD)")
+	return cmd
+}
+
+func newMovementsCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "movements PRODUCT_ID",
+		Short: "List the stock movements of a product, newest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			movements, err := c.ListStockMovements(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			return a.print(movements, movementHeaders, func(add func(...any)) {
+				for _, m := range movements {
+					movementRow(add, m)
+				}
+			})
+		},
+	}
+}
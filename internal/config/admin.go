@@ -0,0 +1,7 @@
+package config
+
+type Admin struct {
+	// Address is where the admin listener serves /metrics, e.g. "localhost:9100",
+	// apart from the public API; it is not started when empty.
+	Address string `env:"ADMIN_ADDRESS"`
+}
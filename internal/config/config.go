@@ -12,7 +12,18 @@ func New() (*Config, error) {
 }
 
 type Config struct {
-	Database Database
-	Server   Server
-	Logger   Logger
+	Database    Database
+	Server      Server
+	GRPC        GRPC
+	GraphQL     GraphQL
+	Admin       Admin
+	Tracing     Tracing
+	Logger      Logger
+	Pricing     Pricing
+	Purchasing  Purchasing
+	Counting    Counting
+	Valuation   Valuation
+	Forecasting Forecasting
+	Idempotency Idempotency
+	Reporting   Reporting
 }
\ No newline at end of file
@@ -0,0 +1,7 @@
+package config
+
+type Counting struct {
+	// ApprovalThreshold is the count variance, in percent of the system quantity,
+	// above which a count needs approval before it is posted.
+	ApprovalThreshold float64 `env:"COUNT_APPROVAL_THRESHOLD" envDefault:"5"`
+}
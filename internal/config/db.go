@@ -11,4 +11,5 @@ type Database struct {
 	MaxIdleConnections       int32  `env:"POSTGRES_MAX_IDLE_CONNS"                envDefault:"10"`
 	MaxConnIdleTimeInSeconds int32  `env:"POSTGRES_MAX_CONN_IDLE_TIME_IN_SECONDS" envDefault:"300"`  // 5 minutes
 	MaxConnLifeTimeInSeconds int32  `env:"POSTGRES_MAX_CONN_LIFETIME_IN_SECONDS"  envDefault:"1500"` // 25 minutes
+	AutoMigrate              bool   `env:"POSTGRES_AUTO_MIGRATE"                  envDefault:"false"` // apply pending migrations on start
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+package config
+
+type Forecasting struct {
+	// Method is moving_average, exponential_smoothing or seasonal_naive.
+	Method string `env:"FORECAST_METHOD" envDefault:"moving_average"`
+	// Demand is counted in periods of PeriodDays days, HistoryPeriods back.
+	PeriodDays     int     `env:"FORECAST_PERIOD_DAYS"     envDefault:"7"`
+	HistoryPeriods int     `env:"FORECAST_HISTORY_PERIODS" envDefault:"52"`
+	Window         int     `env:"FORECAST_WINDOW"          envDefault:"4"`
+	Alpha          float64 `env:"FORECAST_ALPHA"           envDefault:"0.3"`
+	SeasonLength   int     `env:"FORECAST_SEASON_LENGTH"   envDefault:"52"`
+	// ServiceLevel is the wanted chance of not running out, between 0 and 1.
+	ServiceLevel        float64 `env:"FORECAST_SERVICE_LEVEL"          envDefault:"0.95"`
+	ReviewDays          int     `env:"FORECAST_REVIEW_DAYS"            envDefault:"7"`
+	DefaultLeadTimeDays int     `env:"FORECAST_DEFAULT_LEAD_TIME_DAYS" envDefault:"14"`
+}
@@ -0,0 +1,8 @@
+package config
+
+type GraphQL struct {
+	// MaxComplexity is the highest cost of a GraphQL operation: every field costs
+	// one, and fields below a list count once per requested item.
+	MaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
+	MaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"10"`
+}
@@ -0,0 +1,7 @@
+package config
+
+type GRPC struct {
+	// Address is where the gRPC API listens, e.g. "localhost:9090"; the gRPC
+	// server is not started when it is empty.
+	Address string `env:"GRPC_ADDRESS"`
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+type Idempotency struct {
+	// TTL is how long the response to a request with an Idempotency-Key header
+	// is kept and replayed to retries.
+	TTL time.Duration `env:"IDEMPOTENCY_TTL"              envDefault:"24h"`
+	// LockTimeout frees the key of a request that was never answered, e.g.
+	// because the server stopped while serving it.
+	LockTimeout     time.Duration `env:"IDEMPOTENCY_LOCK_TIMEOUT"     envDefault:"1m"`
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" envDefault:"1h"`
+}
@@ -1,5 +1,6 @@
 package config
 
 type Logger struct {
-	Level string `env:"LOG_LEVEL" envDefault:"info"`
-}
\ No newline at end of file
+	Level  string `env:"LOG_LEVEL" envDefault:"info"`
+	Format string `env:"LOG_FORMAT" envDefault:"json"`
+}
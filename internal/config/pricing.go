@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type Pricing struct {
+	Rounding          string        `env:"PRICE_ROUNDING"           envDefault:"half_up"`
+	ExchangeRatesFile string        `env:"EXCHANGE_RATES_FILE"`
+	SchedulerInterval time.Duration `env:"PRICE_SCHEDULER_INTERVAL" envDefault:"1m"`
+}
@@ -0,0 +1,7 @@
+package config
+
+type Purchasing struct {
+	// Receipt tolerances in percent of the ordered quantity.
+	OverReceiptTolerance  float64 `env:"PO_OVER_RECEIPT_TOLERANCE"  envDefault:"0"`
+	UnderReceiptTolerance float64 `env:"PO_UNDER_RECEIPT_TOLERANCE" envDefault:"0"`
+}
@@ -0,0 +1,6 @@
+package config
+
+type Reporting struct {
+	// Currency is what reports convert values of different currencies to.
+	Currency string `env:"REPORT_CURRENCY" envDefault:"USD"`
+}
@@ -6,4 +6,7 @@ type Server struct {
 	Address string `env:"SERVER_ADDRESS"`
 	Timeout time.Duration `env:"SERVER_TIMEOUT"`
 	IdleTimeout time.Duration `env:"SERVER_IDLE_TIMEOUT"`
+	// DrainDelay is how long readiness fails after SIGTERM before the server
+	// stops accepting connections, so load balancers can stop routing to it.
+	DrainDelay time.Duration `env:"SERVER_DRAIN_DELAY" envDefault:"5s"`
 }
\ No newline at end of file
@@ -0,0 +1,14 @@
+package config
+
+type Tracing struct {
+	// Exporter is where spans are sent: "none", "stdout" or "otlp".
+	Exporter    string `env:"TRACING_EXPORTER" envDefault:"none"`
+	ServiceName string `env:"TRACING_SERVICE_NAME" envDefault:"warehouse-api"`
+	// SampleRatio is the share of new traces recorded; requests joining a trace
+	// follow the sampling decision of their caller.
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector; when empty the
+	// OTEL_EXPORTER_OTLP_* variables or their defaults apply.
+	OTLPEndpoint string `env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool   `env:"TRACING_OTLP_INSECURE"`
+}
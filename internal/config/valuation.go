@@ -0,0 +1,6 @@
+package config
+
+type Valuation struct {
+	// Method values issued stock: fifo, lifo or average.
+	Method string `env:"VALUATION_METHOD" envDefault:"fifo"`
+}
@@ -0,0 +1,93 @@
+package cyclecount
+
+import (
+	"math"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type Status string
+
+const (
+	StatusOpen            Status = "open"
+	StatusPendingApproval Status = "pending_approval"
+	StatusApproved        Status = "approved"
+	StatusCancelled       Status = "cancelled"
+)
+
+// Valid reports whether s is a known count status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusOpen, StatusPendingApproval, StatusApproved, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Session is a physical count of the products of one warehouse. The filters
+// select the products on its count sheet; an empty filter matches every product.
+// In a blind count the counters do not see the system quantities.
+type Session struct {
+	ID          int32          `json:"id"`
+	WarehouseID int32          `json:"warehouse_id"`
+	BinLocation string         `json:"bin_location,omitempty"`
+	Category    string         `json:"category,omitempty"`
+	ABCClass    stock.ABCClass `json:"abc_class,omitempty"`
+	Blind       bool           `json:"blind"`
+	Status      Status         `json:"status"`
+	ApprovedBy  string         `json:"approved_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	SubmittedAt *time.Time     `json:"submitted_at,omitempty"`
+	ApprovedAt  *time.Time     `json:"approved_at,omitempty"`
+	CancelledAt *time.Time     `json:"cancelled_at,omitempty"`
+	Lines       []Line         `json:"lines,omitempty"`
+}
+
+// Hidden reports whether the system quantities of the session must not be shown.
+func (s Session) Hidden() bool {
+	return s.Blind && s.Status == StatusOpen
+}
+
+// Line is one product on a count sheet. SystemQuantity is the warehouse stock
+// when the sheet was generated and, once the line is counted, when it was
+// counted; Variance is the counted quantity less it and is what approval
+// adjusts the stock by. Both are nil while they are hidden from a blind count.
+type Line struct {
+	ID              int32  `json:"id"`
+	ProductID       int32  `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	BinLocation     string `json:"bin_location,omitempty"`
+	SystemQuantity  *int32 `json:"system_quantity,omitempty"`
+	CountedQuantity *int32 `json:"counted_quantity"`
+	Variance        *int32 `json:"variance,omitempty"`
+}
+
+// NeedsApproval reports whether the variance of a counted line is more than
+// thresholdPct percent of the system quantity. Any variance on a line with no
+// system quantity needs approval.
+func (l Line) NeedsApproval(thresholdPct float64) bool {
+	if l.Variance == nil || *l.Variance == 0 {
+		return false
+	}
+	if l.SystemQuantity == nil || *l.SystemQuantity == 0 {
+		return true
+	}
+	return math.Abs(float64(*l.Variance)) > float64(*l.SystemQuantity)*thresholdPct/100
+}
+
+// Candidate is a product that may be put on a count sheet, with its stock at
+// the warehouse.
+type Candidate struct {
+	ProductID   int32
+	ProductName string
+	BinLocation string
+	Category    string
+	Quantity    int32
+}
+
+// Count is the quantity counted for one line of a sheet.
+type Count struct {
+	LineID   int32
+	Quantity int32
+}
@@ -0,0 +1,31 @@
+package cyclecount
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+var (
+	ErrNotFound = errors.New("count session not found")
+	// ErrStatus is returned when the session is not in a status that allows the action.
+	ErrStatus = errors.New("count session status does not allow this action")
+)
+
+type Repository interface {
+	// Candidates returns the products that can be counted at the warehouse, with
+	// their stock there.
+	Candidates(ctx context.Context, warehouseID int32) ([]Candidate, error)
+	Create(ctx context.Context, s Session) (int32, error)
+	// Get returns the session with its lines, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Session, error)
+	// List returns sessions without lines; an empty status matches all.
+	List(ctx context.Context, status Status) ([]Session, error)
+
+	// Update locks the session and passes it to fn, which changes its status and
+	// counted quantities and returns the adjusting stock movements to record.
+	// Everything is committed together. fn runs inside the transaction, so it
+	// must not query the database itself.
+	Update(ctx context.Context, id int32, fn func(s *Session) ([]stock.Movement, error)) (Session, error)
+}
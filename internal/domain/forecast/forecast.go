@@ -0,0 +1,168 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+type Method string
+
+const (
+	MethodMovingAverage        Method = "moving_average"
+	MethodExponentialSmoothing Method = "exponential_smoothing"
+	MethodSeasonalNaive        Method = "seasonal_naive"
+)
+
+// Valid reports whether m is a known forecasting method.
+func (m Method) Valid() bool {
+	switch m {
+	case MethodMovingAverage, MethodExponentialSmoothing, MethodSeasonalNaive:
+		return true
+	}
+	return false
+}
+
+// Settings tune the forecasts and the replenishment suggestions built on them.
+// Demand is counted in periods of PeriodDays days.
+type Settings struct {
+	Method         Method
+	PeriodDays     int
+	HistoryPeriods int
+	// Window is the number of periods averaged by the moving average.
+	Window int
+	// Alpha is the weight exponential smoothing gives the latest period.
+	Alpha float64
+	// SeasonLength is the number of periods after which demand repeats.
+	SeasonLength int
+	// ServiceLevel is the wanted probability of not running out during the lead time.
+	ServiceLevel float64
+	// ReviewDays is the stock an order covers beyond the lead time, in days.
+	ReviewDays int
+	// DefaultLeadTimeDays is used for products without a supplier.
+	DefaultLeadTimeDays int
+}
+
+// Validate reports the first setting that is out of range.
+func (s Settings) Validate() error {
+	switch {
+	case !s.Method.Valid():
+		return errors.New("method must be one of moving_average, exponential_smoothing, seasonal_naive")
+	case s.PeriodDays <= 0 || s.HistoryPeriods <= 0 || s.Window <= 0 || s.SeasonLength <= 0:
+		return errors.New("period days, history periods, window and season length must be positive")
+	case s.Alpha <= 0 || s.Alpha > 1:
+		return errors.New("alpha must be greater than 0 and at most 1")
+	case s.ServiceLevel <= 0 || s.ServiceLevel >= 1:
+		return errors.New("service level must be between 0 and 1")
+	case s.ReviewDays < 0 || s.DefaultLeadTimeDays < 0:
+		return errors.New("review days and default lead time must not be negative")
+	}
+	return nil
+}
+
+// MovingAverage is the mean demand of the last window periods.
+func MovingAverage(history []float64, window int) float64 {
+	if len(history) == 0 || window <= 0 {
+		return 0
+	}
+	return mean(history[max(len(history)-window, 0):])
+}
+
+// ExponentialSmoothing is the demand level after smoothing the history with
+// the given weight for each newer period, starting from the oldest period.
+func ExponentialSmoothing(history []float64, alpha float64) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	level := history[0]
+	for _, d := range history[1:] {
+		level = alpha*d + (1-alpha)*level
+	}
+	return level
+}
+
+// SeasonalNaive expects each of the next horizon periods to repeat the demand
+// of the same period one season earlier. With less than a season of history it
+// falls back to the mean of the history.
+func SeasonalNaive(history []float64, season, horizon int) []float64 {
+	f := make([]float64, horizon)
+	if season <= 0 || len(history) < season {
+		avg := mean(history)
+		for i := range f {
+			f[i] = avg
+		}
+		return f
+	}
+	start := len(history) - season
+	for i := range f {
+		f[i] = history[start+i%season]
+	}
+	return f
+}
+
+// Forecast returns the expected demand in each of the next horizon periods.
+func Forecast(history []float64, horizon int, s Settings) []float64 {
+	if s.Method == MethodSeasonalNaive {
+		return SeasonalNaive(history, s.SeasonLength, horizon)
+	}
+	level := MovingAverage(history, s.Window)
+	if s.Method == MethodExponentialSmoothing {
+		level = ExponentialSmoothing(history, s.Alpha)
+	}
+	f := make([]float64, horizon)
+	for i := range f {
+		f[i] = level
+	}
+	return f
+}
+
+// Total is the demand forecast over the given, possibly fractional, number of
+// periods.
+func Total(f []float64, periods float64) float64 {
+	var total float64
+	for i, d := range f {
+		if float64(i) >= periods {
+			break
+		}
+		total += d * math.Min(periods-float64(i), 1)
+	}
+	return total
+}
+
+// StdDev is the sample standard deviation of the demand per period.
+func StdDev(history []float64) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+	avg := mean(history)
+	var sum float64
+	for _, d := range history {
+		sum += (d - avg) * (d - avg)
+	}
+	return math.Sqrt(sum / float64(len(history)-1))
+}
+
+// ZScore is the number of standard deviations of normally distributed demand
+// below which the given share of outcomes falls.
+func ZScore(serviceLevel float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*serviceLevel-1)
+}
+
+// SafetyStock covers demand above the forecast during the lead time:
+// z × σ × √L, with σ the deviation per period and L the lead time in periods.
+func SafetyStock(z, stdDev, leadPeriods float64) float64 {
+	if leadPeriods <= 0 {
+		return 0
+	}
+	return z * stdDev * math.Sqrt(leadPeriods)
+}
+
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, d := range v {
+		sum += d
+	}
+	return sum / float64(len(v))
+}
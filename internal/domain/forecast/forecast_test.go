@@ -0,0 +1,65 @@
+package forecast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastMethods(t *testing.T) {
+	history := []float64{10, 20, 30, 40}
+
+	assert.Equal(t, 35.0, MovingAverage(history, 2))
+	assert.Equal(t, 25.0, MovingAverage(history, 10))
+	assert.Equal(t, 0.0, MovingAverage(nil, 3))
+
+	// 10 → 15 → 22.5 → 31.25
+	assert.Equal(t, 31.25, ExponentialSmoothing(history, 0.5))
+
+	assert.Equal(t, []float64{30, 40, 30}, SeasonalNaive(history, 2, 3))
+	// Less than a season of history falls back to the mean.
+	assert.Equal(t, []float64{25, 25}, SeasonalNaive(history, 8, 2))
+}
+
+func TestTotal(t *testing.T) {
+	f := []float64{10, 20, 30}
+	assert.Equal(t, 20.0, Total(f, 1.5))
+	assert.Equal(t, 60.0, Total(f, 3))
+	assert.Equal(t, 60.0, Total(f, 5))
+	assert.Equal(t, 0.0, Total(f, 0))
+}
+
+func TestSafetyStock(t *testing.T) {
+	assert.InDelta(t, 1.645, ZScore(0.95), 0.001)
+	assert.InDelta(t, 0, ZScore(0.5), 1e-9)
+	assert.InDelta(t, 2, StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}), 0.14)
+	assert.InDelta(t, 1.645*10*2, SafetyStock(ZScore(0.95), 10, 4), 0.01)
+	assert.Equal(t, 0.0, SafetyStock(1.645, 10, 0))
+}
+
+func TestSuggest(t *testing.T) {
+	s := Settings{
+		Method: MethodMovingAverage, PeriodDays: 7, HistoryPeriods: 4, Window: 4,
+		Alpha: 0.3, SeasonLength: 52, ServiceLevel: 0.5, ReviewDays: 7,
+	}
+	history := []float64{10, 10, 10, 10}
+
+	// Two weeks of lead time and one of review at 10 a week, no variability.
+	sg := Suggest(Position{ProductID: 1, OnHand: 23, Allocated: 5, OnOrder: 2}, history, 14, 0, s)
+	assert.Equal(t, int32(0), sg.SafetyStock)
+	assert.Equal(t, int32(20), sg.ReorderPoint)
+	assert.Equal(t, int32(30), sg.OrderUpTo)
+	assert.Equal(t, int32(10), sg.SuggestedQuantity)
+
+	// The minimum order quantity raises the suggestion.
+	sg = Suggest(Position{ProductID: 1, OnHand: 20}, history, 14, 50, s)
+	assert.Equal(t, int32(50), sg.SuggestedQuantity)
+
+	// Above the reorder point nothing is ordered.
+	sg = Suggest(Position{ProductID: 1, OnHand: 21}, history, 14, 0, s)
+	assert.Equal(t, int32(0), sg.SuggestedQuantity)
+
+	// Without demand nothing is ordered either.
+	sg = Suggest(Position{ProductID: 1}, []float64{0, 0}, 14, 10, s)
+	assert.Equal(t, int32(0), sg.SuggestedQuantity)
+}
@@ -0,0 +1,128 @@
+package forecast
+
+import (
+	"math"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+)
+
+// DailyDemand is the quantity of a product issued on one day.
+type DailyDemand struct {
+	ProductID int32
+	Day       time.Time
+	Quantity  int32
+}
+
+// Position is the stock of a product available to meet future demand: what is
+// on hand, less what open sales orders have reserved, plus what open purchase
+// orders still have to deliver.
+type Position struct {
+	ProductID   int32
+	ProductName string
+	OnHand      int32
+	Allocated   int32
+	OnOrder     int32
+}
+
+// Available is the stock position counted against the reorder point.
+func (p Position) Available() int32 {
+	return p.OnHand - p.Allocated + p.OnOrder
+}
+
+// Suggestion is the forecast demand of a product and the quantity to order so
+// that its stock lasts through the supplier lead time and the review period.
+// History and Forecast, the demand per period, are only set for a single product.
+type Suggestion struct {
+	ProductID         int32        `json:"product_id"`
+	ProductName       string       `json:"product_name"`
+	Method            Method       `json:"method"`
+	PeriodDays        int32        `json:"period_days"`
+	History           []float64    `json:"history,omitempty"`
+	Forecast          []float64    `json:"forecast,omitempty"`
+	DemandPerPeriod   float64      `json:"demand_per_period"`
+	StdDev            float64      `json:"std_dev"`
+	SupplierID        *int32       `json:"supplier_id"`
+	SupplierName      string       `json:"supplier_name,omitempty"`
+	UnitCost          *money.Money `json:"unit_cost,omitempty"`
+	MinOrderQty       int32        `json:"min_order_quantity"`
+	LeadTimeDays      int32        `json:"lead_time_days"`
+	LeadTimeDemand    float64      `json:"lead_time_demand"`
+	SafetyStock       int32        `json:"safety_stock"`
+	ReorderPoint      int32        `json:"reorder_point"`
+	OrderUpTo         int32        `json:"order_up_to"`
+	OnHand            int32        `json:"on_hand"`
+	Allocated         int32        `json:"allocated"`
+	OnOrder           int32        `json:"on_order"`
+	SuggestedQuantity int32        `json:"suggested_quantity"`
+}
+
+// Suggest forecasts the demand of the position from its history, oldest period
+// first, and sets the reorder point, the level to order up to and, once the
+// available stock has fallen to the reorder point, the quantity to order,
+// raised to the minimum order quantity.
+func Suggest(p Position, history []float64, leadTimeDays, minOrderQty int32, s Settings) Suggestion {
+	leadPeriods := float64(leadTimeDays) / float64(s.PeriodDays)
+	coverPeriods := float64(int(leadTimeDays)+s.ReviewDays) / float64(s.PeriodDays)
+	f := Forecast(history, max(int(math.Ceil(coverPeriods)), 1), s)
+
+	sg := Suggestion{
+		ProductID:       p.ProductID,
+		ProductName:     p.ProductName,
+		Method:          s.Method,
+		PeriodDays:      int32(s.PeriodDays),
+		Forecast:        roundAll(f),
+		DemandPerPeriod: round2(mean(f)),
+		StdDev:          round2(StdDev(history)),
+		MinOrderQty:     minOrderQty,
+		LeadTimeDays:    leadTimeDays,
+		LeadTimeDemand:  round2(Total(f, leadPeriods)),
+		OnHand:          p.OnHand,
+		Allocated:       p.Allocated,
+		OnOrder:         p.OnOrder,
+	}
+	safety := SafetyStock(ZScore(s.ServiceLevel), StdDev(history), leadPeriods)
+	sg.SafetyStock = int32(math.Ceil(safety))
+	sg.ReorderPoint = int32(math.Ceil(Total(f, leadPeriods) + safety))
+	sg.OrderUpTo = int32(math.Ceil(Total(f, coverPeriods) + safety))
+
+	available := p.Available()
+	if available <= sg.ReorderPoint && sg.OrderUpTo > available {
+		sg.SuggestedQuantity = max(sg.OrderUpTo-available, minOrderQty)
+	}
+	return sg
+}
+
+// DraftOrder is a suggested purchase order, shaped like the body that creates one.
+type DraftOrder struct {
+	SupplierID   int32       `json:"supplier_id"`
+	SupplierName string      `json:"supplier_name"`
+	Currency     string      `json:"currency"`
+	Lines        []DraftLine `json:"lines"`
+}
+
+type DraftLine struct {
+	ProductID int32       `json:"product_id"`
+	Quantity  int32       `json:"quantity"`
+	UnitCost  money.Money `json:"unit_cost"`
+}
+
+// Plan lists the products to reorder and groups those with a supplier into
+// draft purchase orders.
+type Plan struct {
+	Method      Method       `json:"method"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Orders      []DraftOrder `json:"orders"`
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func roundAll(v []float64) []float64 {
+	out := make([]float64, len(v))
+	for i := range v {
+		out[i] = round2(v[i])
+	}
+	return out
+}
@@ -0,0 +1,15 @@
+package forecast
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Demand returns the quantity issued per product and day from the start of
+	// day from up to the start of day to. A nil productID returns every product.
+	Demand(ctx context.Context, productID *int32, from, to time.Time) ([]DailyDemand, error)
+	// Positions returns the stock position of every product, or of one product
+	// when productID is set, ordered by product name.
+	Positions(ctx context.Context, productID *int32) ([]Position, error)
+}
@@ -0,0 +1,55 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrKeyReused means the key was sent before with a different request.
+	ErrKeyReused = errors.New("idempotency key was used with a different request")
+	// ErrInProgress means the first request with the key has not been served yet.
+	ErrInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// Record is a request made with an idempotency key. Response is nil while the
+// request is being served.
+type Record struct {
+	Key         string
+	RequestHash string
+	Response    *Response
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Reservation identifies a record reserved for a request. A key taken over
+// after its request was lost is reserved again with a new CreatedAt, so the
+// request that lost it cannot change the record of the one that took it over.
+type Reservation struct {
+	Key       string
+	CreatedAt time.Time
+}
+
+// Response is what a request got, replayed to every retry with the same key.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+type Repository interface {
+	// Reserve stores a pending record for the key and returns it with true,
+	// unless the key has a record that has not expired at now and, if it is
+	// pending, was created at or after staleBefore. It then returns that record
+	// and false.
+	Reserve(ctx context.Context, r Record, now, staleBefore time.Time) (Record, bool, error)
+	// Complete saves the response of a reservation; it does nothing if the key
+	// has been reserved again since.
+	Complete(ctx context.Context, res Reservation, resp Response) error
+	// Release deletes the record of a reservation so that the request can be
+	// retried; it does nothing if the key has been reserved again since.
+	Release(ctx context.Context, res Reservation) error
+	// DeleteExpired deletes the records expired at now and returns their number.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
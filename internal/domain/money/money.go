@@ -0,0 +1,160 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+)
+
+// currencies maps supported ISO 4217 codes to the number of digits in their minor unit.
+var currencies = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"RUB": 2,
+	"KZT": 2,
+	"UZS": 2,
+	"JPY": 0,
+}
+
+// Money is an amount expressed in minor units (cents, tiyin, ...) of an ISO 4217 currency.
+type Money struct {
+	Amount   int64  `json:"amount" swaggertype:"string" example:"12.50"`
+	Currency string `json:"currency" example:"USD"`
+}
+
+func New(amount int64, currency string) (Money, error) {
+	if _, ok := currencies[currency]; !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Exponent returns the number of minor unit digits of the currency.
+func Exponent(currency string) (int, bool) {
+	exp, ok := currencies[currency]
+	return exp, ok
+}
+
+func IsSupported(currency string) bool {
+	_, ok := currencies[currency]
+	return ok
+}
+
+// Currencies returns the supported currency codes in alphabetical order.
+func Currencies() []string {
+	codes := make([]string, 0, len(currencies))
+	for code := range currencies {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// Parse converts a decimal string such as "12.50" into Money. Amounts with more
+// fractional digits than the currency allows are rejected rather than rounded.
+func Parse(amount, currency string) (Money, error) {
+	exp, ok := currencies[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	s := strings.TrimSpace(amount)
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > exp || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if neg {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+func MustParse(amount, currency string) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Decimal formats the amount in major units, e.g. 1250 USD -> "12.50".
+func (m Money) Decimal() string {
+	exp := currencies[m.Currency]
+	abs := m.Amount
+	sign := ""
+	if abs < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	digits := strconv.FormatInt(abs, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string so clients never see float rounding.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	parsed, err := Parse(raw.Amount, strings.ToUpper(raw.Currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		expected int64
+		wantErr  error
+	}{
+		{"12.50", "USD", 1250, nil},
+		{"12.5", "USD", 1250, nil},
+		{"12", "USD", 1200, nil},
+		{"0.07", "EUR", 7, nil},
+		{"-3.10", "USD", -310, nil},
+		{"1500", "JPY", 1500, nil},
+		{"12.505", "USD", 0, ErrInvalidAmount},
+		{"1.5", "JPY", 0, ErrInvalidAmount},
+		{"abc", "USD", 0, ErrInvalidAmount},
+		{".5", "USD", 0, ErrInvalidAmount},
+		{"10", "XYZ", 0, ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			m, err := Parse(tt.amount, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, m.Amount)
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	assert.Equal(t, "0.05", Money{Amount: 5, Currency: "USD"}.Decimal())
+	assert.Equal(t, "-1.00", Money{Amount: -100, Currency: "USD"}.Decimal())
+	assert.Equal(t, "1500", Money{Amount: 1500, Currency: "JPY"}.Decimal())
+	assert.Equal(t, "125000000.00 UZS", MustParse("125000000", "UZS").String())
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	data, err := json.Marshal(MustParse("9999.99", "USD"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"9999.99","currency":"USD"}`, string(data))
+
+	var m Money
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":"15.10","currency":"eur"}`), &m))
+	assert.Equal(t, Money{Amount: 1510, Currency: "EUR"}, m)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":15.1,"currency":"EUR"}`), &m))
+}
+
+func TestConvert(t *testing.T) {
+	rate, err := ParseRate("12650.5")
+	assert.NoError(t, err)
+
+	uzs, err := MustParse("1.01", "USD").Convert(rate, "UZS", RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "12777.01", uzs.Decimal())
+
+	third, _ := ParseRate("1/3")
+	tests := []struct {
+		mode     RoundingMode
+		amount   string
+		expected string
+	}{
+		{RoundHalfUp, "1.00", "0.33"},
+		{RoundUp, "1.00", "0.34"},
+		{RoundDown, "2.00", "0.66"},
+		{RoundHalfUp, "2.00", "0.67"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String()+" "+tt.amount, func(t *testing.T) {
+			got, err := MustParse(tt.amount, "USD").Convert(third, "USD", tt.mode)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got.Decimal())
+		})
+	}
+
+	_, err = ParseRate("-1")
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		value    string
+		mode     RoundingMode
+		expected int64
+	}{
+		{"5/2", RoundHalfUp, 3},
+		{"5/2", RoundHalfEven, 2},
+		{"7/2", RoundHalfEven, 4},
+		{"-5/2", RoundHalfUp, -3},
+		{"-5/2", RoundDown, -2},
+		{"-5/2", RoundUp, -3},
+		{"21/10", RoundUp, 3},
+		{"29/10", RoundDown, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String()+" "+tt.value, func(t *testing.T) {
+			r, _ := new(big.Rat).SetString(tt.value)
+			assert.Equal(t, tt.expected, RoundRat(r, tt.mode).Int64())
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrInvalidRate = errors.New("invalid exchange rate")
+
+// RoundingMode decides how amounts that fall between two minor units are rounded.
+type RoundingMode int
+
+const (
+	RoundHalfUp RoundingMode = iota
+	RoundHalfEven
+	RoundDown
+	RoundUp
+)
+
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch s {
+	case "half_up", "":
+		return RoundHalfUp, nil
+	case "half_even":
+		return RoundHalfEven, nil
+	case "down":
+		return RoundDown, nil
+	case "up":
+		return RoundUp, nil
+	}
+	return 0, fmt.Errorf("unknown rounding mode %q", s)
+}
+
+func (r RoundingMode) String() string {
+	switch r {
+	case RoundHalfEven:
+		return "half_even"
+	case RoundDown:
+		return "down"
+	case RoundUp:
+		return "up"
+	}
+	return "half_up"
+}
+
+// ParseRate parses a positive decimal or fractional exchange rate such as "12650.5" or "1/3".
+func ParseRate(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return r, nil
+}
+
+// Rat returns the amount in major units as an exact rational number.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(currencies[m.Currency]))
+}
+
+// FromRat rounds an amount given in major units to the minor unit of the currency.
+func FromRat(r *big.Rat, currency string, mode RoundingMode) (Money, error) {
+	exp, ok := currencies[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(exp)))
+	minor := RoundRat(scaled, mode)
+	if !minor.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s overflows", ErrInvalidAmount, r.FloatString(exp))
+	}
+
+	return Money{Amount: minor.Int64(), Currency: currency}, nil
+}
+
+// Convert multiplies the amount by rate (units of `to` per unit of m.Currency).
+func (m Money) Convert(rate *big.Rat, to string, mode RoundingMode) (Money, error) {
+	return FromRat(new(big.Rat).Mul(m.Rat(), rate), to, mode)
+}
+
+// RoundRat rounds r to an integer according to mode.
+func RoundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	num, den := r.Num(), r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	away := false
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	default:
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		switch twice.Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || quo.Bit(0) == 1
+		}
+	}
+
+	if away {
+		if num.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,78 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+)
+
+type PriceList struct {
+	ID            int32      `json:"id"`
+	Name          string     `json:"name"`
+	Currency      string     `json:"currency"`
+	CustomerGroup string     `json:"customer_group"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidTo       *time.Time `json:"valid_to,omitempty"`
+	Items         []Item     `json:"items,omitempty"`
+}
+
+// ActiveAt reports whether the list's validity window covers t.
+func (l PriceList) ActiveAt(t time.Time) bool {
+	return !l.ValidFrom.After(t) && (l.ValidTo == nil || l.ValidTo.After(t))
+}
+
+type Item struct {
+	PriceListID int32       `json:"price_list_id"`
+	ProductID   int32       `json:"product_id"`
+	Price       money.Money `json:"price"`
+}
+
+// ExchangeRate says how many units of Quote one unit of Base buys from ValidFrom on.
+type ExchangeRate struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate" example:"12650.5"`
+	ValidFrom time.Time `json:"valid_from"`
+}
+
+type Source string
+
+const (
+	SourcePriceList Source = "price_list"
+	SourceBase      Source = "base"
+	SourceConverted Source = "converted"
+)
+
+// ResolvedPrice is the price a customer pays for a product in a currency at a point in time.
+type ResolvedPrice struct {
+	ProductID   int32       `json:"product_id"`
+	Price       money.Money `json:"price"`
+	Source      Source      `json:"source"`
+	PriceListID *int32      `json:"price_list_id,omitempty"`
+	Rate        string      `json:"rate,omitempty"`
+	At          time.Time   `json:"at"`
+}
+
+type ChangeStatus string
+
+const (
+	ChangePending    ChangeStatus = "pending"
+	ChangeActive     ChangeStatus = "active"
+	ChangeSuperseded ChangeStatus = "superseded"
+	ChangeCancelled  ChangeStatus = "cancelled"
+)
+
+// PriceChange is one period of a product's price history. Pending changes become
+// active once ValidFrom is reached; when ValidTo passes, the price in effect
+// before the change is restored.
+type PriceChange struct {
+	ID          int32        `json:"id"`
+	ProductID   int32        `json:"product_id"`
+	Price       money.Money  `json:"price"`
+	Status      ChangeStatus `json:"status"`
+	ValidFrom   time.Time    `json:"valid_from"`
+	ValidTo     *time.Time   `json:"valid_to,omitempty"`
+	CreatedBy   string       `json:"created_by,omitempty"`
+	ApprovedBy  string       `json:"approved_by,omitempty"`
+	ActivatedAt *time.Time   `json:"activated_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type Repository interface {
+	CreatePriceList(ctx context.Context, l PriceList) (int32, error)
+	GetPriceList(ctx context.Context, id int32) (PriceList, error)
+	ListPriceLists(ctx context.Context) ([]PriceList, error)
+	DeletePriceList(ctx context.Context, id int32) error
+	SetItem(ctx context.Context, item Item) error
+	DeleteItem(ctx context.Context, priceListID, productID int32) error
+	// FindPrice returns the item of the best matching active price list, or ErrNotFound.
+	FindPrice(ctx context.Context, productID int32, currency, customerGroup string, at time.Time) (Item, error)
+
+	SaveExchangeRates(ctx context.Context, rates []ExchangeRate) error
+	// GetExchangeRate returns the latest base->quote rate valid at the given time, or ErrNotFound.
+	GetExchangeRate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error)
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+
+	ListPriceChanges(ctx context.Context, productID int32) ([]PriceChange, error)
+	GetPriceChange(ctx context.Context, id int32) (PriceChange, error)
+	SchedulePriceChange(ctx context.Context, pc PriceChange) (int32, error)
+	CancelPriceChange(ctx context.Context, id int32) error
+	// ActivateDuePriceChanges makes the price period in effect at now the current
+	// product price for every product where it is not active yet. A period in
+	// another currency than a product that has stock or stock value is cancelled
+	// instead. It returns the changes with their new status.
+	ActivateDuePriceChanges(ctx context.Context, now time.Time) ([]PriceChange, error)
+}
@@ -1,9 +1,43 @@
 package product
 
+import "github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+
 type Product struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int32  `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	ID          int32       `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Quantity    int32       `json:"quantity"`
+	BaseUnit    string      `json:"base_unit"`
+	LotTracked  bool        `json:"lot_tracked"`
+	Serialized  bool        `json:"serialized"`
+	// BinLocation is where the product is stored, e.g. "A-03-2"; pick lists are
+	// grouped and ordered by it.
+	BinLocation string `json:"bin_location"`
+	// Category groups products for reporting and cycle counts, e.g. "Furniture".
+	Category string `json:"category"`
+	// QuarantinedQuantity is returned stock awaiting a decision; it is not part of
+	// Quantity and cannot be sold.
+	QuarantinedQuantity int32 `json:"quarantined_quantity"`
+	// OpeningUnitCost is the cost of one unit of the opening Quantity, only used
+	// on create; without it the opening stock is valued at Price.
+	OpeningUnitCost *money.Money `json:"-"`
+}
+
+// Tracked reports whether the stock of the product is kept per lot or per serial
+// number, so its quantity can only change through stock movements.
+func (p Product) Tracked() bool {
+	return p.LotTracked || p.Serialized
+}
+
+// Filter narrows a product search; empty fields match every product.
+type Filter struct {
+	// Search matches a part of the name or description, ignoring case.
+	Search   string
+	Category string
+	Currency string
+	// InStock, when set, keeps only products with or without stock on hand.
+	InStock *bool
+	Limit   int32
+	Offset  int32
 }
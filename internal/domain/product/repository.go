@@ -1,11 +1,33 @@
 package product
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("product not found")
+	// ErrCurrencyChange is returned for a new price currency while the product
+	// has stock or stock value, which are kept in the current one.
+	ErrCurrencyChange = errors.New("price currency can only change while the product has no stock or stock value")
+	// ErrInUse is returned when deleting a product that has stock or stock value
+	// or that documents such as orders, returns or counts refer to.
+	ErrInUse = errors.New("product has stock or is in use and cannot be deleted")
+)
 
 type Repository interface {
 	Create(ctx context.Context, p Product) (int32, error)
 	GetByID(ctx context.Context, id int32) (Product, error)
-	Update(ctx context.Context, p Product) error
+	// Update locks the product, lets fn change it and saves everything but its
+	// base unit and quantities. It fails with ErrCurrencyChange if fn changes the
+	// price currency of a product with stock or stock value.
+	Update(ctx context.Context, id int32, fn func(p *Product) error) error
+	// Delete removes the product with its stock history, or returns ErrNotFound.
+	// It fails with ErrInUse while the product has stock, quarantined stock or
+	// stock value, or while documents refer to it.
 	Delete(ctx context.Context, id int32) error
 	List(ctx context.Context) ([]Product, error)
+	// Search returns a page of the products matching the filter, ordered by ID,
+	// and the number of matching products on all pages.
+	Search(ctx context.Context, f Filter) ([]Product, int32, error)
 }
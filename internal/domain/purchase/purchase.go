@@ -0,0 +1,109 @@
+package purchase
+
+import (
+	"math"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type Status string
+
+const (
+	StatusDraft             Status = "draft"
+	StatusSubmitted         Status = "submitted"
+	StatusPartiallyReceived Status = "partially_received"
+	StatusReceived          Status = "received"
+	StatusClosed            Status = "closed"
+)
+
+// Valid reports whether s is a known purchase order status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusSubmitted, StatusPartiallyReceived, StatusReceived, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// Receivable reports whether goods can be received against an order in this status.
+func (s Status) Receivable() bool {
+	return s == StatusSubmitted || s == StatusPartiallyReceived
+}
+
+// Order is a purchase order placed with a supplier. All line costs are in Currency.
+type Order struct {
+	ID          int32      `json:"id"`
+	SupplierID  int32      `json:"supplier_id"`
+	Status      Status     `json:"status"`
+	Currency    string     `json:"currency"`
+	Reference   string     `json:"reference,omitempty"`
+	ExpectedAt  *time.Time `json:"expected_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Lines       []Line     `json:"lines,omitempty"`
+}
+
+// Line is the quantity of one product ordered, in the product base unit.
+type Line struct {
+	ID               int32       `json:"id"`
+	ProductID        int32       `json:"product_id"`
+	Quantity         int32       `json:"quantity"`
+	ReceivedQuantity int32       `json:"received_quantity"`
+	UnitCost         money.Money `json:"unit_cost"`
+}
+
+// Receipt is a delivery of goods against the lines of an order.
+type Receipt struct {
+	Reference string
+	Lines     []ReceiptLine
+}
+
+// ReceiptLine is the quantity delivered for one order line; Lot and Serials are
+// required for lot-tracked and serialized products.
+type ReceiptLine struct {
+	LineID   int32
+	Quantity int32
+	Lot      *stock.Lot
+	Serials  []string
+}
+
+// Tolerance is how much a line may be over- or under-received, in percent of the
+// ordered quantity. A line counts as fully received once the received quantity is
+// within the under-receipt tolerance.
+type Tolerance struct {
+	OverPct  float64
+	UnderPct float64
+}
+
+// MaxReceivable returns the largest total quantity that can be received for a line.
+func (t Tolerance) MaxReceivable(ordered int32) int32 {
+	return int32(math.Floor(float64(ordered) * (1 + t.OverPct/100)))
+}
+
+// Complete reports whether enough of the line has been received.
+func (t Tolerance) Complete(l Line) bool {
+	return l.ReceivedQuantity >= int32(math.Ceil(float64(l.Quantity)*(1-t.UnderPct/100)))
+}
+
+// StatusSummary aggregates the orders in one status and currency.
+type StatusSummary struct {
+	Status           Status      `json:"status"`
+	Orders           int32       `json:"orders"`
+	Lines            int32       `json:"lines"`
+	OrderedQuantity  int64       `json:"ordered_quantity"`
+	ReceivedQuantity int64       `json:"received_quantity"`
+	OrderedValue     money.Money `json:"ordered_value"`
+	ReceivedValue    money.Money `json:"received_value"`
+	// Overdue counts open orders whose expected date has passed.
+	Overdue int32 `json:"overdue"`
+}
+
+// ReportFilter narrows the status report to a supplier and a creation period.
+type ReportFilter struct {
+	SupplierID *int32
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,21 @@
+package purchase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTolerance(t *testing.T) {
+	none := Tolerance{}
+	assert.Equal(t, int32(100), none.MaxReceivable(100))
+	assert.False(t, none.Complete(Line{Quantity: 100, ReceivedQuantity: 99}))
+	assert.True(t, none.Complete(Line{Quantity: 100, ReceivedQuantity: 100}))
+
+	tol := Tolerance{OverPct: 5, UnderPct: 2.5}
+	assert.Equal(t, int32(105), tol.MaxReceivable(100))
+	assert.Equal(t, int32(3), tol.MaxReceivable(3))
+	assert.True(t, tol.Complete(Line{Quantity: 40, ReceivedQuantity: 39}))
+	assert.False(t, tol.Complete(Line{Quantity: 40, ReceivedQuantity: 38}))
+	assert.False(t, tol.Complete(Line{Quantity: 3, ReceivedQuantity: 2}))
+}
@@ -0,0 +1,37 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+var (
+	ErrNotFound = errors.New("purchase order not found")
+	// ErrStatus is returned when the order is not in a status that allows the action.
+	ErrStatus = errors.New("purchase order status does not allow this action")
+)
+
+type Repository interface {
+	// Create inserts a draft order with its lines.
+	Create(ctx context.Context, o Order) (int32, error)
+	// Get returns the order with its lines, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Order, error)
+	// List returns orders without lines; an empty status or nil supplier matches all.
+	List(ctx context.Context, status Status, supplierID *int32) ([]Order, error)
+	// UpdateDraft replaces the supplier, currency, reference and lines of a draft
+	// order; it fails with ErrStatus once the order was submitted.
+	UpdateDraft(ctx context.Context, o Order) error
+	Submit(ctx context.Context, id int32, expectedAt time.Time) error
+	Close(ctx context.Context, id int32) error
+
+	// Receive locks the order and passes it to fn, which updates the received
+	// quantities and status and returns the stock movements to record. The order
+	// changes and the movements are committed together. fn runs inside the
+	// transaction, so it must not query the database itself.
+	Receive(ctx context.Context, id int32, fn func(o *Order) ([]stock.Movement, error)) (Order, error)
+
+	StatusReport(ctx context.Context, f ReportFilter) ([]StatusSummary, error)
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"math"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+// Turnover is how often the stock of a product was used up over a period.
+// Turnover and DaysOfInventory are nil when nothing was issued or the product
+// held no stock.
+type Turnover struct {
+	ProductID       int32    `json:"product_id"`
+	ProductName     string   `json:"product_name"`
+	OpeningQuantity int32    `json:"opening_quantity"`
+	ClosingQuantity int32    `json:"closing_quantity"`
+	IssuedQuantity  int32    `json:"issued_quantity"`
+	AverageQuantity float64  `json:"average_quantity"`
+	Turnover        *float64 `json:"turnover"`
+	DaysOfInventory *float64 `json:"days_of_inventory"`
+}
+
+// Ratios sets the average stock, turnover ratio and days of inventory of a
+// period of the given length in days, rounded to two decimals.
+func (t *Turnover) Ratios(days float64) {
+	t.AverageQuantity = float64(t.OpeningQuantity+t.ClosingQuantity) / 2
+	t.Turnover, t.DaysOfInventory = nil, nil
+	if t.IssuedQuantity <= 0 || t.AverageQuantity <= 0 {
+		return
+	}
+	turnover := float64(t.IssuedQuantity) / t.AverageQuantity
+	doi := round2(days / turnover)
+	turnover = round2(turnover)
+	t.Turnover, t.DaysOfInventory = &turnover, &doi
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// AgingBucket is the stock received between MinDays and MaxDays ago; MaxDays
+// is nil for the oldest bucket.
+type AgingBucket struct {
+	Label    string `json:"label"`
+	MinDays  int32  `json:"min_days"`
+	MaxDays  *int32 `json:"max_days,omitempty"`
+	Quantity int32  `json:"quantity"`
+}
+
+// Aging splits the stock on hand of a product by how long ago it was received,
+// assuming the oldest stock leaves first.
+type Aging struct {
+	ProductID   int32         `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	Quantity    int32         `json:"quantity"`
+	Buckets     []AgingBucket `json:"buckets"`
+}
+
+// DeadStock is a product with stock on hand that has not moved since a date.
+type DeadStock struct {
+	ProductID      int32      `json:"product_id"`
+	ProductName    string     `json:"product_name"`
+	Quantity       int32      `json:"quantity"`
+	LastMovementAt *time.Time `json:"last_movement_at"`
+}
+
+// Consumption is what was issued of a product over a period, valued at the
+// cost of the issues, or at the product price where no cost was recorded.
+type Consumption struct {
+	ProductID      int32       `json:"product_id"`
+	ProductName    string      `json:"product_name"`
+	IssuedQuantity int32       `json:"issued_quantity"`
+	Value          money.Money `json:"value"`
+}
+
+// ABC is the class of a product by its share of the consumption value.
+type ABC struct {
+	Consumption
+	SharePct      float64        `json:"share_pct"`
+	CumulativePct float64        `json:"cumulative_pct"`
+	Class         stock.ABCClass `json:"class"`
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Turnover returns the stock of every product at the start and end of the
+	// period and the quantity issued in between.
+	Turnover(ctx context.Context, from, to time.Time) ([]Turnover, error)
+	// Aging returns the stock on hand of every product with one bucket per age
+	// band: below bounds[0] days, between consecutive bounds and from the last
+	// bound on. Stock that no recorded movement explains is left out of the buckets.
+	Aging(ctx context.Context, now time.Time, bounds []int32) ([]Aging, error)
+	// DeadStock returns the products with stock on hand and no movement since
+	// the given time, least recently moved first.
+	DeadStock(ctx context.Context, since time.Time) ([]DeadStock, error)
+	// Consumption returns the products issued in the period.
+	Consumption(ctx context.Context, from, to time.Time) ([]Consumption, error)
+}
@@ -0,0 +1,33 @@
+package rma
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+var (
+	ErrNotFound = errors.New("return not found")
+	// ErrStatus is returned when the return is not in a status that allows the action.
+	ErrStatus = errors.New("return status does not allow this action")
+)
+
+type Repository interface {
+	// Create locks the sales order and passes it to fn together with what other
+	// returns that were not cancelled already cover of each order line. fn
+	// validates the return and fills in its lines, which are then saved. fn runs
+	// inside the transaction, so it must not query the database itself.
+	Create(ctx context.Context, r Return, fn func(r *Return, o sales.Order, returned map[int32]Returned) error) (int32, error)
+	// Get returns the return with its lines, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Return, error)
+	// List returns returns without lines; an empty status or nil order matches all.
+	List(ctx context.Context, status Status, orderID *int32) ([]Return, error)
+
+	// Update locks the return and passes it to fn, which changes the status and
+	// dispositions and returns the stock movements to record and the serial status
+	// changes of scrapped units. Everything is committed together. fn runs inside
+	// the transaction, so it must not query the database itself.
+	Update(ctx context.Context, id int32, fn func(r *Return) ([]stock.Movement, []stock.SerialUpdate, error)) (Return, error)
+}
@@ -0,0 +1,97 @@
+package rma
+
+import (
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusInspected Status = "inspected"
+	StatusCancelled Status = "cancelled"
+)
+
+// Valid reports whether s is a known return status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusOpen, StatusInspected, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Disposition decides where inspected goods go.
+type Disposition string
+
+const (
+	// DispositionRestock puts goods back into sellable stock.
+	DispositionRestock Disposition = "restock"
+	// DispositionQuarantine holds goods apart from sellable stock.
+	DispositionQuarantine Disposition = "quarantine"
+	// DispositionScrap disposes of goods; they never re-enter stock.
+	DispositionScrap Disposition = "scrap"
+)
+
+func (d Disposition) Valid() bool {
+	return d == DispositionRestock || d == DispositionQuarantine || d == DispositionScrap
+}
+
+// Return is a customer return (RMA) of goods shipped on a sales order.
+type Return struct {
+	ID          int32      `json:"id"`
+	OrderID     int32      `json:"order_id"`
+	Status      Status     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	InspectedAt *time.Time `json:"inspected_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	Lines       []Line     `json:"lines,omitempty"`
+}
+
+// Line is the quantity returned against one shipped order line. Serials lists
+// the returned units of serialized products. After inspection the disposition
+// quantities add up to Quantity; goods released from quarantine move from
+// QuarantinedQuantity to the restocked or scrapped quantity.
+type Line struct {
+	ID                  int32    `json:"id"`
+	OrderLineID         int32    `json:"order_line_id"`
+	ProductID           int32    `json:"product_id"`
+	Quantity            int32    `json:"quantity"`
+	Serials             []string `json:"serials,omitempty"`
+	RestockedQuantity   int32    `json:"restocked_quantity"`
+	QuarantinedQuantity int32    `json:"quarantined_quantity"`
+	ScrappedQuantity    int32    `json:"scrapped_quantity"`
+}
+
+// Returned is the quantity and the serial numbers of an order line covered by
+// returns that were not cancelled.
+type Returned struct {
+	Quantity int32
+	Serials  []string
+}
+
+// Inspection sorts every returned unit into a disposition. A line may be split
+// over several dispositions.
+type Inspection struct {
+	Lines []InspectedLine
+}
+
+// InspectedLine sends a quantity of a return line to one disposition. Restocked
+// goods of lot-tracked products need a Lot; serialized lines name the units.
+type InspectedLine struct {
+	LineID      int32
+	Disposition Disposition
+	Quantity    int32
+	Lot         *stock.Lot
+	Serials     []string
+}
+
+// Release takes quarantined goods of an inspected return out of quarantine.
+// Each line is restocked or scrapped; restocked goods of lot-tracked products
+// need a Lot and serialized lines name the units.
+type Release struct {
+	Lines []InspectedLine
+}
@@ -0,0 +1,33 @@
+package sales
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+var (
+	ErrNotFound = errors.New("sales order not found")
+	// ErrStatus is returned when the order is not in a status that allows the action.
+	ErrStatus = errors.New("sales order status does not allow this action")
+)
+
+// Available maps a product to its on-hand quantity less what other open orders
+// have allocated.
+type Available map[int32]int32
+
+type Repository interface {
+	// Create inserts a draft order with its lines.
+	Create(ctx context.Context, o Order) (int32, error)
+	// Get returns the order with its lines, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Order, error)
+	// List returns orders without lines; an empty status matches all.
+	List(ctx context.Context, status Status) ([]Order, error)
+
+	// Update locks the order and the stock of its products and passes them to fn,
+	// which changes the status, timestamps and line quantities and returns the stock
+	// movements to record. The order changes and the movements are committed together.
+	// fn runs inside the transaction, so it must not query the database itself.
+	Update(ctx context.Context, id int32, fn func(o *Order, available Available) ([]stock.Movement, error)) (Order, error)
+}
@@ -0,0 +1,106 @@
+package sales
+
+import (
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusAllocated Status = "allocated"
+	StatusPicking   Status = "picking"
+	StatusPacked    Status = "packed"
+	StatusShipped   Status = "shipped"
+	StatusCancelled Status = "cancelled"
+)
+
+// Valid reports whether s is a known sales order status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusAllocated, StatusPicking, StatusPacked, StatusShipped, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Order is an outbound order for a customer. All line prices are in Currency.
+type Order struct {
+	ID             int32      `json:"id"`
+	Customer       string     `json:"customer"`
+	CustomerGroup  string     `json:"customer_group,omitempty"`
+	Status         Status     `json:"status"`
+	Currency       string     `json:"currency"`
+	Reference      string     `json:"reference,omitempty"`
+	Packages       int32      `json:"packages,omitempty"`
+	Carrier        string     `json:"carrier,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AllocatedAt    *time.Time `json:"allocated_at,omitempty"`
+	PackedAt       *time.Time `json:"packed_at,omitempty"`
+	ShippedAt      *time.Time `json:"shipped_at,omitempty"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
+	Lines          []Line     `json:"lines,omitempty"`
+}
+
+// Line is the quantity of one product ordered, in the product base unit. Serials
+// lists the units shipped of a serialized product.
+type Line struct {
+	ID                int32       `json:"id"`
+	ProductID         int32       `json:"product_id"`
+	Quantity          int32       `json:"quantity"`
+	AllocatedQuantity int32       `json:"allocated_quantity"`
+	ShippedQuantity   int32       `json:"shipped_quantity"`
+	UnitPrice         money.Money `json:"unit_price"`
+	Serials           []string    `json:"serials,omitempty"`
+}
+
+// PickList is the allocated quantity of an order grouped by the bin the products
+// are stored in, in walking order of the bins.
+type PickList struct {
+	OrderID int32     `json:"order_id"`
+	Bins    []PickBin `json:"bins"`
+}
+
+// PickBin holds the lines to pick from one bin location. Products without a bin
+// location are grouped under an empty one, listed last.
+type PickBin struct {
+	BinLocation string     `json:"bin_location"`
+	Lines       []PickLine `json:"lines"`
+}
+
+type PickLine struct {
+	LineID      int32  `json:"line_id"`
+	ProductID   int32  `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int32  `json:"quantity"`
+	Unit        string `json:"unit"`
+}
+
+// Packing confirms the quantity packed for every line and the number of packages.
+type Packing struct {
+	Packages int32
+	Lines    []PackedLine
+}
+
+type PackedLine struct {
+	LineID   int32
+	Quantity int32
+}
+
+// Shipment hands a packed order to the carrier. Lines name the lot or serial
+// numbers shipped; lot-tracked lines without a lot are issued first-expiry-first-out.
+type Shipment struct {
+	Carrier        string
+	TrackingNumber string
+	Lines          []ShipmentLine
+}
+
+type ShipmentLine struct {
+	LineID  int32
+	Lot     *stock.Lot
+	Serials []string
+}
@@ -0,0 +1,54 @@
+package stock
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ABCClass ranks a product by its share of the total usage value.
+type ABCClass string
+
+const (
+	ClassA ABCClass = "A"
+	ClassB ABCClass = "B"
+	ClassC ABCClass = "C"
+)
+
+func (c ABCClass) Valid() bool {
+	return c == ClassA || c == ClassB || c == ClassC
+}
+
+// ClassifyABC sorts products by usage value, highest first. Products are class A
+// until 80 percent of the total value is covered, class B up to 95 percent and
+// class C after that; products without usage are always class C.
+func ClassifyABC(usage map[int32]int64) map[int32]ABCClass {
+	ids := make([]int32, 0, len(usage))
+	var total int64
+	for id, value := range usage {
+		ids = append(ids, id)
+		total += max(value, 0)
+	}
+	slices.SortFunc(ids, func(a, b int32) int {
+		if c := cmp.Compare(usage[b], usage[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	classes := make(map[int32]ABCClass, len(ids))
+	var covered int64
+	for _, id := range ids {
+		switch {
+		case usage[id] <= 0:
+			classes[id] = ClassC
+		case covered*100 < total*80:
+			classes[id] = ClassA
+		case covered*100 < total*95:
+			classes[id] = ClassB
+		default:
+			classes[id] = ClassC
+		}
+		covered += max(usage[id], 0)
+	}
+	return classes
+}
@@ -0,0 +1,29 @@
+package stock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyABC(t *testing.T) {
+	classes := ClassifyABC(map[int32]int64{
+		1: 7000,
+		2: 1500,
+		3: 800,
+		4: 400,
+		5: 300,
+		6: 0,
+	})
+	assert.Equal(t, map[int32]ABCClass{
+		1: ClassA,
+		2: ClassA,
+		3: ClassB,
+		4: ClassB,
+		5: ClassC,
+		6: ClassC,
+	}, classes)
+
+	assert.Equal(t, map[int32]ABCClass{1: ClassC, 2: ClassC}, ClassifyABC(map[int32]int64{1: 0, 2: 0}))
+	assert.Empty(t, ClassifyABC(nil))
+}
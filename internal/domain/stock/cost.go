@@ -0,0 +1,101 @@
+package stock
+
+import (
+	"math/big"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+)
+
+// CostMethod decides the cost at which outgoing stock is valued.
+type CostMethod string
+
+const (
+	// CostFIFO takes stock from the oldest cost layers first.
+	CostFIFO CostMethod = "fifo"
+	// CostLIFO takes stock from the newest cost layers first.
+	CostLIFO CostMethod = "lifo"
+	// CostAverage values stock at the weighted average cost of the stock on hand.
+	CostAverage CostMethod = "average"
+)
+
+// Valid reports whether m is a known cost method.
+func (m CostMethod) Valid() bool {
+	switch m {
+	case CostFIFO, CostLIFO, CostAverage:
+		return true
+	}
+	return false
+}
+
+// Costed reports whether movements of the type change the value of stock.
+// Quarantined goods are not valued, and transfers only move stock between
+// warehouses.
+func (t MovementType) Costed() bool {
+	switch t {
+	case MovementQuarantine, MovementTransferOut, MovementTransferIn:
+		return false
+	}
+	return true
+}
+
+// CostLayer is stock received at one unit cost, in minor units of the product
+// currency. Remaining is the part of it that has not been taken out yet.
+type CostLayer struct {
+	ID        int32
+	UnitCost  int64
+	Remaining int32
+}
+
+// LayerTake is a quantity taken out of a cost layer.
+type LayerTake struct {
+	LayerID  int32
+	Quantity int32
+}
+
+// AverageCost returns the unit cost of stock valued at value for quantity units,
+// rounded half up; zero if there is no stock.
+func AverageCost(quantity int32, value int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+	return mulDiv(value, 1, int64(quantity))
+}
+
+// IssueCost works out the layers an outgoing quantity is taken from and its
+// cost. Layers must be ordered oldest first; quantity and value are the valued
+// stock of the product. FIFO and LIFO value every unit at the cost of its layer;
+// average values the units at value/quantity and takes them oldest first. Stock
+// not covered by layers has no cost, so costed may be less than wanted.
+func IssueCost(method CostMethod, layers []CostLayer, wanted, quantity int32, value int64) (takes []LayerTake, costed int32, cost int64) {
+	order := make([]CostLayer, len(layers))
+	copy(order, layers)
+	if method == CostLIFO {
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	for _, l := range order {
+		if costed == wanted {
+			break
+		}
+		if l.Remaining <= 0 {
+			continue
+		}
+		take := min(l.Remaining, wanted-costed)
+		takes = append(takes, LayerTake{LayerID: l.ID, Quantity: take})
+		costed += take
+		cost += l.UnitCost * int64(take)
+	}
+
+	if method == CostAverage && quantity > 0 {
+		cost = mulDiv(value, int64(costed), int64(quantity))
+	}
+	return takes, costed, cost
+}
+
+// mulDiv returns a*b/c rounded half up.
+func mulDiv(a, b, c int64) int64 {
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)), big.NewInt(c))
+	return money.RoundRat(r, money.RoundHalfUp).Int64()
+}
@@ -0,0 +1,42 @@
+package stock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueCost(t *testing.T) {
+	layers := []CostLayer{
+		{ID: 1, UnitCost: 100, Remaining: 10},
+		{ID: 2, UnitCost: 130, Remaining: 10},
+	}
+
+	tests := []struct {
+		method CostMethod
+		wanted int32
+		takes  []LayerTake
+		costed int32
+		cost   int64
+	}{
+		{CostFIFO, 15, []LayerTake{{1, 10}, {2, 5}}, 15, 1650},
+		{CostLIFO, 15, []LayerTake{{2, 10}, {1, 5}}, 15, 1800},
+		{CostAverage, 15, []LayerTake{{1, 10}, {2, 5}}, 15, 1725},
+		// Stock not covered by layers has no cost.
+		{CostFIFO, 25, []LayerTake{{1, 10}, {2, 10}}, 20, 2300},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			takes, costed, cost := IssueCost(tt.method, layers, tt.wanted, 20, 2300)
+			assert.Equal(t, tt.takes, takes)
+			assert.Equal(t, tt.costed, costed)
+			assert.Equal(t, tt.cost, cost)
+		})
+	}
+}
+
+func TestAverageCost(t *testing.T) {
+	assert.Equal(t, int64(33), AverageCost(3, 100))
+	assert.Equal(t, int64(67), AverageCost(3, 200))
+	assert.Equal(t, int64(0), AverageCost(0, 0))
+}
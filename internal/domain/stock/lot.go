@@ -0,0 +1,55 @@
+package stock
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrLotNotFound      = errors.New("lot not found")
+	ErrLotDatesMismatch = errors.New("lot already exists with different dates")
+)
+
+// Lot is a batch of a product sharing manufacture and expiry dates.
+type Lot struct {
+	ID             int32      `json:"id"`
+	ProductID      int32      `json:"product_id"`
+	Number         string     `json:"lot_number"`
+	ManufacturedAt *time.Time `json:"manufactured_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Quantity       int32      `json:"quantity"`
+}
+
+// Expired reports whether the lot is past its expiry date on the day of now.
+// A lot can still be used on its expiry date.
+func (l Lot) Expired(now time.Time) bool {
+	if l.ExpiresAt == nil {
+		return false
+	}
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return l.ExpiresAt.Before(today)
+}
+
+// Matches reports whether the dates of other agree with the lot's; dates other
+// leaves out match any.
+func (l Lot) Matches(other Lot) bool {
+	return sameDate(l.ManufacturedAt, other.ManufacturedAt) && sameDate(l.ExpiresAt, other.ExpiresAt)
+}
+
+func sameDate(a, b *time.Time) bool {
+	if b == nil {
+		return true
+	}
+	return a != nil && a.Format(time.DateOnly) == b.Format(time.DateOnly)
+}
+
+// LotAllocation is the part of a movement taken from or put into one lot. An
+// incoming allocation without a LotID goes into the movement's Lot, which is
+// created when the movement is applied.
+type LotAllocation struct {
+	LotID     int32      `json:"lot_id"`
+	LotNumber string     `json:"lot_number"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Quantity  int32      `json:"quantity"`
+}
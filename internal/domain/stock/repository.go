@@ -0,0 +1,39 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+type Repository interface {
+	ListUnits(ctx context.Context, productID int32) ([]Unit, error)
+	SetUnit(ctx context.Context, u Unit) error
+	DeleteUnit(ctx context.Context, productID int32, code string) error
+
+	ListLots(ctx context.Context, productID int32) ([]Lot, error)
+	// FindLot returns the lot with the given number, or ErrLotNotFound.
+	FindLot(ctx context.Context, productID int32, number string) (Lot, error)
+	// ListExpiringLots returns lots with stock that expire on or before the given date.
+	ListExpiringLots(ctx context.Context, before time.Time) ([]Lot, error)
+
+	// FindSerials returns the existing serials among the given numbers, of any product.
+	FindSerials(ctx context.Context, numbers []string) ([]Serial, error)
+	// GetSerial returns the serial with the given number, or ErrSerialNotFound.
+	GetSerial(ctx context.Context, number string) (Serial, error)
+	// ListSerialMovements returns the movements of a serial, oldest first.
+	ListSerialMovements(ctx context.Context, serialID int32) ([]Movement, error)
+
+	// ApplyMovement records the movement and changes the product, warehouse and lot
+	// quantities and serial statuses atomically, creating a new lot it receives into.
+	// It fails with ErrInsufficientStock if a quantity would become negative, with
+	// ErrSerialStatus if a serial changed meanwhile and with ErrLotDatesMismatch if
+	// the lot was created meanwhile with other dates.
+	ApplyMovement(ctx context.Context, m Movement) (Movement, error)
+	ListMovements(ctx context.Context, productID int32) ([]Movement, error)
+	// ListRecentMovements returns up to limit of the latest movements of each of
+	// the given products, newest first, without their lots and serials.
+	ListRecentMovements(ctx context.Context, productIDs []int32, limit int32) ([]Movement, error)
+}
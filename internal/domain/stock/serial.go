@@ -0,0 +1,53 @@
+package stock
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSerialNotFound = errors.New("serial number not found")
+	// ErrSerialStatus is returned when a serial is not in a status the movement can
+	// change, e.g. issuing a unit that was already shipped.
+	ErrSerialStatus = errors.New("serial number status does not allow this movement")
+)
+
+type SerialStatus string
+
+const (
+	SerialInStock     SerialStatus = "in_stock"
+	SerialReserved    SerialStatus = "reserved"
+	SerialShipped     SerialStatus = "shipped"
+	SerialReturned    SerialStatus = "returned"
+	SerialQuarantined SerialStatus = "quarantined"
+	SerialWrittenOff  SerialStatus = "written_off"
+)
+
+// OnHand reports whether a unit in this status is counted in stock.
+func (s SerialStatus) OnHand() bool {
+	return s == SerialInStock || s == SerialReserved || s == SerialReturned
+}
+
+// Serial is one individually tracked unit of a serialized product.
+type Serial struct {
+	ID        int32        `json:"id"`
+	ProductID int32        `json:"product_id"`
+	Number    string       `json:"serial_number"`
+	Status    SerialStatus `json:"status"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// SerialTrail is a unit with every movement it took part in, oldest first.
+type SerialTrail struct {
+	Serial
+	Movements []Movement `json:"movements"`
+}
+
+// SerialUpdate moves a serial from one status to another as part of a movement.
+// A zero ID means the serial is new and is created with status To.
+type SerialUpdate struct {
+	ID     int32
+	Number string
+	From   SerialStatus
+	To     SerialStatus
+}
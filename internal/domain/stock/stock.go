@@ -0,0 +1,68 @@
+package stock
+
+import (
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+)
+
+type MovementType string
+
+const (
+	MovementReceipt    MovementType = "receipt"
+	MovementIssue      MovementType = "issue"
+	MovementAdjustment MovementType = "adjustment"
+	// MovementReturn puts goods returned by a customer back into sellable stock.
+	MovementReturn MovementType = "return"
+	// MovementQuarantine changes the quarantined stock of a product, which is kept
+	// apart from its sellable quantity.
+	MovementQuarantine MovementType = "quarantine"
+	// MovementTransferOut and MovementTransferIn move stock out of and into a
+	// warehouse as part of a transfer between warehouses.
+	MovementTransferOut MovementType = "transfer_out"
+	MovementTransferIn  MovementType = "transfer_in"
+)
+
+// Sign returns 1 for types that add stock, -1 for types that remove it and 0
+// for types whose entered quantity carries its own sign.
+func (t MovementType) Sign() int32 {
+	switch t {
+	case MovementReceipt, MovementReturn, MovementTransferIn:
+		return 1
+	case MovementIssue, MovementTransferOut:
+		return -1
+	}
+	return 0
+}
+
+// Movement is a change of on-hand stock. Quantity is signed and expressed in the
+// product base unit; EnteredQuantity and EnteredUnit keep what the user sent.
+// For lot-tracked products Lots says which lots the quantity came from or went to;
+// for serialized products Serials lists every unit moved. WarehouseID is the
+// warehouse whose stock changes; zero means the default warehouse. Cost is the
+// change in stock value the movement caused, in the product currency.
+type Movement struct {
+	ID              int32           `json:"id"`
+	ProductID       int32           `json:"product_id"`
+	Type            MovementType    `json:"type"`
+	WarehouseID     int32           `json:"warehouse_id,omitempty"`
+	Quantity        int32           `json:"quantity"`
+	EnteredQuantity string          `json:"entered_quantity"`
+	EnteredUnit     string          `json:"entered_unit"`
+	Reference       string          `json:"reference,omitempty"`
+	Lots            []LotAllocation `json:"lots,omitempty"`
+	Serials         []string        `json:"serials,omitempty"`
+	Cost            *money.Money    `json:"cost,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+
+	// Lot is the lot requested by the caller, if any; a new lot is created when
+	// the movement is applied.
+	Lot *Lot `json:"-"`
+	// SerialUpdates are the status changes of Serials, set by the use case.
+	SerialUpdates []SerialUpdate `json:"-"`
+	// UnitCost is the cost of one base unit of incoming stock; without it the
+	// stock is valued at the current average cost.
+	UnitCost *money.Money `json:"-"`
+	// CostMethod values outgoing stock, set by the use case.
+	CostMethod CostMethod `json:"-"`
+}
@@ -0,0 +1,116 @@
+package stock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+)
+
+var (
+	ErrInvalidFactor      = errors.New("conversion factor must be a positive number")
+	ErrInvalidQuantity    = errors.New("invalid quantity")
+	ErrFractionalQuantity = errors.New("quantity does not convert to a whole number of base units")
+)
+
+// RoundingRule decides what happens when a quantity converts to a fractional number of base units.
+type RoundingRule string
+
+const (
+	RoundReject RoundingRule = "reject"
+	RoundDown   RoundingRule = "down"
+	RoundUp     RoundingRule = "up"
+	RoundHalfUp RoundingRule = "half_up"
+)
+
+func (r RoundingRule) Valid() bool {
+	switch r {
+	case RoundReject, RoundDown, RoundUp, RoundHalfUp:
+		return true
+	}
+	return false
+}
+
+// Factor is an exact conversion ratio Num/Den, always reduced.
+type Factor struct {
+	Num int64
+	Den int64
+}
+
+// ParseFactor accepts integers ("12"), decimals ("0.5") and fractions ("1/6").
+func ParseFactor(s string) (Factor, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || r.Sign() <= 0 || !r.Num().IsInt64() || !r.Denom().IsInt64() {
+		return Factor{}, fmt.Errorf("%w: %q", ErrInvalidFactor, s)
+	}
+	return Factor{Num: r.Num().Int64(), Den: r.Denom().Int64()}, nil
+}
+
+func (f Factor) Rat() *big.Rat {
+	return big.NewRat(f.Num, f.Den)
+}
+
+func (f Factor) String() string {
+	return f.Rat().RatString()
+}
+
+func (f Factor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+func (f *Factor) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseFactor(s)
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// Unit is an alternate unit of measure of a product, e.g. a case of 12 bottles.
+type Unit struct {
+	ProductID int32        `json:"product_id"`
+	Code      string       `json:"code"`
+	Factor    Factor       `json:"factor" swaggertype:"string" example:"12"`
+	Rounding  RoundingRule `json:"rounding"`
+}
+
+// BaseUnit returns the identity unit for a product's base unit of measure.
+func BaseUnit(productID int32, code string) Unit {
+	return Unit{ProductID: productID, Code: code, Factor: Factor{Num: 1, Den: 1}, Rounding: RoundReject}
+}
+
+// ToBase converts a decimal quantity given in this unit to whole base units.
+func (u Unit) ToBase(quantity string) (int32, error) {
+	q, ok := new(big.Rat).SetString(strings.TrimSpace(quantity))
+	if !ok || strings.Contains(quantity, "/") {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidQuantity, quantity)
+	}
+
+	base := q.Mul(q, u.Factor.Rat())
+	var n *big.Int
+	switch {
+	case base.IsInt():
+		n = base.Num()
+	case u.Rounding == RoundDown:
+		n = money.RoundRat(base, money.RoundDown)
+	case u.Rounding == RoundUp:
+		n = money.RoundRat(base, money.RoundUp)
+	case u.Rounding == RoundHalfUp:
+		n = money.RoundRat(base, money.RoundHalfUp)
+	default:
+		return 0, fmt.Errorf("%w: %s %s = %s base units", ErrFractionalQuantity, quantity, u.Code, base.FloatString(3))
+	}
+
+	if !n.IsInt64() || n.Int64() > 1<<31-1 || n.Int64() < -(1<<31) {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidQuantity, quantity)
+	}
+	return int32(n.Int64()), nil
+}
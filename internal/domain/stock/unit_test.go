@@ -0,0 +1,63 @@
+package stock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFactor(t *testing.T) {
+	f, err := ParseFactor("12")
+	assert.NoError(t, err)
+	assert.Equal(t, Factor{Num: 12, Den: 1}, f)
+
+	f, err = ParseFactor("0.25")
+	assert.NoError(t, err)
+	assert.Equal(t, Factor{Num: 1, Den: 4}, f)
+	assert.Equal(t, "1/4", f.String())
+
+	for _, s := range []string{"0", "-2", "abc", ""} {
+		_, err := ParseFactor(s)
+		assert.ErrorIs(t, err, ErrInvalidFactor, s)
+	}
+}
+
+func TestToBase(t *testing.T) {
+	caseOf12 := Unit{Code: "case", Factor: Factor{Num: 12, Den: 1}, Rounding: RoundReject}
+	sixth := Unit{Code: "sixth", Factor: Factor{Num: 1, Den: 6}}
+
+	tests := []struct {
+		name     string
+		unit     Unit
+		quantity string
+		rounding RoundingRule
+		expected int32
+		wantErr  error
+	}{
+		{"Whole cases", caseOf12, "3", RoundReject, 36, nil},
+		{"Half case", caseOf12, "1.5", RoundReject, 18, nil},
+		{"Negative adjustment", caseOf12, "-2", RoundReject, -24, nil},
+		{"Fraction rejected", caseOf12, "0.1", RoundReject, 0, ErrFractionalQuantity},
+		{"Round down", sixth, "5", RoundDown, 0, nil},
+		{"Round up", sixth, "5", RoundUp, 1, nil},
+		{"Round half up", sixth, "3", RoundHalfUp, 1, nil},
+		{"Exact fraction", sixth, "12", RoundReject, 2, nil},
+		{"Not a number", caseOf12, "two", RoundReject, 0, ErrInvalidQuantity},
+		{"Fraction syntax", caseOf12, "1/2", RoundReject, 0, ErrInvalidQuantity},
+		{"Out of range", caseOf12, "999999999999", RoundReject, 0, ErrInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := tt.unit
+			unit.Rounding = tt.rounding
+			got, err := unit.ToBase(tt.quantity)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package supplier
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("supplier not found")
+	ErrInUse    = errors.New("supplier has purchase orders and cannot be deleted")
+)
+
+type Repository interface {
+	Create(ctx context.Context, s Supplier) (int32, error)
+	GetByID(ctx context.Context, id int32) (Supplier, error)
+	Update(ctx context.Context, s Supplier) error
+	// Delete removes the supplier with its product links; it fails with ErrInUse
+	// while purchase orders refer to the supplier.
+	Delete(ctx context.Context, id int32) error
+	List(ctx context.Context) ([]Supplier, error)
+
+	SetProductSupplier(ctx context.Context, ps ProductSupplier) error
+	DeleteProductSupplier(ctx context.Context, productID, supplierID int32) error
+	ListProductSuppliers(ctx context.Context, productID int32) ([]ProductSupplier, error)
+	// ListAllProductSuppliers returns the supplier links of every product.
+	ListAllProductSuppliers(ctx context.Context) ([]ProductSupplier, error)
+}
@@ -0,0 +1,30 @@
+package supplier
+
+import "github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+
+type Supplier struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	// PaymentTerms is free text such as "NET30" or "prepaid".
+	PaymentTerms string `json:"payment_terms"`
+}
+
+// ProductSupplier links a product to a supplier that sells it. LeadTimeDays and
+// SupplierName come from the supplier.
+type ProductSupplier struct {
+	ProductID    int32       `json:"product_id"`
+	SupplierID   int32       `json:"supplier_id"`
+	SupplierName string      `json:"supplier_name,omitempty"`
+	SupplierSKU  string      `json:"supplier_sku"`
+	CostPrice    money.Money `json:"cost_price"`
+	MinOrderQty  int32       `json:"min_order_quantity"`
+	LeadTimeDays int32       `json:"lead_time_days"`
+	// ComparableCost is the cost price in the product currency, used to rank
+	// suppliers; it is empty when no exchange rate is available.
+	ComparableCost *money.Money `json:"comparable_cost,omitempty"`
+}
@@ -0,0 +1,31 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+var (
+	ErrNotFound = errors.New("transfer not found")
+	// ErrStatus is returned when the transfer is not in a status that allows the action.
+	ErrStatus = errors.New("transfer status does not allow this action")
+)
+
+type Repository interface {
+	Create(ctx context.Context, t Transfer) (int32, error)
+	// Get returns the transfer with its lines, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Transfer, error)
+	// List returns transfers without lines; an empty status or nil warehouse
+	// matches all. A warehouse matches transfers from or to it.
+	List(ctx context.Context, status Status, warehouseID *int32) ([]Transfer, error)
+	// ListOpen returns draft and in-transit transfers with their lines, oldest first.
+	ListOpen(ctx context.Context, warehouseID *int32) ([]Transfer, error)
+
+	// Update locks the transfer and passes it to fn, which changes its status and
+	// line quantities and returns the stock movements to record. Everything is
+	// committed together. fn runs inside the transaction, so it must not query the
+	// database itself.
+	Update(ctx context.Context, id int32, fn func(t *Transfer) ([]stock.Movement, error)) (Transfer, error)
+}
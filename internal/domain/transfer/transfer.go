@@ -0,0 +1,77 @@
+package transfer
+
+import "time"
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusInTransit Status = "in_transit"
+	StatusReceived  Status = "received"
+	StatusCancelled Status = "cancelled"
+)
+
+// Valid reports whether s is a known transfer status.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusInTransit, StatusReceived, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Open reports whether a transfer in this status still has to be shipped or received.
+func (s Status) Open() bool {
+	return s == StatusDraft || s == StatusInTransit
+}
+
+// Transfer moves stock from one warehouse to another. Shipped goods leave the
+// source warehouse and are in transit, counted in neither warehouse, until the
+// destination receives them.
+type Transfer struct {
+	ID              int32      `json:"id"`
+	FromWarehouseID int32      `json:"from_warehouse_id"`
+	ToWarehouseID   int32      `json:"to_warehouse_id"`
+	Status          Status     `json:"status"`
+	Reference       string     `json:"reference,omitempty"`
+	Carrier         string     `json:"carrier,omitempty"`
+	TrackingNumber  string     `json:"tracking_number,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ShippedAt       *time.Time `json:"shipped_at,omitempty"`
+	ReceivedAt      *time.Time `json:"received_at,omitempty"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	Lines           []Line     `json:"lines,omitempty"`
+}
+
+// Line is the quantity of one product on a transfer. Discrepancy is the
+// received quantity less the shipped one: negative for goods lost in transit,
+// positive for goods received in excess.
+type Line struct {
+	ID                int32  `json:"id"`
+	ProductID         int32  `json:"product_id"`
+	Quantity          int32  `json:"quantity"`
+	ShippedQuantity   int32  `json:"shipped_quantity"`
+	ReceivedQuantity  int32  `json:"received_quantity"`
+	Discrepancy       int32  `json:"discrepancy"`
+	DiscrepancyReason string `json:"discrepancy_reason,omitempty"`
+}
+
+// Shipment describes how a transfer is sent.
+type Shipment struct {
+	Carrier        string
+	TrackingNumber string
+}
+
+// Receipt lists what arrived at the destination. Lines that are left out are
+// received with a quantity of zero.
+type Receipt struct {
+	Lines []ReceivedLine
+}
+
+// ReceivedLine is the quantity of a transfer line counted at the destination.
+// Reason explains a quantity that differs from the shipped one.
+type ReceivedLine struct {
+	LineID   int32
+	Quantity int32
+	Reason   string
+}
@@ -0,0 +1,12 @@
+package valuation
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// ProductValues returns the stock quantity and value of every product that
+	// had valued stock at the given time, ordered by product name.
+	ProductValues(ctx context.Context, asOf time.Time) ([]ProductValue, error)
+}
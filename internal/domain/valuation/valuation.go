@@ -0,0 +1,26 @@
+package valuation
+
+import (
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+// ProductValue is the valued stock of a product at a point in time. UnitCost is
+// the average cost of that stock.
+type ProductValue struct {
+	ProductID   int32       `json:"product_id"`
+	ProductName string      `json:"product_name"`
+	Quantity    int32       `json:"quantity"`
+	Value       money.Money `json:"value"`
+	UnitCost    money.Money `json:"unit_cost"`
+}
+
+// Report is the value of stock as of a date, with one total per currency.
+type Report struct {
+	AsOf     time.Time        `json:"as_of"`
+	Method   stock.CostMethod `json:"method"`
+	Totals   []money.Money    `json:"totals"`
+	Products []ProductValue   `json:"products"`
+}
@@ -0,0 +1,21 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNotFound  = errors.New("warehouse not found")
+	ErrCodeTaken = errors.New("warehouse code is already in use")
+)
+
+type Repository interface {
+	// Create fails with ErrCodeTaken if another warehouse has the same code.
+	Create(ctx context.Context, w Warehouse) (int32, error)
+	// Get returns the warehouse, or ErrNotFound.
+	Get(ctx context.Context, id int32) (Warehouse, error)
+	List(ctx context.Context) ([]Warehouse, error)
+	// Stock returns the products on hand at the warehouse or in transit to it.
+	Stock(ctx context.Context, id int32) ([]Stock, error)
+}
@@ -0,0 +1,23 @@
+package warehouse
+
+import "time"
+
+// Warehouse is a site that holds stock. Stock movements that do not name a
+// warehouse belong to the default one.
+type Warehouse struct {
+	ID        int32     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Default   bool      `json:"default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Stock is the quantity of a product on hand at a warehouse. InboundQuantity
+// is shipped to the warehouse by transfers that have not been received yet; it
+// is not part of Quantity.
+type Stock struct {
+	ProductID       int32  `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	Quantity        int32  `json:"quantity"`
+	InboundQuantity int32  `json:"inbound_quantity"`
+}
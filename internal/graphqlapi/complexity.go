@@ -0,0 +1,177 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// checkLimits rejects an operation of the parsed request whose cost or depth
+// exceeds the limits.
+func (s *Server) checkLimits(doc *ast.Document, req Request) *queryError {
+	m := &measure{
+		schema:    s.schema,
+		variables: req.Variables,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		visiting:  make(map[string]bool),
+	}
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if op == nil && (req.OperationName == "" || def.Name != nil && def.Name.Value == req.OperationName) {
+				op = def
+			}
+		}
+	}
+	if op == nil {
+		return nil
+	}
+
+	var root graphql.Named
+	switch op.Operation {
+	case ast.OperationTypeQuery:
+		root = s.schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = s.schema.MutationType()
+	default:
+		return nil
+	}
+	cost, depth := m.selections(op.SelectionSet, root)
+
+	switch {
+	case s.limits.MaxDepth > 0 && depth > s.limits.MaxDepth:
+		return &queryError{
+			code:    codeTooComplex,
+			message: fmt.Sprintf("query depth %d exceeds the limit of %d", depth, s.limits.MaxDepth),
+		}
+	case s.limits.MaxComplexity > 0 && cost > s.limits.MaxComplexity:
+		return &queryError{
+			code:    codeTooComplex,
+			message: fmt.Sprintf("query complexity %d exceeds the limit of %d", cost, s.limits.MaxComplexity),
+		}
+	}
+	return nil
+}
+
+// measure computes the cost and depth of a selection set against the schema.
+// Unknown fields are free; the executor validates them. Introspection fields
+// are free too but count toward the depth, as the introspection types nest
+// without bound.
+type measure struct {
+	schema    graphql.Schema
+	variables map[string]any
+	fragments map[string]*ast.FragmentDefinition
+	// visiting guards against fragments that spread themselves.
+	visiting map[string]bool
+}
+
+func (m *measure) selections(set *ast.SelectionSet, parent graphql.Named) (cost, depth int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var c, d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			c, d = m.field(sel, parent)
+		case *ast.InlineFragment:
+			t := parent
+			if sel.TypeCondition != nil {
+				t = m.schema.Type(sel.TypeCondition.Name.Value)
+			}
+			c, d = m.selections(sel.SelectionSet, t)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag := m.fragments[name]
+			if frag == nil || m.visiting[name] {
+				continue
+			}
+			m.visiting[name] = true
+			c, d = m.selections(frag.SelectionSet, m.schema.Type(frag.TypeCondition.Name.Value))
+			m.visiting[name] = false
+		}
+		cost = saturatingAdd(cost, c)
+		depth = max(depth, d)
+	}
+	return cost, depth
+}
+
+func (m *measure) field(f *ast.Field, parent graphql.Named) (cost, depth int) {
+	name := f.Name.Value
+	var def *graphql.FieldDefinition
+	switch {
+	case name == "__schema":
+		def = graphql.SchemaMetaFieldDef
+	case name == "__type":
+		def = graphql.TypeMetaFieldDef
+	case strings.HasPrefix(name, "__"):
+		return 0, 0
+	default:
+		obj, ok := parent.(*graphql.Object)
+		if !ok {
+			return 0, 0
+		}
+		def = obj.Fields()[name]
+	}
+	if def == nil {
+		return 0, 0
+	}
+	childCost, childDepth := m.selections(f.SelectionSet, graphql.GetNamed(def.Type))
+	if strings.HasPrefix(name, "__") || strings.HasPrefix(parent.String(), "__") {
+		return 0, childDepth + 1
+	}
+	return saturatingAdd(1, saturatingMul(m.items(f, def), childCost)), childDepth + 1
+}
+
+// items is the number of list items the field asks for: its `first` argument,
+// or that argument's default, or one for fields without it.
+func (m *measure) items(f *ast.Field, def *graphql.FieldDefinition) int {
+	n := 1
+	for _, arg := range def.Args {
+		if arg.Name() == "first" {
+			if v, ok := arg.DefaultValue.(int); ok {
+				n = v
+			}
+		}
+	}
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if i, err := strconv.Atoi(v.Value); err == nil {
+				n = i
+			}
+		case *ast.Variable:
+			switch i := m.variables[v.Name.Value].(type) {
+			case int:
+				n = i
+			case float64:
+				n = int(min(i, math.MaxInt32))
+			}
+		}
+	}
+	return max(n, 1)
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func saturatingMul(a, b int) int {
+	if b != 0 && a > math.MaxInt32/b {
+		return math.MaxInt32
+	}
+	return a * b
+}
@@ -0,0 +1,73 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+)
+
+type loaderKey struct{}
+
+// movementLoader batches the stock movements requested by the products of one
+// request. Resolvers register their product and return a thunk; the executor
+// calls the thunks once the whole list is resolved, and the first one loads the
+// movements of every registered product in a single query.
+type movementLoader struct {
+	ctx   context.Context
+	stock *usecase.StockUseCase
+
+	mu sync.Mutex
+	// pending holds the batch still accepting products per movement limit.
+	pending map[int32]*movementBatch
+}
+
+type movementBatch struct {
+	ids    []int32
+	seen   map[int32]bool
+	loaded bool
+	result map[int32][]stock.Movement
+	err    error
+}
+
+func withMovementLoader(ctx context.Context, uc *usecase.StockUseCase) context.Context {
+	l := &movementLoader{ctx: ctx, stock: uc, pending: make(map[int32]*movementBatch)}
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func movementLoaderFrom(ctx context.Context) *movementLoader {
+	return ctx.Value(loaderKey{}).(*movementLoader)
+}
+
+// load adds the product to the pending batch for the limit and returns a thunk
+// yielding its movements.
+func (l *movementLoader) load(productID, limit int32) func() ([]stock.Movement, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.pending[limit]
+	if b == nil {
+		b = &movementBatch{seen: make(map[int32]bool)}
+		l.pending[limit] = b
+	}
+	if !b.seen[productID] {
+		b.seen[productID] = true
+		b.ids = append(b.ids, productID)
+	}
+
+	return func() ([]stock.Movement, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !b.loaded {
+			// Products resolved from now on start a new batch.
+			if l.pending[limit] == b {
+				delete(l.pending, limit)
+			}
+			b.result, b.err = l.stock.RecentMovements(l.ctx, b.ids, limit)
+			b.loaded = true
+		}
+		return b.result[productID], b.err
+	}
+}
@@ -0,0 +1,351 @@
+package graphqlapi
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/graphql-go/graphql"
+)
+
+// maxMovements is the most stock movements a product field returns.
+const maxMovements = 100
+
+// productPage is the source of the ProductPage type.
+type productPage struct {
+	items       []product.Product
+	totalCount  int32
+	hasNextPage bool
+}
+
+// field resolves a field of a source of type T with get.
+func field[T any](get func(T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(T)), nil
+	}
+}
+
+func (s *Server) newSchema() (graphql.Schema, error) {
+	moneyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Money",
+		Description: "An amount of money as a decimal string, e.g. \"12.50\", in a currency.",
+		Fields: graphql.Fields{
+			"amount":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m money.Money) any { return m.Decimal() })},
+			"currency": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m money.Money) any { return m.Currency })},
+		},
+	})
+
+	movementType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "StockMovement",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(m stock.Movement) any { return m.ID })},
+			"type":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m stock.Movement) any { return string(m.Type) })},
+			"warehouseId":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(m stock.Movement) any { return m.WarehouseID })},
+			"quantity":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(m stock.Movement) any { return m.Quantity })},
+			"enteredQuantity": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m stock.Movement) any { return m.EnteredQuantity })},
+			"enteredUnit":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m stock.Movement) any { return m.EnteredUnit })},
+			"reference":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(m stock.Movement) any { return m.Reference })},
+			"createdAt": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "RFC 3339 time the movement was recorded.",
+				Resolve:     field(func(m stock.Movement) any { return m.CreatedAt.Format(time.RFC3339) }),
+			},
+		},
+	})
+
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(p product.Product) any { return p.ID })},
+			"name":                &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(p product.Product) any { return p.Name })},
+			"description":         &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(p product.Product) any { return p.Description })},
+			"price":               &graphql.Field{Type: graphql.NewNonNull(moneyType), Resolve: field(func(p product.Product) any { return p.Price })},
+			"quantity":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(p product.Product) any { return p.Quantity })},
+			"baseUnit":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(p product.Product) any { return p.BaseUnit })},
+			"lotTracked":          &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(p product.Product) any { return p.LotTracked })},
+			"serialized":          &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(p product.Product) any { return p.Serialized })},
+			"binLocation":         &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(p product.Product) any { return p.BinLocation })},
+			"category":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(p product.Product) any { return p.Category })},
+			"quarantinedQuantity": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(p product.Product) any { return p.QuarantinedQuantity })},
+			"movements": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(movementType))),
+				Description: "The latest stock movements of the product, newest first.",
+				Args: graphql.FieldConfigArgument{
+					"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+				},
+				Resolve: s.resolveMovements,
+			},
+		},
+	})
+
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProductPage",
+		Fields: graphql.Fields{
+			"items":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(productType))), Resolve: field(func(p productPage) any { return p.items })},
+			"totalCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(p productPage) any { return p.totalCount })},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(p productPage) any { return p.hasNextPage })},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ProductFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"search":   &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Part of the name or description, ignoring case."},
+			"category": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"currency": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"inStock":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		},
+	})
+
+	moneyInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MoneyInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"amount":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"currency": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	// productInput mirrors the REST product request; on update every field is
+	// optional and only the given ones change.
+	productInput := func(name string, create bool) *graphql.InputObject {
+		required := func(t graphql.Input) graphql.Input {
+			if create {
+				return graphql.NewNonNull(t)
+			}
+			return t
+		}
+		fields := graphql.InputObjectConfigFieldMap{
+			"name":        &graphql.InputObjectFieldConfig{Type: required(graphql.String)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"price":       &graphql.InputObjectFieldConfig{Type: required(moneyInput)},
+			"quantity":    &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"lotTracked":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"serialized":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"binLocation": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"category":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		}
+		if create {
+			// The base unit cannot change once stock is kept in it.
+			fields["baseUnit"] = &graphql.InputObjectFieldConfig{Type: graphql.String}
+			// The cost of one unit of the opening stock; the price if omitted.
+			fields["unitCost"] = &graphql.InputObjectFieldConfig{Type: moneyInput}
+		}
+		return graphql.NewInputObject(graphql.InputObjectConfig{Name: name, Fields: fields})
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"products": &graphql.Field{
+				Type: graphql.NewNonNull(pageType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+					"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: s.resolveProducts,
+			},
+			"product": &graphql.Field{
+				Type: productType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: s.resolveProduct,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createProduct": &graphql.Field{
+				Type: graphql.NewNonNull(productType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(productInput("CreateProductInput", true))},
+				},
+				Resolve: s.createProduct,
+			},
+			"updateProduct": &graphql.Field{
+				Type: graphql.NewNonNull(productType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(productInput("UpdateProductInput", false))},
+				},
+				Resolve: s.updateProduct,
+			},
+			"deleteProduct": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: s.deleteProduct,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (s *Server) resolveProducts(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.products"
+
+	f := product.Filter{
+		Limit:  int32(p.Args["first"].(int)),
+		Offset: int32(p.Args["offset"].(int)),
+	}
+	if filter, ok := p.Args["filter"].(map[string]any); ok {
+		f.Search, _ = filter["search"].(string)
+		f.Category, _ = filter["category"].(string)
+		f.Currency, _ = filter["currency"].(string)
+		if inStock, ok := filter["inStock"].(bool); ok {
+			f.InStock = &inStock
+		}
+	}
+
+	items, total, err := s.dep.Product.Search(p.Context, f)
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error searching products", err)
+	}
+	return productPage{
+		items:       items,
+		totalCount:  total,
+		hasNextPage: f.Offset+int32(len(items)) < total,
+	}, nil
+}
+
+func (s *Server) resolveProduct(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.product"
+
+	pr, err := s.dep.Product.GetByID(p.Context, int32(p.Args["id"].(int)))
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error getting product", err)
+	}
+	return pr, nil
+}
+
+// resolveMovements returns a thunk so the movements of all products in a list
+// load together once the list is resolved.
+func (s *Server) resolveMovements(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.product.movements"
+
+	first := p.Args["first"].(int)
+	if first < 0 || first > maxMovements {
+		return nil, badInput("first must be between 0 and %d", maxMovements)
+	}
+	load := movementLoaderFrom(p.Context).load(p.Source.(product.Product).ID, int32(first))
+	return func() (any, error) {
+		movements, err := load()
+		if err != nil {
+			return nil, fail(p.Context, s.dep, op, "Error listing stock movements", err)
+		}
+		if movements == nil {
+			movements = []stock.Movement{}
+		}
+		return movements, nil
+	}, nil
+}
+
+func (s *Server) createProduct(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.product.create"
+
+	var pr product.Product
+	if err := applyInput(&pr, p.Args["input"].(map[string]any)); err != nil {
+		return nil, err
+	}
+	id, err := s.dep.Product.Create(p.Context, pr)
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error creating product", err)
+	}
+	pr, err = s.dep.Product.GetByID(p.Context, id)
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error getting created product", err)
+	}
+	return pr, nil
+}
+
+func (s *Server) updateProduct(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.product.update"
+
+	id := int32(p.Args["id"].(int))
+	pr, err := s.dep.Product.GetByID(p.Context, id)
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error getting product", err)
+	}
+	if err := applyInput(&pr, p.Args["input"].(map[string]any)); err != nil {
+		return nil, err
+	}
+	if err := s.dep.Product.Update(p.Context, pr); err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error updating product", err)
+	}
+	pr, err = s.dep.Product.GetByID(p.Context, id)
+	if err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error getting updated product", err)
+	}
+	return pr, nil
+}
+
+func (s *Server) deleteProduct(p graphql.ResolveParams) (any, error) {
+	const op = "graphql.product.delete"
+
+	if err := s.dep.Product.Delete(p.Context, int32(p.Args["id"].(int))); err != nil {
+		return nil, fail(p.Context, s.dep, op, "Error deleting product", err)
+	}
+	return true, nil
+}
+
+// applyInput sets the fields given in the input on the product and checks the
+// fields the REST API checks when binding a request.
+func applyInput(pr *product.Product, input map[string]any) error {
+	if v, ok := input["name"].(string); ok {
+		pr.Name = v
+	}
+	if v, ok := input["description"].(string); ok {
+		pr.Description = v
+	}
+	if v, ok := input["price"].(map[string]any); ok {
+		price, err := money.Parse(v["amount"].(string), v["currency"].(string))
+		if err != nil {
+			return badInput("%s", err.Error())
+		}
+		pr.Price = price
+	}
+	if v, ok := input["quantity"].(int); ok {
+		pr.Quantity = int32(v)
+	}
+	if v, ok := input["baseUnit"].(string); ok {
+		pr.BaseUnit = v
+	}
+	if v, ok := input["unitCost"].(map[string]any); ok {
+		cost, err := money.Parse(v["amount"].(string), v["currency"].(string))
+		if err != nil {
+			return badInput("%s", err.Error())
+		}
+		pr.OpeningUnitCost = &cost
+	}
+	if v, ok := input["lotTracked"].(bool); ok {
+		pr.LotTracked = v
+	}
+	if v, ok := input["serialized"].(bool); ok {
+		pr.Serialized = v
+	}
+	if v, ok := input["binLocation"].(string); ok {
+		pr.BinLocation = v
+	}
+	if v, ok := input["category"].(string); ok {
+		pr.Category = v
+	}
+
+	switch {
+	case utf8.RuneCountInString(pr.Name) < 2 || utf8.RuneCountInString(pr.Name) > 255:
+		return badInput("name must be 2 to 255 characters")
+	case utf8.RuneCountInString(pr.Description) > 1000:
+		return badInput("description must be at most 1000 characters")
+	case pr.Quantity < 0:
+		return badInput("quantity must not be negative")
+	case len(pr.BaseUnit) > 20 || len(pr.BinLocation) > 50 || len(pr.Category) > 100:
+		return badInput("base unit, bin location or category is too long")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Limits bound the work a single request may ask for.
+type Limits struct {
+	// MaxComplexity is the highest cost of an operation: every field costs one,
+	// and the fields below a list taking a `first` argument count once per item.
+	MaxComplexity int
+	// MaxDepth is the deepest nesting of fields in an operation.
+	MaxDepth int
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]any
+}
+
+// Server executes GraphQL operations against the same use cases as the REST
+// handlers.
+type Server struct {
+	dep    *scope.Dependencies
+	schema graphql.Schema
+	limits Limits
+}
+
+func NewServer(dep *scope.Dependencies, limits Limits) (*Server, error) {
+	s := &Server{dep: dep, limits: limits}
+	schema, err := s.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs the operation unless it does not parse, exceeds the limits or is
+// invalid against the schema. Stock movements of the products it returns are
+// loaded in one query per list.
+func (s *Server) Execute(ctx context.Context, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		formatted := gqlerrors.FormatError(err)
+		formatted.Extensions = map[string]any{"code": codeParseFailed}
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{formatted}}
+	}
+	if err := s.checkLimits(doc, req); err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    err.Error(),
+			Extensions: err.Extensions(),
+		}}}
+	}
+	if v := graphql.ValidateDocument(&s.schema, doc, nil); !v.IsValid {
+		return &graphql.Result{Errors: v.Errors}
+	}
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		Args:          req.Variables,
+		OperationName: req.OperationName,
+		Context:       withMovementLoader(ctx, s.dep.Stock),
+	})
+}
+
+const (
+	codeBadUserInput = "BAD_USER_INPUT"
+	codeNotFound     = "NOT_FOUND"
+	codeInternal     = "INTERNAL"
+	codeTooComplex   = "QUERY_TOO_COMPLEX"
+	codeParseFailed  = "GRAPHQL_PARSE_FAILED"
+)
+
+// queryError is an error reported to the client with a code in its extensions.
+type queryError struct {
+	code    string
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+func badInput(format string, args ...any) error {
+	return &queryError{code: codeBadUserInput, message: fmt.Sprintf(format, args...)}
+}
+
+// fail logs a failed resolver with the logger of ctx and returns err as a query
+// error: missing records are NOT_FOUND and business rule failures
+// BAD_USER_INPUT with the error message, anything else INTERNAL without details.
+func fail(ctx context.Context, dep *scope.Dependencies, op, msg string, err error) error {
+	sl.FromContext(ctx, dep.Sl).Error(fmt.Sprintf("%s | %s: ", op, msg), sl.Err(err))
+	dep.Metrics.Reject(op, err)
+	switch {
+	case usecase.IsNotFound(err):
+		return &queryError{code: codeNotFound, message: err.Error()}
+	case usecase.IsBusinessError(err):
+		return &queryError{code: codeBadUserInput, message: err.Error()}
+	}
+	return &queryError{code: codeInternal, message: "internal error"}
+}
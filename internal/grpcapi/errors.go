@@ -0,0 +1,77 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failedPreconditions are business errors caused by the current state of the
+// stored data rather than by the request alone; retrying after that state
+// changes may succeed.
+var failedPreconditions = []error{
+	stock.ErrInsufficientStock,
+	stock.ErrSerialStatus,
+	usecase.ErrTrackingChange,
+	usecase.ErrCurrencyChange,
+	usecase.ErrQuantityManaged,
+	usecase.ErrLotExpired,
+	usecase.ErrPriceChangeNotPending,
+	usecase.ErrNoExchangeRate,
+	usecase.ErrNoSupplierCost,
+	supplier.ErrInUse,
+	product.ErrInUse,
+	purchase.ErrStatus,
+	sales.ErrStatus,
+	rma.ErrStatus,
+	transfer.ErrStatus,
+	cyclecount.ErrStatus,
+}
+
+var alreadyExists = []error{
+	usecase.ErrDuplicateSerial,
+	warehouse.ErrCodeTaken,
+}
+
+// statusError maps a use case error to a gRPC status: missing records become
+// NotFound, business rule failures InvalidArgument, FailedPrecondition or
+// AlreadyExists with the error message, and anything else Internal without
+// details.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case usecase.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case isAny(err, alreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case isAny(err, failedPreconditions):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case usecase.IsBusinessError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package grpcapi
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/grpcapi/warehousev1"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type productServer struct {
+	warehousev1.UnimplementedProductServiceServer
+	dep *scope.Dependencies
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *warehousev1.CreateProductRequest) (*warehousev1.CreateProductResponse, error) {
+	const op = "grpc.product.create"
+
+	p, err := fromProduct(req.GetProduct())
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.dep.Product.Create(ctx, p)
+	if err != nil {
+		return nil, fail(ctx, s.dep, op, "Error creating product", err)
+	}
+	return &warehousev1.CreateProductResponse{Id: id}, nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *warehousev1.GetProductRequest) (*warehousev1.Product, error) {
+	const op = "grpc.product.get"
+
+	p, err := s.dep.Product.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, fail(ctx, s.dep, op, "Error getting product", err)
+	}
+	return toProduct(p), nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *warehousev1.UpdateProductRequest) (*emptypb.Empty, error) {
+	const op = "grpc.product.update"
+
+	p, err := fromProduct(req.GetProduct())
+	if err != nil {
+		return nil, err
+	}
+	p.ID = req.GetProduct().GetId()
+	if err := s.dep.Product.Update(ctx, p); err != nil {
+		return nil, fail(ctx, s.dep, op, "Error updating product", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *warehousev1.DeleteProductRequest) (*emptypb.Empty, error) {
+	const op = "grpc.product.delete"
+
+	if err := s.dep.Product.Delete(ctx, req.GetId()); err != nil {
+		return nil, fail(ctx, s.dep, op, "Error deleting product", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *warehousev1.ListProductsRequest) (*warehousev1.ListProductsResponse, error) {
+	const op = "grpc.product.list"
+
+	products, err := s.dep.Product.List(ctx)
+	if err != nil {
+		return nil, fail(ctx, s.dep, op, "Error listing products", err)
+	}
+	resp := &warehousev1.ListProductsResponse{Products: make([]*warehousev1.Product, 0, len(products))}
+	for _, p := range products {
+		resp.Products = append(resp.Products, toProduct(p))
+	}
+	return resp, nil
+}
+
+// fromProduct checks the fields the REST API checks when binding a request.
+func fromProduct(p *warehousev1.Product) (product.Product, error) {
+	switch {
+	case p == nil:
+		return product.Product{}, status.Error(codes.InvalidArgument, "product is required")
+	case utf8.RuneCountInString(p.GetName()) < 2 || utf8.RuneCountInString(p.GetName()) > 255:
+		return product.Product{}, status.Error(codes.InvalidArgument, "name must be 2 to 255 characters")
+	case utf8.RuneCountInString(p.GetDescription()) > 1000:
+		return product.Product{}, status.Error(codes.InvalidArgument, "description must be at most 1000 characters")
+	case p.GetQuantity() < 0:
+		return product.Product{}, status.Error(codes.InvalidArgument, "quantity must not be negative")
+	case len(p.GetBaseUnit()) > 20 || len(p.GetBinLocation()) > 50 || len(p.GetCategory()) > 100:
+		return product.Product{}, status.Error(codes.InvalidArgument, "base unit, bin location or category is too long")
+	}
+	price, err := fromMoney(p.GetPrice())
+	if err != nil {
+		return product.Product{}, err
+	}
+	return product.Product{
+		Name:        p.GetName(),
+		Description: p.GetDescription(),
+		Price:       price,
+		Quantity:    p.GetQuantity(),
+		BaseUnit:    p.GetBaseUnit(),
+		LotTracked:  p.GetLotTracked(),
+		Serialized:  p.GetSerialized(),
+		BinLocation: p.GetBinLocation(),
+		Category:    p.GetCategory(),
+	}, nil
+}
+
+func toProduct(p product.Product) *warehousev1.Product {
+	return &warehousev1.Product{
+		Id:                  p.ID,
+		Name:                p.Name,
+		Description:         p.Description,
+		Price:               toMoney(p.Price),
+		Quantity:            p.Quantity,
+		BaseUnit:            p.BaseUnit,
+		LotTracked:          p.LotTracked,
+		Serialized:          p.Serialized,
+		BinLocation:         p.BinLocation,
+		Category:            p.Category,
+		QuarantinedQuantity: p.QuarantinedQuantity,
+	}
+}
+
+// fromMoney parses a decimal amount; a missing amount is zero.
+func fromMoney(m *warehousev1.Money) (money.Money, error) {
+	if m == nil {
+		return money.Money{}, nil
+	}
+	v, err := money.Parse(m.GetAmount(), m.GetCurrency())
+	if err != nil {
+		return money.Money{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return v, nil
+}
+
+func toMoney(m money.Money) *warehousev1.Money {
+	return &warehousev1.Money{Amount: m.Decimal(), Currency: m.Currency}
+}
@@ -0,0 +1,45 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/grpcapi/warehousev1"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer returns a gRPC server with the product and stock services backed by
+// the same use cases as the REST handlers.
+func NewServer(dep *scope.Dependencies, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	warehousev1.RegisterProductServiceServer(s, &productServer{dep: dep})
+	warehousev1.RegisterStockServiceServer(s, &stockServer{dep: dep})
+	reflection.Register(s)
+	return s
+}
+
+// GracefulStop stops the server once pending calls finish, or at once when ctx
+// is done first.
+func GracefulStop(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
+
+// fail logs a failed call with the logger of ctx and returns err as a gRPC
+// status.
+func fail(ctx context.Context, dep *scope.Dependencies, op, msg string, err error) error {
+	sl.FromContext(ctx, dep.Sl).Error(fmt.Sprintf("%s | %s: ", op, msg), sl.Err(err))
+	dep.Metrics.Reject(op, err)
+	return statusError(err)
+}
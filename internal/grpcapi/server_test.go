@@ -0,0 +1,304 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/grpcapi/warehousev1"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockProductRepo struct {
+	products map[int32]product.Product
+	nextID   int32
+}
+
+func (m *mockProductRepo) Create(ctx context.Context, p product.Product) (int32, error) {
+	m.nextID++
+	p.ID = m.nextID
+	m.products[p.ID] = p
+	return p.ID, nil
+}
+
+func (m *mockProductRepo) GetByID(ctx context.Context, id int32) (product.Product, error) {
+	p, ok := m.products[id]
+	if !ok {
+		return product.Product{}, product.ErrNotFound
+	}
+	return p, nil
+}
+
+func (m *mockProductRepo) Update(ctx context.Context, id int32, fn func(p *product.Product) error) error {
+	p, ok := m.products[id]
+	if !ok {
+		return product.ErrNotFound
+	}
+	if err := fn(&p); err != nil {
+		return err
+	}
+	m.products[id] = p
+	return nil
+}
+
+func (m *mockProductRepo) Delete(ctx context.Context, id int32) error {
+	p, ok := m.products[id]
+	if !ok {
+		return product.ErrNotFound
+	}
+	if p.Quantity != 0 || p.QuarantinedQuantity != 0 {
+		return product.ErrInUse
+	}
+	delete(m.products, id)
+	return nil
+}
+
+func (m *mockProductRepo) List(ctx context.Context) ([]product.Product, error) {
+	var list []product.Product
+	for _, p := range m.products {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+// Search pages through all products; the filter fields are not applied.
+func (m *mockProductRepo) Search(ctx context.Context, f product.Filter) ([]product.Product, int32, error) {
+	list, _ := m.List(ctx)
+	total := int32(len(list))
+	return list[min(f.Offset, total):min(f.Offset+f.Limit, total)], total, nil
+}
+
+// mockStockRepo keeps movements of untracked products in base units only.
+type mockStockRepo struct {
+	products  *mockProductRepo
+	movements []stock.Movement
+}
+
+func (m *mockStockRepo) ListUnits(ctx context.Context, productID int32) ([]stock.Unit, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) SetUnit(ctx context.Context, u stock.Unit) error { return nil }
+func (m *mockStockRepo) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	return nil
+}
+func (m *mockStockRepo) ListLots(ctx context.Context, productID int32) ([]stock.Lot, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) FindLot(ctx context.Context, productID int32, number string) (stock.Lot, error) {
+	return stock.Lot{}, stock.ErrLotNotFound
+}
+func (m *mockStockRepo) ListExpiringLots(ctx context.Context, before time.Time) ([]stock.Lot, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) FindSerials(ctx context.Context, numbers []string) ([]stock.Serial, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) GetSerial(ctx context.Context, number string) (stock.Serial, error) {
+	return stock.Serial{}, stock.ErrSerialNotFound
+}
+func (m *mockStockRepo) ListSerialMovements(ctx context.Context, serialID int32) ([]stock.Movement, error) {
+	return nil, nil
+}
+
+func (m *mockStockRepo) ApplyMovement(ctx context.Context, mv stock.Movement) (stock.Movement, error) {
+	p := m.products.products[mv.ProductID]
+	if p.Quantity+mv.Quantity < 0 {
+		return stock.Movement{}, stock.ErrInsufficientStock
+	}
+	p.Quantity += mv.Quantity
+	m.products.products[p.ID] = p
+	mv.ID = int32(len(m.movements) + 1)
+	mv.CreatedAt = time.Now()
+	m.movements = append(m.movements, mv)
+	return mv, nil
+}
+
+func (m *mockStockRepo) ListMovements(ctx context.Context, productID int32) ([]stock.Movement, error) {
+	var list []stock.Movement
+	for i := len(m.movements) - 1; i >= 0; i-- {
+		if m.movements[i].ProductID == productID {
+			list = append(list, m.movements[i])
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) ListRecentMovements(ctx context.Context, productIDs []int32, limit int32) ([]stock.Movement, error) {
+	var list []stock.Movement
+	for _, id := range productIDs {
+		movements, _ := m.ListMovements(ctx, id)
+		list = append(list, movements[:min(int(limit), len(movements))]...)
+	}
+	return list, nil
+}
+
+// setupServer serves the gRPC API over an in-memory connection and returns
+// clients for it.
+func setupServer(t *testing.T) (warehousev1.ProductServiceClient, warehousev1.StockServiceClient, *mockProductRepo) {
+	products := &mockProductRepo{products: make(map[int32]product.Product)}
+	dep := &scope.Dependencies{
+		Product: usecase.NewProductUseCase(products),
+		Stock:   usecase.NewStockUseCase(&mockStockRepo{products: products}, products, stock.CostFIFO),
+		Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer(dep)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return warehousev1.NewProductServiceClient(conn), warehousev1.NewStockServiceClient(conn), products
+}
+
+func TestProductService(t *testing.T) {
+	products, stocks, _ := setupServer(t)
+	ctx := context.Background()
+
+	created, err := products.CreateProduct(ctx, &warehousev1.CreateProductRequest{Product: &warehousev1.Product{
+		Name: "Chair", Description: "Oak", Price: &warehousev1.Money{Amount: "49.90", Currency: "USD"}, Quantity: 3,
+	}})
+	assert.NoError(t, err)
+
+	p, err := products.GetProduct(ctx, &warehousev1.GetProductRequest{Id: created.GetId()})
+	assert.NoError(t, err)
+	assert.Equal(t, "Chair", p.GetName())
+	assert.Equal(t, "49.90", p.GetPrice().GetAmount())
+	assert.Equal(t, "pcs", p.GetBaseUnit())
+
+	p.Name = "Armchair"
+	_, err = products.UpdateProduct(ctx, &warehousev1.UpdateProductRequest{Product: p})
+	assert.NoError(t, err)
+
+	list, err := products.ListProducts(ctx, &warehousev1.ListProductsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, list.GetProducts(), 1) {
+		assert.Equal(t, "Armchair", list.GetProducts()[0].GetName())
+	}
+
+	// The chairs in stock keep the product from being deleted.
+	_, err = products.DeleteProduct(ctx, &warehousev1.DeleteProductRequest{Id: created.GetId()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = stocks.IssueStock(ctx, &warehousev1.StockMovementRequest{ProductId: created.GetId(), Quantity: "3"})
+	assert.NoError(t, err)
+
+	_, err = products.DeleteProduct(ctx, &warehousev1.DeleteProductRequest{Id: created.GetId()})
+	assert.NoError(t, err)
+	_, err = products.GetProduct(ctx, &warehousev1.GetProductRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestProductService_Errors(t *testing.T) {
+	products, _, _ := setupServer(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		product *warehousev1.Product
+		code    codes.Code
+		message string
+	}{
+		{"price limit", &warehousev1.Product{Name: "Yacht", Price: &warehousev1.Money{Amount: "20000", Currency: "USD"}},
+			codes.InvalidArgument, "price exceeds maximum allowed value of 10000.00 USD"},
+		{"reserved name", &warehousev1.Product{Name: "Sarkor", Price: &warehousev1.Money{Amount: "1", Currency: "USD"}},
+			codes.InvalidArgument, "product name is reserved"},
+		{"invalid amount", &warehousev1.Product{Name: "Lamp", Price: &warehousev1.Money{Amount: "1.2.3", Currency: "USD"}},
+			codes.InvalidArgument, ""},
+		{"short name", &warehousev1.Product{Name: "L"}, codes.InvalidArgument, "name must be 2 to 255 characters"},
+		{"tracked quantity", &warehousev1.Product{Name: "Milk", Price: &warehousev1.Money{Amount: "1", Currency: "USD"}, LotTracked: true, Quantity: 5},
+			codes.FailedPrecondition, "quantity of tracked products can only change through stock movements"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := products.CreateProduct(ctx, &warehousev1.CreateProductRequest{Product: tt.product})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.message != "" {
+				assert.Equal(t, tt.message, status.Convert(err).Message())
+			}
+		})
+	}
+
+	_, err := products.CreateProduct(ctx, &warehousev1.CreateProductRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = products.DeleteProduct(ctx, &warehousev1.DeleteProductRequest{Id: 42})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestStockService(t *testing.T) {
+	products, stocks, repo := setupServer(t)
+	ctx := context.Background()
+
+	created, err := products.CreateProduct(ctx, &warehousev1.CreateProductRequest{Product: &warehousev1.Product{
+		Name: "Screws", Price: &warehousev1.Money{Amount: "10", Currency: "USD"},
+	}})
+	assert.NoError(t, err)
+	id := created.GetId()
+
+	m, err := stocks.ReceiveStock(ctx, &warehousev1.StockMovementRequest{ProductId: id, Quantity: "5", Reference: "PO-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt", m.GetType())
+	assert.Equal(t, int32(5), m.GetQuantity())
+	assert.Equal(t, "pcs", m.GetEnteredUnit())
+
+	_, err = stocks.IssueStock(ctx, &warehousev1.StockMovementRequest{ProductId: id, Quantity: "10"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "insufficient stock", status.Convert(err).Message())
+
+	m, err = stocks.AdjustStock(ctx, &warehousev1.StockMovementRequest{ProductId: id, Quantity: "-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-2), m.GetQuantity())
+	assert.Equal(t, int32(3), repo.products[id].Quantity)
+
+	_, err = stocks.ReceiveStock(ctx, &warehousev1.StockMovementRequest{ProductId: id, Quantity: "1", Unit: "case"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = stocks.ReceiveStock(ctx, &warehousev1.StockMovementRequest{ProductId: id})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = stocks.ReceiveStock(ctx, &warehousev1.StockMovementRequest{ProductId: 42, Quantity: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	list, err := stocks.ListStockMovements(ctx, &warehousev1.ListStockMovementsRequest{ProductId: id})
+	assert.NoError(t, err)
+	if assert.Len(t, list.GetMovements(), 2) {
+		assert.Equal(t, "adjustment", list.GetMovements()[0].GetType())
+	}
+}
+
+func TestStatusError_Internal(t *testing.T) {
+	err := statusError(errors.New("connection refused"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal error", status.Convert(err).Message())
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(statusError(context.DeadlineExceeded)))
+}
+
+func TestFail_ContextLogger(t *testing.T) {
+	var base, scoped bytes.Buffer
+	dep := &scope.Dependencies{Sl: slog.New(slog.NewTextHandler(&base, nil))}
+	ctx := sl.WithLogger(context.Background(), slog.New(slog.NewTextHandler(&scoped, nil)))
+
+	err := fail(ctx, dep, "grpc.product.get", "Error getting product", product.ErrNotFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, base.String())
+	assert.Contains(t, scoped.String(), "grpc.product.get | Error getting product")
+}
@@ -0,0 +1,130 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/grpcapi/warehousev1"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type stockServer struct {
+	warehousev1.UnimplementedStockServiceServer
+	dep *scope.Dependencies
+}
+
+func (s *stockServer) ReceiveStock(ctx context.Context, req *warehousev1.StockMovementRequest) (*warehousev1.StockMovement, error) {
+	return s.record(ctx, "grpc.stock.receive", req, s.dep.Stock.Receive)
+}
+
+func (s *stockServer) IssueStock(ctx context.Context, req *warehousev1.StockMovementRequest) (*warehousev1.StockMovement, error) {
+	return s.record(ctx, "grpc.stock.issue", req, s.dep.Stock.Issue)
+}
+
+func (s *stockServer) AdjustStock(ctx context.Context, req *warehousev1.StockMovementRequest) (*warehousev1.StockMovement, error) {
+	return s.record(ctx, "grpc.stock.adjust", req, s.dep.Stock.Adjust)
+}
+
+func (s *stockServer) ListStockMovements(ctx context.Context, req *warehousev1.ListStockMovementsRequest) (*warehousev1.ListStockMovementsResponse, error) {
+	const op = "grpc.stock.listMovements"
+
+	movements, err := s.dep.Stock.Movements(ctx, req.GetProductId())
+	if err != nil {
+		return nil, fail(ctx, s.dep, op, "Failed to list movements", err)
+	}
+	resp := &warehousev1.ListStockMovementsResponse{Movements: make([]*warehousev1.StockMovement, 0, len(movements))}
+	for _, m := range movements {
+		resp.Movements = append(resp.Movements, toMovement(m))
+	}
+	return resp, nil
+}
+
+func (s *stockServer) record(ctx context.Context, op string, req *warehousev1.StockMovementRequest, record func(context.Context, stock.Movement) (stock.Movement, error)) (*warehousev1.StockMovement, error) {
+	if req.GetQuantity() == "" {
+		return nil, status.Error(codes.InvalidArgument, "quantity is required")
+	}
+	if len(req.GetReference()) > 255 || len(req.GetSerials()) > 1000 {
+		return nil, status.Error(codes.InvalidArgument, "reference or serials too long")
+	}
+	m := stock.Movement{
+		ProductID:       req.GetProductId(),
+		EnteredQuantity: req.GetQuantity(),
+		EnteredUnit:     req.GetUnit(),
+		Reference:       req.GetReference(),
+		WarehouseID:     req.GetWarehouseId(),
+		Serials:         req.GetSerials(),
+	}
+	if lot := req.GetLot(); lot != nil {
+		l, err := fromLot(lot)
+		if err != nil {
+			return nil, err
+		}
+		m.Lot = l
+	}
+	if req.GetUnitCost() != nil {
+		cost, err := fromMoney(req.GetUnitCost())
+		if err != nil {
+			return nil, err
+		}
+		m.UnitCost = &cost
+	}
+
+	movement, err := record(ctx, m)
+	if err != nil {
+		return nil, fail(ctx, s.dep, op, "Failed to record stock movement", err)
+	}
+	return toMovement(movement), nil
+}
+
+func fromLot(l *warehousev1.Lot) (*stock.Lot, error) {
+	if l.GetLotNumber() == "" || len(l.GetLotNumber()) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "lot number must be 1 to 100 characters")
+	}
+	manufactured, err := parseDate(l.GetManufacturedAt())
+	if err != nil {
+		return nil, err
+	}
+	expires, err := parseDate(l.GetExpiresAt())
+	if err != nil {
+		return nil, err
+	}
+	return &stock.Lot{Number: l.GetLotNumber(), ManufacturedAt: manufactured, ExpiresAt: expires}, nil
+}
+
+// parseDate parses an optional YYYY-MM-DD date.
+func parseDate(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "lot dates must be YYYY-MM-DD")
+	}
+	return &t, nil
+}
+
+func toMovement(m stock.Movement) *warehousev1.StockMovement {
+	pb := &warehousev1.StockMovement{
+		Id:              m.ID,
+		ProductId:       m.ProductID,
+		Type:            string(m.Type),
+		WarehouseId:     m.WarehouseID,
+		Quantity:        m.Quantity,
+		EnteredQuantity: m.EnteredQuantity,
+		EnteredUnit:     m.EnteredUnit,
+		Reference:       m.Reference,
+		Serials:         m.Serials,
+		CreatedAt:       timestamppb.New(m.CreatedAt),
+	}
+	for _, a := range m.Lots {
+		pb.Lots = append(pb.Lots, &warehousev1.LotAllocation{LotId: a.LotID, LotNumber: a.LotNumber, Quantity: a.Quantity})
+	}
+	if m.Cost != nil {
+		pb.Cost = toMoney(*m.Cost)
+	}
+	return pb
+}
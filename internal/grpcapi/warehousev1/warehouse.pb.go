@@ -0,0 +1,1137 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: warehouse/v1/warehouse.proto
+
+package warehousev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money is an amount in a currency, written as a decimal string such as
+// "12.50" as in the REST API.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        string                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Product struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description         string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price               *Money                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity            int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	BaseUnit            string                 `protobuf:"bytes,6,opt,name=base_unit,json=baseUnit,proto3" json:"base_unit,omitempty"`
+	LotTracked          bool                   `protobuf:"varint,7,opt,name=lot_tracked,json=lotTracked,proto3" json:"lot_tracked,omitempty"`
+	Serialized          bool                   `protobuf:"varint,8,opt,name=serialized,proto3" json:"serialized,omitempty"`
+	BinLocation         string                 `protobuf:"bytes,9,opt,name=bin_location,json=binLocation,proto3" json:"bin_location,omitempty"`
+	Category            string                 `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"`
+	QuarantinedQuantity int32                  `protobuf:"varint,11,opt,name=quarantined_quantity,json=quarantinedQuantity,proto3" json:"quarantined_quantity,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Product) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Product) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Product) GetBaseUnit() string {
+	if x != nil {
+		return x.BaseUnit
+	}
+	return ""
+}
+
+func (x *Product) GetLotTracked() bool {
+	if x != nil {
+		return x.LotTracked
+	}
+	return false
+}
+
+func (x *Product) GetSerialized() bool {
+	if x != nil {
+		return x.Serialized
+	}
+	return false
+}
+
+func (x *Product) GetBinLocation() string {
+	if x != nil {
+		return x.BinLocation
+	}
+	return ""
+}
+
+func (x *Product) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Product) GetQuarantinedQuantity() int32 {
+	if x != nil {
+		return x.QuarantinedQuantity
+	}
+	return 0
+}
+
+// CreateProductRequest ignores the product id and quarantined quantity.
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateProductResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// UpdateProductRequest replaces the product with the id of the given one; the
+// base unit and quantities cannot change.
+type UpdateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{7}
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// Lot names the lot of a lot-tracked product; dates are YYYY-MM-DD and only
+// used when the lot is received for the first time.
+type Lot struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LotNumber      string                 `protobuf:"bytes,1,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	ManufacturedAt string                 `protobuf:"bytes,2,opt,name=manufactured_at,json=manufacturedAt,proto3" json:"manufactured_at,omitempty"`
+	ExpiresAt      string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Lot) Reset() {
+	*x = Lot{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lot) ProtoMessage() {}
+
+func (x *Lot) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lot.ProtoReflect.Descriptor instead.
+func (*Lot) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Lot) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *Lot) GetManufacturedAt() string {
+	if x != nil {
+		return x.ManufacturedAt
+	}
+	return ""
+}
+
+func (x *Lot) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type StockMovementRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// quantity is a decimal in unit, e.g. "2.5"; the base unit if unit is empty.
+	Quantity  string `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit      string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// warehouse_id is the warehouse whose stock changes; the default warehouse if 0.
+	WarehouseId int32 `protobuf:"varint,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Lot         *Lot  `protobuf:"bytes,6,opt,name=lot,proto3" json:"lot,omitempty"`
+	// serials lists every unit moved; required for serialized products.
+	Serials []string `protobuf:"bytes,7,rep,name=serials,proto3" json:"serials,omitempty"`
+	// unit_cost is the cost of one base unit of incoming stock in the product currency.
+	UnitCost      *Money `protobuf:"bytes,8,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovementRequest) Reset() {
+	*x = StockMovementRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovementRequest) ProtoMessage() {}
+
+func (x *StockMovementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovementRequest.ProtoReflect.Descriptor instead.
+func (*StockMovementRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StockMovementRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockMovementRequest) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *StockMovementRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *StockMovementRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *StockMovementRequest) GetWarehouseId() int32 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *StockMovementRequest) GetLot() *Lot {
+	if x != nil {
+		return x.Lot
+	}
+	return nil
+}
+
+func (x *StockMovementRequest) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *StockMovementRequest) GetUnitCost() *Money {
+	if x != nil {
+		return x.UnitCost
+	}
+	return nil
+}
+
+type LotAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotId         int32                  `protobuf:"varint,1,opt,name=lot_id,json=lotId,proto3" json:"lot_id,omitempty"`
+	LotNumber     string                 `protobuf:"bytes,2,opt,name=lot_number,json=lotNumber,proto3" json:"lot_number,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotAllocation) Reset() {
+	*x = LotAllocation{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotAllocation) ProtoMessage() {}
+
+func (x *LotAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotAllocation.ProtoReflect.Descriptor instead.
+func (*LotAllocation) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LotAllocation) GetLotId() int32 {
+	if x != nil {
+		return x.LotId
+	}
+	return 0
+}
+
+func (x *LotAllocation) GetLotNumber() string {
+	if x != nil {
+		return x.LotNumber
+	}
+	return ""
+}
+
+func (x *LotAllocation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type StockMovement struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId   int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	WarehouseId int32                  `protobuf:"varint,4,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// quantity is signed and in the product base unit.
+	Quantity        int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	EnteredQuantity string                 `protobuf:"bytes,6,opt,name=entered_quantity,json=enteredQuantity,proto3" json:"entered_quantity,omitempty"`
+	EnteredUnit     string                 `protobuf:"bytes,7,opt,name=entered_unit,json=enteredUnit,proto3" json:"entered_unit,omitempty"`
+	Reference       string                 `protobuf:"bytes,8,opt,name=reference,proto3" json:"reference,omitempty"`
+	Lots            []*LotAllocation       `protobuf:"bytes,9,rep,name=lots,proto3" json:"lots,omitempty"`
+	Serials         []string               `protobuf:"bytes,10,rep,name=serials,proto3" json:"serials,omitempty"`
+	Cost            *Money                 `protobuf:"bytes,11,opt,name=cost,proto3" json:"cost,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StockMovement) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockMovement) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StockMovement) GetWarehouseId() int32 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *StockMovement) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetEnteredQuantity() string {
+	if x != nil {
+		return x.EnteredQuantity
+	}
+	return ""
+}
+
+func (x *StockMovement) GetEnteredUnit() string {
+	if x != nil {
+		return x.EnteredUnit
+	}
+	return ""
+}
+
+func (x *StockMovement) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *StockMovement) GetLots() []*LotAllocation {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+func (x *StockMovement) GetSerials() []string {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+func (x *StockMovement) GetCost() *Money {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListStockMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListStockMovementsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type ListStockMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warehouse_v1_warehouse_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_warehouse_v1_warehouse_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+var File_warehouse_v1_warehouse_proto protoreflect.FileDescriptor
+
+const file_warehouse_v1_warehouse_proto_rawDesc = "" +
+	"\n" +
+	"\x1cwarehouse/v1/warehouse.proto\x12\fwarehouse.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xe6\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x05price\x18\x04 \x01(\v2\x13.warehouse.v1.MoneyR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1b\n" +
+	"\tbase_unit\x18\x06 \x01(\tR\bbaseUnit\x12\x1f\n" +
+	"\vlot_tracked\x18\a \x01(\bR\n" +
+	"lotTracked\x12\x1e\n" +
+	"\n" +
+	"serialized\x18\b \x01(\bR\n" +
+	"serialized\x12!\n" +
+	"\fbin_location\x18\t \x01(\tR\vbinLocation\x12\x1a\n" +
+	"\bcategory\x18\n" +
+	" \x01(\tR\bcategory\x121\n" +
+	"\x14quarantined_quantity\x18\v \x01(\x05R\x13quarantinedQuantity\"G\n" +
+	"\x14CreateProductRequest\x12/\n" +
+	"\aproduct\x18\x01 \x01(\v2\x15.warehouse.v1.ProductR\aproduct\"'\n" +
+	"\x15CreateProductResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"G\n" +
+	"\x14UpdateProductRequest\x12/\n" +
+	"\aproduct\x18\x01 \x01(\v2\x15.warehouse.v1.ProductR\aproduct\"&\n" +
+	"\x14DeleteProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x15\n" +
+	"\x13ListProductsRequest\"I\n" +
+	"\x14ListProductsResponse\x121\n" +
+	"\bproducts\x18\x01 \x03(\v2\x15.warehouse.v1.ProductR\bproducts\"l\n" +
+	"\x03Lot\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x01 \x01(\tR\tlotNumber\x12'\n" +
+	"\x0fmanufactured_at\x18\x02 \x01(\tR\x0emanufacturedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\"\x97\x02\n" +
+	"\x14StockMovementRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\tR\bquantity\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12!\n" +
+	"\fwarehouse_id\x18\x05 \x01(\x05R\vwarehouseId\x12#\n" +
+	"\x03lot\x18\x06 \x01(\v2\x11.warehouse.v1.LotR\x03lot\x12\x18\n" +
+	"\aserials\x18\a \x03(\tR\aserials\x120\n" +
+	"\tunit_cost\x18\b \x01(\v2\x13.warehouse.v1.MoneyR\bunitCost\"a\n" +
+	"\rLotAllocation\x12\x15\n" +
+	"\x06lot_id\x18\x01 \x01(\x05R\x05lotId\x12\x1d\n" +
+	"\n" +
+	"lot_number\x18\x02 \x01(\tR\tlotNumber\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\xac\x03\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\fwarehouse_id\x18\x04 \x01(\x05R\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12)\n" +
+	"\x10entered_quantity\x18\x06 \x01(\tR\x0fenteredQuantity\x12!\n" +
+	"\fentered_unit\x18\a \x01(\tR\venteredUnit\x12\x1c\n" +
+	"\treference\x18\b \x01(\tR\treference\x12/\n" +
+	"\x04lots\x18\t \x03(\v2\x1b.warehouse.v1.LotAllocationR\x04lots\x12\x18\n" +
+	"\aserials\x18\n" +
+	" \x03(\tR\aserials\x12'\n" +
+	"\x04cost\x18\v \x01(\v2\x13.warehouse.v1.MoneyR\x04cost\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\":\n" +
+	"\x19ListStockMovementsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\"W\n" +
+	"\x1aListStockMovementsResponse\x129\n" +
+	"\tmovements\x18\x01 \x03(\v2\x1b.warehouse.v1.StockMovementR\tmovements2\xa1\x03\n" +
+	"\x0eProductService\x12X\n" +
+	"\rCreateProduct\x12\".warehouse.v1.CreateProductRequest\x1a#.warehouse.v1.CreateProductResponse\x12D\n" +
+	"\n" +
+	"GetProduct\x12\x1f.warehouse.v1.GetProductRequest\x1a\x15.warehouse.v1.Product\x12K\n" +
+	"\rUpdateProduct\x12\".warehouse.v1.UpdateProductRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\rDeleteProduct\x12\".warehouse.v1.DeleteProductRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
+	"\fListProducts\x12!.warehouse.v1.ListProductsRequest\x1a\".warehouse.v1.ListProductsResponse2\xe7\x02\n" +
+	"\fStockService\x12O\n" +
+	"\fReceiveStock\x12\".warehouse.v1.StockMovementRequest\x1a\x1b.warehouse.v1.StockMovement\x12M\n" +
+	"\n" +
+	"IssueStock\x12\".warehouse.v1.StockMovementRequest\x1a\x1b.warehouse.v1.StockMovement\x12N\n" +
+	"\vAdjustStock\x12\".warehouse.v1.StockMovementRequest\x1a\x1b.warehouse.v1.StockMovement\x12g\n" +
+	"\x12ListStockMovements\x12'.warehouse.v1.ListStockMovementsRequest\x1a(.warehouse.v1.ListStockMovementsResponseBNZLgithub.com/Gen1usBruh/warehouse-api/internal/grpcapi/warehousev1;warehousev1b\x06proto3"
+
+var (
+	file_warehouse_v1_warehouse_proto_rawDescOnce sync.Once
+	file_warehouse_v1_warehouse_proto_rawDescData []byte
+)
+
+func file_warehouse_v1_warehouse_proto_rawDescGZIP() []byte {
+	file_warehouse_v1_warehouse_proto_rawDescOnce.Do(func() {
+		file_warehouse_v1_warehouse_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_warehouse_v1_warehouse_proto_rawDesc), len(file_warehouse_v1_warehouse_proto_rawDesc)))
+	})
+	return file_warehouse_v1_warehouse_proto_rawDescData
+}
+
+var file_warehouse_v1_warehouse_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_warehouse_v1_warehouse_proto_goTypes = []any{
+	(*Money)(nil),                      // 0: warehouse.v1.Money
+	(*Product)(nil),                    // 1: warehouse.v1.Product
+	(*CreateProductRequest)(nil),       // 2: warehouse.v1.CreateProductRequest
+	(*CreateProductResponse)(nil),      // 3: warehouse.v1.CreateProductResponse
+	(*GetProductRequest)(nil),          // 4: warehouse.v1.GetProductRequest
+	(*UpdateProductRequest)(nil),       // 5: warehouse.v1.UpdateProductRequest
+	(*DeleteProductRequest)(nil),       // 6: warehouse.v1.DeleteProductRequest
+	(*ListProductsRequest)(nil),        // 7: warehouse.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),       // 8: warehouse.v1.ListProductsResponse
+	(*Lot)(nil),                        // 9: warehouse.v1.Lot
+	(*StockMovementRequest)(nil),       // 10: warehouse.v1.StockMovementRequest
+	(*LotAllocation)(nil),              // 11: warehouse.v1.LotAllocation
+	(*StockMovement)(nil),              // 12: warehouse.v1.StockMovement
+	(*ListStockMovementsRequest)(nil),  // 13: warehouse.v1.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil), // 14: warehouse.v1.ListStockMovementsResponse
+	(*timestamppb.Timestamp)(nil),      // 15: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),              // 16: google.protobuf.Empty
+}
+var file_warehouse_v1_warehouse_proto_depIdxs = []int32{
+	0,  // 0: warehouse.v1.Product.price:type_name -> warehouse.v1.Money
+	1,  // 1: warehouse.v1.CreateProductRequest.product:type_name -> warehouse.v1.Product
+	1,  // 2: warehouse.v1.UpdateProductRequest.product:type_name -> warehouse.v1.Product
+	1,  // 3: warehouse.v1.ListProductsResponse.products:type_name -> warehouse.v1.Product
+	9,  // 4: warehouse.v1.StockMovementRequest.lot:type_name -> warehouse.v1.Lot
+	0,  // 5: warehouse.v1.StockMovementRequest.unit_cost:type_name -> warehouse.v1.Money
+	11, // 6: warehouse.v1.StockMovement.lots:type_name -> warehouse.v1.LotAllocation
+	0,  // 7: warehouse.v1.StockMovement.cost:type_name -> warehouse.v1.Money
+	15, // 8: warehouse.v1.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	12, // 9: warehouse.v1.ListStockMovementsResponse.movements:type_name -> warehouse.v1.StockMovement
+	2,  // 10: warehouse.v1.ProductService.CreateProduct:input_type -> warehouse.v1.CreateProductRequest
+	4,  // 11: warehouse.v1.ProductService.GetProduct:input_type -> warehouse.v1.GetProductRequest
+	5,  // 12: warehouse.v1.ProductService.UpdateProduct:input_type -> warehouse.v1.UpdateProductRequest
+	6,  // 13: warehouse.v1.ProductService.DeleteProduct:input_type -> warehouse.v1.DeleteProductRequest
+	7,  // 14: warehouse.v1.ProductService.ListProducts:input_type -> warehouse.v1.ListProductsRequest
+	10, // 15: warehouse.v1.StockService.ReceiveStock:input_type -> warehouse.v1.StockMovementRequest
+	10, // 16: warehouse.v1.StockService.IssueStock:input_type -> warehouse.v1.StockMovementRequest
+	10, // 17: warehouse.v1.StockService.AdjustStock:input_type -> warehouse.v1.StockMovementRequest
+	13, // 18: warehouse.v1.StockService.ListStockMovements:input_type -> warehouse.v1.ListStockMovementsRequest
+	3,  // 19: warehouse.v1.ProductService.CreateProduct:output_type -> warehouse.v1.CreateProductResponse
+	1,  // 20: warehouse.v1.ProductService.GetProduct:output_type -> warehouse.v1.Product
+	16, // 21: warehouse.v1.ProductService.UpdateProduct:output_type -> google.protobuf.Empty
+	16, // 22: warehouse.v1.ProductService.DeleteProduct:output_type -> google.protobuf.Empty
+	8,  // 23: warehouse.v1.ProductService.ListProducts:output_type -> warehouse.v1.ListProductsResponse
+	12, // 24: warehouse.v1.StockService.ReceiveStock:output_type -> warehouse.v1.StockMovement
+	12, // 25: warehouse.v1.StockService.IssueStock:output_type -> warehouse.v1.StockMovement
+	12, // 26: warehouse.v1.StockService.AdjustStock:output_type -> warehouse.v1.StockMovement
+	14, // 27: warehouse.v1.StockService.ListStockMovements:output_type -> warehouse.v1.ListStockMovementsResponse
+	19, // [19:28] is the sub-list for method output_type
+	10, // [10:19] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_warehouse_v1_warehouse_proto_init() }
+func file_warehouse_v1_warehouse_proto_init() {
+	if File_warehouse_v1_warehouse_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_warehouse_v1_warehouse_proto_rawDesc), len(file_warehouse_v1_warehouse_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_warehouse_v1_warehouse_proto_goTypes,
+		DependencyIndexes: file_warehouse_v1_warehouse_proto_depIdxs,
+		MessageInfos:      file_warehouse_v1_warehouse_proto_msgTypes,
+	}.Build()
+	File_warehouse_v1_warehouse_proto = out.File
+	file_warehouse_v1_warehouse_proto_goTypes = nil
+	file_warehouse_v1_warehouse_proto_depIdxs = nil
+}
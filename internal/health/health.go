@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check is a dependency the service needs to serve traffic, such as Postgres.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the status of the service and of each dependency.
+type Report struct {
+	Status       string            `json:"status"`
+	ShuttingDown bool              `json:"shutting_down"`
+	Checks       map[string]Result `json:"checks"`
+}
+
+// Health runs the dependency checks and tracks whether the service is
+// shutting down.
+type Health struct {
+	checks  []Check
+	timeout time.Duration
+	// draining is set once shutdown starts, so readiness fails and load
+	// balancers stop sending traffic before the server stops accepting it.
+	draining atomic.Bool
+}
+
+// New returns a Health running the checks concurrently, each bounded by timeout.
+func New(timeout time.Duration, checks ...Check) *Health {
+	return &Health{checks: checks, timeout: timeout}
+}
+
+// Drain marks the service as shutting down.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Report runs every check. The service is up when all checks pass and it is
+// not shutting down.
+func (h *Health) Report(ctx context.Context) Report {
+	r := Report{
+		Status:       StatusUp,
+		ShuttingDown: h.draining.Load(),
+		Checks:       make(map[string]Result, len(h.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := h.run(ctx, c)
+			mu.Lock()
+			r.Checks[c.Name] = res
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range r.Checks {
+		if res.Status != StatusUp {
+			r.Status = StatusDown
+		}
+	}
+	if r.ShuttingDown {
+		r.Status = StatusDown
+	}
+	return r
+}
+
+func (h *Health) run(ctx context.Context, c Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Run(ctx)
+	res := Result{
+		Status:    StatusUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -1,21 +1,40 @@
 package sl
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+
 	"github.com/Gen1usBruh/warehouse-api/internal/config"
 )
 
-func SetupLogger(conf *config.Logger) *slog.Logger {
-	var log *slog.Logger
-	if conf.Level == string("info") {
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// SetupLogger returns a logger writing to stdout at the configured level,
+// one of debug, info, warn or error, in JSON or text format.
+func SetupLogger(conf *config.Logger) (*slog.Logger, error) {
+	return newLogger(os.Stdout, conf)
+}
+
+func newLogger(w io.Writer, conf *config.Logger) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(conf.Level)); err != nil {
+		return nil, fmt.Errorf("level must be one of debug, info, warn, error: %w", err)
 	}
-	// not the right way to parse from Config, but now is ok
+	opts := &slog.HandlerOptions{Level: level}
 
-	return log
+	switch conf.Format {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("format must be one of json, text")
 }
 
 func Err(err error) slog.Attr {
@@ -23,4 +42,20 @@ func Err(err error) slog.Attr {
 		Key:   "error",
 		Value: slog.StringValue(err.Error()),
 	}
-}
\ No newline at end of file
+}
+
+type ctxKey struct{}
+
+// WithLogger returns a context carrying the logger, e.g. one with the request ID
+// of the request the context belongs to.
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, log)
+}
+
+// FromContext returns the logger carried by ctx, or fallback if it has none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if log, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return log
+	}
+	return fallback
+}
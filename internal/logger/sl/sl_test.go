@@ -0,0 +1,43 @@
+package sl
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := newLogger(&buf, &config.Logger{Level: "warn", Format: FormatJSON})
+	if assert.NoError(t, err) {
+		log.Info("skipped")
+		log.Warn("kept", slog.Int("n", 1))
+		assert.NotContains(t, buf.String(), "skipped")
+		assert.Contains(t, buf.String(), `"level":"WARN","msg":"kept","n":1`)
+	}
+
+	buf.Reset()
+	log, err = newLogger(&buf, &config.Logger{Level: "DEBUG", Format: FormatText})
+	if assert.NoError(t, err) {
+		log.Debug("details")
+		assert.Contains(t, buf.String(), "level=DEBUG msg=details")
+	}
+
+	_, err = newLogger(&buf, &config.Logger{Level: "verbose", Format: FormatJSON})
+	assert.ErrorContains(t, err, "level must be one of debug, info, warn, error")
+
+	_, err = newLogger(&buf, &config.Logger{Level: "info", Format: "xml"})
+	assert.EqualError(t, err, "format must be one of json, text")
+}
+
+func TestFromContext(t *testing.T) {
+	base := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	scoped := base.With("request_id", "abc")
+
+	assert.Same(t, base, FromContext(context.Background(), base))
+	assert.Same(t, scoped, FromContext(WithLogger(context.Background(), scoped), base))
+}
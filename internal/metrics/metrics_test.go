@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := New()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(m.Middleware())
+	r.GET("/products/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/products/1", "/products/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "/products/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues("GET", "unmatched", "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.duration))
+}
+
+func TestReject(t *testing.T) {
+	m := New()
+	m.Reject("/products", fmt.Errorf("%w of 10000.00 USD", usecase.ErrPriceLimit))
+	m.Reject("/products", usecase.ErrPriceLimit)
+	m.Reject("/products/:id", product.ErrNotFound)
+	m.Reject("/products/:id", context.Canceled)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.rejections.WithLabelValues("/products", usecase.ErrPriceLimit.Error())))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.rejections))
+
+	var none *Metrics
+	assert.NotPanics(t, func() { none.Reject("/products", usecase.ErrPriceLimit) })
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	// The pool connects lazily, so its statistics are available without a server.
+	pool, err := pgxpool.New(context.Background(), "postgres://user@127.0.0.1:1/db?pool_max_conns=4")
+	assert.NoError(t, err)
+	defer pool.Close()
+	assert.NoError(t, m.Register(NewPoolCollector(pool)))
+	m.Reject("/products", usecase.ErrInvalidPrice)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "warehouse_db_pool_max_connections 4")
+	assert.Contains(t, body, "warehouse_db_pool_acquired_connections 0")
+	assert.Contains(t, body, `warehouse_business_rejections_total{route="/products",rule="price must be greater than zero"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
@@ -0,0 +1,291 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+// CountSessionRequest selects the products to count; empty filters match every
+// product of the warehouse.
+type CountSessionRequest struct {
+	// WarehouseID is the warehouse to count; the default warehouse if omitted.
+	WarehouseID int32 `json:"warehouse_id"`
+	// BinLocation matches products whose bin location starts with it.
+	BinLocation string `json:"bin_location" binding:"max=50" example:"A-03"`
+	Category    string `json:"category" binding:"max=100" example:"Furniture"`
+	ABCClass    string `json:"abc_class" enums:"A,B,C"`
+	// Blind hides the system quantities from the counters.
+	Blind bool `json:"blind"`
+}
+
+type RecordCountsRequest struct {
+	Lines []CountLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type CountLineRequest struct {
+	LineID          int32  `json:"line_id" binding:"required"`
+	CountedQuantity *int32 `json:"counted_quantity" binding:"required,gte=0"`
+}
+
+type ApproveCountRequest struct {
+	ApprovedBy string `json:"approved_by" binding:"required,max=100" example:"j.doe"`
+}
+
+// CreateCount godoc
+// @Summary Create a count session
+// @Description Generate a count sheet for the products of a warehouse, optionally filtered by bin location prefix,
+// @Description category and ABC class (as in the ABC report over the past year).
+// @Description Lot-tracked and serialized products are not counted
+// @Tags counts
+// @Accept json
+// @Produce json
+// @Param count body CountSessionRequest true "Warehouse and filters"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created count session"
+// @Failure 400 {object} BaseResponse "Invalid input or no products to count"
+// @Failure 404 {object} BaseResponse "Warehouse not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts [post]
+func (h *HandlerConfig) CreateCount(c *gin.Context) {
+	const op = "rest.count.create"
+
+	var req CountSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	id, err := h.Dep.Count.Create(c.Request.Context(), cyclecount.Session{
+		WarehouseID: req.WarehouseID,
+		BinLocation: req.BinLocation,
+		Category:    req.Category,
+		ABCClass:    stock.ABCClass(req.ABCClass),
+		Blind:       req.Blind,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating count session: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListCounts godoc
+// @Summary List count sessions
+// @Description Get count sessions without lines, newest first
+// @Tags counts
+// @Produce json
+// @Param status query string false "Status" Enums(open, pending_approval, approved, cancelled)
+// @Success 200 {object} map[string]interface{} "List of count sessions"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts [get]
+func (h *HandlerConfig) ListCounts(c *gin.Context) {
+	const op = "rest.count.list"
+
+	sessions, err := h.Dep.Count.List(c.Request.Context(), cyclecount.Status(c.Query("status")))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list count sessions: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// GetCount godoc
+// @Summary Get count session by ID
+// @Description Retrieve a count session with its count sheet ordered by bin location.
+// @Description System quantities and variances of an open blind count are left out
+// @Tags counts
+// @Produce json
+// @Param id path int true "Count session ID"
+// @Success 200 {object} map[string]interface{} "Count session data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Count session not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts/{id} [get]
+func (h *HandlerConfig) GetCount(c *gin.Context) {
+	const op = "rest.count.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	s, err := h.Dep.Count.Get(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get count session: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
+
+// RecordCounts godoc
+// @Summary Record counted quantities
+// @Description Save the counted quantities of lines of an open count session; a line can be counted again until the session is submitted
+// @Tags counts
+// @Accept json
+// @Produce json
+// @Param id path int true "Count session ID"
+// @Param counts body RecordCountsRequest true "Counted quantities"
+// @Success 200 {object} map[string]interface{} "Count session"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Count session not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts/{id}/lines [put]
+func (h *HandlerConfig) RecordCounts(c *gin.Context) {
+	const op = "rest.count.record"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req RecordCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	counts := make([]cyclecount.Count, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		counts = append(counts, cyclecount.Count{LineID: line.LineID, Quantity: *line.CountedQuantity})
+	}
+
+	s, err := h.Dep.Count.RecordCounts(c.Request.Context(), id, counts)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to record counts: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
+
+// SubmitCount godoc
+// @Summary Submit a count session
+// @Description Close counting once every line is counted. Without variances above the approval threshold the
+// @Description adjusting stock movements are posted at once; otherwise the count waits for approval
+// @Tags counts
+// @Produce json
+// @Param id path int true "Count session ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Submitted count session"
+// @Failure 400 {object} BaseResponse "Business rule failed"
+// @Failure 404 {object} BaseResponse "Count session not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts/{id}/submit [post]
+func (h *HandlerConfig) SubmitCount(c *gin.Context) {
+	const op = "rest.count.submit"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	s, err := h.Dep.Count.Submit(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to submit count session: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
+
+// ApproveCount godoc
+// @Summary Approve a count session
+// @Description Approve a count waiting for approval and post its adjusting stock movements
+// @Tags counts
+// @Accept json
+// @Produce json
+// @Param id path int true "Count session ID"
+// @Param approval body ApproveCountRequest true "Approver"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Approved count session"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Count session not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts/{id}/approve [post]
+func (h *HandlerConfig) ApproveCount(c *gin.Context) {
+	const op = "rest.count.approve"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req ApproveCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	s, err := h.Dep.Count.Approve(c.Request.Context(), id, req.ApprovedBy)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to approve count session: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
+
+// CancelCount godoc
+// @Summary Cancel a count session
+// @Description Cancel a count that has not been approved; stock is not changed
+// @Tags counts
+// @Produce json
+// @Param id path int true "Count session ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Cancelled count session"
+// @Failure 400 {object} BaseResponse "Count is already approved or cancelled"
+// @Failure 404 {object} BaseResponse "Count session not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /counts/{id}/cancel [post]
+func (h *HandlerConfig) CancelCount(c *gin.Context) {
+	const op = "rest.count.cancel"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	s, err := h.Dep.Count.Cancel(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to cancel count session: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
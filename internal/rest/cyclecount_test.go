@@ -0,0 +1,299 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCountRepo struct {
+	sessions map[int32]cyclecount.Session
+	stock    *mockStockRepo
+	nextID   int32
+	nextLine int32
+}
+
+func (m *mockCountRepo) Candidates(ctx context.Context, warehouseID int32) ([]cyclecount.Candidate, error) {
+	var list []cyclecount.Candidate
+	for id := int32(1); id <= m.stock.products.nextID; id++ {
+		p, ok := m.stock.products.products[id]
+		if !ok || p.Tracked() {
+			continue
+		}
+		list = append(list, cyclecount.Candidate{
+			ProductID:   p.ID,
+			ProductName: p.Name,
+			BinLocation: p.BinLocation,
+			Category:    p.Category,
+			Quantity:    m.stock.siteQuantity(warehouseID, p.ID),
+		})
+	}
+	return list, nil
+}
+
+func (m *mockCountRepo) Create(ctx context.Context, s cyclecount.Session) (int32, error) {
+	m.nextID++
+	s.ID = m.nextID
+	s.Status = cyclecount.StatusOpen
+	s.CreatedAt = time.Now()
+	for i := range s.Lines {
+		m.nextLine++
+		s.Lines[i].ID = m.nextLine
+	}
+	m.sessions[s.ID] = s
+	return s.ID, nil
+}
+
+func (m *mockCountRepo) Get(ctx context.Context, id int32) (cyclecount.Session, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return cyclecount.Session{}, cyclecount.ErrNotFound
+	}
+	s.Lines = slices.Clone(s.Lines)
+	return s, nil
+}
+
+func (m *mockCountRepo) List(ctx context.Context, status cyclecount.Status) ([]cyclecount.Session, error) {
+	var list []cyclecount.Session
+	for id := m.nextID; id >= 1; id-- {
+		if s, ok := m.sessions[id]; ok && (status == "" || s.Status == status) {
+			s.Lines = nil
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockCountRepo) Update(ctx context.Context, id int32, fn func(s *cyclecount.Session) ([]stock.Movement, error)) (cyclecount.Session, error) {
+	s, err := m.Get(ctx, id)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	movements, err := fn(&s)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	for _, mv := range movements {
+		if _, err := m.stock.ApplyMovement(ctx, mv); err != nil {
+			return cyclecount.Session{}, err
+		}
+	}
+	m.sessions[id] = s
+	return s, nil
+}
+
+func setupCountHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+	warehouses := &mockWarehouseRepo{
+		warehouses: map[int32]warehouse.Warehouse{
+			defaultWarehouseID: {ID: defaultWarehouseID, Code: "MAIN", Name: "Main warehouse", Default: true},
+		},
+		stock:     stockRepo,
+		transfers: &mockTransferRepo{transfers: make(map[int32]transfer.Transfer), stock: stockRepo},
+	}
+	counts := &mockCountRepo{sessions: make(map[int32]cyclecount.Session), stock: stockRepo}
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+	prices := &mockPricingRepo{lists: make(map[int32]pricing.PriceList), items: make(map[[2]int32]pricing.Item), products: products}
+	reportUC := usecase.NewReportUseCase(&mockReportRepo{stock: stockRepo}, usecase.NewPricingUseCase(prices, products, money.RoundHalfUp), "USD")
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock: stockUC,
+			Count: usecase.NewCountUseCase(counts, warehouses, stockUC, reportUC, 5),
+			Sl:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/products/:id/stock/issues", h.IssueStock)
+	router.POST("/counts", h.CreateCount)
+	router.GET("/counts", h.ListCounts)
+	router.GET("/counts/:id", h.GetCount)
+	router.PUT("/counts/:id/lines", h.RecordCounts)
+	router.POST("/counts/:id/submit", h.SubmitCount)
+	router.POST("/counts/:id/approve", h.ApproveCount)
+	router.POST("/counts/:id/cancel", h.CancelCount)
+	return router, products, stockRepo
+}
+
+func createShelfProducts(products *mockProductUseCase) (chairs, tables, lamps int32) {
+	chairs, _ = products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 100, BinLocation: "A-01", Category: "Furniture"})
+	tables, _ = products.Create(context.TODO(), product.Product{Name: "Table", Price: money.MustParse("150", "USD"), Quantity: 10, BinLocation: "A-02", Category: "Furniture"})
+	lamps, _ = products.Create(context.TODO(), product.Product{Name: "Lamp", Price: money.MustParse("40", "USD"), Quantity: 20, BinLocation: "B-01", Category: "Lighting"})
+	return chairs, tables, lamps
+}
+
+func TestBlindCount_AutoApproved(t *testing.T) {
+	router, products, stockRepo := setupCountHandlerWithMock()
+	chairs, _, _ := createShelfProducts(products)
+
+	resp := performRequest(router, "POST", "/counts", []byte(`{"bin_location":"A-","category":"furniture","blind":true}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+
+	resp = performRequest(router, "GET", "/counts/1", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"warehouse_id":1`)
+	assert.Contains(t, resp.Body.String(), `"product_name":"Chair"`)
+	assert.Contains(t, resp.Body.String(), `"product_name":"Table"`)
+	assert.NotContains(t, resp.Body.String(), "Lamp")
+	assert.NotContains(t, resp.Body.String(), "system_quantity")
+
+	resp = performRequest(router, "PUT", "/counts/1/lines", []byte(`{"lines":[{"line_id":1,"counted_quantity":97},{"line_id":2,"counted_quantity":10}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), "variance")
+
+	// A variance of 3 percent stays under the threshold of 5.
+	resp = performRequest(router, "POST", "/counts/1/submit", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"approved"`)
+	assert.Contains(t, resp.Body.String(), `"system_quantity":100,"counted_quantity":97,"variance":-3`)
+
+	assert.Equal(t, int32(97), products.products[chairs].Quantity)
+	last := stockRepo.movements[len(stockRepo.movements)-1]
+	assert.Equal(t, stock.MovementAdjustment, last.Type)
+	assert.Equal(t, int32(-3), last.Quantity)
+	assert.Equal(t, "CNT-1", last.Reference)
+	assert.Len(t, stockRepo.movements, 1)
+}
+
+func TestCount_ApprovalAboveThreshold(t *testing.T) {
+	router, products, stockRepo := setupCountHandlerWithMock()
+	_, tables, lamps := createShelfProducts(products)
+
+	resp := performRequest(router, "POST", "/counts", []byte(`{"bin_location":"B"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "PUT", "/counts/1/lines", []byte(`{"lines":[{"line_id":1,"counted_quantity":23}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"system_quantity":20,"counted_quantity":23,"variance":3`)
+
+	resp = performRequest(router, "POST", "/counts/1/submit", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"pending_approval"`)
+	assert.Equal(t, int32(20), products.products[lamps].Quantity)
+
+	resp = performRequest(router, "GET", "/counts?status=pending_approval", nil)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+
+	resp = performRequest(router, "POST", "/counts/1/approve", []byte(`{}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(router, "POST", "/counts/1/approve", []byte(`{"approved_by":"j.doe"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"approved","approved_by":"j.doe"`)
+	assert.Equal(t, int32(23), products.products[lamps].Quantity)
+	assert.Equal(t, "CNT-1", stockRepo.movements[0].Reference)
+
+	resp = performRequest(router, "POST", "/counts/1/cancel", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "count is approved")
+
+	// The top seller by issue value is the only class A product.
+	resp = performRequest(router, "POST", "/products/"+itoa(tables)+"/stock/issues", []byte(`{"quantity":"5"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/counts", []byte(`{"abc_class":"A"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "GET", "/counts/2", nil)
+	assert.Contains(t, resp.Body.String(), `"product_name":"Table","bin_location":"A-02","system_quantity":5`)
+	assert.NotContains(t, resp.Body.String(), "Chair")
+}
+
+func TestCount_Rejected(t *testing.T) {
+	router, products, _ := setupCountHandlerWithMock()
+	createShelfProducts(products)
+	performRequest(router, "POST", "/counts", []byte(`{"category":"Lighting"}`))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		want   string
+	}{
+		{"invalid ABC class", "POST", "/counts", `{"abc_class":"D"}`, http.StatusBadRequest, "ABC class"},
+		{"nothing to count", "POST", "/counts", `{"bin_location":"Z"}`, http.StatusBadRequest, "no products match"},
+		{"unknown warehouse", "POST", "/counts", `{"warehouse_id":9}`, http.StatusNotFound, "warehouse not found"},
+		{"negative count", "PUT", "/counts/1/lines", `{"lines":[{"line_id":1,"counted_quantity":-1}]}`, http.StatusBadRequest, "CountedQuantity"},
+		{"unknown line", "PUT", "/counts/1/lines", `{"lines":[{"line_id":9,"counted_quantity":1}]}`, http.StatusBadRequest, "9"},
+		{"uncounted lines", "POST", "/counts/1/submit", ``, http.StatusBadRequest, "every line must be counted"},
+		{"approve open count", "POST", "/counts/1/approve", `{"approved_by":"j.doe"}`, http.StatusBadRequest, "count is open"},
+		{"unknown session", "GET", "/counts/9", ``, http.StatusNotFound, "count session not found"},
+		{"unknown status", "GET", "/counts?status=lost", ``, http.StatusBadRequest, "unknown count status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, tt.method, tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.want)
+		})
+	}
+
+	resp := performRequest(router, "POST", "/counts/1/cancel", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"cancelled"`)
+}
+
+func TestCount_MovementsAfterSheet(t *testing.T) {
+	router, products, stockRepo := setupCountHandlerWithMock()
+	chairs, _, _ := createShelfProducts(products)
+
+	resp := performRequest(router, "POST", "/counts", []byte(`{"bin_location":"A-01"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Of the 5 chairs missing from the sheet, 4 were issued after it was
+	// generated and are already off the stock; only the fifth is adjusted.
+	resp = performRequest(router, "POST", "/products/"+itoa(chairs)+"/stock/issues", []byte(`{"quantity":"4"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "PUT", "/counts/1/lines", []byte(`{"lines":[{"line_id":1,"counted_quantity":95}]}`))
+	assert.Contains(t, resp.Body.String(), `"system_quantity":96,"counted_quantity":95,"variance":-1`)
+
+	resp = performRequest(router, "POST", "/counts/1/submit", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"approved"`)
+	assert.Equal(t, int32(95), products.products[chairs].Quantity)
+	assert.Len(t, stockRepo.movements, 2)
+	assert.Equal(t, int32(-1), stockRepo.movements[1].Quantity)
+	assert.Equal(t, "-1", stockRepo.movements[1].EnteredQuantity)
+}
+
+func TestCount_MovementsBeforeApproval(t *testing.T) {
+	router, products, stockRepo := setupCountHandlerWithMock()
+	chairs, _, _ := createShelfProducts(products)
+
+	resp := performRequest(router, "POST", "/counts", []byte(`{"bin_location":"A-01"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "PUT", "/counts/1/lines", []byte(`{"lines":[{"line_id":1,"counted_quantity":90}]}`))
+	assert.Contains(t, resp.Body.String(), `"system_quantity":100,"counted_quantity":90,"variance":-10`)
+	resp = performRequest(router, "POST", "/counts/1/submit", nil)
+	assert.Contains(t, resp.Body.String(), `"status":"pending_approval"`)
+
+	// The 4 chairs issued while the count waits for approval are not part of
+	// its variance; approval still writes off the 10 found missing.
+	resp = performRequest(router, "POST", "/products/"+itoa(chairs)+"/stock/issues", []byte(`{"quantity":"4"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/counts/1/approve", []byte(`{"approved_by":"j.doe"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(86), products.products[chairs].Quantity)
+	assert.Len(t, stockRepo.movements, 2)
+	assert.Equal(t, int32(-10), stockRepo.movements[1].Quantity)
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/graphqlapi"
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLRequest struct {
+	Query         string         `json:"query" binding:"required" example:"{ products(first: 5) { totalCount items { id name } } }"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphQLQuery godoc
+// @Summary Run a GraphQL operation
+// @Description Query products with filters and pagination, with their latest stock movements, or create, update and delete products. Operations above the configured complexity or depth are rejected with the code QUERY_TOO_COMPLEX; other errors carry NOT_FOUND, BAD_USER_INPUT or INTERNAL in their extensions.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body GraphQLRequest true "GraphQL request"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "GraphQL result with data and errors"
+// @Failure 400 {object} BaseResponse "Invalid request body"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Router /graphql [post]
+func (h *HandlerConfig) GraphQLQuery(c *gin.Context) {
+	var req GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	result := h.GraphQL.Execute(c.Request.Context(), graphqlapi.Request{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+	})
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,187 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/graphqlapi"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGraphQLHandlerWithMock(limits graphqlapi.Limits) (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+
+	dep := &scope.Dependencies{
+		Product: usecase.NewProductUseCase(products),
+		Stock:   usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO),
+		Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	server, err := graphqlapi.NewServer(dep, limits)
+	if err != nil {
+		panic(err)
+	}
+	h := &HandlerConfig{Dep: dep, GraphQL: server}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/graphql", h.GraphQLQuery)
+	return router, products, stockRepo
+}
+
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func graphQL(t *testing.T, router *gin.Engine, query string, variables map[string]any) graphQLResponse {
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	resp := performRequest(router, "POST", "/graphql", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var result graphQLResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	return result
+}
+
+func TestGraphQLProducts(t *testing.T) {
+	router, products, stockRepo := setupGraphQLHandlerWithMock(graphqlapi.Limits{MaxComplexity: 1000, MaxDepth: 10})
+
+	var ids []int32
+	for _, name := range []string{"Apple Juice", "Orange Juice", "Cherry Juice"} {
+		id, _ := products.Create(context.TODO(), product.Product{
+			Name: name, Price: money.MustParse("1.20", "USD"), Quantity: 10, BaseUnit: "bottle", Category: "Drinks",
+		})
+		ids = append(ids, id)
+	}
+	products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("40", "USD"), Category: "Furniture"})
+
+	now := time.Now()
+	for i, id := range ids {
+		stockRepo.movements = append(stockRepo.movements,
+			stock.Movement{ID: int32(2*i + 1), ProductID: id, Type: stock.MovementReceipt, Quantity: 10, CreatedAt: now},
+			stock.Movement{ID: int32(2*i + 2), ProductID: id, Type: stock.MovementIssue, Quantity: 3, CreatedAt: now},
+		)
+	}
+
+	result := graphQL(t, router, `query($first: Int) {
+		products(filter: {category: "Drinks", inStock: true}, first: $first) {
+			totalCount
+			hasNextPage
+			items { id name price { amount currency } movements(first: 1) { type quantity } }
+		}
+	}`, map[string]any{"first": 2})
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{
+		"totalCount": 3,
+		"hasNextPage": true,
+		"items": [
+			{"id": 1, "name": "Apple Juice", "price": {"amount": "1.20", "currency": "USD"}, "movements": [{"type": "issue", "quantity": 3}]},
+			{"id": 2, "name": "Orange Juice", "price": {"amount": "1.20", "currency": "USD"}, "movements": [{"type": "issue", "quantity": 3}]}
+		]
+	}`, string(result.Data["products"]))
+	// The movements of both products load in one query.
+	assert.Equal(t, 1, stockRepo.recentQueries)
+
+	result = graphQL(t, router, `{ products(filter: {search: "juice"}, offset: 2) { totalCount hasNextPage items { name } } }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"totalCount": 3, "hasNextPage": false, "items": [{"name": "Cherry Juice"}]}`, string(result.Data["products"]))
+
+	result = graphQL(t, router, `{ products(first: 500) { totalCount } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "BAD_USER_INPUT", result.Errors[0].Extensions.Code)
+	}
+}
+
+func TestGraphQLMutations(t *testing.T) {
+	router, _, _ := setupGraphQLHandlerWithMock(graphqlapi.Limits{MaxComplexity: 1000, MaxDepth: 10})
+
+	result := graphQL(t, router, `mutation($input: CreateProductInput!) {
+		createProduct(input: $input) { id name baseUnit price { amount } }
+	}`, map[string]any{"input": map[string]any{
+		"name": "Juice", "description": "Apple", "price": map[string]any{"amount": "1.20", "currency": "USD"},
+	}})
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"id": 1, "name": "Juice", "baseUnit": "pcs", "price": {"amount": "1.20"}}`, string(result.Data["createProduct"]))
+
+	// Only the given fields change.
+	result = graphQL(t, router, `mutation { updateProduct(id: 1, input: {category: "Drinks"}) { name description category } }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `{"name": "Juice", "description": "Apple", "category": "Drinks"}`, string(result.Data["updateProduct"]))
+
+	result = graphQL(t, router, `mutation { updateProduct(id: 1, input: {price: {amount: "0", currency: "USD"}}) { id } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "BAD_USER_INPUT", result.Errors[0].Extensions.Code)
+		assert.Equal(t, usecase.ErrInvalidPrice.Error(), result.Errors[0].Message)
+	}
+
+	result = graphQL(t, router, `mutation { deleteProduct(id: 1) }`, nil)
+	assert.Empty(t, result.Errors)
+	assert.JSONEq(t, `true`, string(result.Data["deleteProduct"]))
+
+	result = graphQL(t, router, `{ product(id: 1) { id } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "NOT_FOUND", result.Errors[0].Extensions.Code)
+	}
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	router, _, _ := setupGraphQLHandlerWithMock(graphqlapi.Limits{MaxComplexity: 100, MaxDepth: 10})
+
+	// products + 20 × (items + id + movements + 10 × 2) = 461 with the default
+	// page and movement sizes.
+	result := graphQL(t, router, `{ products { items { id movements { id quantity } } } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "QUERY_TOO_COMPLEX", result.Errors[0].Extensions.Code)
+		assert.Contains(t, result.Errors[0].Message, "complexity 461")
+	}
+	assert.Nil(t, result.Data)
+
+	result = graphQL(t, router, `query($n: Int) { products(first: $n) { items { id movements(first: 2) { id } } } }`, map[string]any{"n": 5})
+	assert.Empty(t, result.Errors)
+
+	result = graphQL(t, router, `query($n: Int) { products(first: $n) { items { id } } }`, map[string]any{"n": 100})
+	if assert.Len(t, result.Errors, 1) {
+		assert.Contains(t, result.Errors[0].Message, "complexity 201")
+	}
+
+	result = graphQL(t, router, `{ products(`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "GRAPHQL_PARSE_FAILED", result.Errors[0].Extensions.Code)
+		assert.Contains(t, result.Errors[0].Message, "Syntax Error")
+	}
+	assert.Nil(t, result.Data)
+
+	router, _, _ = setupGraphQLHandlerWithMock(graphqlapi.Limits{MaxComplexity: 100, MaxDepth: 3})
+
+	result = graphQL(t, router, `{ products(first: 1) { items { ...withPrice } } } fragment withPrice on Product { price { amount } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "QUERY_TOO_COMPLEX", result.Errors[0].Extensions.Code)
+		assert.Contains(t, result.Errors[0].Message, "depth 4")
+	}
+
+	// Introspection is free but as deep as it is nested.
+	result = graphQL(t, router, `{ __schema { queryType { name } } }`, nil)
+	assert.Empty(t, result.Errors)
+	result = graphQL(t, router, `{ __type(name: "Product") { fields { type { ofType { name } } } } }`, nil)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "QUERY_TOO_COMPLEX", result.Errors[0].Extensions.Code)
+		assert.Contains(t, result.Errors[0].Message, "depth 5")
+	}
+}
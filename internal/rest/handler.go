@@ -1,28 +1,200 @@
 package rest
 
 import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/graphqlapi"
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
 	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
 
 	_ "github.com/Gen1usBruh/warehouse-api/docs"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 type HandlerConfig struct {
 	Dep *scope.Dependencies
+	// GraphQL serves /graphql; the endpoint is not registered when it is nil.
+	GraphQL *graphqlapi.Server
+	// Health serves /healthz, /readyz and /health; they are not registered when it is nil.
+	Health *health.Health
 }
 
 func NewHandler(cfg HandlerConfig) *gin.Engine {
-	r := gin.Default()
+	r := gin.New()
+	// Spans join the trace of the caller given in a W3C traceparent header.
+	r.Use(otelgin.Middleware("warehouse-api"))
+	r.Use(requestLogger(cfg.Dep.Sl))
+	r.Use(gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered any) {
+		cfg.logger(c).Error("Recovered from panic", slog.Any("panic", recovered), slog.String("stack", string(debug.Stack())))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	if cfg.Dep.Metrics != nil {
+		r.Use(cfg.Dep.Metrics.Middleware())
+	}
+	if cfg.Dep.Idempotency != nil {
+		r.Use(cfg.idempotency)
+	}
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	if cfg.Health != nil {
+		r.GET("/healthz", cfg.Liveness)
+		r.GET("/readyz", cfg.Readiness)
+		r.GET("/health", cfg.HealthReport)
+	}
+
 	r.POST("/products", cfg.CreateProduct)
 	r.GET("/products/:id", cfg.GetProduct)
 	r.PUT("/products/:id", cfg.UpdateProduct)
 	r.DELETE("/products/:id", cfg.DeleteProduct)
 	r.GET("/products", cfg.ListProducts)
+	r.GET("/products/:id/price", cfg.GetProductPrice)
+	r.GET("/products/:id/prices", cfg.ListProductPrices)
+	r.POST("/products/:id/prices", cfg.ScheduleProductPrice)
+	r.DELETE("/products/:id/prices/:priceId", cfg.CancelProductPrice)
+
+	r.GET("/products/:id/units", cfg.ListUnits)
+	r.PUT("/products/:id/units/:code", cfg.SetUnit)
+	r.DELETE("/products/:id/units/:code", cfg.DeleteUnit)
+	r.POST("/products/:id/stock/receipts", cfg.ReceiveStock)
+	r.POST("/products/:id/stock/issues", cfg.IssueStock)
+	r.POST("/products/:id/stock/adjustments", cfg.AdjustStock)
+	r.GET("/products/:id/stock/movements", cfg.ListStockMovements)
+	r.GET("/products/:id/lots", cfg.ListLots)
+	r.GET("/lots/expiring", cfg.ListExpiringLots)
+	r.GET("/serials/:sn", cfg.GetSerial)
+
+	r.POST("/suppliers", cfg.CreateSupplier)
+	r.GET("/suppliers", cfg.ListSuppliers)
+	r.GET("/suppliers/:id", cfg.GetSupplier)
+	r.PUT("/suppliers/:id", cfg.UpdateSupplier)
+	r.DELETE("/suppliers/:id", cfg.DeleteSupplier)
+	r.GET("/products/:id/suppliers", cfg.ListProductSuppliers)
+	r.PUT("/products/:id/suppliers/:supplierId", cfg.SetProductSupplier)
+	r.DELETE("/products/:id/suppliers/:supplierId", cfg.DeleteProductSupplier)
+	r.GET("/products/:id/forecast", cfg.GetProductForecast)
+	r.GET("/replenishment/suggestions", cfg.ReplenishmentSuggestions)
+
+	r.POST("/purchase-orders", cfg.CreatePurchaseOrder)
+	r.GET("/purchase-orders", cfg.ListPurchaseOrders)
+	r.GET("/purchase-orders/:id", cfg.GetPurchaseOrder)
+	r.PUT("/purchase-orders/:id", cfg.UpdatePurchaseOrder)
+	r.POST("/purchase-orders/:id/submit", cfg.SubmitPurchaseOrder)
+	r.POST("/purchase-orders/:id/receipts", cfg.ReceivePurchaseOrder)
+	r.POST("/purchase-orders/:id/close", cfg.ClosePurchaseOrder)
+	r.GET("/reports/purchase-orders", cfg.PurchaseOrderReport)
+	r.GET("/reports/valuation", cfg.ValuationReport)
+	r.GET("/reports/turnover", cfg.TurnoverReport)
+	r.GET("/reports/aging", cfg.AgingReport)
+	r.GET("/reports/dead-stock", cfg.DeadStockReport)
+	r.GET("/reports/abc", cfg.ABCReport)
+
+	r.POST("/sales-orders", cfg.CreateSalesOrder)
+	r.GET("/sales-orders", cfg.ListSalesOrders)
+	r.GET("/sales-orders/:id", cfg.GetSalesOrder)
+	r.POST("/sales-orders/:id/allocate", cfg.AllocateSalesOrder)
+	r.POST("/sales-orders/:id/pick", cfg.StartPickingSalesOrder)
+	r.GET("/sales-orders/:id/pick-list", cfg.GetPickList)
+	r.POST("/sales-orders/:id/pack", cfg.PackSalesOrder)
+	r.POST("/sales-orders/:id/ship", cfg.ShipSalesOrder)
+	r.POST("/sales-orders/:id/cancel", cfg.CancelSalesOrder)
+
+	r.POST("/returns", cfg.CreateReturn)
+	r.GET("/returns", cfg.ListReturns)
+	r.GET("/returns/:id", cfg.GetReturn)
+	r.POST("/returns/:id/inspection", cfg.InspectReturn)
+	r.POST("/returns/:id/quarantine/release", cfg.ReleaseQuarantine)
+	r.POST("/returns/:id/cancel", cfg.CancelReturn)
+
+	r.POST("/warehouses", cfg.CreateWarehouse)
+	r.GET("/warehouses", cfg.ListWarehouses)
+	r.GET("/warehouses/:id/stock", cfg.GetWarehouseStock)
+	r.POST("/transfers", cfg.CreateTransfer)
+	r.GET("/transfers", cfg.ListTransfers)
+	r.GET("/transfers/open", cfg.ListOpenTransfers)
+	r.GET("/transfers/:id", cfg.GetTransfer)
+	r.POST("/transfers/:id/ship", cfg.ShipTransfer)
+	r.POST("/transfers/:id/receipts", cfg.ReceiveTransfer)
+	r.POST("/transfers/:id/cancel", cfg.CancelTransfer)
+
+	r.POST("/counts", cfg.CreateCount)
+	r.GET("/counts", cfg.ListCounts)
+	r.GET("/counts/:id", cfg.GetCount)
+	r.PUT("/counts/:id/lines", cfg.RecordCounts)
+	r.POST("/counts/:id/submit", cfg.SubmitCount)
+	r.POST("/counts/:id/approve", cfg.ApproveCount)
+	r.POST("/counts/:id/cancel", cfg.CancelCount)
+
+	r.POST("/price-lists", cfg.CreatePriceList)
+	r.GET("/price-lists", cfg.ListPriceLists)
+	r.GET("/price-lists/:id", cfg.GetPriceList)
+	r.DELETE("/price-lists/:id", cfg.DeletePriceList)
+	r.PUT("/price-lists/:id/items/:productId", cfg.SetPriceListItem)
+	r.DELETE("/price-lists/:id/items/:productId", cfg.DeletePriceListItem)
+
+	r.GET("/exchange-rates", cfg.ListExchangeRates)
+	r.POST("/exchange-rates/import", cfg.ImportExchangeRates)
+
+	if cfg.GraphQL != nil {
+		r.POST("/graphql", cfg.GraphQLQuery)
+	}
 
 	return r
 }
+
+// pathID parses an int32 path parameter.
+func pathID(c *gin.Context, key string) (int32, error) {
+	id, err := strconv.ParseInt(c.Param(key), 10, 32)
+	return int32(id), err
+}
+
+// queryID parses an optional int32 query parameter.
+func queryID(c *gin.Context, key string) (*int32, error) {
+	s := c.Query(key)
+	if s == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	v := int32(id)
+	return &v, nil
+}
+
+// queryTime parses an optional query parameter given as a date or an RFC 3339 timestamp.
+func queryTime(c *gin.Context, key string) (*time.Time, error) {
+	s := c.Query(key)
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// errorStatus maps usecase errors to HTTP status codes and counts business rule
+// rejections against the route.
+func (h *HandlerConfig) errorStatus(c *gin.Context, err error) int {
+	h.Dep.Metrics.Reject(c.FullPath(), err)
+	switch {
+	case usecase.IsNotFound(err):
+		return http.StatusNotFound
+	case usecase.IsBusinessError(err):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
+	"github.com/gin-gonic/gin"
+)
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Description Succeeds while the process is able to serve requests, without checking dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Returns status up"
+// @Router /healthz [get]
+func (h *HandlerConfig) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// Readiness godoc
+// @Summary Readiness probe
+// @Description Succeeds when Postgres answers, the schema is at the expected migration version and the server is not shutting down
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Returns status up"
+// @Failure 503 {object} map[string]string "Returns status down; see /health for the failing dependency"
+// @Router /readyz [get]
+func (h *HandlerConfig) Readiness(c *gin.Context) {
+	r := h.Health.Report(c.Request.Context())
+	c.JSON(reportStatus(r), gin.H{"status": r.Status})
+}
+
+// HealthReport godoc
+// @Summary Dependency health
+// @Description Status and check latency of every dependency, and whether the server is shutting down
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report "All dependencies are up"
+// @Failure 503 {object} health.Report "A dependency is down or the server is shutting down"
+// @Router /health [get]
+func (h *HandlerConfig) HealthReport(c *gin.Context) {
+	r := h.Health.Report(c.Request.Context())
+	c.JSON(reportStatus(r), r)
+}
+
+func reportStatus(r health.Report) int {
+	if r.Status != health.StatusUp {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHealthHandler(migrations error) (*gin.Engine, *health.Health) {
+	checker := health.New(time.Second,
+		health.Check{Name: "postgres", Run: func(ctx context.Context) error { return nil }},
+		health.Check{Name: "migrations", Run: func(ctx context.Context) error { return migrations }},
+	)
+	gin.SetMode(gin.TestMode)
+	router := NewHandler(HandlerConfig{
+		Dep:    &scope.Dependencies{Sl: slog.New(slog.NewTextHandler(io.Discard, nil))},
+		Health: checker,
+	})
+	return router, checker
+}
+
+func TestHealth(t *testing.T) {
+	router, checker := setupHealthHandler(nil)
+
+	resp := performRequest(router, "GET", "/healthz", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"status":"up"}`, resp.Body.String())
+
+	resp = performRequest(router, "GET", "/readyz", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"status":"up"}`, resp.Body.String())
+
+	resp = performRequest(router, "GET", "/health", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var report health.Report
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.Equal(t, "up", report.Status)
+	assert.False(t, report.ShuttingDown)
+	assert.Equal(t, "up", report.Checks["postgres"].Status)
+	assert.Equal(t, "up", report.Checks["migrations"].Status)
+
+	// Readiness fails once shutdown starts, while the process stays live.
+	checker.Drain()
+	resp = performRequest(router, "GET", "/readyz", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.JSONEq(t, `{"status":"down"}`, resp.Body.String())
+
+	resp = performRequest(router, "GET", "/health", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"shutting_down":true`)
+
+	resp = performRequest(router, "GET", "/healthz", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHealth_DependencyDown(t *testing.T) {
+	router, _ := setupHealthHandler(errors.New("schema is at version 13, expected 14"))
+
+	resp := performRequest(router, "GET", "/readyz", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+
+	resp = performRequest(router, "GET", "/health", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	var report health.Report
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.Equal(t, "down", report.Status)
+	assert.Equal(t, "up", report.Checks["postgres"].Status)
+	assert.Equal(t, health.Result{Status: "down", LatencyMS: report.Checks["migrations"].LatencyMS,
+		Error: "schema is at version 13, expected 14"}, report.Checks["migrations"])
+}
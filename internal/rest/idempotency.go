@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/idempotency"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader makes a POST request safe to retry: the response to
+	// the first request with a key is stored and replayed to later ones.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed for a repeated key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotency serves POST requests that carry an Idempotency-Key header at most
+// once per key. A repeated key gets the stored response, a key sent with a
+// different request 422 and a key whose first request is still being served
+// 409. Server errors are not stored, so the request can be retried.
+func (h *HandlerConfig) idempotency(c *gin.Context) {
+	const op = "rest.idempotency"
+
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if c.Request.Method != http.MethodPost || key == "" || c.FullPath() == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BaseResponse{Error: fmt.Sprintf("Invalid '%s', must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength), ErrorCode: 400})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BaseResponse{Error: "Failed to read request body", ErrorCode: 400})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The outcome is saved even if the caller hangs up, which is when it is
+	// most likely to retry.
+	ctx := context.WithoutCancel(c.Request.Context())
+	stored, reservation, err := h.Dep.Idempotency.Begin(ctx, key, requestHash(c.Request, body))
+	switch {
+	case errors.Is(err, idempotency.ErrKeyReused):
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, BaseResponse{Error: err.Error(), ErrorCode: 422})
+		return
+	case errors.Is(err, idempotency.ErrInProgress):
+		c.AbortWithStatusJSON(http.StatusConflict, BaseResponse{Error: err.Error(), ErrorCode: 409})
+		return
+	case err != nil:
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to reserve idempotency key: ", op), sl.Err(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to check idempotency key", ErrorCode: 500})
+		return
+	case stored != nil:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+		c.Abort()
+		return
+	}
+
+	w := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = w
+	served := false
+	defer func() {
+		// Free the key of a request that failed or panicked for a retry.
+		if served {
+			return
+		}
+		if err := h.Dep.Idempotency.Release(ctx, reservation); err != nil {
+			h.logger(c).Error(fmt.Sprintf("%s | Failed to release idempotency key: ", op), sl.Err(err))
+		}
+	}()
+
+	c.Next()
+
+	if w.Status() >= http.StatusInternalServerError {
+		return
+	}
+	served = true
+	resp := idempotency.Response{
+		StatusCode:  w.Status(),
+		ContentType: w.Header().Get("Content-Type"),
+		Body:        w.body.Bytes(),
+	}
+	if err := h.Dep.Idempotency.Complete(ctx, reservation, resp); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to store response: ", op), sl.Err(err))
+	}
+}
+
+// requestHash identifies a request by its method, path, query and body.
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.RequestURI())
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordingWriter keeps a copy of the response body.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
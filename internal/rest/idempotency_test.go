@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/idempotency"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func (m *mockIdempotencyRepo) Reserve(ctx context.Context, r idempotency.Record, now, staleBefore time.Time) (idempotency.Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if rec, ok := m.records[r.Key]; ok && rec.ExpiresAt.After(now) && (rec.Response != nil || !rec.CreatedAt.Before(staleBefore)) {
+		return rec, false, nil
+	}
+	r.CreatedAt = now
+	m.records[r.Key] = r
+	return r, true, nil
+}
+
+func (m *mockIdempotencyRepo) Complete(ctx context.Context, res idempotency.Reservation, resp idempotency.Response) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if rec, ok := m.records[res.Key]; ok && rec.CreatedAt.Equal(res.CreatedAt) {
+		rec.Response = &resp
+		m.records[res.Key] = rec
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepo) Release(ctx context.Context, res idempotency.Reservation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if rec, ok := m.records[res.Key]; ok && rec.CreatedAt.Equal(res.CreatedAt) {
+		delete(m.records, res.Key)
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+// failingProductRepo fails to create products while err is set.
+type failingProductRepo struct {
+	*mockProductUseCase
+	err error
+}
+
+func (f *failingProductRepo) Create(ctx context.Context, p product.Product) (int32, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.mockProductUseCase.Create(ctx, p)
+}
+
+func setupIdempotency() (*gin.Engine, *failingProductRepo, *mockIdempotencyRepo) {
+	products := &failingProductRepo{mockProductUseCase: &mockProductUseCase{products: make(map[int32]product.Product)}}
+	keys := &mockIdempotencyRepo{records: make(map[string]idempotency.Record)}
+
+	gin.SetMode(gin.TestMode)
+	router := NewHandler(HandlerConfig{Dep: &scope.Dependencies{
+		Product:     usecase.NewProductUseCase(products),
+		Idempotency: usecase.NewIdempotencyUseCase(keys, time.Hour, time.Minute),
+		Sl:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}})
+	return router, products, keys
+}
+
+const chairBody = `{"name": "Office chair", "description": "Black", "price": {"amount": "129.90", "currency": "USD"}, "quantity": 4}`
+
+func postWithKey(router http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	router, products, _ := setupIdempotency()
+
+	first := postWithKey(router, "/products", "scan-1", chairBody)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	retry := postWithKey(router, "/products", "scan-1", chairBody)
+	assert.Equal(t, http.StatusOK, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Len(t, products.products, 1)
+
+	// Without a key, or with another one, the request is served again.
+	assert.Equal(t, http.StatusOK, postWithKey(router, "/products", "", chairBody).Code)
+	assert.Equal(t, http.StatusOK, postWithKey(router, "/products", "scan-2", chairBody).Code)
+	assert.Len(t, products.products, 3)
+}
+
+func TestIdempotency_ClientErrorReplayed(t *testing.T) {
+	router, products, _ := setupIdempotency()
+
+	body := `{"name": "Office chair", "description": "Black", "price": {"amount": "0", "currency": "USD"}, "quantity": 4}`
+	first := postWithKey(router, "/products", "scan-1", body)
+	assert.Equal(t, http.StatusBadRequest, first.Code)
+
+	retry := postWithKey(router, "/products", "scan-1", body)
+	assert.Equal(t, http.StatusBadRequest, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Empty(t, products.products)
+}
+
+func TestIdempotency_KeyReused(t *testing.T) {
+	router, products, _ := setupIdempotency()
+
+	assert.Equal(t, http.StatusOK, postWithKey(router, "/products", "scan-1", chairBody).Code)
+
+	resp := postWithKey(router, "/products", "scan-1", strings.Replace(chairBody, "Black", "White", 1))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.JSONEq(t, `{"success": false, "error": "idempotency key was used with a different request", "errorCode": 422}`, resp.Body.String())
+
+	// The same key on another route is a different request too.
+	resp = postWithKey(router, "/products/1/stock/receipts", "scan-1", chairBody)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Len(t, products.products, 1)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	router, products, keys := setupIdempotency()
+
+	hash := requestHash(httptest.NewRequest(http.MethodPost, "/products", nil), []byte(chairBody))
+	keys.records["scan-1"] = idempotency.Record{Key: "scan-1", RequestHash: hash, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+	resp := postWithKey(router, "/products", "scan-1", chairBody)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Empty(t, products.products)
+
+	// A request that has been pending for longer than the lock timeout was lost
+	// and is served again.
+	keys.records["scan-1"] = idempotency.Record{Key: "scan-1", RequestHash: hash, CreatedAt: time.Now().Add(-2 * time.Minute), ExpiresAt: time.Now().Add(time.Hour)}
+	resp = postWithKey(router, "/products", "scan-1", chairBody)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Len(t, products.products, 1)
+}
+
+func TestIdempotency_TakenOver(t *testing.T) {
+	keys := &mockIdempotencyRepo{records: make(map[string]idempotency.Record)}
+	uc := usecase.NewIdempotencyUseCase(keys, time.Hour, time.Minute)
+
+	// The first request has been pending for longer than the lock timeout, so a
+	// retry takes the key over.
+	lost := idempotency.Reservation{Key: "scan-1", CreatedAt: time.Now().Add(-2 * time.Minute)}
+	keys.records["scan-1"] = idempotency.Record{Key: "scan-1", RequestHash: "hash", CreatedAt: lost.CreatedAt, ExpiresAt: time.Now().Add(time.Hour)}
+	_, retry, err := uc.Begin(context.TODO(), "scan-1", "hash")
+	assert.NoError(t, err)
+	assert.NotEqual(t, lost, retry)
+
+	// The first request finishing late leaves the record of the retry alone.
+	assert.NoError(t, uc.Complete(context.TODO(), lost, idempotency.Response{StatusCode: http.StatusOK}))
+	assert.NoError(t, uc.Release(context.TODO(), lost))
+	assert.Nil(t, keys.records["scan-1"].Response)
+
+	assert.NoError(t, uc.Complete(context.TODO(), retry, idempotency.Response{StatusCode: http.StatusCreated}))
+	assert.Equal(t, http.StatusCreated, keys.records["scan-1"].Response.StatusCode)
+	assert.NoError(t, uc.Release(context.TODO(), lost))
+	assert.Contains(t, keys.records, "scan-1")
+}
+
+func TestIdempotency_ServerErrorNotStored(t *testing.T) {
+	router, products, keys := setupIdempotency()
+
+	products.err = errors.New("connection reset")
+	assert.Equal(t, http.StatusInternalServerError, postWithKey(router, "/products", "scan-1", chairBody).Code)
+	assert.Empty(t, keys.records)
+
+	products.err = nil
+	resp := postWithKey(router, "/products", "scan-1", chairBody)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get(IdempotentReplayedHeader))
+	assert.Len(t, products.products, 1)
+}
+
+func TestIdempotency_Expired(t *testing.T) {
+	router, products, keys := setupIdempotency()
+
+	assert.Equal(t, http.StatusOK, postWithKey(router, "/products", "scan-1", chairBody).Code)
+	rec := keys.records["scan-1"]
+	rec.ExpiresAt = time.Now().Add(-time.Second)
+	keys.records["scan-1"] = rec
+
+	resp := postWithKey(router, "/products", "scan-1", strings.Replace(chairBody, "Black", "White", 1))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Len(t, products.products, 2)
+}
+
+func TestIdempotency_InvalidKey(t *testing.T) {
+	router, products, _ := setupIdempotency()
+
+	resp := postWithKey(router, "/products", strings.Repeat("k", 256), chairBody)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Empty(t, products.products)
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// RequestIDHeader carries the ID correlating the log lines of a request; one
+	// given by the caller is kept, otherwise a new one is generated.
+	RequestIDHeader = "X-Request-ID"
+	// UserHeader names the caller, as set by the authenticating proxy in front
+	// of the API.
+	UserHeader = "X-User"
+)
+
+// requestLogger puts a logger with the request ID, route, user and trace ID in
+// the request context, where handlers and use cases find it with sl.FromContext,
+// and logs every request with its status and latency once it is served.
+func requestLogger(base *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = newRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+
+		attrs := []any{
+			slog.String("request_id", id),
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+		}
+		if user := c.GetHeader(UserHeader); user != "" {
+			attrs = append(attrs, slog.String("user", user))
+		}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			attrs = append(attrs, slog.String("trace_id", span.TraceID().String()))
+		}
+		log := base.With(attrs...)
+		c.Request = c.Request.WithContext(sl.WithLogger(c.Request.Context(), log))
+
+		c.Next()
+
+		level := slog.LevelInfo
+		switch status := c.Writer.Status(); {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		log.LogAttrs(c.Request.Context(), level, "request served",
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logger returns the request-scoped logger, or the base logger outside a request.
+func (h *HandlerConfig) logger(c *gin.Context) *slog.Logger {
+	return sl.FromContext(c.Request.Context(), h.Dep.Sl)
+}
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/graphqlapi"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// logLines decodes the JSON log lines written to buf.
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	gin.SetMode(gin.TestMode)
+	router := NewHandler(HandlerConfig{Dep: &scope.Dependencies{
+		Product: usecase.NewProductUseCase(&mockProductUseCase{products: make(map[int32]product.Product)}),
+		Sl:      slog.New(slog.NewJSONHandler(&buf, nil)),
+	}})
+
+	body := []byte(`{"name":"Juice","description":"Apple","price":{"amount":"1.20","currency":"USD"}}`)
+	req := httptest.NewRequest("POST", "/products", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, "req-42")
+	req.Header.Set(UserHeader, "alice")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-42", w.Header().Get(RequestIDHeader))
+
+	// The use case logs through the request logger, then the request is logged.
+	lines := logLines(t, &buf)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, "Product created", lines[0]["msg"])
+		assert.Equal(t, "req-42", lines[0]["request_id"])
+		assert.Equal(t, "alice", lines[0]["user"])
+
+		assert.Equal(t, "request served", lines[1]["msg"])
+		assert.Equal(t, "INFO", lines[1]["level"])
+		assert.Equal(t, "req-42", lines[1]["request_id"])
+		assert.Equal(t, "/products", lines[1]["route"])
+		assert.Equal(t, float64(200), lines[1]["status"])
+		assert.Contains(t, lines[1], "latency_ms")
+	}
+
+	// Without a request ID one is generated; failures are logged as warnings
+	// with the same ID as the handler's error.
+	buf.Reset()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/products/7", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	id := w.Header().Get(RequestIDHeader)
+	assert.Len(t, id, 32)
+
+	lines = logLines(t, &buf)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, "ERROR", lines[0]["level"])
+		assert.Equal(t, id, lines[0]["request_id"])
+		assert.Equal(t, "WARN", lines[1]["level"])
+		assert.Equal(t, id, lines[1]["request_id"])
+		assert.Equal(t, "/products/:id", lines[1]["route"])
+	}
+}
+
+func TestRequestLogger_GraphQL(t *testing.T) {
+	var buf bytes.Buffer
+	gin.SetMode(gin.TestMode)
+	dep := &scope.Dependencies{
+		Product: usecase.NewProductUseCase(&mockProductUseCase{products: make(map[int32]product.Product)}),
+		Sl:      slog.New(slog.NewJSONHandler(&buf, nil)),
+	}
+	server, err := graphqlapi.NewServer(dep, graphqlapi.Limits{MaxComplexity: 100, MaxDepth: 10})
+	assert.NoError(t, err)
+	router := NewHandler(HandlerConfig{Dep: dep, GraphQL: server})
+
+	// Resolvers log their failures through the request logger.
+	body := []byte(`{"query":"{ product(id: 7) { id } }"}`)
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, "req-43")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	lines := logLines(t, &buf)
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, "ERROR", lines[0]["level"])
+		assert.Equal(t, "req-43", lines[0]["request_id"])
+		assert.Equal(t, "/graphql", lines[0]["route"])
+	}
+}
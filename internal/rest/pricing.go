@@ -0,0 +1,450 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type PriceListRequest struct {
+	Name          string     `json:"name" binding:"required,min=2,max=255"`
+	Currency      string     `json:"currency" binding:"required,len=3"`
+	CustomerGroup string     `json:"customer_group" binding:"max=100"`
+	ValidFrom     *time.Time `json:"valid_from"`
+	ValidTo       *time.Time `json:"valid_to"`
+}
+
+type PriceListItemRequest struct {
+	Price money.Money `json:"price"`
+}
+
+type SchedulePriceRequest struct {
+	Price       money.Money `json:"price"`
+	ValidFrom   *time.Time  `json:"valid_from"`
+	ValidTo     *time.Time  `json:"valid_to"`
+	RequestedBy string      `json:"requested_by" binding:"max=100"`
+	ApprovedBy  string      `json:"approved_by" binding:"required,max=100"`
+}
+
+// CreatePriceList godoc
+// @Summary Create a price list
+// @Description Add a price list for a currency and optional customer group with a validity window
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Param priceList body PriceListRequest true "Price list info"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created price list"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /price-lists [post]
+func (h *HandlerConfig) CreatePriceList(c *gin.Context) {
+	const op = "rest.pricing.createPriceList"
+
+	var req PriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	list := pricing.PriceList{
+		Name:          req.Name,
+		Currency:      req.Currency,
+		CustomerGroup: req.CustomerGroup,
+		ValidTo:       req.ValidTo,
+	}
+	if req.ValidFrom != nil {
+		list.ValidFrom = *req.ValidFrom
+	}
+
+	id, err := h.Dep.Pricing.CreatePriceList(c.Request.Context(), list)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating price list: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListPriceLists godoc
+// @Summary List price lists
+// @Description Get all price lists without their items
+// @Tags pricing
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of price lists"
+// @Failure 500 {object} BaseResponse "List retrieval failed"
+// @Router /price-lists [get]
+func (h *HandlerConfig) ListPriceLists(c *gin.Context) {
+	const op = "rest.pricing.listPriceLists"
+
+	lists, err := h.Dep.Pricing.ListPriceLists(c.Request.Context())
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list price lists: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to list price lists", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": lists})
+}
+
+// GetPriceList godoc
+// @Summary Get price list by ID
+// @Description Retrieve a price list together with its product prices
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Price list ID"
+// @Success 200 {object} map[string]interface{} "Price list data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Price list not found"
+// @Router /price-lists/{id} [get]
+func (h *HandlerConfig) GetPriceList(c *gin.Context) {
+	const op = "rest.pricing.getPriceList"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	list, err := h.Dep.Pricing.GetPriceList(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get price list: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// DeletePriceList godoc
+// @Summary Delete price list by ID
+// @Description Remove a price list and all of its product prices
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Price list ID"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 500 {object} BaseResponse "Delete failed"
+// @Router /price-lists/{id} [delete]
+func (h *HandlerConfig) DeletePriceList(c *gin.Context) {
+	const op = "rest.pricing.deletePriceList"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Pricing.DeletePriceList(c.Request.Context(), id); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to delete price list: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to delete price list", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// SetPriceListItem godoc
+// @Summary Set product price in a price list
+// @Description Create or replace the price of a product in a price list; the currency must match the list
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Param id path int true "Price list ID"
+// @Param productId path int true "Product ID"
+// @Param item body PriceListItemRequest true "Product price"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Price list or product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /price-lists/{id}/items/{productId} [put]
+func (h *HandlerConfig) SetPriceListItem(c *gin.Context) {
+	const op = "rest.pricing.setPriceListItem"
+
+	listID, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+	productID, err := pathID(c, "productId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid product ID", ErrorCode: 400})
+		return
+	}
+
+	var req PriceListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	err = h.Dep.Pricing.SetItem(c.Request.Context(), pricing.Item{
+		PriceListID: listID,
+		ProductID:   productID,
+		Price:       req.Price,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to set price list item: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// DeletePriceListItem godoc
+// @Summary Remove product from a price list
+// @Description Delete the price of a product from a price list
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Price list ID"
+// @Param productId path int true "Product ID"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 500 {object} BaseResponse "Delete failed"
+// @Router /price-lists/{id}/items/{productId} [delete]
+func (h *HandlerConfig) DeletePriceListItem(c *gin.Context) {
+	const op = "rest.pricing.deletePriceListItem"
+
+	listID, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+	productID, err := pathID(c, "productId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid product ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Pricing.DeleteItem(c.Request.Context(), listID, productID); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to delete price list item: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to delete price list item", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// GetProductPrice godoc
+// @Summary Resolve product price
+// @Description Resolve the applicable price of a product from price lists, falling back to the converted base price
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param currency query string false "ISO 4217 currency, defaults to the base price currency"
+// @Param at query string false "Point in time (RFC 3339), defaults to now"
+// @Param group query string false "Customer group"
+// @Success 200 {object} map[string]interface{} "Resolved price"
+// @Failure 400 {object} BaseResponse "Invalid input or no exchange rate"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/price [get]
+func (h *HandlerConfig) GetProductPrice(c *gin.Context) {
+	const op = "rest.pricing.getProductPrice"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	at := time.Now()
+	if s := c.Query("at"); s != "" {
+		at, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'at' timestamp", ErrorCode: 400})
+			return
+		}
+	}
+
+	price, err := h.Dep.Pricing.Resolve(c.Request.Context(), id, c.Query("currency"), c.Query("group"), at)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to resolve price: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": price})
+}
+
+// ListExchangeRates godoc
+// @Summary List exchange rates
+// @Description Get all exchange rates, newest first per currency pair
+// @Tags pricing
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of exchange rates"
+// @Failure 500 {object} BaseResponse "List retrieval failed"
+// @Router /exchange-rates [get]
+func (h *HandlerConfig) ListExchangeRates(c *gin.Context) {
+	const op = "rest.pricing.listExchangeRates"
+
+	rates, err := h.Dep.Pricing.ListExchangeRates(c.Request.Context())
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list exchange rates: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to list exchange rates", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rates})
+}
+
+// ImportExchangeRates godoc
+// @Summary Import exchange rates
+// @Description Load exchange rates from a CSV body with the columns base,quote,rate,valid_from
+// @Tags pricing
+// @Accept text/csv
+// @Produce json
+// @Param rates body string true "CSV rows"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Number of imported rates"
+// @Failure 400 {object} BaseResponse "Invalid file"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /exchange-rates/import [post]
+func (h *HandlerConfig) ImportExchangeRates(c *gin.Context) {
+	const op = "rest.pricing.importExchangeRates"
+
+	n, err := h.Dep.Pricing.LoadExchangeRates(c.Request.Context(), c.Request.Body)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to import exchange rates: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"imported": n})
+}
+
+// ListProductPrices godoc
+// @Summary Get product price history
+// @Description List past, current and scheduled prices of a product, newest first
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{} "Price history"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/prices [get]
+func (h *HandlerConfig) ListProductPrices(c *gin.Context) {
+	const op = "rest.pricing.listProductPrices"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	history, err := h.Dep.Pricing.PriceHistory(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get price history: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
+// ScheduleProductPrice godoc
+// @Summary Schedule a product price change
+// @Description Schedule an approved price for a future period; without valid_from the price applies immediately
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param change body SchedulePriceRequest true "Price change"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of the price change"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/prices [post]
+func (h *HandlerConfig) ScheduleProductPrice(c *gin.Context) {
+	const op = "rest.pricing.scheduleProductPrice"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	change := pricing.PriceChange{
+		ProductID:  id,
+		Price:      req.Price,
+		ValidTo:    req.ValidTo,
+		CreatedBy:  req.RequestedBy,
+		ApprovedBy: req.ApprovedBy,
+	}
+	if req.ValidFrom != nil {
+		change.ValidFrom = *req.ValidFrom
+	}
+
+	changeID, err := h.Dep.Pricing.SchedulePrice(c.Request.Context(), change)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to schedule price: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": changeID})
+}
+
+// CancelProductPrice godoc
+// @Summary Cancel a scheduled price change
+// @Description Cancel a price change that has not been activated yet
+// @Tags pricing
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param priceId path int true "Price change ID"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID or change is not pending"
+// @Failure 404 {object} BaseResponse "Price change not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/prices/{priceId} [delete]
+func (h *HandlerConfig) CancelProductPrice(c *gin.Context) {
+	const op = "rest.pricing.cancelProductPrice"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+	changeID, err := pathID(c, "priceId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid price ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Pricing.CancelPriceChange(c.Request.Context(), id, changeID); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to cancel price change: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
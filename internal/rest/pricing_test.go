@@ -0,0 +1,403 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPricingRepo struct {
+	lists    map[int32]pricing.PriceList
+	items    map[[2]int32]pricing.Item
+	rates    []pricing.ExchangeRate
+	changes  []pricing.PriceChange
+	products *mockProductUseCase
+	nextID   int32
+}
+
+func (m *mockPricingRepo) CreatePriceList(ctx context.Context, l pricing.PriceList) (int32, error) {
+	m.nextID++
+	l.ID = m.nextID
+	m.lists[l.ID] = l
+	return l.ID, nil
+}
+
+func (m *mockPricingRepo) GetPriceList(ctx context.Context, id int32) (pricing.PriceList, error) {
+	l, ok := m.lists[id]
+	if !ok {
+		return pricing.PriceList{}, pricing.ErrNotFound
+	}
+	for _, item := range m.items {
+		if item.PriceListID == id {
+			l.Items = append(l.Items, item)
+		}
+	}
+	return l, nil
+}
+
+func (m *mockPricingRepo) ListPriceLists(ctx context.Context) ([]pricing.PriceList, error) {
+	var list []pricing.PriceList
+	for _, l := range m.lists {
+		list = append(list, l)
+	}
+	return list, nil
+}
+
+func (m *mockPricingRepo) DeletePriceList(ctx context.Context, id int32) error {
+	delete(m.lists, id)
+	return nil
+}
+
+func (m *mockPricingRepo) SetItem(ctx context.Context, item pricing.Item) error {
+	m.items[[2]int32{item.PriceListID, item.ProductID}] = item
+	return nil
+}
+
+func (m *mockPricingRepo) DeleteItem(ctx context.Context, priceListID, productID int32) error {
+	delete(m.items, [2]int32{priceListID, productID})
+	return nil
+}
+
+func (m *mockPricingRepo) FindPrice(ctx context.Context, productID int32, currency, group string, at time.Time) (pricing.Item, error) {
+	var candidates []pricing.PriceList
+	for _, l := range m.lists {
+		_, ok := m.items[[2]int32{l.ID, productID}]
+		if ok && l.Currency == currency && l.ActiveAt(at) && (l.CustomerGroup == "" || l.CustomerGroup == group) {
+			candidates = append(candidates, l)
+		}
+	}
+	if len(candidates) == 0 {
+		return pricing.Item{}, pricing.ErrNotFound
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].CustomerGroup > candidates[j].CustomerGroup
+	})
+	return m.items[[2]int32{candidates[0].ID, productID}], nil
+}
+
+func (m *mockPricingRepo) SaveExchangeRates(ctx context.Context, rates []pricing.ExchangeRate) error {
+	m.rates = append(m.rates, rates...)
+	return nil
+}
+
+func (m *mockPricingRepo) GetExchangeRate(ctx context.Context, base, quote string, at time.Time) (pricing.ExchangeRate, error) {
+	var found *pricing.ExchangeRate
+	for i, r := range m.rates {
+		if r.Base == base && r.Quote == quote && !r.ValidFrom.After(at) && (found == nil || r.ValidFrom.After(found.ValidFrom)) {
+			found = &m.rates[i]
+		}
+	}
+	if found == nil {
+		return pricing.ExchangeRate{}, pricing.ErrNotFound
+	}
+	return *found, nil
+}
+
+func (m *mockPricingRepo) ListExchangeRates(ctx context.Context) ([]pricing.ExchangeRate, error) {
+	return m.rates, nil
+}
+
+func (m *mockPricingRepo) ListPriceChanges(ctx context.Context, productID int32) ([]pricing.PriceChange, error) {
+	var list []pricing.PriceChange
+	for _, pc := range m.changes {
+		if pc.ProductID == productID {
+			list = append(list, pc)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockPricingRepo) GetPriceChange(ctx context.Context, id int32) (pricing.PriceChange, error) {
+	for _, pc := range m.changes {
+		if pc.ID == id {
+			return pc, nil
+		}
+	}
+	return pricing.PriceChange{}, pricing.ErrNotFound
+}
+
+func (m *mockPricingRepo) SchedulePriceChange(ctx context.Context, pc pricing.PriceChange) (int32, error) {
+	pc.ID = int32(len(m.changes) + 1)
+	pc.Status = pricing.ChangePending
+	m.changes = append(m.changes, pc)
+	return pc.ID, nil
+}
+
+func (m *mockPricingRepo) CancelPriceChange(ctx context.Context, id int32) error {
+	m.changes[id-1].Status = pricing.ChangeCancelled
+	return nil
+}
+
+func (m *mockPricingRepo) ActivateDuePriceChanges(ctx context.Context, now time.Time) ([]pricing.PriceChange, error) {
+	effective := make(map[int32]int)
+	for i, pc := range m.changes {
+		if pc.Status == pricing.ChangeCancelled || pc.ValidFrom.After(now) || (pc.ValidTo != nil && !pc.ValidTo.After(now)) {
+			continue
+		}
+		if j, ok := effective[pc.ProductID]; !ok || !pc.ValidFrom.Before(m.changes[j].ValidFrom) {
+			effective[pc.ProductID] = i
+		}
+	}
+
+	var activated []pricing.PriceChange
+	for productID, i := range effective {
+		if m.changes[i].Status == pricing.ChangeActive {
+			continue
+		}
+		for j := range m.changes {
+			if m.changes[j].ProductID == productID && m.changes[j].Status == pricing.ChangeActive {
+				m.changes[j].Status = pricing.ChangeSuperseded
+			}
+		}
+		m.changes[i].Status = pricing.ChangeActive
+		p := m.products.products[productID]
+		p.Price = m.changes[i].Price
+		m.products.products[productID] = p
+		activated = append(activated, m.changes[i])
+	}
+	return activated, nil
+}
+
+func setupPricingHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockPricingRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	prices := &mockPricingRepo{
+		lists:    make(map[int32]pricing.PriceList),
+		items:    make(map[[2]int32]pricing.Item),
+		products: products,
+	}
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Product: usecase.NewProductUseCase(products),
+			Pricing: usecase.NewPricingUseCase(prices, products, money.RoundHalfUp),
+			Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/products/:id/price", h.GetProductPrice)
+	router.POST("/price-lists", h.CreatePriceList)
+	router.GET("/price-lists/:id", h.GetPriceList)
+	router.PUT("/price-lists/:id/items/:productId", h.SetPriceListItem)
+	router.POST("/exchange-rates/import", h.ImportExchangeRates)
+	router.GET("/products/:id/prices", h.ListProductPrices)
+	router.POST("/products/:id/prices", h.ScheduleProductPrice)
+	router.DELETE("/products/:id/prices/:priceId", h.CancelProductPrice)
+	return router, products, prices
+}
+
+func TestCreatePriceList(t *testing.T) {
+	router, _, _ := setupPricingHandlerWithMock()
+
+	resp := performRequest(router, "POST", "/price-lists", []byte(`{"name":"Wholesale EUR","currency":"EUR","customer_group":"wholesale"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+
+	resp = performRequest(router, "POST", "/price-lists", []byte(`{
+		"name": "Broken",
+		"currency": "EUR",
+		"valid_from": "2026-02-01T00:00:00Z",
+		"valid_to": "2026-01-01T00:00:00Z"
+	}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "valid_to must be after valid_from")
+}
+
+func TestSetPriceListItem(t *testing.T) {
+	router, products, _ := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2", "USD")})
+
+	performRequest(router, "POST", "/price-lists", []byte(`{"name":"Retail EUR","currency":"EUR"}`))
+
+	resp := performRequest(router, "PUT", "/price-lists/1/items/"+itoa(id), []byte(`{"price":{"amount":"1.80","currency":"USD"}}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "does not match price list currency")
+
+	resp = performRequest(router, "PUT", "/price-lists/1/items/99", []byte(`{"price":{"amount":"1.80","currency":"EUR"}}`))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = performRequest(router, "PUT", "/price-lists/1/items/"+itoa(id), []byte(`{"price":{"amount":"1.80","currency":"EUR"}}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "GET", "/price-lists/1", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"price":{"amount":"1.80","currency":"EUR"}`)
+}
+
+func TestGetProductPrice(t *testing.T) {
+	router, products, _ := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2.50", "USD")})
+
+	performRequest(router, "POST", "/price-lists", []byte(`{"name":"Retail EUR","currency":"EUR","valid_from":"2026-01-01T00:00:00Z"}`))
+	performRequest(router, "POST", "/price-lists", []byte(`{"name":"Wholesale EUR","currency":"EUR","customer_group":"wholesale","valid_from":"2026-01-01T00:00:00Z"}`))
+	performRequest(router, "PUT", "/price-lists/1/items/"+itoa(id), []byte(`{"price":{"amount":"2.40","currency":"EUR"}}`))
+	performRequest(router, "PUT", "/price-lists/2/items/"+itoa(id), []byte(`{"price":{"amount":"2.10","currency":"EUR"}}`))
+
+	rates := "base,quote,rate,valid_from\nUSD,UZS,12650.5,2026-01-01\nUSD,UZS,12800,2026-06-01\n"
+	resp := performRequest(router, "POST", "/exchange-rates/import", []byte(rates))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"imported":2`)
+
+	tests := []struct {
+		name     string
+		query    string
+		code     int
+		expected []string
+	}{
+		{"Base price", "", http.StatusOK, []string{`"amount":"2.50","currency":"USD"`, `"source":"base"`}},
+		{"Price list", "?currency=EUR&at=2026-03-01T00:00:00Z", http.StatusOK, []string{`"amount":"2.40"`, `"source":"price_list"`, `"price_list_id":1`}},
+		{"Customer group", "?currency=EUR&group=wholesale&at=2026-03-01T00:00:00Z", http.StatusOK, []string{`"amount":"2.10"`, `"price_list_id":2`}},
+		{"Before price list validity", "?currency=EUR&at=2025-12-01T00:00:00Z", http.StatusBadRequest, []string{"no exchange rate available"}},
+		{"Converted", "?currency=UZS&at=2026-03-01T00:00:00Z", http.StatusOK, []string{`"amount":"31626.25","currency":"UZS"`, `"source":"converted"`, `"rate":"12650.5"`}},
+		{"Later rate", "?currency=UZS&at=2026-07-01T00:00:00Z", http.StatusOK, []string{`"amount":"32000.00"`}},
+		{"Unsupported currency", "?currency=XYZ", http.StatusBadRequest, []string{"currency is not supported"}},
+		{"Invalid at", "?at=yesterday", http.StatusBadRequest, []string{"Invalid 'at'"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "GET", "/products/"+itoa(id)+"/price"+tt.query, nil)
+			assert.Equal(t, tt.code, resp.Code)
+			for _, s := range tt.expected {
+				assert.Contains(t, resp.Body.String(), s)
+			}
+		})
+	}
+
+	resp = performRequest(router, "GET", "/products/99/price", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestGetProductPrice_InverseRate(t *testing.T) {
+	router, products, _ := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("12800", "UZS")})
+
+	performRequest(router, "POST", "/exchange-rates/import", []byte("USD,UZS,12800,2026-01-01T00:00:00Z\n"))
+
+	resp := performRequest(router, "GET", "/products/"+itoa(id)+"/price?currency=USD&at=2026-02-01T00:00:00Z", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"amount":"1.00","currency":"USD"`)
+}
+
+func TestImportExchangeRates_Invalid(t *testing.T) {
+	router, _, _ := setupPricingHandlerWithMock()
+
+	for _, body := range []string{
+		"USD,XYZ,1.5,2026-01-01\n",
+		"USD,EUR,-1,2026-01-01\n",
+		"USD,EUR,1/3,2026-01-01\n",
+		"USD,EUR,0.9,yesterday\n",
+		"USD,EUR,0.9\n",
+	} {
+		resp := performRequest(router, "POST", "/exchange-rates/import", []byte(body))
+		assert.Equal(t, http.StatusBadRequest, resp.Code, strings.TrimSpace(body))
+		assert.Contains(t, resp.Body.String(), "invalid exchange rates file")
+	}
+}
+
+func TestScheduleProductPrice(t *testing.T) {
+	router, products, prices := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2.50", "USD")})
+	prices.changes = append(prices.changes, pricing.PriceChange{
+		ID: 1, ProductID: id, Price: money.MustParse("2.50", "USD"), Status: pricing.ChangeActive,
+		ValidFrom: time.Now().Add(-24 * time.Hour),
+	})
+
+	start := time.Now().Add(time.Hour).UTC()
+	end := start.Add(48 * time.Hour)
+	body := `{"price":{"amount":"1.99","currency":"USD"},"valid_from":"` + start.Format(time.RFC3339) +
+		`","valid_to":"` + end.Format(time.RFC3339) + `","approved_by":"manager"}`
+
+	resp := performRequest(router, "POST", "/products/"+itoa(id)+"/prices", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":2`)
+	assert.Equal(t, "2.50", products.products[id].Price.Decimal())
+
+	resp = performRequest(router, "GET", "/products/"+itoa(id)+"/prices", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"pending"`)
+	assert.Contains(t, resp.Body.String(), `"approved_by":"manager"`)
+
+	uc := usecase.NewPricingUseCase(prices, products, money.RoundHalfUp)
+
+	_, err := uc.ActivateDuePrices(context.TODO(), start.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.99", products.products[id].Price.Decimal())
+	assert.Equal(t, pricing.ChangeSuperseded, prices.changes[0].Status)
+
+	_, err = uc.ActivateDuePrices(context.TODO(), end.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "2.50", products.products[id].Price.Decimal())
+	assert.Equal(t, pricing.ChangeActive, prices.changes[0].Status)
+}
+
+func TestScheduleProductPrice_Validation(t *testing.T) {
+	router, products, _ := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2.50", "USD")})
+	stocked, _ := products.Create(context.TODO(), product.Product{Name: "Water", Price: money.MustParse("1", "USD"), Quantity: 5})
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Missing approver", "/products/" + itoa(id) + "/prices", `{"price":{"amount":"1","currency":"USD"}}`, http.StatusBadRequest, "'ApprovedBy' failed on the 'required'"},
+		{"In the past", "/products/" + itoa(id) + "/prices", `{"price":{"amount":"1","currency":"USD"},"valid_from":"` + past + `","approved_by":"boss"}`, http.StatusBadRequest, "must not be in the past"},
+		{"Price limit", "/products/" + itoa(id) + "/prices", `{"price":{"amount":"20000","currency":"USD"},"approved_by":"boss"}`, http.StatusBadRequest, "price exceeds"},
+		{"Currency of stock", "/products/" + itoa(stocked) + "/prices", `{"price":{"amount":"1","currency":"EUR"},"approved_by":"boss"}`, http.StatusBadRequest, "price currency can only change"},
+		{"Unknown product", "/products/99/prices", `{"price":{"amount":"1","currency":"USD"},"approved_by":"boss"}`, http.StatusNotFound, "product not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+}
+
+func TestScheduleProductPrice_Immediate(t *testing.T) {
+	router, products, _ := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2.50", "USD")})
+
+	resp := performRequest(router, "POST", "/products/"+itoa(id)+"/prices", []byte(`{"price":{"amount":"3","currency":"USD"},"approved_by":"boss"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "3.00", products.products[id].Price.Decimal())
+
+	resp = performRequest(router, "DELETE", "/products/"+itoa(id)+"/prices/1", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "only pending price changes")
+}
+
+func TestCancelProductPrice(t *testing.T) {
+	router, products, prices := setupPricingHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("2.50", "USD")})
+
+	start := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	performRequest(router, "POST", "/products/"+itoa(id)+"/prices", []byte(`{"price":{"amount":"3","currency":"USD"},"valid_from":"`+start+`","approved_by":"boss"}`))
+
+	resp := performRequest(router, "DELETE", "/products/99/prices/1", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = performRequest(router, "DELETE", "/products/"+itoa(id)+"/prices/1", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, pricing.ChangeCancelled, prices.changes[0].Status)
+}
@@ -1,10 +1,12 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
 	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
 	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
 	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
@@ -18,10 +20,23 @@ type BaseResponse struct {
 }
 
 type ProductRequest struct {
-	Name        string `json:"name" binding:"required,min=2,max=255"`
-	Description string `json:"description" binding:"required,max=1000"`
-	Price       int32  `json:"price" binding:"required,gt=0"`
-	Quantity    int32  `json:"quantity" binding:"required,gte=0"`
+	Name        string      `json:"name" binding:"required,min=2,max=255"`
+	Description string      `json:"description" binding:"required,max=1000"`
+	Price       money.Money `json:"price"`
+	// Quantity is only used on create, as opening stock; later changes go
+	// through stock adjustments.
+	Quantity int32 `json:"quantity" binding:"gte=0"`
+	// UnitCost is the cost of one unit of the opening stock in the price
+	// currency, only used on create; the opening stock is valued at the price
+	// if omitted.
+	UnitCost *money.Money `json:"unit_cost"`
+	// BaseUnit is only used on create; it cannot change once stock is kept in it.
+	BaseUnit   string `json:"base_unit" binding:"max=20"`
+	LotTracked bool   `json:"lot_tracked"`
+	Serialized bool   `json:"serialized"`
+	// BinLocation is the storage bin pick lists are grouped by.
+	BinLocation string `json:"bin_location" binding:"max=50" example:"A-03-2"`
+	Category    string `json:"category" binding:"max=100" example:"Furniture"`
 }
 
 // CreateProduct godoc
@@ -31,8 +46,11 @@ type ProductRequest struct {
 // @Accept json
 // @Produce json
 // @Param product body ProductRequest true "Product info"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
 // @Success 200 {object} map[string]int "Returns ID of created product"
 // @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
 // @Failure 500 {object} BaseResponse "Server error"
 // @Router /products [post]
 func (h *HandlerConfig) CreateProduct(c *gin.Context) {
@@ -45,13 +63,20 @@ func (h *HandlerConfig) CreateProduct(c *gin.Context) {
 	}
 
 	id, err := h.Dep.Product.Create(c.Request.Context(), product.Product{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Quantity:    req.Quantity,
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		Quantity:        req.Quantity,
+		BaseUnit:        req.BaseUnit,
+		LotTracked:      req.LotTracked,
+		Serialized:      req.Serialized,
+		BinLocation:     req.BinLocation,
+		Category:        req.Category,
+		OpeningUnitCost: req.UnitCost,
 	})
 	if err != nil {
-		h.Dep.Sl.Error(fmt.Sprintf("%s | Error creating product: ", op), sl.Err(err))
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating product: ", op), sl.Err(err))
+		h.Dep.Metrics.Reject(c.FullPath(), err)
 		code := http.StatusInternalServerError
 		if usecase.IsBusinessError(err) {
 			code = http.StatusBadRequest
@@ -85,7 +110,7 @@ func (h *HandlerConfig) GetProduct(c *gin.Context) {
 
 	product, err := h.Dep.Product.GetByID(c.Request.Context(), int32(id))
 	if err != nil {
-		h.Dep.Sl.Error(fmt.Sprintf("%s | Product not found: ", op), sl.Err(err))
+		h.logger(c).Error(fmt.Sprintf("%s | Product not found: ", op), sl.Err(err))
 		c.JSON(http.StatusNotFound, BaseResponse{Error: "Product not found", ErrorCode: 404})
 		return
 	}
@@ -125,10 +150,14 @@ func (h *HandlerConfig) UpdateProduct(c *gin.Context) {
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
-		Quantity:    req.Quantity,
+		LotTracked:  req.LotTracked,
+		Serialized:  req.Serialized,
+		BinLocation: req.BinLocation,
+		Category:    req.Category,
 	})
 	if err != nil {
-		h.Dep.Sl.Error(fmt.Sprintf("%s | Failed to update product: ", op), sl.Err(err))
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to update product: ", op), sl.Err(err))
+		h.Dep.Metrics.Reject(c.FullPath(), err)
 		code := http.StatusInternalServerError
 		if usecase.IsBusinessError(err) {
 			code = http.StatusBadRequest
@@ -142,13 +171,16 @@ func (h *HandlerConfig) UpdateProduct(c *gin.Context) {
 
 // DeleteProduct godoc
 // @Summary Delete product by ID
-// @Description Remove a product from the warehouse
+// @Description Remove a product with its stock history. A product with stock, quarantined stock or stock value,
+// @Description or one that orders, returns, transfers or counts refer to, cannot be deleted
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
 // @Success 200 {object} BaseResponse "Success"
 // @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "Product has stock or is in use"
 // @Failure 500 {object} BaseResponse "Delete failed"
 // @Router /products/{id} [delete]
 func (h *HandlerConfig) DeleteProduct(c *gin.Context) {
@@ -162,7 +194,16 @@ func (h *HandlerConfig) DeleteProduct(c *gin.Context) {
 
 	err = h.Dep.Product.Delete(c.Request.Context(), int32(id))
 	if err != nil {
-		h.Dep.Sl.Error(fmt.Sprintf("%s | Failed to delete product: ", op), sl.Err(err))
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to delete product: ", op), sl.Err(err))
+		switch {
+		case errors.Is(err, product.ErrNotFound):
+			c.JSON(http.StatusNotFound, BaseResponse{Error: "Product not found", ErrorCode: 404})
+			return
+		case errors.Is(err, product.ErrInUse):
+			h.Dep.Metrics.Reject(c.FullPath(), err)
+			c.JSON(http.StatusConflict, BaseResponse{Error: err.Error(), ErrorCode: 409})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to delete product", ErrorCode: 500})
 		return
 	}
@@ -184,7 +225,7 @@ func (h *HandlerConfig) ListProducts(c *gin.Context) {
 
 	products, err := h.Dep.Product.List(c.Request.Context())
 	if err != nil {
-		h.Dep.Sl.Error(fmt.Sprintf("%s | Failed to list products: ", op), sl.Err(err))
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list products: ", op), sl.Err(err))
 		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to list products", ErrorCode: 500})
 		return
 	}
@@ -8,9 +8,12 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
 	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
 	"github.com/Gen1usBruh/warehouse-api/internal/scope"
 	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
@@ -33,22 +36,30 @@ func (m *mockProductUseCase) Create(ctx context.Context, p product.Product) (int
 func (m *mockProductUseCase) GetByID(ctx context.Context, id int32) (product.Product, error) {
 	p, ok := m.products[id]
 	if !ok {
-		return product.Product{}, errors.New("not found")
+		return product.Product{}, product.ErrNotFound
 	}
 	return p, nil
 }
 
-func (m *mockProductUseCase) Update(ctx context.Context, p product.Product) error {
-	if _, ok := m.products[p.ID]; !ok {
+func (m *mockProductUseCase) Update(ctx context.Context, id int32, fn func(p *product.Product) error) error {
+	p, ok := m.products[id]
+	if !ok {
 		return errors.New("not found")
 	}
-	m.products[p.ID] = p
+	if err := fn(&p); err != nil {
+		return err
+	}
+	m.products[id] = p
 	return nil
 }
 
 func (m *mockProductUseCase) Delete(ctx context.Context, id int32) error {
-	if _, ok := m.products[id]; !ok {
-		return errors.New("not found")
+	p, ok := m.products[id]
+	if !ok {
+		return product.ErrNotFound
+	}
+	if p.Quantity != 0 || p.QuarantinedQuantity != 0 {
+		return product.ErrInUse
 	}
 	delete(m.products, id)
 	return nil
@@ -62,6 +73,26 @@ func (m *mockProductUseCase) List(ctx context.Context) ([]product.Product, error
 	return list, nil
 }
 
+func (m *mockProductUseCase) Search(ctx context.Context, f product.Filter) ([]product.Product, int32, error) {
+	var matches []product.Product
+	for _, p := range m.products {
+		search := strings.ToLower(f.Search)
+		switch {
+		case search != "" && !strings.Contains(strings.ToLower(p.Name), search) &&
+			!strings.Contains(strings.ToLower(p.Description), search):
+		case f.Category != "" && p.Category != f.Category:
+		case f.Currency != "" && p.Price.Currency != f.Currency:
+		case f.InStock != nil && (p.Quantity > 0) != *f.InStock:
+		default:
+			matches = append(matches, p)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	total := int32(len(matches))
+	matches = matches[min(f.Offset, total):min(f.Offset+f.Limit, total)]
+	return matches, total, nil
+}
+
 type stubLogger struct{}
 
 func (s *stubLogger) Error(msg string, fields ...any) {}
@@ -108,7 +139,7 @@ func TestCreateProduct(t *testing.T) {
 	body := []byte(`{
 		"name": "Test Product",
 		"description": "Valid description",
-		"price": 5000,
+		"price": {"amount": "5000.00", "currency": "USD"},
 		"quantity": 10
 	}`)
 
@@ -118,10 +149,29 @@ func TestCreateProduct(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), `"id":`)
 }
 
+func TestCreateProduct_OpeningUnitCost(t *testing.T) {
+	router, mock := setupHandlerWithMock()
+
+	body := []byte(`{
+		"name": "Test Product",
+		"description": "Valid description",
+		"price": {"amount": "5000.00", "currency": "USD"},
+		"quantity": 10,
+		"unit_cost": {"amount": "3100.00", "currency": "USD"}
+	}`)
+
+	resp := performRequest(router, "POST", "/products", body)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	if p := mock.products[mock.nextID]; assert.NotNil(t, p.OpeningUnitCost) {
+		assert.Equal(t, money.MustParse("3100.00", "USD"), *p.OpeningUnitCost)
+	}
+}
+
 func TestCreateProduct_InvalidJSON(t *testing.T) {
 	router, _ := setupHandlerWithMock()
 
-	body := []byte(`{"description":"Missing name","price":10,"quantity":1}`)
+	body := []byte(`{"description":"Missing name","price":{"amount":"10","currency":"USD"},"quantity":1}`)
 
 	resp := performRequest(router, "POST", "/products", body)
 
@@ -137,9 +187,12 @@ func TestCreateProduct_BusinessValidation(t *testing.T) {
 		body     string
 		expected string
 	}{
-		{"Price too high", `{"name":"Valid","description":"Desc","price":20000,"quantity":1}`, "price exceeds"},
-		{"Reserved name", `{"name":"Sarkor","description":"Desc","price":10,"quantity":1}`, "name is reserved"},
-		{"Quantity too high", `{"name":"Valid","description":"Desc","price":10,"quantity":1001}`, "quantity exceeds"},
+		{"Price too high", `{"name":"Valid","description":"Desc","price":{"amount":"20000","currency":"USD"},"quantity":1}`, "price exceeds"},
+		{"Price too high in UZS", `{"name":"Valid","description":"Desc","price":{"amount":"130000000","currency":"UZS"},"quantity":1}`, "price exceeds"},
+		{"Missing price", `{"name":"Valid","description":"Desc","quantity":1}`, "price must be greater than zero"},
+		{"Unknown currency", `{"name":"Valid","description":"Desc","price":{"amount":"10","currency":"XYZ"},"quantity":1}`, "unknown currency"},
+		{"Reserved name", `{"name":"Sarkor","description":"Desc","price":{"amount":"10","currency":"USD"},"quantity":1}`, "name is reserved"},
+		{"Quantity too high", `{"name":"Valid","description":"Desc","price":{"amount":"10","currency":"USD"},"quantity":1001}`, "quantity exceeds"},
 	}
 
 	for _, tt := range tests {
@@ -151,51 +204,77 @@ func TestCreateProduct_BusinessValidation(t *testing.T) {
 	}
 }
 
+func TestCreateProduct_EveryCurrency(t *testing.T) {
+	router, _ := setupHandlerWithMock()
+
+	for _, currency := range money.Currencies() {
+		t.Run(currency, func(t *testing.T) {
+			body := `{"name":"Valid","description":"Desc","price":{"amount":"1","currency":"` + currency + `"},"quantity":1}`
+			resp := performRequest(router, "POST", "/products", []byte(body))
+			assert.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+		})
+	}
+}
+
 func TestGetProduct(t *testing.T) {
 	router, mock := setupHandlerWithMock()
 
 	id, _ := mock.Create(context.TODO(), product.Product{
-		Name: "Iphone", Description: "Smartphone", Price: 12, Quantity: 1,
+		Name: "Iphone", Description: "Smartphone", Price: money.MustParse("12", "USD"), Quantity: 1,
 	})
 
 	resp := performRequest(router, "GET", "/products/"+itoa(id), nil)
 	assert.Equal(t, http.StatusOK, resp.Code)
 	assert.Contains(t, resp.Body.String(), `"name":"Iphone"`)
+	assert.Contains(t, resp.Body.String(), `"price":{"amount":"12.00","currency":"USD"}`)
 }
 
 func TestUpdateProduct(t *testing.T) {
 	router, mock := setupHandlerWithMock()
 
 	id, _ := mock.Create(context.TODO(), product.Product{
-		Name: "Old", Description: "desc", Price: 10, Quantity: 1,
+		Name: "Old", Description: "desc", Price: money.MustParse("10", "USD"), Quantity: 1,
 	})
 
 	body := []byte(`{
 		"name": "New Name",
 		"description": "Updated desc",
-		"price": 22,
+		"price": {"amount": "22", "currency": "USD"},
 		"quantity": 3
 	}`)
 	resp := performRequest(router, "PUT", "/products/"+itoa(id), body)
 	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "New Name", mock.products[id].Name)
+	assert.Equal(t, int32(1), mock.products[id].Quantity, "quantity only changes through stock movements")
 }
 
 func TestDeleteProduct(t *testing.T) {
 	router, mock := setupHandlerWithMock()
 
 	id, _ := mock.Create(context.TODO(), product.Product{
-		Name: "Olcha", Description: "qizil", Price: 10, Quantity: 1,
+		Name: "Olcha", Description: "qizil", Price: money.MustParse("10", "USD"), Quantity: 1,
 	})
 
 	resp := performRequest(router, "DELETE", "/products/"+itoa(id), nil)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), "product has stock or is in use")
+
+	p := mock.products[id]
+	p.Quantity = 0
+	mock.products[id] = p
+	resp = performRequest(router, "DELETE", "/products/"+itoa(id), nil)
 	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "DELETE", "/products/"+itoa(id), nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Product not found")
 }
 
 func TestListProducts(t *testing.T) {
 	router, mock := setupHandlerWithMock()
 
-	mock.Create(context.TODO(), product.Product{Name: "klubnika", Description: "meva", Price: 10, Quantity: 1})
-	mock.Create(context.TODO(), product.Product{Name: "pomidor", Description: "sabzavot", Price: 20, Quantity: 2})
+	mock.Create(context.TODO(), product.Product{Name: "klubnika", Description: "meva", Price: money.MustParse("10", "USD"), Quantity: 1})
+	mock.Create(context.TODO(), product.Product{Name: "pomidor", Description: "sabzavot", Price: money.MustParse("20", "USD"), Quantity: 2})
 
 	resp := performRequest(router, "GET", "/products", nil)
 	assert.Equal(t, http.StatusOK, resp.Code)
@@ -206,3 +285,51 @@ func TestListProducts(t *testing.T) {
 func itoa(i int32) string {
 	return strconv.Itoa(int(i))
 }
+
+func TestUpdateProduct_Tracking(t *testing.T) {
+	router, mock := setupHandlerWithMock()
+
+	id, _ := mock.Create(context.TODO(), product.Product{
+		Name: "Juice", Description: "desc", Price: money.MustParse("10", "USD"), Quantity: 5,
+	})
+
+	body := []byte(`{"name":"Juice","description":"desc","price":{"amount":"10","currency":"USD"},"quantity":5,"lot_tracked":true}`)
+	resp := performRequest(router, "PUT", "/products/"+itoa(id), body)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	mock.products[id] = product.Product{ID: id, Name: "Juice", Price: money.MustParse("10", "USD"), Quantity: 5, LotTracked: true}
+	body = []byte(`{"name":"Juice","description":"desc","price":{"amount":"10","currency":"USD"},"quantity":7,"lot_tracked":true}`)
+	resp = performRequest(router, "PUT", "/products/"+itoa(id), body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(5), mock.products[id].Quantity)
+
+	body = []byte(`{"name":"Laptop","description":"desc","price":{"amount":"900","currency":"USD"},"quantity":3,"serialized":true}`)
+	resp = performRequest(router, "POST", "/products", body)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "through stock movements")
+
+	body = []byte(`{"name":"Laptop","description":"desc","price":{"amount":"900","currency":"USD"},"serialized":true}`)
+	resp = performRequest(router, "POST", "/products", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestUpdateProduct_Currency(t *testing.T) {
+	router, mock := setupHandlerWithMock()
+
+	id, _ := mock.Create(context.TODO(), product.Product{
+		Name: "Juice", Description: "desc", Price: money.MustParse("10", "USD"), Quantity: 5,
+	})
+
+	// The stock of the product is valued in USD.
+	body := []byte(`{"name":"Juice","description":"desc","price":{"amount":"9","currency":"EUR"}}`)
+	resp := performRequest(router, "PUT", "/products/"+itoa(id), body)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "USD", mock.products[id].Price.Currency)
+
+	p := mock.products[id]
+	p.Quantity = 0
+	mock.products[id] = p
+	resp = performRequest(router, "PUT", "/products/"+itoa(id), body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, money.MustParse("9", "EUR"), mock.products[id].Price)
+}
@@ -0,0 +1,366 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderRequest struct {
+	SupplierID int32                      `json:"supplier_id" binding:"required"`
+	Currency   string                     `json:"currency" binding:"omitempty,len=3"`
+	Reference  string                     `json:"reference" binding:"max=255"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// PurchaseOrderLineRequest orders a quantity in the product base unit. Without a
+// unit cost the cost price of the supplier is used.
+type PurchaseOrderLineRequest struct {
+	ProductID int32       `json:"product_id" binding:"required"`
+	Quantity  int32       `json:"quantity" binding:"required,gt=0"`
+	UnitCost  money.Money `json:"unit_cost"`
+}
+
+func (r PurchaseOrderRequest) toOrder(id int32) purchase.Order {
+	o := purchase.Order{
+		ID:         id,
+		SupplierID: r.SupplierID,
+		Currency:   r.Currency,
+		Reference:  r.Reference,
+	}
+	for _, line := range r.Lines {
+		o.Lines = append(o.Lines, purchase.Line{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  line.UnitCost,
+		})
+	}
+	return o
+}
+
+type SubmitPurchaseOrderRequest struct {
+	ExpectedAt *time.Time `json:"expected_at"`
+}
+
+type PurchaseReceiptRequest struct {
+	Reference string                       `json:"reference" binding:"max=255" example:"DN-2291"`
+	Lines     []PurchaseReceiptLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type PurchaseReceiptLineRequest struct {
+	LineID   int32       `json:"line_id" binding:"required"`
+	Quantity int32       `json:"quantity" binding:"required,gt=0"`
+	Lot      *LotRequest `json:"lot"`
+	Serials  []string    `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Create a purchase order
+// @Description Create a draft purchase order with lines; lines without a unit cost use the supplier cost price
+// @Tags purchasing
+// @Accept json
+// @Produce json
+// @Param order body PurchaseOrderRequest true "Supplier and lines"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created order"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Supplier or product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders [post]
+func (h *HandlerConfig) CreatePurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.create"
+
+	var req PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	id, err := h.Dep.Purchase.Create(c.Request.Context(), req.toOrder(0))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListPurchaseOrders godoc
+// @Summary List purchase orders
+// @Description Get purchase orders without lines, newest first
+// @Tags purchasing
+// @Produce json
+// @Param status query string false "Status" Enums(draft, submitted, partially_received, received, closed)
+// @Param supplier_id query int false "Supplier ID"
+// @Success 200 {object} map[string]interface{} "List of purchase orders"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders [get]
+func (h *HandlerConfig) ListPurchaseOrders(c *gin.Context) {
+	const op = "rest.purchase.list"
+
+	supplierID, err := queryID(c, "supplier_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid supplier ID", ErrorCode: 400})
+		return
+	}
+
+	orders, err := h.Dep.Purchase.List(c.Request.Context(), purchase.Status(c.Query("status")), supplierID)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list purchase orders: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": orders})
+}
+
+// GetPurchaseOrder godoc
+// @Summary Get purchase order by ID
+// @Description Retrieve a purchase order with its lines and received quantities
+// @Tags purchasing
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} map[string]interface{} "Purchase order data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Purchase order not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders/{id} [get]
+func (h *HandlerConfig) GetPurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	order, err := h.Dep.Purchase.Get(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// UpdatePurchaseOrder godoc
+// @Summary Update a draft purchase order
+// @Description Replace the supplier and lines of an order that was not submitted yet
+// @Tags purchasing
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param order body PurchaseOrderRequest true "Supplier and lines"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Order, supplier or product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders/{id} [put]
+func (h *HandlerConfig) UpdatePurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.update"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Purchase.UpdateDraft(c.Request.Context(), req.toOrder(id)); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to update purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// SubmitPurchaseOrder godoc
+// @Summary Submit a purchase order
+// @Description Send a draft order to the supplier; without expected_at it is expected after the supplier lead time
+// @Tags purchasing
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param submit body SubmitPurchaseOrderRequest false "Expected delivery time"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or order is not a draft"
+// @Failure 404 {object} BaseResponse "Purchase order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders/{id}/submit [post]
+func (h *HandlerConfig) SubmitPurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.submit"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req SubmitPurchaseOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+			return
+		}
+	}
+
+	if err := h.Dep.Purchase.Submit(c.Request.Context(), id, req.ExpectedAt); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to submit purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// ReceivePurchaseOrder godoc
+// @Summary Receive goods against a purchase order
+// @Description Record delivered quantities per line and add them to stock in one transaction.
+// @Description Quantities above the over-receipt tolerance are rejected; lines within the
+// @Description under-receipt tolerance count as fully received
+// @Tags purchasing
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param receipt body PurchaseReceiptRequest true "Delivered quantities"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Updated purchase order"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Purchase order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders/{id}/receipts [post]
+func (h *HandlerConfig) ReceivePurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.receive"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req PurchaseReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	receipt := purchase.Receipt{Reference: req.Reference}
+	for _, line := range req.Lines {
+		receipt.Lines = append(receipt.Lines, purchase.ReceiptLine{
+			LineID:   line.LineID,
+			Quantity: line.Quantity,
+			Lot:      line.Lot.toLot(),
+			Serials:  line.Serials,
+		})
+	}
+
+	order, err := h.Dep.Purchase.Receive(c.Request.Context(), id, receipt)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to receive purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// ClosePurchaseOrder godoc
+// @Summary Close a purchase order
+// @Description Close a received order, or short-close a partially received one
+// @Tags purchasing
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Order cannot be closed in its status"
+// @Failure 404 {object} BaseResponse "Purchase order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /purchase-orders/{id}/close [post]
+func (h *HandlerConfig) ClosePurchaseOrder(c *gin.Context) {
+	const op = "rest.purchase.close"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Purchase.Close(c.Request.Context(), id); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to close purchase order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// PurchaseOrderReport godoc
+// @Summary Purchase order status report
+// @Description Count orders, quantities and values per status and currency, with overdue open orders
+// @Tags purchasing
+// @Produce json
+// @Param supplier_id query int false "Supplier ID"
+// @Param from query string false "Orders created from (date or RFC 3339)"
+// @Param to query string false "Orders created before (date or RFC 3339)"
+// @Success 200 {object} map[string]interface{} "Summary per status"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/purchase-orders [get]
+func (h *HandlerConfig) PurchaseOrderReport(c *gin.Context) {
+	const op = "rest.purchase.report"
+
+	var f purchase.ReportFilter
+	var err error
+	if f.SupplierID, err = queryID(c, "supplier_id"); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid supplier ID", ErrorCode: 400})
+		return
+	}
+	if f.From, err = queryTime(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'from' time", ErrorCode: 400})
+		return
+	}
+	if f.To, err = queryTime(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'to' time", ErrorCode: 400})
+		return
+	}
+
+	report, err := h.Dep.Purchase.StatusReport(c.Request.Context(), f)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build purchase order report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,265 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPurchaseRepo struct {
+	orders   map[int32]purchase.Order
+	stock    *mockStockRepo
+	nextID   int32
+	nextLine int32
+}
+
+func (m *mockPurchaseRepo) setLines(o *purchase.Order) {
+	for i := range o.Lines {
+		m.nextLine++
+		o.Lines[i].ID = m.nextLine
+	}
+}
+
+func (m *mockPurchaseRepo) Create(ctx context.Context, o purchase.Order) (int32, error) {
+	m.nextID++
+	o.ID = m.nextID
+	o.Status = purchase.StatusDraft
+	o.CreatedAt = time.Now()
+	m.setLines(&o)
+	m.orders[o.ID] = o
+	return o.ID, nil
+}
+
+func (m *mockPurchaseRepo) Get(ctx context.Context, id int32) (purchase.Order, error) {
+	o, ok := m.orders[id]
+	if !ok {
+		return purchase.Order{}, purchase.ErrNotFound
+	}
+	o.Lines = slices.Clone(o.Lines)
+	return o, nil
+}
+
+func (m *mockPurchaseRepo) List(ctx context.Context, status purchase.Status, supplierID *int32) ([]purchase.Order, error) {
+	var list []purchase.Order
+	for _, o := range m.orders {
+		if (status == "" || o.Status == status) && (supplierID == nil || o.SupplierID == *supplierID) {
+			o.Lines = nil
+			list = append(list, o)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockPurchaseRepo) UpdateDraft(ctx context.Context, o purchase.Order) error {
+	current := m.orders[o.ID]
+	o.Status = current.Status
+	o.CreatedAt = current.CreatedAt
+	m.setLines(&o)
+	m.orders[o.ID] = o
+	return nil
+}
+
+func (m *mockPurchaseRepo) Submit(ctx context.Context, id int32, expectedAt time.Time) error {
+	o := m.orders[id]
+	o.Status = purchase.StatusSubmitted
+	o.ExpectedAt = &expectedAt
+	m.orders[id] = o
+	return nil
+}
+
+func (m *mockPurchaseRepo) Close(ctx context.Context, id int32) error {
+	o := m.orders[id]
+	o.Status = purchase.StatusClosed
+	m.orders[id] = o
+	return nil
+}
+
+func (m *mockPurchaseRepo) Receive(ctx context.Context, id int32, fn func(o *purchase.Order) ([]stock.Movement, error)) (purchase.Order, error) {
+	o, err := m.Get(ctx, id)
+	if err != nil {
+		return purchase.Order{}, err
+	}
+	movements, err := fn(&o)
+	if err != nil {
+		return purchase.Order{}, err
+	}
+	for _, mv := range movements {
+		if _, err := m.stock.ApplyMovement(ctx, mv); err != nil {
+			return purchase.Order{}, err
+		}
+	}
+	m.orders[id] = o
+	return o, nil
+}
+
+func (m *mockPurchaseRepo) StatusReport(ctx context.Context, f purchase.ReportFilter) ([]purchase.StatusSummary, error) {
+	summaries := make(map[purchase.Status]*purchase.StatusSummary)
+	var result []purchase.StatusSummary
+	for _, o := range m.orders {
+		s, ok := summaries[o.Status]
+		if !ok {
+			s = &purchase.StatusSummary{Status: o.Status, OrderedValue: money.Money{Currency: o.Currency}}
+			summaries[o.Status] = s
+		}
+		s.Orders++
+		for _, l := range o.Lines {
+			s.Lines++
+			s.OrderedQuantity += int64(l.Quantity)
+			s.ReceivedQuantity += int64(l.ReceivedQuantity)
+			s.OrderedValue.Amount += int64(l.Quantity) * l.UnitCost.Amount
+		}
+	}
+	for _, s := range summaries {
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+func setupPurchaseHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockSupplierRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+	suppliers := &mockSupplierRepo{
+		suppliers: make(map[int32]supplier.Supplier),
+		links:     make(map[[2]int32]supplier.ProductSupplier),
+	}
+	orders := &mockPurchaseRepo{orders: make(map[int32]purchase.Order), stock: stockRepo}
+	prices := &mockPricingRepo{lists: make(map[int32]pricing.PriceList), items: make(map[[2]int32]pricing.Item), products: products}
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+	pricingUC := usecase.NewPricingUseCase(prices, products, money.RoundHalfUp)
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock:    stockUC,
+			Supplier: usecase.NewSupplierUseCase(suppliers, products, pricingUC),
+			Purchase: usecase.NewPurchaseUseCase(orders, suppliers, products, stockUC, pricingUC, purchase.Tolerance{OverPct: 10, UnderPct: 5}),
+			Sl:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/purchase-orders", h.CreatePurchaseOrder)
+	router.GET("/purchase-orders", h.ListPurchaseOrders)
+	router.GET("/purchase-orders/:id", h.GetPurchaseOrder)
+	router.PUT("/purchase-orders/:id", h.UpdatePurchaseOrder)
+	router.POST("/purchase-orders/:id/submit", h.SubmitPurchaseOrder)
+	router.POST("/purchase-orders/:id/receipts", h.ReceivePurchaseOrder)
+	router.POST("/purchase-orders/:id/close", h.ClosePurchaseOrder)
+	router.GET("/reports/purchase-orders", h.PurchaseOrderReport)
+	return router, products, suppliers
+}
+
+// seedPurchasing creates a supplier selling two products; the first has a cost
+// price of 4.00 USD and a minimum order quantity of 10.
+func seedPurchasing(products *mockProductUseCase, suppliers *mockSupplierRepo) (supplierID, screws, nails int32) {
+	supplierID, _ = suppliers.Create(context.TODO(), supplier.Supplier{Name: "Acme", LeadTimeDays: 7})
+	screws, _ = products.Create(context.TODO(), product.Product{Name: "Screws", Price: money.MustParse("10", "USD")})
+	nails, _ = products.Create(context.TODO(), product.Product{Name: "Nails", Price: money.MustParse("8", "USD")})
+	suppliers.SetProductSupplier(context.TODO(), supplier.ProductSupplier{
+		ProductID: screws, SupplierID: supplierID, CostPrice: money.MustParse("4", "USD"), MinOrderQty: 10,
+	})
+	return supplierID, screws, nails
+}
+
+func TestPurchaseOrderLifecycle(t *testing.T) {
+	router, products, suppliers := setupPurchaseHandlerWithMock()
+	supplierID, screws, nails := seedPurchasing(products, suppliers)
+
+	body := `{"supplier_id":` + itoa(supplierID) + `,"lines":[` +
+		`{"product_id":` + itoa(screws) + `,"quantity":100},` +
+		`{"product_id":` + itoa(nails) + `,"quantity":50,"unit_cost":{"amount":"2.50","currency":"USD"}}]}`
+	resp := performRequest(router, "POST", "/purchase-orders", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "GET", "/purchase-orders/1", nil)
+	assert.Contains(t, resp.Body.String(), `"status":"draft"`)
+	assert.Contains(t, resp.Body.String(), `"unit_cost":{"amount":"4.00","currency":"USD"}`)
+
+	resp = performRequest(router, "POST", "/purchase-orders/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":10}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "order is draft")
+
+	resp = performRequest(router, "POST", "/purchase-orders/1/submit", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "PUT", "/purchase-orders/1", []byte(body))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(router, "POST", "/purchase-orders/1/receipts", []byte(`{"reference":"DN-1","lines":[{"line_id":1,"quantity":60}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"partially_received"`)
+	assert.Equal(t, int32(60), products.products[screws].Quantity)
+
+	// 100 ordered with 10% over-receipt tolerance allows 110 in total.
+	resp = performRequest(router, "POST", "/purchase-orders/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":51}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "allows 50 more")
+	assert.Equal(t, int32(60), products.products[screws].Quantity)
+
+	// 48 of 50 nails is within the 5% under-receipt tolerance.
+	resp = performRequest(router, "POST", "/purchase-orders/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":45},{"line_id":2,"quantity":48}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"received"`)
+	assert.Equal(t, int32(105), products.products[screws].Quantity)
+	assert.Equal(t, int32(48), products.products[nails].Quantity)
+
+	resp = performRequest(router, "POST", "/purchase-orders/1/close", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/purchase-orders/1/receipts", []byte(`{"lines":[{"line_id":2,"quantity":2}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "order is closed")
+
+	resp = performRequest(router, "GET", "/purchase-orders?status=closed", nil)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+	resp = performRequest(router, "GET", "/reports/purchase-orders", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"ordered_value":{"amount":"525.00","currency":"USD"}`)
+}
+
+func TestCreatePurchaseOrder_Rejected(t *testing.T) {
+	router, products, suppliers := setupPurchaseHandlerWithMock()
+	supplierID, screws, nails := seedPurchasing(products, suppliers)
+	sid := itoa(supplierID)
+
+	tests := []struct {
+		name     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"No lines", `{"supplier_id":` + sid + `,"lines":[]}`, http.StatusBadRequest, "Lines"},
+		{"Below MOQ", `{"supplier_id":` + sid + `,"lines":[{"product_id":` + itoa(screws) + `,"quantity":5}]}`, http.StatusBadRequest, "minimum order quantity of 10"},
+		{"No cost", `{"supplier_id":` + sid + `,"lines":[{"product_id":` + itoa(nails) + `,"quantity":5}]}`, http.StatusBadRequest, "unit cost is required"},
+		{"Mixed currencies", `{"supplier_id":` + sid + `,"lines":[{"product_id":` + itoa(screws) + `,"quantity":10},` +
+			`{"product_id":` + itoa(nails) + `,"quantity":5,"unit_cost":{"amount":"1","currency":"EUR"}}]}`, http.StatusBadRequest, "order currency USD"},
+		{"Duplicate product", `{"supplier_id":` + sid + `,"lines":[{"product_id":` + itoa(screws) + `,"quantity":10},{"product_id":` + itoa(screws) + `,"quantity":10}]}`, http.StatusBadRequest, "more than once"},
+		{"Unknown supplier", `{"supplier_id":99,"lines":[{"product_id":` + itoa(screws) + `,"quantity":10}]}`, http.StatusNotFound, "supplier not found"},
+		{"Unknown product", `{"supplier_id":` + sid + `,"lines":[{"product_id":99,"quantity":10}]}`, http.StatusNotFound, "product not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/purchase-orders", []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+
+	resp := performRequest(router, "GET", "/purchase-orders?status=lost", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = performRequest(router, "GET", "/reports/purchase-orders?from=2026-02-01&to=2026-01-01", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
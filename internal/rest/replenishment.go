@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+// ReplenishmentSuggestions godoc
+// @Summary Suggested replenishment
+// @Description Forecast the demand of every product from its past stock issues and list those whose stock on hand,
+// @Description less allocated and plus on order, has fallen to the reorder point: the demand over the supplier lead time
+// @Description plus safety stock. The suggested quantity brings the stock up to cover the lead time and review period,
+// @Description raised to the minimum order quantity. Products with a supplier are grouped into draft purchase orders
+// @Description with their cheapest supplier, ready to be created
+// @Tags replenishment
+// @Produce json
+// @Param method query string false "Forecast method; the configured one if omitted" Enums(moving_average, exponential_smoothing, seasonal_naive)
+// @Param supplier_id query int false "Only products bought from this supplier"
+// @Success 200 {object} map[string]interface{} "Suggestions and draft purchase orders"
+// @Failure 400 {object} BaseResponse "Invalid method or supplier ID"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /replenishment/suggestions [get]
+func (h *HandlerConfig) ReplenishmentSuggestions(c *gin.Context) {
+	const op = "rest.replenishment.suggestions"
+
+	supplierID, err := queryID(c, "supplier_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'supplier_id'", ErrorCode: 400})
+		return
+	}
+
+	plan, err := h.Dep.Replenishment.Suggestions(c.Request.Context(), forecast.Method(c.Query("method")), supplierID)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to suggest replenishment: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": plan})
+}
+
+// GetProductForecast godoc
+// @Summary Forecast product demand
+// @Description Get the demand of a product per period, oldest first, the forecast for the periods an order has to cover,
+// @Description its safety stock, reorder point and suggested order quantity
+// @Tags replenishment
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param method query string false "Forecast method; the configured one if omitted" Enums(moving_average, exponential_smoothing, seasonal_naive)
+// @Success 200 {object} map[string]interface{} "Product forecast"
+// @Failure 400 {object} BaseResponse "Invalid ID or method"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/forecast [get]
+func (h *HandlerConfig) GetProductForecast(c *gin.Context) {
+	const op = "rest.replenishment.productForecast"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	f, err := h.Dep.Replenishment.ProductForecast(c.Request.Context(), id, forecast.Method(c.Query("method")))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to forecast product: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": f})
+}
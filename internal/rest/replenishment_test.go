@@ -0,0 +1,168 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockForecastRepo keeps daily demand and stock positions as they are given.
+type mockForecastRepo struct {
+	demand    []forecast.DailyDemand
+	positions []forecast.Position
+}
+
+func (m *mockForecastRepo) Demand(ctx context.Context, productID *int32, from, to time.Time) ([]forecast.DailyDemand, error) {
+	var rows []forecast.DailyDemand
+	for _, d := range m.demand {
+		if (productID == nil || d.ProductID == *productID) && !d.Day.Before(from) && d.Day.Before(to) {
+			rows = append(rows, d)
+		}
+	}
+	return rows, nil
+}
+
+func (m *mockForecastRepo) Positions(ctx context.Context, productID *int32) ([]forecast.Position, error) {
+	var rows []forecast.Position
+	for _, p := range m.positions {
+		if productID == nil || p.ProductID == *productID {
+			rows = append(rows, p)
+		}
+	}
+	return rows, nil
+}
+
+// testForecasting counts demand per week over four weeks without safety stock,
+// so suggestions follow the forecast exactly.
+var testForecasting = forecast.Settings{
+	Method:              forecast.MethodMovingAverage,
+	PeriodDays:          7,
+	HistoryPeriods:      4,
+	Window:              4,
+	Alpha:               0.5,
+	SeasonLength:        2,
+	ServiceLevel:        0.5,
+	ReviewDays:          7,
+	DefaultLeadTimeDays: 14,
+}
+
+func setupReplenishmentHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockSupplierRepo, *mockForecastRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	suppliers := &mockSupplierRepo{
+		suppliers: make(map[int32]supplier.Supplier),
+		links:     make(map[[2]int32]supplier.ProductSupplier),
+	}
+	prices := &mockPricingRepo{lists: make(map[int32]pricing.PriceList), items: make(map[[2]int32]pricing.Item), products: products}
+	forecasts := &mockForecastRepo{}
+	supplierUC := usecase.NewSupplierUseCase(suppliers, products, usecase.NewPricingUseCase(prices, products, money.RoundHalfUp))
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Replenishment: usecase.NewReplenishmentUseCase(forecasts, supplierUC, testForecasting),
+			Sl:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/replenishment/suggestions", h.ReplenishmentSuggestions)
+	router.GET("/products/:id/forecast", h.GetProductForecast)
+	return router, products, suppliers, forecasts
+}
+
+// weeklyDemand records the given quantity issued in each of the last weeks,
+// oldest first.
+func weeklyDemand(f *mockForecastRepo, productID int32, weeks ...int32) {
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	for i, q := range weeks {
+		day := today.AddDate(0, 0, -7*(len(weeks)-i)+3)
+		f.demand = append(f.demand, forecast.DailyDemand{ProductID: productID, Day: day, Quantity: q})
+	}
+}
+
+func TestReplenishmentSuggestions(t *testing.T) {
+	router, products, suppliers, forecasts := setupReplenishmentHandlerWithMock()
+	supplierID, screws, nails := seedPurchasing(products, suppliers)
+	lamp, _ := products.Create(context.TODO(), product.Product{Name: "Lamp", Price: money.MustParse("30", "USD")})
+
+	weeklyDemand(forecasts, screws, 10, 10, 10, 10)
+	weeklyDemand(forecasts, nails, 5, 5, 5, 5)
+	weeklyDemand(forecasts, lamp, 1, 1, 1, 1)
+	forecasts.positions = []forecast.Position{
+		{ProductID: lamp, ProductName: "Lamp", OnHand: 100},
+		{ProductID: nails, ProductName: "Nails", OnHand: 2},
+		{ProductID: screws, ProductName: "Screws", OnHand: 12, Allocated: 8, OnOrder: 1},
+	}
+
+	resp := performRequest(router, "GET", "/replenishment/suggestions", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	body := resp.Body.String()
+	// Nails have no supplier: two weeks of default lead time and one of review.
+	assert.Contains(t, body, `"product_id":`+itoa(nails)+`,"product_name":"Nails","method":"moving_average","period_days":7,"demand_per_period":5,"std_dev":0,"supplier_id":null,"min_order_quantity":0,"lead_time_days":14,"lead_time_demand":10,"safety_stock":0,"reorder_point":10,"order_up_to":15,"on_hand":2,"allocated":0,"on_order":0,"suggested_quantity":13}`)
+	// Screws come from Acme in a week: 5 available against 20 needed over lead time and review.
+	assert.Contains(t, body, `"reorder_point":10,"order_up_to":20,"on_hand":12,"allocated":8,"on_order":1,"suggested_quantity":15}`)
+	assert.NotContains(t, body, `"product_name":"Lamp"`)
+	assert.Contains(t, body, `"orders":[{"supplier_id":`+itoa(supplierID)+`,"supplier_name":"Acme","currency":"USD","lines":[{"product_id":`+itoa(screws)+`,"quantity":15,"unit_cost":{"amount":"4.00","currency":"USD"}}]}]`)
+	// The supplier links of all products are loaded at once.
+	assert.Zero(t, suppliers.productQueries)
+
+	// The minimum order quantity raises small orders.
+	suppliers.SetProductSupplier(context.TODO(), supplier.ProductSupplier{
+		ProductID: screws, SupplierID: supplierID, CostPrice: money.MustParse("4", "USD"), MinOrderQty: 25,
+	})
+	resp = performRequest(router, "GET", "/replenishment/suggestions?supplier_id="+itoa(supplierID), nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"suggested_quantity":25}`)
+	assert.NotContains(t, resp.Body.String(), `"product_name":"Nails"`)
+
+	resp = performRequest(router, "GET", "/replenishment/suggestions?method=guess", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "invalid forecast method: guess")
+
+	resp = performRequest(router, "GET", "/replenishment/suggestions?supplier_id=x", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestGetProductForecast(t *testing.T) {
+	router, products, suppliers, forecasts := setupReplenishmentHandlerWithMock()
+	_, screws, _ := seedPurchasing(products, suppliers)
+	weeklyDemand(forecasts, screws, 2, 10, 2, 10)
+	forecasts.positions = []forecast.Position{{ProductID: screws, ProductName: "Screws", OnHand: 50}}
+
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"", `"method":"moving_average","period_days":7,"history":[2,10,2,10],"forecast":[6,6],"demand_per_period":6,`},
+		{"exponential_smoothing", `"history":[2,10,2,10],"forecast":[7,7],"demand_per_period":7,`},
+		{"seasonal_naive", `"history":[2,10,2,10],"forecast":[2,10],"demand_per_period":6,`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			resp := performRequest(router, "GET", "/products/"+itoa(screws)+"/forecast?method="+tt.method, nil)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.want)
+			assert.Contains(t, resp.Body.String(), `"std_dev":4.62,`)
+		})
+	}
+
+	resp := performRequest(router, "GET", "/products/99/forecast", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = performRequest(router, "GET", "/products/abc/forecast", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
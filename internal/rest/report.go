@@ -0,0 +1,271 @@
+package rest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultReportPeriod is the period of a report when no 'from' is given.
+const defaultReportPeriod = 365 * 24 * time.Hour
+
+// TurnoverReport godoc
+// @Summary Stock turnover report
+// @Description Per product, the quantity issued in the period against the average of its opening and closing stock
+// @Description (turnover ratio) and the days that stock lasts at that rate (days of inventory)
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "Start of period (date or RFC 3339); a year before 'to' if omitted"
+// @Param to query string false "End of period (date or RFC 3339); now if omitted"
+// @Param format query string false "Output format" Enums(json, csv) default(json)
+// @Success 200 {object} map[string]interface{} "Turnover per product"
+// @Failure 400 {object} BaseResponse "Invalid period or format"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/turnover [get]
+func (h *HandlerConfig) TurnoverReport(c *gin.Context) {
+	const op = "rest.report.turnover"
+
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
+	from, to, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.Dep.Report.Turnover(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build turnover report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	respondReport(c, format, "turnover", rows, func() [][]string {
+		records := [][]string{{"product_id", "product_name", "opening_quantity", "closing_quantity", "issued_quantity", "average_quantity", "turnover", "days_of_inventory"}}
+		for _, r := range rows {
+			records = append(records, []string{
+				formatInt(r.ProductID), r.ProductName, formatInt(r.OpeningQuantity), formatInt(r.ClosingQuantity), formatInt(r.IssuedQuantity),
+				formatFloat(&r.AverageQuantity), formatFloat(r.Turnover), formatFloat(r.DaysOfInventory),
+			})
+		}
+		return records
+	})
+}
+
+// AgingReport godoc
+// @Summary Stock aging report
+// @Description Split the stock on hand of every product by how long ago it was received, assuming the oldest stock
+// @Description leaves first. Stock without a recorded receipt counts as the oldest
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param bounds query string false "Ascending age band bounds in days" default(30,60,90,180)
+// @Param format query string false "Output format" Enums(json, csv) default(json)
+// @Success 200 {object} map[string]interface{} "Age bands per product"
+// @Failure 400 {object} BaseResponse "Invalid bounds or format"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/aging [get]
+func (h *HandlerConfig) AgingReport(c *gin.Context) {
+	const op = "rest.report.aging"
+
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
+	var bounds []int32
+	if s := c.Query("bounds"); s != "" {
+		for _, part := range strings.Split(s, ",") {
+			b, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'bounds'", ErrorCode: 400})
+				return
+			}
+			bounds = append(bounds, int32(b))
+		}
+	}
+
+	rows, err := h.Dep.Report.Aging(c.Request.Context(), bounds)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build aging report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	respondReport(c, format, "aging", rows, func() [][]string {
+		records := [][]string{{"product_id", "product_name", "quantity", "age_band", "band_quantity"}}
+		for _, r := range rows {
+			for _, b := range r.Buckets {
+				records = append(records, []string{formatInt(r.ProductID), r.ProductName, formatInt(r.Quantity), b.Label, formatInt(b.Quantity)})
+			}
+		}
+		return records
+	})
+}
+
+// DeadStockReport godoc
+// @Summary Dead stock report
+// @Description List products with stock on hand and no stock movement in the given number of days, least recently moved first
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param days query int false "Days without movement" default(90)
+// @Param format query string false "Output format" Enums(json, csv) default(json)
+// @Success 200 {object} map[string]interface{} "Products without movement"
+// @Failure 400 {object} BaseResponse "Invalid days or format"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/dead-stock [get]
+func (h *HandlerConfig) DeadStockReport(c *gin.Context) {
+	const op = "rest.report.deadStock"
+
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", "90"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'days'", ErrorCode: 400})
+		return
+	}
+
+	rows, err := h.Dep.Report.DeadStock(c.Request.Context(), days)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build dead stock report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	respondReport(c, format, "dead-stock", rows, func() [][]string {
+		records := [][]string{{"product_id", "product_name", "quantity", "last_movement_at"}}
+		for _, r := range rows {
+			last := ""
+			if r.LastMovementAt != nil {
+				last = r.LastMovementAt.Format(time.RFC3339)
+			}
+			records = append(records, []string{formatInt(r.ProductID), r.ProductName, formatInt(r.Quantity), last})
+		}
+		return records
+	})
+}
+
+// ABCReport godoc
+// @Summary ABC analysis
+// @Description Classify the products issued in the period by consumption value: class A covers the first 80 percent
+// @Description of the total, B the next 15 percent and C the rest. Issues are valued at their cost, or at the product
+// @Description price where no cost was recorded, in the reporting currency
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "Start of period (date or RFC 3339); a year before 'to' if omitted"
+// @Param to query string false "End of period (date or RFC 3339); now if omitted"
+// @Param format query string false "Output format" Enums(json, csv) default(json)
+// @Success 200 {object} map[string]interface{} "Products by consumption value"
+// @Failure 400 {object} BaseResponse "Invalid period or format, or no exchange rate to the reporting currency"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/abc [get]
+func (h *HandlerConfig) ABCReport(c *gin.Context) {
+	const op = "rest.report.abc"
+
+	format, ok := reportFormat(c)
+	if !ok {
+		return
+	}
+	from, to, ok := reportPeriod(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.Dep.Report.ABC(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build ABC report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	respondReport(c, format, "abc", rows, func() [][]string {
+		records := [][]string{{"product_id", "product_name", "issued_quantity", "value", "currency", "share_pct", "cumulative_pct", "class"}}
+		for _, r := range rows {
+			records = append(records, []string{
+				formatInt(r.ProductID), r.ProductName, formatInt(r.IssuedQuantity), r.Value.Decimal(), r.Value.Currency,
+				formatFloat(&r.SharePct), formatFloat(&r.CumulativePct), string(r.Class),
+			})
+		}
+		return records
+	})
+}
+
+// reportFormat reads the format query parameter and answers 400 if it is unknown.
+func reportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'format', must be json or csv", ErrorCode: 400})
+		return "", false
+	}
+	return format, true
+}
+
+// reportPeriod reads the from and to query parameters and answers 400 if they
+// are invalid. The period ends now and starts a year before its end by default.
+func reportPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	from, err := queryTime(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'from' time", ErrorCode: 400})
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := queryTime(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'to' time", ErrorCode: 400})
+		return time.Time{}, time.Time{}, false
+	}
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+	if from == nil {
+		start := to.Add(-defaultReportPeriod)
+		from = &start
+	}
+	return *from, *to, true
+}
+
+// respondReport writes the report as JSON, or as a CSV attachment built by
+// records when format is csv.
+func respondReport[T any](c *gin.Context, format, name string, rows []T, records func() [][]string) {
+	if format != "csv" {
+		if rows == nil {
+			rows = []T{}
+		}
+		c.JSON(http.StatusOK, gin.H{"data": rows})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records()); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func formatInt(v int32) string {
+	return strconv.Itoa(int(v))
+}
+
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
@@ -0,0 +1,260 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/report"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReportRepo returns canned rows and records the parameters it was called with.
+type mockReportRepo struct {
+	turnover    []report.Turnover
+	aging       []report.Aging
+	deadStock   []report.DeadStock
+	consumption []report.Consumption
+	// stock, if set, values the issues of its movements at the product price
+	// instead of the canned consumption.
+	stock *mockStockRepo
+
+	from, to time.Time
+	bounds   []int32
+	since    time.Time
+}
+
+func (m *mockReportRepo) Turnover(ctx context.Context, from, to time.Time) ([]report.Turnover, error) {
+	m.from, m.to = from, to
+	return append([]report.Turnover(nil), m.turnover...), nil
+}
+
+func (m *mockReportRepo) Aging(ctx context.Context, now time.Time, bounds []int32) ([]report.Aging, error) {
+	m.bounds = bounds
+	var rows []report.Aging
+	for _, a := range m.aging {
+		a.Buckets = append([]report.AgingBucket(nil), a.Buckets...)
+		rows = append(rows, a)
+	}
+	return rows, nil
+}
+
+func (m *mockReportRepo) DeadStock(ctx context.Context, since time.Time) ([]report.DeadStock, error) {
+	m.since = since
+	return m.deadStock, nil
+}
+
+func (m *mockReportRepo) Consumption(ctx context.Context, from, to time.Time) ([]report.Consumption, error) {
+	m.from, m.to = from, to
+	if m.stock == nil {
+		return append([]report.Consumption(nil), m.consumption...), nil
+	}
+	var rows []report.Consumption
+	for _, mv := range m.stock.movements {
+		if mv.Type != stock.MovementIssue || mv.CreatedAt.Before(from) || !mv.CreatedAt.Before(to) {
+			continue
+		}
+		p := m.stock.products.products[mv.ProductID]
+		rows = append(rows, report.Consumption{
+			ProductID:      p.ID,
+			ProductName:    p.Name,
+			IssuedQuantity: -mv.Quantity,
+			Value:          money.Money{Amount: -int64(mv.Quantity) * p.Price.Amount, Currency: p.Price.Currency},
+		})
+	}
+	return rows, nil
+}
+
+func setupReportHandlerWithMock() (*gin.Engine, *mockReportRepo) {
+	reports := &mockReportRepo{}
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	prices := &mockPricingRepo{
+		lists:    make(map[int32]pricing.PriceList),
+		items:    make(map[[2]int32]pricing.Item),
+		rates:    []pricing.ExchangeRate{{Base: "EUR", Quote: "USD", Rate: "1.10", ValidFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		products: products,
+	}
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Report: usecase.NewReportUseCase(reports, usecase.NewPricingUseCase(prices, products, money.RoundHalfUp), "USD"),
+			Sl:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/reports/turnover", h.TurnoverReport)
+	router.GET("/reports/aging", h.AgingReport)
+	router.GET("/reports/dead-stock", h.DeadStockReport)
+	router.GET("/reports/abc", h.ABCReport)
+	return router, reports
+}
+
+func TestTurnoverReport(t *testing.T) {
+	router, reports := setupReportHandlerWithMock()
+	reports.turnover = []report.Turnover{
+		{ProductID: 1, ProductName: "Chair", OpeningQuantity: 10, ClosingQuantity: 30, IssuedQuantity: 60},
+		{ProductID: 2, ProductName: "Lamp"},
+	}
+
+	resp := performRequest(router, "GET", "/reports/turnover?from=2026-01-01&to=2026-04-01", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), reports.from)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), reports.to)
+	assert.Contains(t, resp.Body.String(), `"issued_quantity":60,"average_quantity":20,"turnover":3,"days_of_inventory":30`)
+	assert.Contains(t, resp.Body.String(), `"average_quantity":0,"turnover":null,"days_of_inventory":null`)
+
+	resp = performRequest(router, "GET", "/reports/turnover?from=2026-01-01&to=2026-04-01&format=csv", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="turnover.csv"`, resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, "product_id,product_name,opening_quantity,closing_quantity,issued_quantity,average_quantity,turnover,days_of_inventory\n"+
+		"1,Chair,10,30,60,20,3,30\n"+
+		"2,Lamp,0,0,0,0,,\n", resp.Body.String())
+
+	// Without a period the report covers the last year.
+	performRequest(router, "GET", "/reports/turnover", nil)
+	assert.WithinDuration(t, time.Now(), reports.to, time.Minute)
+	assert.Equal(t, defaultReportPeriod, reports.to.Sub(reports.from))
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"from=2026-04-01&to=2026-01-01", "end of period must be after its start"},
+		{"from=April", "Invalid 'from' time"},
+		{"format=xlsx", "Invalid 'format'"},
+	}
+	for _, tt := range tests {
+		resp := performRequest(router, "GET", "/reports/turnover?"+tt.query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), tt.want)
+	}
+}
+
+func TestAgingReport(t *testing.T) {
+	router, reports := setupReportHandlerWithMock()
+	reports.aging = []report.Aging{{
+		ProductID: 1, ProductName: "Chair", Quantity: 50,
+		Buckets: []report.AgingBucket{{Quantity: 20}, {Quantity: 10}, {}, {Quantity: 5}, {}},
+	}}
+
+	resp := performRequest(router, "GET", "/reports/aging", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, usecase.DefaultAgingBounds, reports.bounds)
+	assert.Contains(t, resp.Body.String(), `{"label":"0-29","min_days":0,"max_days":29,"quantity":20}`)
+	assert.Contains(t, resp.Body.String(), `{"label":"90-179","min_days":90,"max_days":179,"quantity":5}`)
+	// Stock without a recorded receipt counts as the oldest.
+	assert.Contains(t, resp.Body.String(), `{"label":"180+","min_days":180,"quantity":15}`)
+
+	reports.aging[0].Buckets = []report.AgingBucket{{Quantity: 40}, {Quantity: 10}, {}}
+	resp = performRequest(router, "GET", "/reports/aging?bounds=7,30&format=csv", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []int32{7, 30}, reports.bounds)
+	assert.Equal(t, "product_id,product_name,quantity,age_band,band_quantity\n"+
+		"1,Chair,50,0-6,40\n"+
+		"1,Chair,50,7-29,10\n"+
+		"1,Chair,50,30+,0\n", resp.Body.String())
+
+	for _, query := range []string{"bounds=30,7", "bounds=0,30", "bounds=week"} {
+		resp := performRequest(router, "GET", "/reports/aging?"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.Code, query)
+	}
+}
+
+func TestDeadStockReport(t *testing.T) {
+	router, reports := setupReportHandlerWithMock()
+	lastMoved := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+	reports.deadStock = []report.DeadStock{
+		{ProductID: 3, ProductName: "Vase", Quantity: 4},
+		{ProductID: 1, ProductName: "Chair", Quantity: 12, LastMovementAt: &lastMoved},
+	}
+
+	resp := performRequest(router, "GET", "/reports/dead-stock", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -90), reports.since, time.Minute)
+	assert.Contains(t, resp.Body.String(), `{"product_id":3,"product_name":"Vase","quantity":4,"last_movement_at":null}`)
+
+	resp = performRequest(router, "GET", "/reports/dead-stock?days=30&format=csv", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), reports.since, time.Minute)
+	assert.Equal(t, "product_id,product_name,quantity,last_movement_at\n"+
+		"3,Vase,4,\n"+
+		"1,Chair,12,2025-11-03T10:00:00Z\n", resp.Body.String())
+
+	resp = performRequest(router, "GET", "/reports/dead-stock?days=0", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "must be positive")
+
+	reports.deadStock = nil
+	resp = performRequest(router, "GET", "/reports/dead-stock", nil)
+	assert.Equal(t, `{"data":[]}`, resp.Body.String())
+}
+
+func TestABCReport(t *testing.T) {
+	router, reports := setupReportHandlerWithMock()
+	consumed := func(id int32, name string, quantity int32, value string) report.Consumption {
+		return report.Consumption{ProductID: id, ProductName: name, IssuedQuantity: quantity, Value: money.MustParse(value, "USD")}
+	}
+	reports.consumption = []report.Consumption{
+		consumed(1, "Chair", 10, "70.00"),
+		consumed(3, "Lamp", 2, "8.00"),
+		consumed(4, "Rug", 1, "4.00"),
+		consumed(2, "Table", 1, "15.00"),
+		consumed(5, "Vase", 3, "3.00"),
+	}
+
+	resp := performRequest(router, "GET", "/reports/abc?from=2026-01-01", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), reports.from)
+	body := resp.Body.String()
+	assert.Contains(t, body, `"product_name":"Chair","issued_quantity":10,"value":{"amount":"70.00","currency":"USD"},"share_pct":70,"cumulative_pct":70,"class":"A"`)
+	assert.Contains(t, body, `"product_name":"Table","issued_quantity":1,"value":{"amount":"15.00","currency":"USD"},"share_pct":15,"cumulative_pct":85,"class":"A"`)
+	assert.Contains(t, body, `"product_name":"Lamp","issued_quantity":2,"value":{"amount":"8.00","currency":"USD"},"share_pct":8,"cumulative_pct":93,"class":"B"`)
+	assert.Less(t, strings.Index(body, "Table"), strings.Index(body, "Lamp"))
+
+	resp = performRequest(router, "GET", "/reports/abc?format=csv", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "product_id,product_name,issued_quantity,value,currency,share_pct,cumulative_pct,class\n"+
+		"1,Chair,10,70.00,USD,70,70,A\n"+
+		"2,Table,1,15.00,USD,15,85,A\n"+
+		"3,Lamp,2,8.00,USD,8,93,B\n"+
+		"4,Rug,1,4.00,USD,4,97,B\n"+
+		"5,Vase,3,3.00,USD,3,100,C\n", resp.Body.String())
+}
+
+func TestABCReport_Currencies(t *testing.T) {
+	router, reports := setupReportHandlerWithMock()
+	reports.consumption = []report.Consumption{
+		{ProductID: 1, ProductName: "Chair", IssuedQuantity: 4, Value: money.MustParse("40.00", "USD")},
+		{ProductID: 1, ProductName: "Chair", IssuedQuantity: 2, Value: money.MustParse("20.00", "EUR")},
+		{ProductID: 2, ProductName: "Clock", IssuedQuantity: 1, Value: money.MustParse("50.00", "EUR")},
+		{ProductID: 3, ProductName: "Lamp", IssuedQuantity: 1, Value: money.MustParse("10.00", "USD")},
+	}
+
+	// EUR values are converted to USD, and the two rows of the chair are merged.
+	resp := performRequest(router, "GET", "/reports/abc?from=2026-01-01&format=csv", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "product_id,product_name,issued_quantity,value,currency,share_pct,cumulative_pct,class\n"+
+		"1,Chair,6,62.00,USD,48.82,48.82,A\n"+
+		"2,Clock,1,55.00,USD,43.31,92.13,A\n"+
+		"3,Lamp,1,10.00,USD,7.87,100,B\n", resp.Body.String())
+
+	// Without a rate to the reporting currency the report cannot be built.
+	reports.consumption = append(reports.consumption, report.Consumption{ProductID: 4, ProductName: "Rug", IssuedQuantity: 1, Value: money.MustParse("900", "UZS")})
+	resp = performRequest(router, "GET", "/reports/abc?from=2026-01-01", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "product 4: no exchange rate")
+}
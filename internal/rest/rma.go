@@ -0,0 +1,301 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type ReturnRequest struct {
+	OrderID int32               `json:"order_id" binding:"required"`
+	Reason  string              `json:"reason" binding:"max=1000" example:"Arrived damaged"`
+	Lines   []ReturnLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// ReturnLineRequest returns a quantity of a shipped order line; serialized lines
+// list the returned units.
+type ReturnLineRequest struct {
+	OrderLineID int32    `json:"order_line_id" binding:"required"`
+	Quantity    int32    `json:"quantity" binding:"required,gt=0"`
+	Serials     []string `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+}
+
+type InspectReturnRequest struct {
+	Lines []InspectReturnLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// InspectReturnLineRequest sends a quantity of a return line to a disposition.
+// Restocking a lot-tracked product needs the lot the goods go back into.
+type InspectReturnLineRequest struct {
+	LineID      int32       `json:"line_id" binding:"required"`
+	Disposition string      `json:"disposition" binding:"required" enums:"restock,quarantine,scrap"`
+	Quantity    int32       `json:"quantity" binding:"required,gt=0"`
+	Lot         *LotRequest `json:"lot"`
+	Serials     []string    `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+}
+
+// CreateReturn godoc
+// @Summary Create a customer return
+// @Description Open a return (RMA) against lines of a shipped sales order; a line cannot return more than was shipped
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param return body ReturnRequest true "Order and returned lines"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created return"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns [post]
+func (h *HandlerConfig) CreateReturn(c *gin.Context) {
+	const op = "rest.rma.create"
+
+	var req ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	r := rma.Return{OrderID: req.OrderID, Reason: req.Reason}
+	for _, line := range req.Lines {
+		r.Lines = append(r.Lines, rma.Line{
+			OrderLineID: line.OrderLineID,
+			Quantity:    line.Quantity,
+			Serials:     line.Serials,
+		})
+	}
+
+	id, err := h.Dep.Return.Create(c.Request.Context(), r)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating return: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListReturns godoc
+// @Summary List customer returns
+// @Description Get returns without lines, newest first
+// @Tags returns
+// @Produce json
+// @Param status query string false "Status" Enums(open, inspected, cancelled)
+// @Param order_id query int false "Sales order ID"
+// @Success 200 {object} map[string]interface{} "List of returns"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns [get]
+func (h *HandlerConfig) ListReturns(c *gin.Context) {
+	const op = "rest.rma.list"
+
+	orderID, err := queryID(c, "order_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid order ID", ErrorCode: 400})
+		return
+	}
+
+	returns, err := h.Dep.Return.List(c.Request.Context(), rma.Status(c.Query("status")), orderID)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list returns: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": returns})
+}
+
+// GetReturn godoc
+// @Summary Get customer return by ID
+// @Description Retrieve a return with its lines and dispositions
+// @Tags returns
+// @Produce json
+// @Param id path int true "Return ID"
+// @Success 200 {object} map[string]interface{} "Return data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Return not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns/{id} [get]
+func (h *HandlerConfig) GetReturn(c *gin.Context) {
+	const op = "rest.rma.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	r, err := h.Dep.Return.Get(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get return: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": r})
+}
+
+// InspectReturn godoc
+// @Summary Inspect a customer return
+// @Description Sort every returned unit into restock, quarantine or scrap and post the stock movements in one transaction.
+// @Description Restocked goods become sellable, quarantined goods are kept in the separate quarantined quantity
+// @Description and scrapped goods never re-enter stock
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param inspection body InspectReturnRequest true "Dispositions"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Inspected return"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Return not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns/{id}/inspection [post]
+func (h *HandlerConfig) InspectReturn(c *gin.Context) {
+	const op = "rest.rma.inspect"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req InspectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	var insp rma.Inspection
+	for _, line := range req.Lines {
+		insp.Lines = append(insp.Lines, rma.InspectedLine{
+			LineID:      line.LineID,
+			Disposition: rma.Disposition(line.Disposition),
+			Quantity:    line.Quantity,
+			Lot:         line.Lot.toLot(),
+			Serials:     line.Serials,
+		})
+	}
+
+	r, err := h.Dep.Return.Inspect(c.Request.Context(), id, insp)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to inspect return: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": r})
+}
+
+// ReleaseReturnRequest restocks or scraps quarantined goods of return lines.
+// Restocking a lot-tracked product needs the lot the goods go back into.
+type ReleaseReturnRequest struct {
+	Lines []ReleaseReturnLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type ReleaseReturnLineRequest struct {
+	LineID      int32       `json:"line_id" binding:"required"`
+	Disposition string      `json:"disposition" binding:"required" enums:"restock,scrap"`
+	Quantity    int32       `json:"quantity" binding:"required,gt=0"`
+	Lot         *LotRequest `json:"lot"`
+	Serials     []string    `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+}
+
+// ReleaseQuarantine godoc
+// @Summary Release quarantined goods of a customer return
+// @Description Take quarantined goods of an inspected return out of quarantine and post the stock movements in one transaction.
+// @Description Restocked goods become sellable at the current average cost and scrapped goods leave stock
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param release body ReleaseReturnRequest true "Released lines"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Return with its updated dispositions"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Return not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns/{id}/quarantine/release [post]
+func (h *HandlerConfig) ReleaseQuarantine(c *gin.Context) {
+	const op = "rest.rma.release"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req ReleaseReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	var rel rma.Release
+	for _, line := range req.Lines {
+		rel.Lines = append(rel.Lines, rma.InspectedLine{
+			LineID:      line.LineID,
+			Disposition: rma.Disposition(line.Disposition),
+			Quantity:    line.Quantity,
+			Lot:         line.Lot.toLot(),
+			Serials:     line.Serials,
+		})
+	}
+
+	r, err := h.Dep.Return.ReleaseQuarantine(c.Request.Context(), id, rel)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to release quarantined goods: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": r})
+}
+
+// CancelReturn godoc
+// @Summary Cancel a customer return
+// @Description Cancel an open return; its quantities can be returned again
+// @Tags returns
+// @Produce json
+// @Param id path int true "Return ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Cancelled return"
+// @Failure 400 {object} BaseResponse "Return is not open"
+// @Failure 404 {object} BaseResponse "Return not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /returns/{id}/cancel [post]
+func (h *HandlerConfig) CancelReturn(c *gin.Context) {
+	const op = "rest.rma.cancel"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	r, err := h.Dep.Return.Cancel(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to cancel return: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": r})
+}
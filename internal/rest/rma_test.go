@@ -0,0 +1,378 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockReturnRepo struct {
+	returns  map[int32]rma.Return
+	orders   *mockSalesRepo
+	stock    *mockStockRepo
+	nextID   int32
+	nextLine int32
+}
+
+func (m *mockReturnRepo) Create(ctx context.Context, r rma.Return, fn func(r *rma.Return, o sales.Order, returned map[int32]rma.Returned) error) (int32, error) {
+	o, err := m.orders.Get(ctx, r.OrderID)
+	if err != nil {
+		return 0, err
+	}
+	returned := make(map[int32]rma.Returned)
+	for _, other := range m.returns {
+		if other.OrderID == r.OrderID && other.Status != rma.StatusCancelled {
+			for _, line := range other.Lines {
+				earlier := returned[line.OrderLineID]
+				earlier.Quantity += line.Quantity
+				earlier.Serials = append(earlier.Serials, line.Serials...)
+				returned[line.OrderLineID] = earlier
+			}
+		}
+	}
+	if err := fn(&r, o, returned); err != nil {
+		return 0, err
+	}
+
+	m.nextID++
+	r.ID = m.nextID
+	r.Status = rma.StatusOpen
+	r.CreatedAt = time.Now()
+	for i := range r.Lines {
+		m.nextLine++
+		r.Lines[i].ID = m.nextLine
+	}
+	m.returns[r.ID] = r
+	return r.ID, nil
+}
+
+func (m *mockReturnRepo) Get(ctx context.Context, id int32) (rma.Return, error) {
+	r, ok := m.returns[id]
+	if !ok {
+		return rma.Return{}, rma.ErrNotFound
+	}
+	r.Lines = slices.Clone(r.Lines)
+	return r, nil
+}
+
+func (m *mockReturnRepo) List(ctx context.Context, status rma.Status, orderID *int32) ([]rma.Return, error) {
+	var list []rma.Return
+	for _, r := range m.returns {
+		if (status == "" || r.Status == status) && (orderID == nil || r.OrderID == *orderID) {
+			r.Lines = nil
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockReturnRepo) Update(ctx context.Context, id int32, fn func(r *rma.Return) ([]stock.Movement, []stock.SerialUpdate, error)) (rma.Return, error) {
+	r, err := m.Get(ctx, id)
+	if err != nil {
+		return rma.Return{}, err
+	}
+	movements, writeOffs, err := fn(&r)
+	if err != nil {
+		return rma.Return{}, err
+	}
+	for _, mv := range movements {
+		if _, err := m.stock.ApplyMovement(ctx, mv); err != nil {
+			return rma.Return{}, err
+		}
+	}
+	for _, u := range writeOffs {
+		m.stock.serials[u.ID-1].Status = u.To
+	}
+	m.returns[id] = r
+	return r, nil
+}
+
+func setupReturnHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockStockRepo, *mockSalesRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+	orders := &mockSalesRepo{orders: make(map[int32]sales.Order), stock: stockRepo}
+	returns := &mockReturnRepo{returns: make(map[int32]rma.Return), orders: orders, stock: stockRepo}
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock:  stockUC,
+			Return: usecase.NewReturnUseCase(returns, stockUC),
+			Sl:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/returns", h.CreateReturn)
+	router.GET("/returns", h.ListReturns)
+	router.GET("/returns/:id", h.GetReturn)
+	router.POST("/returns/:id/inspection", h.InspectReturn)
+	router.POST("/returns/:id/quarantine/release", h.ReleaseQuarantine)
+	router.POST("/returns/:id/cancel", h.CancelReturn)
+	return router, products, stockRepo, orders
+}
+
+// seedShippedOrder ships 5 chairs and the laptops SN-1 and SN-2 on order 1 (lines
+// 1 and 2); SN-3 stays in stock.
+func seedShippedOrder(t *testing.T, products *mockProductUseCase, stockRepo *mockStockRepo, orders *mockSalesRepo) (chairs, laptops int32) {
+	chairs, _ = products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 5})
+	laptops, _ = products.Create(context.TODO(), product.Product{Name: "Laptop", Price: money.MustParse("900", "USD"), Serialized: true})
+
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+	_, err := stockUC.Receive(context.TODO(), stock.Movement{ProductID: laptops, EnteredQuantity: "3", Serials: []string{"SN-1", "SN-2", "SN-3"}})
+	assert.NoError(t, err)
+	_, err = stockUC.Issue(context.TODO(), stock.Movement{ProductID: laptops, EnteredQuantity: "2", Reference: "SO-1", Serials: []string{"SN-1", "SN-2"}})
+	assert.NoError(t, err)
+
+	orders.Create(context.TODO(), sales.Order{Customer: "Bob", Currency: "USD", Lines: []sales.Line{
+		{ProductID: chairs, Quantity: 5, AllocatedQuantity: 5, ShippedQuantity: 5},
+		{ProductID: laptops, Quantity: 2, AllocatedQuantity: 2, ShippedQuantity: 2, Serials: []string{"SN-1", "SN-2"}},
+	}})
+	o := orders.orders[1]
+	o.Status = sales.StatusShipped
+	orders.orders[1] = o
+	return chairs, laptops
+}
+
+func TestReturnLifecycle(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	chairs, laptops := seedShippedOrder(t, products, stockRepo, orders)
+
+	body := `{"order_id":1,"reason":"Damaged in transit","lines":[{"order_line_id":1,"quantity":4},{"order_line_id":2,"quantity":2,"serials":["SN-1","SN-2"]}]}`
+	resp := performRequest(router, "POST", "/returns", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":1,"quantity":2}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "order line 1 has 1 left to return")
+
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[{"line_id":1,"disposition":"restock","quantity":2}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "line 1 returned 4, inspected 2")
+
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[`+
+		`{"line_id":1,"disposition":"restock","quantity":2},`+
+		`{"line_id":1,"disposition":"quarantine","quantity":1},`+
+		`{"line_id":1,"disposition":"scrap","quantity":1},`+
+		`{"line_id":2,"disposition":"restock","quantity":1,"serials":["SN-1"]},`+
+		`{"line_id":2,"disposition":"scrap","quantity":1,"serials":["SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"inspected"`)
+	assert.Contains(t, resp.Body.String(), `"restocked_quantity":2,"quarantined_quantity":1,"scrapped_quantity":1`)
+
+	assert.Equal(t, int32(7), products.products[chairs].Quantity)
+	assert.Equal(t, int32(1), products.products[chairs].QuarantinedQuantity)
+	assert.Equal(t, int32(2), products.products[laptops].Quantity)
+	assert.Equal(t, stock.SerialReturned, stockRepo.serials[0].Status)
+	assert.Equal(t, stock.SerialWrittenOff, stockRepo.serials[1].Status)
+
+	var types []stock.MovementType
+	for _, mv := range stockRepo.movements {
+		if mv.Reference == "RMA-1" {
+			types = append(types, mv.Type)
+		}
+	}
+	assert.Equal(t, []stock.MovementType{stock.MovementReturn, stock.MovementQuarantine, stock.MovementReturn}, types)
+
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[{"line_id":1,"disposition":"restock","quantity":4}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "return is inspected")
+
+	resp = performRequest(router, "GET", "/returns?order_id=1&status=inspected", nil)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+}
+
+func TestReturn_QuarantineSerialized(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	_, laptops := seedShippedOrder(t, products, stockRepo, orders)
+
+	performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":2,"quantity":1,"serials":["SN-2"]}]}`))
+
+	resp := performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[{"line_id":1,"disposition":"quarantine","quantity":1,"serials":["SN-1"]}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "not part of this return line")
+
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[{"line_id":1,"disposition":"quarantine","quantity":1,"serials":["SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, stock.SerialQuarantined, stockRepo.serials[1].Status)
+	assert.Equal(t, int32(1), products.products[laptops].Quantity)
+	assert.Equal(t, int32(1), products.products[laptops].QuarantinedQuantity)
+}
+
+func TestReturn_ReleaseQuarantine(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	chairs, laptops := seedShippedOrder(t, products, stockRepo, orders)
+
+	body := `{"order_id":1,"lines":[{"order_line_id":1,"quantity":4},{"order_line_id":2,"quantity":2,"serials":["SN-1","SN-2"]}]}`
+	resp := performRequest(router, "POST", "/returns", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	release := `{"lines":[{"line_id":1,"disposition":"restock","quantity":2},{"line_id":1,"disposition":"scrap","quantity":1}]}`
+	resp = performRequest(router, "POST", "/returns/1/quarantine/release", []byte(release))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "return is open")
+
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[`+
+		`{"line_id":1,"disposition":"restock","quantity":1},`+
+		`{"line_id":1,"disposition":"quarantine","quantity":3},`+
+		`{"line_id":2,"disposition":"quarantine","quantity":2,"serials":["SN-1","SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(6), products.products[chairs].Quantity)
+	assert.Equal(t, int32(3), products.products[chairs].QuarantinedQuantity)
+
+	resp = performRequest(router, "POST", "/returns/1/quarantine/release", []byte(release))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"restocked_quantity":3,"quarantined_quantity":0,"scrapped_quantity":1`)
+	assert.Equal(t, int32(8), products.products[chairs].Quantity)
+	assert.Equal(t, int32(0), products.products[chairs].QuarantinedQuantity)
+
+	resp = performRequest(router, "POST", "/returns/1/quarantine/release", []byte(`{"lines":[`+
+		`{"line_id":2,"disposition":"restock","quantity":1,"serials":["SN-1"]},`+
+		`{"line_id":2,"disposition":"scrap","quantity":1,"serials":["SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"restocked_quantity":1,"quarantined_quantity":0,"scrapped_quantity":1`)
+	assert.Equal(t, int32(2), products.products[laptops].Quantity)
+	assert.Equal(t, int32(0), products.products[laptops].QuarantinedQuantity)
+	assert.Equal(t, stock.SerialReturned, stockRepo.serials[0].Status)
+	assert.Equal(t, stock.SerialWrittenOff, stockRepo.serials[1].Status)
+
+	// Released goods leave quarantine first; only restocked ones come back
+	// into stock with a return movement.
+	var moves []string
+	for _, mv := range stockRepo.movements {
+		if mv.Reference == "RMA-1" {
+			moves = append(moves, string(mv.Type)+" "+mv.EnteredQuantity)
+		}
+	}
+	assert.Equal(t, []string{
+		"return 1", "quarantine 3", "quarantine 2",
+		"quarantine -2", "return 2", "quarantine -1",
+		"quarantine -1", "return 1", "quarantine -1",
+	}, moves)
+
+	resp = performRequest(router, "POST", "/returns/1/quarantine/release", []byte(`{"lines":[{"line_id":1,"disposition":"scrap","quantity":1}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "line 1 has 0 quarantined")
+}
+
+func TestReturn_ReleaseQuarantineRejected(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	seedShippedOrder(t, products, stockRepo, orders)
+	performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":1,"quantity":2},{"order_line_id":2,"quantity":2,"serials":["SN-1","SN-2"]}]}`))
+	resp := performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[`+
+		`{"line_id":1,"disposition":"quarantine","quantity":2},`+
+		`{"line_id":2,"disposition":"restock","quantity":1,"serials":["SN-1"]},`+
+		`{"line_id":2,"disposition":"quarantine","quantity":1,"serials":["SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	tests := []struct {
+		name string
+		body string
+		code int
+		want string
+	}{
+		{"quarantine again", `{"lines":[{"line_id":1,"disposition":"quarantine","quantity":1}]}`, http.StatusBadRequest, "can only be restocked or scrapped"},
+		{"more than quarantined", `{"lines":[{"line_id":1,"disposition":"restock","quantity":3}]}`, http.StatusBadRequest, "line 1 has 2 quarantined"},
+		{"unknown line", `{"lines":[{"line_id":9,"disposition":"scrap","quantity":1}]}`, http.StatusBadRequest, "9"},
+		{"serial of another line", `{"lines":[{"line_id":2,"disposition":"scrap","quantity":1,"serials":["SN-3"]}]}`, http.StatusBadRequest, "not part of this return line"},
+		{"restocked serial", `{"lines":[{"line_id":2,"disposition":"scrap","quantity":1,"serials":["SN-1"]}]}`, http.StatusBadRequest, "SN-1 is returned"},
+		{"missing serials", `{"lines":[{"line_id":2,"disposition":"restock","quantity":1}]}`, http.StatusBadRequest, "0 listed for a quantity of 1"},
+		{"no lines", `{"lines":[]}`, http.StatusBadRequest, "Lines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/returns/1/quarantine/release", []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.want)
+		})
+	}
+
+	resp = performRequest(router, "POST", "/returns/9/quarantine/release", []byte(`{"lines":[{"line_id":1,"disposition":"scrap","quantity":1}]}`))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, resp.Body.String(), "return not found")
+	assert.Equal(t, int32(2), products.products[1].QuarantinedQuantity)
+	assert.Equal(t, stock.SerialQuarantined, stockRepo.serials[1].Status)
+}
+
+func TestCreateReturn_Rejected(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	chairs, _ := seedShippedOrder(t, products, stockRepo, orders)
+	orders.Create(context.TODO(), sales.Order{Customer: "Eve", Currency: "USD", Lines: []sales.Line{{ProductID: chairs, Quantity: 1}}})
+
+	tests := []struct {
+		name     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Order not shipped", `{"order_id":2,"lines":[{"order_line_id":3,"quantity":1}]}`, http.StatusBadRequest, "order is draft"},
+		{"Line of another order", `{"order_id":1,"lines":[{"order_line_id":3,"quantity":1}]}`, http.StatusBadRequest, "does not belong"},
+		{"More than shipped", `{"order_id":1,"lines":[{"order_line_id":1,"quantity":6}]}`, http.StatusBadRequest, "has 5 left to return"},
+		{"Missing serials", `{"order_id":1,"lines":[{"order_line_id":2,"quantity":1}]}`, http.StatusBadRequest, "0 listed for a quantity of 1"},
+		{"Serial not shipped", `{"order_id":1,"lines":[{"order_line_id":2,"quantity":1,"serials":["SN-3"]}]}`, http.StatusBadRequest, "not shipped on this order line: SN-3"},
+		{"Serials on plain product", `{"order_id":1,"lines":[{"order_line_id":1,"quantity":1,"serials":["X"]}]}`, http.StatusBadRequest, "not serialized"},
+		{"Unknown order", `{"order_id":99,"lines":[{"order_line_id":1,"quantity":1}]}`, http.StatusNotFound, "sales order not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/returns", []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+
+	// A cancelled return no longer counts against the shipped quantity.
+	body := []byte(`{"order_id":1,"lines":[{"order_line_id":1,"quantity":5}]}`)
+	resp := performRequest(router, "POST", "/returns", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/returns/1/cancel", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/returns", body)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/returns/1/inspection", []byte(`{"lines":[{"line_id":1,"disposition":"burn","quantity":5}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "return is cancelled")
+}
+
+func TestCreateReturn_SerialsOfTheOrderLine(t *testing.T) {
+	router, products, stockRepo, orders := setupReturnHandlerWithMock()
+	_, laptops := seedShippedOrder(t, products, stockRepo, orders)
+
+	// SN-3 ships on a second order; it cannot come back against the first.
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+	_, err := stockUC.Issue(context.TODO(), stock.Movement{ProductID: laptops, EnteredQuantity: "1", Reference: "SO-2", Serials: []string{"SN-3"}})
+	assert.NoError(t, err)
+	orders.Create(context.TODO(), sales.Order{Customer: "Eve", Currency: "USD", Lines: []sales.Line{
+		{ProductID: laptops, Quantity: 1, AllocatedQuantity: 1, ShippedQuantity: 1, Serials: []string{"SN-3"}},
+	}})
+	o := orders.orders[2]
+	o.Status = sales.StatusShipped
+	orders.orders[2] = o
+
+	resp := performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":2,"quantity":1,"serials":["SN-3"]}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "not shipped on this order line: SN-3")
+	resp = performRequest(router, "POST", "/returns", []byte(`{"order_id":2,"lines":[{"order_line_id":3,"quantity":1,"serials":["SN-3"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":2,"quantity":1,"serials":["SN-1"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/returns", []byte(`{"order_id":1,"lines":[{"order_line_id":2,"quantity":1,"serials":["SN-1"]}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "already returned: SN-1")
+}
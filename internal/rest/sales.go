@@ -0,0 +1,390 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type SalesOrderRequest struct {
+	Customer      string                  `json:"customer" binding:"required,max=255"`
+	CustomerGroup string                  `json:"customer_group" binding:"max=50" example:"wholesale"`
+	Currency      string                  `json:"currency" binding:"omitempty,len=3"`
+	Reference     string                  `json:"reference" binding:"max=255"`
+	Lines         []SalesOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// SalesOrderLineRequest orders a quantity in the product base unit. Without a unit
+// price the price resolved for the customer group is used.
+type SalesOrderLineRequest struct {
+	ProductID int32       `json:"product_id" binding:"required"`
+	Quantity  int32       `json:"quantity" binding:"required,gt=0"`
+	UnitPrice money.Money `json:"unit_price"`
+}
+
+func (r SalesOrderRequest) toOrder() sales.Order {
+	o := sales.Order{
+		Customer:      r.Customer,
+		CustomerGroup: r.CustomerGroup,
+		Currency:      r.Currency,
+		Reference:     r.Reference,
+	}
+	for _, line := range r.Lines {
+		o.Lines = append(o.Lines, sales.Line{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		})
+	}
+	return o
+}
+
+type PackSalesOrderRequest struct {
+	Packages int32                       `json:"packages" binding:"required,gt=0"`
+	Lines    []PackSalesOrderLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type PackSalesOrderLineRequest struct {
+	LineID   int32 `json:"line_id" binding:"required"`
+	Quantity int32 `json:"quantity" binding:"gte=0"`
+}
+
+type ShipSalesOrderRequest struct {
+	Carrier        string `json:"carrier" binding:"max=100" example:"DHL"`
+	TrackingNumber string `json:"tracking_number" binding:"required,max=100" example:"JD014600003828"`
+	// Lines name the lots or serial numbers shipped; serialized lines must list them.
+	Lines []ShipSalesOrderLineRequest `json:"lines" binding:"omitempty,dive"`
+}
+
+type ShipSalesOrderLineRequest struct {
+	LineID  int32       `json:"line_id" binding:"required"`
+	Lot     *LotRequest `json:"lot"`
+	Serials []string    `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+}
+
+// CreateSalesOrder godoc
+// @Summary Create a sales order
+// @Description Create a draft sales order; lines without a unit price use the price resolved for the customer group
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param order body SalesOrderRequest true "Customer and lines"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created order"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders [post]
+func (h *HandlerConfig) CreateSalesOrder(c *gin.Context) {
+	const op = "rest.sales.create"
+
+	var req SalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	id, err := h.Dep.Sales.Create(c.Request.Context(), req.toOrder())
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListSalesOrders godoc
+// @Summary List sales orders
+// @Description Get sales orders without lines, newest first
+// @Tags sales
+// @Produce json
+// @Param status query string false "Status" Enums(draft, allocated, picking, packed, shipped, cancelled)
+// @Success 200 {object} map[string]interface{} "List of sales orders"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders [get]
+func (h *HandlerConfig) ListSalesOrders(c *gin.Context) {
+	const op = "rest.sales.list"
+
+	orders, err := h.Dep.Sales.List(c.Request.Context(), sales.Status(c.Query("status")))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list sales orders: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": orders})
+}
+
+// GetSalesOrder godoc
+// @Summary Get sales order by ID
+// @Description Retrieve a sales order with its lines and allocated and shipped quantities
+// @Tags sales
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Success 200 {object} map[string]interface{} "Sales order data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id} [get]
+func (h *HandlerConfig) GetSalesOrder(c *gin.Context) {
+	const op = "rest.sales.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	order, err := h.Dep.Sales.Get(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// AllocateSalesOrder godoc
+// @Summary Allocate stock to a sales order
+// @Description Reserve on-hand stock that is not allocated to other open orders for every line of a draft order.
+// @Description Stock does not move until the order ships
+// @Tags sales
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Allocated sales order"
+// @Failure 400 {object} BaseResponse "Insufficient stock or order is not a draft"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/allocate [post]
+func (h *HandlerConfig) AllocateSalesOrder(c *gin.Context) {
+	const op = "rest.sales.allocate"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	order, err := h.Dep.Sales.Allocate(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to allocate sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// StartPickingSalesOrder godoc
+// @Summary Start picking a sales order
+// @Description Release an allocated order for picking and return its pick list grouped by bin location
+// @Tags sales
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Pick list"
+// @Failure 400 {object} BaseResponse "Order is not allocated"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/pick [post]
+func (h *HandlerConfig) StartPickingSalesOrder(c *gin.Context) {
+	const op = "rest.sales.pick"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	list, err := h.Dep.Sales.StartPicking(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to start picking sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// GetPickList godoc
+// @Summary Get the pick list of a sales order
+// @Description Allocated lines grouped by bin location in walking order; products without a bin come last
+// @Tags sales
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Success 200 {object} map[string]interface{} "Pick list"
+// @Failure 400 {object} BaseResponse "Order holds no allocation"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/pick-list [get]
+func (h *HandlerConfig) GetPickList(c *gin.Context) {
+	const op = "rest.sales.pick_list"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	list, err := h.Dep.Sales.PickList(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get pick list: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// PackSalesOrder godoc
+// @Summary Confirm packing of a sales order
+// @Description Confirm the packed quantity of every line, which must match its allocation, and the number of packages
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param packing body PackSalesOrderRequest true "Packed quantities"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Packed sales order"
+// @Failure 400 {object} BaseResponse "Invalid input, quantity mismatch or order is not being picked"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/pack [post]
+func (h *HandlerConfig) PackSalesOrder(c *gin.Context) {
+	const op = "rest.sales.pack"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req PackSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	packing := sales.Packing{Packages: req.Packages}
+	for _, line := range req.Lines {
+		packing.Lines = append(packing.Lines, sales.PackedLine{LineID: line.LineID, Quantity: line.Quantity})
+	}
+
+	order, err := h.Dep.Sales.Pack(c.Request.Context(), id, packing)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to pack sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// ShipSalesOrder godoc
+// @Summary Ship a sales order
+// @Description Hand a packed order to the carrier and issue its allocated stock in one transaction.
+// @Description Lot-tracked lines without a lot are issued first-expiry-first-out
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param shipment body ShipSalesOrderRequest true "Carrier, tracking number and lots or serials"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Shipped sales order"
+// @Failure 400 {object} BaseResponse "Invalid input, insufficient stock or order is not packed"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/ship [post]
+func (h *HandlerConfig) ShipSalesOrder(c *gin.Context) {
+	const op = "rest.sales.ship"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req ShipSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	shipment := sales.Shipment{Carrier: req.Carrier, TrackingNumber: req.TrackingNumber}
+	for _, line := range req.Lines {
+		shipment.Lines = append(shipment.Lines, sales.ShipmentLine{
+			LineID:  line.LineID,
+			Lot:     line.Lot.toLot(),
+			Serials: line.Serials,
+		})
+	}
+
+	order, err := h.Dep.Sales.Ship(c.Request.Context(), id, shipment)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to ship sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
+
+// CancelSalesOrder godoc
+// @Summary Cancel a sales order
+// @Description Cancel an order that has not shipped and release its allocation
+// @Tags sales
+// @Produce json
+// @Param id path int true "Sales order ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Cancelled sales order"
+// @Failure 400 {object} BaseResponse "Order already shipped or cancelled"
+// @Failure 404 {object} BaseResponse "Sales order not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /sales-orders/{id}/cancel [post]
+func (h *HandlerConfig) CancelSalesOrder(c *gin.Context) {
+	const op = "rest.sales.cancel"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	order, err := h.Dep.Sales.Cancel(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to cancel sales order: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": order})
+}
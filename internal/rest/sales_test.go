@@ -0,0 +1,332 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSalesRepo struct {
+	orders   map[int32]sales.Order
+	stock    *mockStockRepo
+	nextID   int32
+	nextLine int32
+}
+
+func (m *mockSalesRepo) Create(ctx context.Context, o sales.Order) (int32, error) {
+	m.nextID++
+	o.ID = m.nextID
+	o.Status = sales.StatusDraft
+	o.CreatedAt = time.Now()
+	for i := range o.Lines {
+		m.nextLine++
+		o.Lines[i].ID = m.nextLine
+	}
+	m.orders[o.ID] = o
+	return o.ID, nil
+}
+
+func (m *mockSalesRepo) Get(ctx context.Context, id int32) (sales.Order, error) {
+	o, ok := m.orders[id]
+	if !ok {
+		return sales.Order{}, sales.ErrNotFound
+	}
+	o.Lines = slices.Clone(o.Lines)
+	return o, nil
+}
+
+func (m *mockSalesRepo) List(ctx context.Context, status sales.Status) ([]sales.Order, error) {
+	var list []sales.Order
+	for _, o := range m.orders {
+		if status == "" || o.Status == status {
+			o.Lines = nil
+			list = append(list, o)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockSalesRepo) Update(ctx context.Context, id int32, fn func(o *sales.Order, available sales.Available) ([]stock.Movement, error)) (sales.Order, error) {
+	o, err := m.Get(ctx, id)
+	if err != nil {
+		return sales.Order{}, err
+	}
+
+	available := make(sales.Available)
+	for _, line := range o.Lines {
+		available[line.ProductID] = m.stock.siteQuantity(defaultWarehouseID, line.ProductID) - m.allocated(line.ProductID, id)
+	}
+
+	movements, err := fn(&o, available)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	// Like the repo, save the order before its movements so a shipment takes its
+	// own allocation.
+	previous := m.orders[id]
+	m.orders[id] = o
+	for _, mv := range movements {
+		if _, err := m.stock.ApplyMovement(ctx, mv); err != nil {
+			m.orders[id] = previous
+			return sales.Order{}, err
+		}
+	}
+	return o, nil
+}
+
+// allocated is the stock of a product reserved by open orders other than the
+// given one.
+func (m *mockSalesRepo) allocated(productID, orderID int32) int32 {
+	var qty int32
+	for _, o := range m.orders {
+		switch o.Status {
+		case sales.StatusAllocated, sales.StatusPicking, sales.StatusPacked:
+		default:
+			continue
+		}
+		for _, line := range o.Lines {
+			if line.ProductID == productID && o.ID != orderID {
+				qty += line.AllocatedQuantity
+			}
+		}
+	}
+	return qty
+}
+
+func setupSalesHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+	orders := &mockSalesRepo{orders: make(map[int32]sales.Order), stock: stockRepo}
+	stockRepo.orders = orders
+	prices := &mockPricingRepo{lists: make(map[int32]pricing.PriceList), items: make(map[[2]int32]pricing.Item), products: products}
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock: stockUC,
+			Sales: usecase.NewSalesUseCase(orders, products, usecase.NewPricingUseCase(prices, products, money.RoundHalfUp), stockUC),
+			Sl:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/sales-orders", h.CreateSalesOrder)
+	router.GET("/sales-orders", h.ListSalesOrders)
+	router.GET("/sales-orders/:id", h.GetSalesOrder)
+	router.POST("/sales-orders/:id/allocate", h.AllocateSalesOrder)
+	router.POST("/sales-orders/:id/pick", h.StartPickingSalesOrder)
+	router.GET("/sales-orders/:id/pick-list", h.GetPickList)
+	router.POST("/sales-orders/:id/pack", h.PackSalesOrder)
+	router.POST("/sales-orders/:id/ship", h.ShipSalesOrder)
+	router.POST("/sales-orders/:id/cancel", h.CancelSalesOrder)
+	router.POST("/products/:id/stock/issues", h.IssueStock)
+	return router, products, stockRepo
+}
+
+// seedSelling stocks three products: chairs in bin B-02, tables in bin A-01 and
+// lamps without a bin.
+func seedSelling(products *mockProductUseCase) (chairs, tables, lamps int32) {
+	chairs, _ = products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 10, BinLocation: "B-02", BaseUnit: "pcs"})
+	tables, _ = products.Create(context.TODO(), product.Product{Name: "Table", Price: money.MustParse("120", "USD"), Quantity: 4, BinLocation: "A-01", BaseUnit: "pcs"})
+	lamps, _ = products.Create(context.TODO(), product.Product{Name: "Lamp", Price: money.MustParse("15", "USD"), Quantity: 6, BaseUnit: "pcs"})
+	return chairs, tables, lamps
+}
+
+func TestSalesOrderLifecycle(t *testing.T) {
+	router, products, _ := setupSalesHandlerWithMock()
+	chairs, tables, lamps := seedSelling(products)
+
+	body := `{"customer":"Bob's Diner","lines":[` +
+		`{"product_id":` + itoa(chairs) + `,"quantity":8},` +
+		`{"product_id":` + itoa(lamps) + `,"quantity":2},` +
+		`{"product_id":` + itoa(tables) + `,"quantity":2,"unit_price":{"amount":"100","currency":"USD"}}]}`
+	resp := performRequest(router, "POST", "/sales-orders", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "GET", "/sales-orders/1", nil)
+	assert.Contains(t, resp.Body.String(), `"status":"draft"`)
+	assert.Contains(t, resp.Body.String(), `"unit_price":{"amount":"25.00","currency":"USD"}`)
+
+	resp = performRequest(router, "POST", "/sales-orders/1/ship", []byte(`{"tracking_number":"TRK-1"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "order is draft and cannot become shipped")
+
+	resp = performRequest(router, "POST", "/sales-orders/1/allocate", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"allocated_quantity":8`)
+
+	// Only 2 of the 10 chairs are still free for a second order.
+	resp = performRequest(router, "POST", "/sales-orders", []byte(`{"customer":"Eve","lines":[{"product_id":`+itoa(chairs)+`,"quantity":3}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/sales-orders/2/allocate", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "has 2 available")
+
+	resp = performRequest(router, "POST", "/sales-orders/1/pick", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"bins":[{"bin_location":"A-01","lines":[{"line_id":3,"product_id":`+itoa(tables)+`,"product_name":"Table","quantity":2,"unit":"pcs"}]},`+
+		`{"bin_location":"B-02"`)
+	assert.Contains(t, resp.Body.String(), `{"bin_location":"","lines":[{"line_id":2,`)
+
+	resp = performRequest(router, "POST", "/sales-orders/1/pack", []byte(`{"packages":3,"lines":[{"line_id":1,"quantity":8},{"line_id":2,"quantity":1},{"line_id":3,"quantity":2}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "line 2 has 2 allocated, 1 packed")
+
+	resp = performRequest(router, "POST", "/sales-orders/1/pack", []byte(`{"packages":3,"lines":[{"line_id":1,"quantity":8},{"line_id":2,"quantity":2},{"line_id":3,"quantity":2}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"packed"`)
+	assert.Equal(t, int32(10), products.products[chairs].Quantity)
+
+	resp = performRequest(router, "POST", "/sales-orders/1/ship", []byte(`{"carrier":"DHL","tracking_number":"TRK-1"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"tracking_number":"TRK-1"`)
+	assert.Contains(t, resp.Body.String(), `"shipped_quantity":8`)
+	assert.Equal(t, int32(2), products.products[chairs].Quantity)
+	assert.Equal(t, int32(2), products.products[tables].Quantity)
+	assert.Equal(t, int32(4), products.products[lamps].Quantity)
+
+	resp = performRequest(router, "POST", "/sales-orders/1/cancel", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "order is shipped")
+
+	resp = performRequest(router, "GET", "/sales-orders?status=shipped", nil)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+	assert.NotContains(t, resp.Body.String(), `"id":2`)
+}
+
+func TestSalesOrder_CancelReleasesAllocation(t *testing.T) {
+	router, products, _ := setupSalesHandlerWithMock()
+	chairs, _, _ := seedSelling(products)
+	body := []byte(`{"customer":"Bob","lines":[{"product_id":` + itoa(chairs) + `,"quantity":6}]}`)
+
+	performRequest(router, "POST", "/sales-orders", body)
+	performRequest(router, "POST", "/sales-orders", body)
+	resp := performRequest(router, "POST", "/sales-orders/1/allocate", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "POST", "/sales-orders/2/allocate", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(router, "POST", "/sales-orders/1/cancel", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"allocated_quantity":0`)
+	resp = performRequest(router, "POST", "/sales-orders/2/allocate", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "GET", "/sales-orders/1/pick-list", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, int32(10), products.products[chairs].Quantity)
+}
+
+func TestSalesOrder_AllocationReservesStock(t *testing.T) {
+	router, products, _ := setupSalesHandlerWithMock()
+	chairs, _, _ := seedSelling(products)
+
+	performRequest(router, "POST", "/sales-orders", []byte(`{"customer":"Bob","lines":[{"product_id":`+itoa(chairs)+`,"quantity":8}]}`))
+	resp := performRequest(router, "POST", "/sales-orders/1/allocate", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Only the 2 chairs not allocated to the order can be issued directly.
+	resp = performRequest(router, "POST", "/products/"+itoa(chairs)+"/stock/issues", []byte(`{"quantity":"3"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = performRequest(router, "POST", "/products/"+itoa(chairs)+"/stock/issues", []byte(`{"quantity":"2"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	performRequest(router, "POST", "/sales-orders/1/pick", nil)
+	performRequest(router, "POST", "/sales-orders/1/pack", []byte(`{"packages":1,"lines":[{"line_id":1,"quantity":8}]}`))
+	resp = performRequest(router, "POST", "/sales-orders/1/ship", []byte(`{"tracking_number":"TRK-1"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(0), products.products[chairs].Quantity)
+}
+
+func TestSalesOrder_AllocatesDefaultWarehouseStock(t *testing.T) {
+	router, products, stockRepo := setupSalesHandlerWithMock()
+	chairs, _, _ := seedSelling(products)
+	// 7 of the 10 chairs are at another warehouse, which orders do not ship from.
+	stockRepo.sites = map[int32]map[int32]int32{2: {chairs: 7}}
+
+	performRequest(router, "POST", "/sales-orders", []byte(`{"customer":"Bob","lines":[{"product_id":`+itoa(chairs)+`,"quantity":5}]}`))
+	resp := performRequest(router, "POST", "/sales-orders/1/allocate", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "has 3 available")
+
+	performRequest(router, "POST", "/sales-orders", []byte(`{"customer":"Ann","lines":[{"product_id":`+itoa(chairs)+`,"quantity":3}]}`))
+	resp = performRequest(router, "POST", "/sales-orders/2/allocate", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// Stock at the other warehouse is not allocated and can still be issued.
+	_, err := stockRepo.ApplyMovement(context.TODO(), stock.Movement{ProductID: chairs, Type: stock.MovementIssue, Quantity: -7, WarehouseID: 2})
+	assert.NoError(t, err)
+	_, err = stockRepo.ApplyMovement(context.TODO(), stock.Movement{ProductID: chairs, Type: stock.MovementIssue, Quantity: -1})
+	assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+}
+
+func TestSalesOrder_ShipSerialized(t *testing.T) {
+	router, products, stockRepo := setupSalesHandlerWithMock()
+	laptops, _ := products.Create(context.TODO(), product.Product{Name: "Laptop", Price: money.MustParse("900", "USD"), Serialized: true})
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+	_, err := stockUC.Receive(context.TODO(), stock.Movement{ProductID: laptops, EnteredQuantity: "2", Serials: []string{"SN-1", "SN-2"}})
+	assert.NoError(t, err)
+
+	performRequest(router, "POST", "/sales-orders", []byte(`{"customer":"Bob","lines":[{"product_id":`+itoa(laptops)+`,"quantity":1}]}`))
+	performRequest(router, "POST", "/sales-orders/1/allocate", nil)
+	performRequest(router, "POST", "/sales-orders/1/pick", nil)
+	performRequest(router, "POST", "/sales-orders/1/pack", []byte(`{"packages":1,"lines":[{"line_id":1,"quantity":1}]}`))
+
+	resp := performRequest(router, "POST", "/sales-orders/1/ship", []byte(`{"tracking_number":"TRK-9"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "line 1: serial numbers must match the quantity")
+
+	resp = performRequest(router, "POST", "/sales-orders/1/ship", []byte(`{"tracking_number":"TRK-9","lines":[{"line_id":1,"serials":["SN-2"]}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"serials":["SN-2"]`)
+	assert.Equal(t, stock.SerialShipped, stockRepo.serials[1].Status)
+	assert.Equal(t, "SO-1", stockRepo.movements[1].Reference)
+	assert.Equal(t, int32(1), products.products[laptops].Quantity)
+}
+
+func TestCreateSalesOrder_Rejected(t *testing.T) {
+	router, products, _ := setupSalesHandlerWithMock()
+	chairs, tables, _ := seedSelling(products)
+
+	tests := []struct {
+		name     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"No customer", `{"lines":[{"product_id":` + itoa(chairs) + `,"quantity":1}]}`, http.StatusBadRequest, "Customer"},
+		{"No lines", `{"customer":"Bob","lines":[]}`, http.StatusBadRequest, "Lines"},
+		{"Duplicate product", `{"customer":"Bob","lines":[{"product_id":` + itoa(chairs) + `,"quantity":1},{"product_id":` + itoa(chairs) + `,"quantity":2}]}`, http.StatusBadRequest, "more than once"},
+		{"Mixed currencies", `{"customer":"Bob","lines":[{"product_id":` + itoa(chairs) + `,"quantity":1},` +
+			`{"product_id":` + itoa(tables) + `,"quantity":1,"unit_price":{"amount":"1","currency":"EUR"}}]}`, http.StatusBadRequest, "order currency USD"},
+		{"No exchange rate", `{"customer":"Bob","currency":"EUR","lines":[{"product_id":` + itoa(chairs) + `,"quantity":1}]}`, http.StatusBadRequest, "exchange rate"},
+		{"Unknown product", `{"customer":"Bob","lines":[{"product_id":99,"quantity":1}]}`, http.StatusNotFound, "product not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/sales-orders", []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+
+	resp := performRequest(router, "GET", "/sales-orders?status=lost", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = performRequest(router, "POST", "/sales-orders/99/allocate", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,390 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type UnitRequest struct {
+	Factor   stock.Factor       `json:"factor" swaggertype:"string" example:"12"`
+	Rounding stock.RoundingRule `json:"rounding" enums:"reject,down,up,half_up"`
+}
+
+type StockMovementRequest struct {
+	Quantity  string      `json:"quantity" binding:"required" example:"2.5"`
+	Unit      string      `json:"unit" example:"case"`
+	Reference string      `json:"reference" binding:"max=255"`
+	Lot       *LotRequest `json:"lot"`
+	// WarehouseID is the warehouse whose stock changes; the default warehouse if omitted.
+	WarehouseID int32 `json:"warehouse_id"`
+	// Serials lists every unit moved; required for serialized products.
+	Serials []string `json:"serials" binding:"omitempty,max=1000,dive,required,max=100"`
+	// UnitCost is the cost of one base unit of incoming stock in the product
+	// currency; stock received without it is valued at the current average cost.
+	UnitCost *money.Money `json:"unit_cost"`
+}
+
+// LotRequest names the lot of a lot-tracked product; dates are only used when
+// the lot is received for the first time.
+type LotRequest struct {
+	Number         string `json:"lot_number" binding:"required,max=100"`
+	ManufacturedAt string `json:"manufactured_at" binding:"omitempty,datetime=2006-01-02" example:"2026-01-31"`
+	ExpiresAt      string `json:"expires_at" binding:"omitempty,datetime=2006-01-02" example:"2026-07-31"`
+}
+
+func (r *LotRequest) toLot() *stock.Lot {
+	if r == nil {
+		return nil
+	}
+	lot := &stock.Lot{Number: r.Number}
+	if t, err := time.Parse(time.DateOnly, r.ManufacturedAt); err == nil {
+		lot.ManufacturedAt = &t
+	}
+	if t, err := time.Parse(time.DateOnly, r.ExpiresAt); err == nil {
+		lot.ExpiresAt = &t
+	}
+	return lot
+}
+
+// ListUnits godoc
+// @Summary List units of measure of a product
+// @Description Get the base unit followed by the alternate units with their conversion factors
+// @Tags stock
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{} "List of units"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/units [get]
+func (h *HandlerConfig) ListUnits(c *gin.Context) {
+	const op = "rest.stock.listUnits"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	units, err := h.Dep.Stock.Units(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list units: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": units})
+}
+
+// SetUnit godoc
+// @Summary Set an alternate unit of measure
+// @Description Create or replace a unit; one unit equals factor base units ("12", "0.5" or "1/6")
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param code path string true "Unit code"
+// @Param unit body UnitRequest true "Conversion factor and rounding rule"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/units/{code} [put]
+func (h *HandlerConfig) SetUnit(c *gin.Context) {
+	const op = "rest.stock.setUnit"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req UnitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	err = h.Dep.Stock.SetUnit(c.Request.Context(), stock.Unit{
+		ProductID: id,
+		Code:      c.Param("code"),
+		Factor:    req.Factor,
+		Rounding:  req.Rounding,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to set unit: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// DeleteUnit godoc
+// @Summary Delete an alternate unit of measure
+// @Tags stock
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param code path string true "Unit code"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 500 {object} BaseResponse "Delete failed"
+// @Router /products/{id}/units/{code} [delete]
+func (h *HandlerConfig) DeleteUnit(c *gin.Context) {
+	const op = "rest.stock.deleteUnit"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Stock.DeleteUnit(c.Request.Context(), id, c.Param("code")); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to delete unit: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to delete unit", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// ReceiveStock godoc
+// @Summary Receive stock
+// @Description Add stock in any configured unit; it is stored in the base unit.
+// @Description Lot-tracked products require a lot, which is created on its first receipt.
+// @Description Serialized products require one serial number per unit
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param movement body StockMovementRequest true "Positive quantity and unit"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Recorded movement"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/stock/receipts [post]
+func (h *HandlerConfig) ReceiveStock(c *gin.Context) {
+	h.recordMovement(c, "rest.stock.receive", h.Dep.Stock.Receive)
+}
+
+// IssueStock godoc
+// @Summary Issue stock
+// @Description Remove stock in any configured unit; fails if not enough is on hand.
+// @Description Serialized products require the serial numbers of the shipped units
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param movement body StockMovementRequest true "Positive quantity and unit"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Recorded movement"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/stock/issues [post]
+func (h *HandlerConfig) IssueStock(c *gin.Context) {
+	h.recordMovement(c, "rest.stock.issue", h.Dep.Stock.Issue)
+}
+
+// AdjustStock godoc
+// @Summary Adjust stock
+// @Description Correct stock by a signed quantity in any configured unit
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param movement body StockMovementRequest true "Signed quantity and unit"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Recorded movement"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/stock/adjustments [post]
+func (h *HandlerConfig) AdjustStock(c *gin.Context) {
+	h.recordMovement(c, "rest.stock.adjust", h.Dep.Stock.Adjust)
+}
+
+// ListStockMovements godoc
+// @Summary List stock movements of a product
+// @Description Get all stock movements of a product, newest first
+// @Tags stock
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{} "List of movements"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/stock/movements [get]
+func (h *HandlerConfig) ListStockMovements(c *gin.Context) {
+	const op = "rest.stock.listMovements"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	movements, err := h.Dep.Stock.Movements(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list movements: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": movements})
+}
+
+func (h *HandlerConfig) recordMovement(c *gin.Context, op string, record func(context.Context, stock.Movement) (stock.Movement, error)) {
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req StockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	movement, err := record(c.Request.Context(), stock.Movement{
+		ProductID:       id,
+		EnteredQuantity: req.Quantity,
+		EnteredUnit:     req.Unit,
+		Reference:       req.Reference,
+		WarehouseID:     req.WarehouseID,
+		Lot:             req.Lot.toLot(),
+		Serials:         req.Serials,
+		UnitCost:        req.UnitCost,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to record stock movement: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": movement})
+}
+
+// ListLots godoc
+// @Summary List lots of a product
+// @Description Get all lots of a product ordered by expiry date
+// @Tags stock
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{} "List of lots"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/lots [get]
+func (h *HandlerConfig) ListLots(c *gin.Context) {
+	const op = "rest.stock.listLots"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	lots, err := h.Dep.Stock.Lots(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list lots: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": lots})
+}
+
+// ListExpiringLots godoc
+// @Summary List expiring lots
+// @Description Get lots with stock that expire within the given period, including already expired ones
+// @Tags stock
+// @Produce json
+// @Param within query string false "Period such as 30d, 2w or 72h" default(30d)
+// @Success 200 {object} map[string]interface{} "List of lots"
+// @Failure 400 {object} BaseResponse "Invalid period"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /lots/expiring [get]
+func (h *HandlerConfig) ListExpiringLots(c *gin.Context) {
+	const op = "rest.stock.listExpiringLots"
+
+	within, err := parsePeriod(c.DefaultQuery("within", "30d"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'within' period", ErrorCode: 400})
+		return
+	}
+
+	lots, err := h.Dep.Stock.ExpiringLots(c.Request.Context(), within)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list expiring lots: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to list expiring lots", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": lots})
+}
+
+// parsePeriod accepts day ("30d") and week ("2w") suffixes on top of time.ParseDuration.
+func parsePeriod(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid period %q", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid period %q", s)
+	}
+	return d, nil
+}
+
+// GetSerial godoc
+// @Summary Get a serial number
+// @Description Get the status of a serialized unit with all its stock movements, oldest first
+// @Tags stock
+// @Produce json
+// @Param sn path string true "Serial number"
+// @Success 200 {object} map[string]interface{} "Serial with movement trail"
+// @Failure 404 {object} BaseResponse "Serial number not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /serials/{sn} [get]
+func (h *HandlerConfig) GetSerial(c *gin.Context) {
+	const op = "rest.stock.getSerial"
+
+	trail, err := h.Dep.Stock.Serial(c.Request.Context(), c.Param("sn"))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get serial: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trail})
+}
@@ -0,0 +1,568 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultWarehouseID is the warehouse mock movements without one belong to.
+const defaultWarehouseID = 1
+
+type mockStockRepo struct {
+	units     map[int32][]stock.Unit
+	movements []stock.Movement
+	lots      []stock.Lot
+	serials   []stock.Serial
+	products  *mockProductUseCase
+	// sites holds the stock per product at warehouses other than the default
+	// one, which holds the rest of the product quantity.
+	sites map[int32]map[int32]int32
+	// layers and costs value stock like the cost layers and entries of the
+	// stock repo.
+	layers map[int32][]stock.CostLayer
+	costs  []costEntry
+	// recentQueries counts the calls to ListRecentMovements.
+	recentQueries int
+	// orders, if set, holds the sales orders whose allocations outgoing
+	// movements may not take.
+	orders *mockSalesRepo
+}
+
+type costEntry struct {
+	productID int32
+	quantity  int32
+	value     money.Money
+	createdAt time.Time
+}
+
+func (m *mockStockRepo) siteQuantity(warehouseID, productID int32) int32 {
+	if warehouseID != defaultWarehouseID {
+		return m.sites[warehouseID][productID]
+	}
+	qty := m.products.products[productID].Quantity
+	for _, site := range m.sites {
+		qty -= site[productID]
+	}
+	return qty
+}
+
+func (m *mockStockRepo) ListUnits(ctx context.Context, productID int32) ([]stock.Unit, error) {
+	return m.units[productID], nil
+}
+
+func (m *mockStockRepo) SetUnit(ctx context.Context, u stock.Unit) error {
+	m.units[u.ProductID] = append(m.units[u.ProductID], u)
+	return nil
+}
+
+func (m *mockStockRepo) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	var kept []stock.Unit
+	for _, u := range m.units[productID] {
+		if u.Code != code {
+			kept = append(kept, u)
+		}
+	}
+	m.units[productID] = kept
+	return nil
+}
+
+func (m *mockStockRepo) ApplyMovement(ctx context.Context, mv stock.Movement) (stock.Movement, error) {
+	p, ok := m.products.products[mv.ProductID]
+	if !ok {
+		return stock.Movement{}, product.ErrNotFound
+	}
+	onHand := &p.Quantity
+	if mv.Type == stock.MovementQuarantine {
+		onHand = &p.QuarantinedQuantity
+	}
+	if *onHand+mv.Quantity < 0 {
+		return stock.Movement{}, stock.ErrInsufficientStock
+	}
+	if mv.Type != stock.MovementQuarantine {
+		if mv.WarehouseID == 0 {
+			mv.WarehouseID = defaultWarehouseID
+		}
+		remaining := m.siteQuantity(mv.WarehouseID, mv.ProductID) + mv.Quantity
+		if remaining < 0 {
+			return stock.Movement{}, stock.ErrInsufficientStock
+		}
+		if mv.Quantity < 0 && mv.WarehouseID == defaultWarehouseID && m.orders != nil {
+			if remaining < m.orders.allocated(mv.ProductID, 0) {
+				return stock.Movement{}, stock.ErrInsufficientStock
+			}
+		}
+		if mv.WarehouseID != defaultWarehouseID {
+			if m.sites == nil {
+				m.sites = make(map[int32]map[int32]int32)
+			}
+			if m.sites[mv.WarehouseID] == nil {
+				m.sites[mv.WarehouseID] = make(map[int32]int32)
+			}
+			m.sites[mv.WarehouseID][mv.ProductID] += mv.Quantity
+		}
+	}
+	for i := range mv.Lots {
+		alloc := &mv.Lots[i]
+		if alloc.LotID == 0 {
+			lot, err := m.FindLot(ctx, mv.ProductID, mv.Lot.Number)
+			if err != nil {
+				lot = *mv.Lot
+				lot.ID, lot.ProductID, lot.Quantity = int32(len(m.lots)+1), mv.ProductID, 0
+				m.lots = append(m.lots, lot)
+			}
+			alloc.LotID = lot.ID
+		}
+		lot := &m.lots[alloc.LotID-1]
+		if lot.Quantity+alloc.Quantity < 0 {
+			return stock.Movement{}, stock.ErrInsufficientStock
+		}
+		lot.Quantity += alloc.Quantity
+	}
+	*onHand += mv.Quantity
+	m.products.products[mv.ProductID] = p
+
+	mv.ID = int32(len(m.movements) + 1)
+	for _, u := range mv.SerialUpdates {
+		if u.ID == 0 {
+			m.serials = append(m.serials, stock.Serial{ID: int32(len(m.serials) + 1), ProductID: mv.ProductID, Number: u.Number})
+			u.ID = int32(len(m.serials))
+		}
+		m.serials[u.ID-1].Status = u.To
+	}
+	mv.CreatedAt = time.Now()
+	if mv.Type.Costed() {
+		mv.Cost = m.applyCost(p, mv)
+	}
+	m.movements = append(m.movements, mv)
+	return mv, nil
+}
+
+func (m *mockStockRepo) applyCost(p product.Product, mv stock.Movement) *money.Money {
+	var quantity int32
+	var value int64
+	for _, e := range m.costs {
+		if e.productID == p.ID {
+			quantity += e.quantity
+			value += e.value.Amount
+		}
+	}
+
+	entry := costEntry{productID: p.ID, createdAt: mv.CreatedAt}
+	if mv.Quantity > 0 {
+		unitCost := stock.AverageCost(quantity, value)
+		if mv.UnitCost != nil {
+			unitCost = mv.UnitCost.Amount
+		}
+		if m.layers == nil {
+			m.layers = make(map[int32][]stock.CostLayer)
+		}
+		m.layers[p.ID] = append(m.layers[p.ID], stock.CostLayer{ID: int32(len(m.layers[p.ID]) + 1), UnitCost: unitCost, Remaining: mv.Quantity})
+		entry.quantity, entry.value = mv.Quantity, money.Money{Amount: unitCost * int64(mv.Quantity), Currency: p.Price.Currency}
+	} else {
+		takes, costed, cost := stock.IssueCost(mv.CostMethod, m.layers[p.ID], -mv.Quantity, quantity, value)
+		for _, take := range takes {
+			m.layers[p.ID][take.LayerID-1].Remaining -= take.Quantity
+		}
+		entry.quantity, entry.value = -costed, money.Money{Amount: -cost, Currency: p.Price.Currency}
+	}
+	m.costs = append(m.costs, entry)
+	return &entry.value
+}
+
+func (m *mockStockRepo) ListMovements(ctx context.Context, productID int32) ([]stock.Movement, error) {
+	var list []stock.Movement
+	for _, mv := range m.movements {
+		if mv.ProductID == productID {
+			list = append(list, mv)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) ListRecentMovements(ctx context.Context, productIDs []int32, limit int32) ([]stock.Movement, error) {
+	m.recentQueries++
+	var list []stock.Movement
+	for _, id := range productIDs {
+		var n int32
+		for i := len(m.movements) - 1; i >= 0 && n < limit; i-- {
+			if m.movements[i].ProductID == id {
+				list = append(list, m.movements[i])
+				n++
+			}
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) ListLots(ctx context.Context, productID int32) ([]stock.Lot, error) {
+	var list []stock.Lot
+	for _, l := range m.lots {
+		if l.ProductID == productID {
+			list = append(list, l)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) FindLot(ctx context.Context, productID int32, number string) (stock.Lot, error) {
+	for _, l := range m.lots {
+		if l.ProductID == productID && l.Number == number {
+			return l, nil
+		}
+	}
+	return stock.Lot{}, stock.ErrLotNotFound
+}
+
+func (m *mockStockRepo) ListExpiringLots(ctx context.Context, before time.Time) ([]stock.Lot, error) {
+	var list []stock.Lot
+	for _, l := range m.lots {
+		if l.Quantity > 0 && l.ExpiresAt != nil && !l.ExpiresAt.After(before) {
+			list = append(list, l)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) FindSerials(ctx context.Context, numbers []string) ([]stock.Serial, error) {
+	var list []stock.Serial
+	for _, s := range m.serials {
+		for _, n := range numbers {
+			if s.Number == n {
+				list = append(list, s)
+			}
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) GetSerial(ctx context.Context, number string) (stock.Serial, error) {
+	for _, s := range m.serials {
+		if s.Number == number {
+			return s, nil
+		}
+	}
+	return stock.Serial{}, stock.ErrSerialNotFound
+}
+
+func (m *mockStockRepo) ListSerialMovements(ctx context.Context, serialID int32) ([]stock.Movement, error) {
+	var list []stock.Movement
+	for _, mv := range m.movements {
+		for _, u := range mv.SerialUpdates {
+			if u.Number == m.serials[serialID-1].Number {
+				list = append(list, mv)
+			}
+		}
+	}
+	return list, nil
+}
+
+func setupStockHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Product: usecase.NewProductUseCase(products),
+			Stock:   usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO),
+			Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/products/:id/units", h.ListUnits)
+	router.PUT("/products/:id/units/:code", h.SetUnit)
+	router.POST("/products/:id/stock/receipts", h.ReceiveStock)
+	router.POST("/products/:id/stock/issues", h.IssueStock)
+	router.POST("/products/:id/stock/adjustments", h.AdjustStock)
+	router.GET("/products/:id/stock/movements", h.ListStockMovements)
+	router.GET("/lots/expiring", h.ListExpiringLots)
+	router.GET("/serials/:sn", h.GetSerial)
+	return router, products, stockRepo
+}
+
+func createJuice(products *mockProductUseCase) int32 {
+	id, _ := products.Create(context.TODO(), product.Product{
+		Name: "Juice", Price: money.MustParse("1.20", "USD"), BaseUnit: "bottle",
+	})
+	return id
+}
+
+func TestSetUnit(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	id := createJuice(products)
+
+	resp := performRequest(router, "PUT", "/products/"+itoa(id)+"/units/case", []byte(`{"factor":"12"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "GET", "/products/"+itoa(id)+"/units", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"code":"bottle","factor":"1"`)
+	assert.Contains(t, resp.Body.String(), `"code":"case","factor":"12","rounding":"reject"`)
+
+	tests := []struct {
+		name     string
+		code     string
+		body     string
+		expected string
+	}{
+		{"Base unit", "bottle", `{"factor":"1"}`, "cannot reuse the base unit"},
+		{"Zero factor", "pack", `{"factor":"0"}`, "conversion factor must be a positive number"},
+		{"Missing factor", "pack", `{}`, "conversion factor must be a positive number"},
+		{"Unknown rounding", "pack", `{"factor":"6","rounding":"sideways"}`, "rounding must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "PUT", "/products/"+itoa(id)+"/units/"+tt.code, []byte(tt.body))
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+}
+
+func TestStockMovements(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	id := createJuice(products)
+	performRequest(router, "PUT", "/products/"+itoa(id)+"/units/case", []byte(`{"factor":"12"}`))
+
+	resp := performRequest(router, "POST", "/products/"+itoa(id)+"/stock/receipts", []byte(`{"quantity":"2.5","unit":"case","reference":"delivery 42"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"quantity":30`)
+	assert.Contains(t, resp.Body.String(), `"entered_quantity":"2.5","entered_unit":"case"`)
+	assert.Equal(t, int32(30), products.products[id].Quantity)
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"7"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"quantity":-7`)
+	assert.Contains(t, resp.Body.String(), `"entered_unit":"bottle"`)
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/adjustments", []byte(`{"quantity":"-3","unit":"bottle"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(20), products.products[id].Quantity)
+
+	resp = performRequest(router, "GET", "/products/"+itoa(id)+"/stock/movements", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"type":"receipt"`)
+	assert.Contains(t, resp.Body.String(), `"type":"issue"`)
+	assert.Contains(t, resp.Body.String(), `"type":"adjustment"`)
+}
+
+func TestStockMovements_Rejected(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	id := createJuice(products)
+	performRequest(router, "PUT", "/products/"+itoa(id)+"/units/case", []byte(`{"factor":"12"}`))
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Unknown unit", "receipts", `{"quantity":"1","unit":"pallet"}`, http.StatusBadRequest, "unit is not configured"},
+		{"Fractional bottles", "receipts", `{"quantity":"0.1","unit":"case"}`, http.StatusBadRequest, "whole number of base units"},
+		{"Negative receipt", "receipts", `{"quantity":"-1"}`, http.StatusBadRequest, "must be positive"},
+		{"Zero adjustment", "adjustments", `{"quantity":"0"}`, http.StatusBadRequest, "must not be zero"},
+		{"Insufficient stock", "issues", `{"quantity":"1","unit":"case"}`, http.StatusBadRequest, "insufficient stock"},
+		{"Missing quantity", "issues", `{}`, http.StatusBadRequest, "'Quantity' failed on the 'required'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/products/"+itoa(id)+"/stock/"+tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+
+	resp := performRequest(router, "POST", "/products/99/stock/receipts", []byte(`{"quantity":"1"}`))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func createLotTrackedJuice(products *mockProductUseCase) int32 {
+	id, _ := products.Create(context.TODO(), product.Product{
+		Name: "Juice", Price: money.MustParse("1.20", "USD"), BaseUnit: "bottle", LotTracked: true,
+	})
+	return id
+}
+
+func receiveLot(router *gin.Engine, id int32, quantity, lot string, expiresAt time.Time) *httptest.ResponseRecorder {
+	body := `{"quantity":"` + quantity + `","lot":{"lot_number":"` + lot + `","expires_at":"` + expiresAt.Format(time.DateOnly) + `"}}`
+	return performRequest(router, "POST", "/products/"+itoa(id)+"/stock/receipts", []byte(body))
+}
+
+func TestLotTracking_FEFO(t *testing.T) {
+	router, products, stockRepo := setupStockHandlerWithMock()
+	id := createLotTrackedJuice(products)
+	today := time.Now()
+
+	assert.Equal(t, http.StatusOK, receiveLot(router, id, "10", "L-LATE", today.AddDate(0, 3, 0)).Code)
+	assert.Equal(t, http.StatusOK, receiveLot(router, id, "5", "L-SOON", today.AddDate(0, 0, 10)).Code)
+	assert.Equal(t, http.StatusOK, receiveLot(router, id, "4", "L-OLD", today.AddDate(0, 0, -1)).Code)
+	assert.Equal(t, int32(19), products.products[id].Quantity)
+
+	resp := performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"8"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"lot_number":"L-SOON"`)
+	assert.Contains(t, resp.Body.String(), `"lot_number":"L-LATE"`)
+	assert.NotContains(t, resp.Body.String(), "L-OLD")
+	assert.Equal(t, int32(0), stockRepo.lots[1].Quantity)
+	assert.Equal(t, int32(7), stockRepo.lots[0].Quantity)
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"1","lot":{"lot_number":"L-OLD"}}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "lot is expired")
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"12"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "insufficient stock")
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"7"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"2"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "only expired stock is available")
+
+	resp = performRequest(router, "POST", "/products/"+itoa(id)+"/stock/adjustments", []byte(`{"quantity":"-4","reference":"write-off","lot":{"lot_number":"L-OLD"}}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, int32(0), products.products[id].Quantity)
+}
+
+func TestLotTracking_Rejected(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	lotted := createLotTrackedJuice(products)
+	plain := createJuice(products)
+	receiveLot(router, lotted, "5", "L1", time.Now().AddDate(0, 1, 0))
+
+	tests := []struct {
+		name     string
+		id       int32
+		path     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Receipt without lot", lotted, "receipts", `{"quantity":"1"}`, http.StatusBadRequest, "lot number is required"},
+		{"Adjustment without lot", lotted, "adjustments", `{"quantity":"-1"}`, http.StatusBadRequest, "lot number is required"},
+		{"Lot on plain product", plain, "receipts", `{"quantity":"1","lot":{"lot_number":"L1"}}`, http.StatusBadRequest, "not lot-tracked"},
+		{"Different expiry", lotted, "receipts", `{"quantity":"1","lot":{"lot_number":"L1","expires_at":"2020-01-01"}}`, http.StatusBadRequest, "different dates"},
+		{"Bad date", lotted, "receipts", `{"quantity":"1","lot":{"lot_number":"L2","expires_at":"01.01.2030"}}`, http.StatusBadRequest, "datetime"},
+		{"Unknown lot", lotted, "issues", `{"quantity":"1","lot":{"lot_number":"NOPE"}}`, http.StatusNotFound, "lot not found"},
+		{"Lot too small", lotted, "issues", `{"quantity":"6","lot":{"lot_number":"L1"}}`, http.StatusBadRequest, "insufficient stock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/products/"+itoa(tt.id)+"/stock/"+tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+}
+
+func TestListExpiringLots(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	id := createLotTrackedJuice(products)
+	receiveLot(router, id, "5", "L-SOON", time.Now().AddDate(0, 0, 10))
+	receiveLot(router, id, "5", "L-LATE", time.Now().AddDate(0, 2, 0))
+
+	resp := performRequest(router, "GET", "/lots/expiring?within=30d", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "L-SOON")
+	assert.NotContains(t, resp.Body.String(), "L-LATE")
+
+	resp = performRequest(router, "GET", "/lots/expiring?within=9w", nil)
+	assert.Contains(t, resp.Body.String(), "L-LATE")
+
+	resp = performRequest(router, "GET", "/lots/expiring?within=soon", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestSerialTracking(t *testing.T) {
+	router, products, stockRepo := setupStockHandlerWithMock()
+	id, _ := products.Create(context.TODO(), product.Product{
+		Name: "Laptop", Price: money.MustParse("900", "USD"), Serialized: true,
+	})
+	path := "/products/" + itoa(id) + "/stock/"
+
+	resp := performRequest(router, "POST", path+"receipts", []byte(`{"quantity":"3","serials":["SN-1","SN-2","SN-3"]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"serials":["SN-1","SN-2","SN-3"]`)
+
+	resp = performRequest(router, "POST", path+"issues", []byte(`{"quantity":"1","reference":"SO-7","serials":["SN-2"]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "POST", path+"receipts", []byte(`{"quantity":"1","reference":"return","serials":["SN-2"]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, stock.SerialReturned, stockRepo.serials[1].Status)
+
+	resp = performRequest(router, "POST", path+"adjustments", []byte(`{"quantity":"-1","reference":"damaged","serials":["SN-3"]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, stock.SerialWrittenOff, stockRepo.serials[2].Status)
+	assert.Equal(t, int32(2), products.products[id].Quantity)
+
+	resp = performRequest(router, "GET", "/serials/SN-2", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"returned"`)
+	assert.Contains(t, resp.Body.String(), `"reference":"SO-7"`)
+	assert.Contains(t, resp.Body.String(), `"reference":"return"`)
+	assert.NotContains(t, resp.Body.String(), "damaged")
+
+	resp = performRequest(router, "GET", "/serials/SN-404", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestSerialTracking_Rejected(t *testing.T) {
+	router, products, _ := setupStockHandlerWithMock()
+	serialized, _ := products.Create(context.TODO(), product.Product{
+		Name: "Laptop", Price: money.MustParse("900", "USD"), Serialized: true,
+	})
+	other, _ := products.Create(context.TODO(), product.Product{
+		Name: "Phone", Price: money.MustParse("500", "USD"), Serialized: true,
+	})
+	plain := createJuice(products)
+	performRequest(router, "POST", "/products/"+itoa(serialized)+"/stock/receipts", []byte(`{"quantity":"1","serials":["SN-1"]}`))
+	performRequest(router, "POST", "/products/"+itoa(other)+"/stock/receipts", []byte(`{"quantity":"1","serials":["PH-1"]}`))
+
+	tests := []struct {
+		name     string
+		id       int32
+		path     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Missing serials", serialized, "receipts", `{"quantity":"2"}`, http.StatusBadRequest, "must match the quantity"},
+		{"Too few serials", serialized, "receipts", `{"quantity":"2","serials":["SN-9"]}`, http.StatusBadRequest, "1 listed for a quantity of 2"},
+		{"Duplicate serial", serialized, "receipts", `{"quantity":"2","serials":["SN-9","SN-9"]}`, http.StatusBadRequest, "listed more than once"},
+		{"Already in stock", serialized, "receipts", `{"quantity":"1","serials":["SN-1"]}`, http.StatusBadRequest, "SN-1 is in_stock"},
+		{"Other product", serialized, "issues", `{"quantity":"1","serials":["PH-1"]}`, http.StatusBadRequest, "belongs to another product"},
+		{"Unknown serial", serialized, "issues", `{"quantity":"1","serials":["SN-404"]}`, http.StatusNotFound, "serial number not found"},
+		{"Empty serial", serialized, "receipts", `{"quantity":"1","serials":[""]}`, http.StatusBadRequest, "required"},
+		{"Serials on plain product", plain, "receipts", `{"quantity":"1","serials":["X-1"]}`, http.StatusBadRequest, "not serialized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/products/"+itoa(tt.id)+"/stock/"+tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+
+	performRequest(router, "POST", "/products/"+itoa(serialized)+"/stock/issues", []byte(`{"quantity":"1","serials":["SN-1"]}`))
+	resp := performRequest(router, "POST", "/products/"+itoa(serialized)+"/stock/issues", []byte(`{"quantity":"1","serials":["SN-1"]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "SN-1 is shipped")
+}
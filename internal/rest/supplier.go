@@ -0,0 +1,307 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierRequest struct {
+	Name         string `json:"name" binding:"required,min=2,max=255"`
+	ContactName  string `json:"contact_name" binding:"max=255"`
+	Email        string `json:"email" binding:"omitempty,email,max=255"`
+	Phone        string `json:"phone" binding:"max=50"`
+	Address      string `json:"address" binding:"max=1000"`
+	LeadTimeDays int32  `json:"lead_time_days" binding:"gte=0"`
+	PaymentTerms string `json:"payment_terms" binding:"max=100" example:"NET30"`
+}
+
+func (r SupplierRequest) toSupplier(id int32) supplier.Supplier {
+	return supplier.Supplier{
+		ID:           id,
+		Name:         r.Name,
+		ContactName:  r.ContactName,
+		Email:        r.Email,
+		Phone:        r.Phone,
+		Address:      r.Address,
+		LeadTimeDays: r.LeadTimeDays,
+		PaymentTerms: r.PaymentTerms,
+	}
+}
+
+type ProductSupplierRequest struct {
+	SupplierSKU string      `json:"supplier_sku" binding:"max=100"`
+	CostPrice   money.Money `json:"cost_price"`
+	MinOrderQty int32       `json:"min_order_quantity" binding:"gte=0" example:"10"`
+}
+
+// CreateSupplier godoc
+// @Summary Create a supplier
+// @Description Add a supplier with contact info, lead time and payment terms
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param supplier body SupplierRequest true "Supplier info"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created supplier"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /suppliers [post]
+func (h *HandlerConfig) CreateSupplier(c *gin.Context) {
+	const op = "rest.supplier.create"
+
+	var req SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	id, err := h.Dep.Supplier.Create(c.Request.Context(), req.toSupplier(0))
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating supplier: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// GetSupplier godoc
+// @Summary Get supplier by ID
+// @Tags suppliers
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} map[string]interface{} "Supplier data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Supplier not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /suppliers/{id} [get]
+func (h *HandlerConfig) GetSupplier(c *gin.Context) {
+	const op = "rest.supplier.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	s, err := h.Dep.Supplier.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get supplier: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": s})
+}
+
+// UpdateSupplier godoc
+// @Summary Update supplier by ID
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Param supplier body SupplierRequest true "Updated supplier info"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Supplier not found"
+// @Failure 500 {object} BaseResponse "Update failed"
+// @Router /suppliers/{id} [put]
+func (h *HandlerConfig) UpdateSupplier(c *gin.Context) {
+	const op = "rest.supplier.update"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Supplier.Update(c.Request.Context(), req.toSupplier(id)); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to update supplier: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// DeleteSupplier godoc
+// @Summary Delete supplier by ID
+// @Description Remove a supplier together with its product links
+// @Tags suppliers
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID or supplier has purchase orders"
+// @Failure 500 {object} BaseResponse "Delete failed"
+// @Router /suppliers/{id} [delete]
+func (h *HandlerConfig) DeleteSupplier(c *gin.Context) {
+	const op = "rest.supplier.delete"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Supplier.Delete(c.Request.Context(), id); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to delete supplier: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// ListSuppliers godoc
+// @Summary List suppliers
+// @Description Get all suppliers ordered by name
+// @Tags suppliers
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of suppliers"
+// @Failure 500 {object} BaseResponse "List retrieval failed"
+// @Router /suppliers [get]
+func (h *HandlerConfig) ListSuppliers(c *gin.Context) {
+	const op = "rest.supplier.list"
+
+	suppliers, err := h.Dep.Supplier.List(c.Request.Context())
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list suppliers: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to list suppliers", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suppliers})
+}
+
+// ListProductSuppliers godoc
+// @Summary List suppliers of a product
+// @Description Get the suppliers of a product, cheapest first and then by lead time.
+// @Description Costs in other currencies are compared in the product currency
+// @Tags suppliers
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} map[string]interface{} "List of product suppliers"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Product not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/suppliers [get]
+func (h *HandlerConfig) ListProductSuppliers(c *gin.Context) {
+	const op = "rest.supplier.listProductSuppliers"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	links, err := h.Dep.Supplier.ProductSuppliers(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list product suppliers: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": links})
+}
+
+// SetProductSupplier godoc
+// @Summary Link a supplier to a product
+// @Description Create or replace the supplier SKU, cost price and minimum order quantity of a product
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param supplierId path int true "Supplier ID"
+// @Param link body ProductSupplierRequest true "Supplier terms for the product"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Product or supplier not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /products/{id}/suppliers/{supplierId} [put]
+func (h *HandlerConfig) SetProductSupplier(c *gin.Context) {
+	const op = "rest.supplier.setProductSupplier"
+
+	productID, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+	supplierID, err := pathID(c, "supplierId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid supplier ID", ErrorCode: 400})
+		return
+	}
+
+	var req ProductSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	err = h.Dep.Supplier.SetProductSupplier(c.Request.Context(), supplier.ProductSupplier{
+		ProductID:   productID,
+		SupplierID:  supplierID,
+		SupplierSKU: req.SupplierSKU,
+		CostPrice:   req.CostPrice,
+		MinOrderQty: req.MinOrderQty,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to link supplier: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
+
+// DeleteProductSupplier godoc
+// @Summary Unlink a supplier from a product
+// @Tags suppliers
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param supplierId path int true "Supplier ID"
+// @Success 200 {object} BaseResponse "Success"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 500 {object} BaseResponse "Delete failed"
+// @Router /products/{id}/suppliers/{supplierId} [delete]
+func (h *HandlerConfig) DeleteProductSupplier(c *gin.Context) {
+	const op = "rest.supplier.deleteProductSupplier"
+
+	productID, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+	supplierID, err := pathID(c, "supplierId")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid supplier ID", ErrorCode: 400})
+		return
+	}
+
+	if err := h.Dep.Supplier.DeleteProductSupplier(c.Request.Context(), productID, supplierID); err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to unlink supplier: ", op), sl.Err(err))
+		c.JSON(http.StatusInternalServerError, BaseResponse{Error: "Failed to unlink supplier", ErrorCode: 500})
+		return
+	}
+
+	c.JSON(http.StatusOK, BaseResponse{Success: true})
+}
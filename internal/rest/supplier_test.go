@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSupplierRepo struct {
+	suppliers map[int32]supplier.Supplier
+	links     map[[2]int32]supplier.ProductSupplier
+	nextID    int32
+	// productQueries counts the calls to ListProductSuppliers.
+	productQueries int
+}
+
+func (m *mockSupplierRepo) Create(ctx context.Context, s supplier.Supplier) (int32, error) {
+	m.nextID++
+	s.ID = m.nextID
+	m.suppliers[s.ID] = s
+	return s.ID, nil
+}
+
+func (m *mockSupplierRepo) GetByID(ctx context.Context, id int32) (supplier.Supplier, error) {
+	s, ok := m.suppliers[id]
+	if !ok {
+		return supplier.Supplier{}, supplier.ErrNotFound
+	}
+	return s, nil
+}
+
+func (m *mockSupplierRepo) Update(ctx context.Context, s supplier.Supplier) error {
+	if _, ok := m.suppliers[s.ID]; !ok {
+		return supplier.ErrNotFound
+	}
+	m.suppliers[s.ID] = s
+	return nil
+}
+
+func (m *mockSupplierRepo) Delete(ctx context.Context, id int32) error {
+	delete(m.suppliers, id)
+	return nil
+}
+
+func (m *mockSupplierRepo) List(ctx context.Context) ([]supplier.Supplier, error) {
+	var list []supplier.Supplier
+	for _, s := range m.suppliers {
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func (m *mockSupplierRepo) SetProductSupplier(ctx context.Context, ps supplier.ProductSupplier) error {
+	m.links[[2]int32{ps.ProductID, ps.SupplierID}] = ps
+	return nil
+}
+
+func (m *mockSupplierRepo) DeleteProductSupplier(ctx context.Context, productID, supplierID int32) error {
+	delete(m.links, [2]int32{productID, supplierID})
+	return nil
+}
+
+func (m *mockSupplierRepo) ListProductSuppliers(ctx context.Context, productID int32) ([]supplier.ProductSupplier, error) {
+	m.productQueries++
+	var list []supplier.ProductSupplier
+	for _, ps := range m.links {
+		if ps.ProductID == productID {
+			s := m.suppliers[ps.SupplierID]
+			ps.SupplierName = s.Name
+			ps.LeadTimeDays = s.LeadTimeDays
+			list = append(list, ps)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockSupplierRepo) ListAllProductSuppliers(ctx context.Context) ([]supplier.ProductSupplier, error) {
+	var list []supplier.ProductSupplier
+	for _, ps := range m.links {
+		s := m.suppliers[ps.SupplierID]
+		ps.SupplierName = s.Name
+		ps.LeadTimeDays = s.LeadTimeDays
+		list = append(list, ps)
+	}
+	slices.SortFunc(list, func(a, b supplier.ProductSupplier) int {
+		if c := cmp.Compare(a.ProductID, b.ProductID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SupplierID, b.SupplierID)
+	})
+	return list, nil
+}
+
+func setupSupplierHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockPricingRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	prices := &mockPricingRepo{
+		lists:    make(map[int32]pricing.PriceList),
+		items:    make(map[[2]int32]pricing.Item),
+		products: products,
+	}
+	suppliers := &mockSupplierRepo{
+		suppliers: make(map[int32]supplier.Supplier),
+		links:     make(map[[2]int32]supplier.ProductSupplier),
+	}
+	pricingUC := usecase.NewPricingUseCase(prices, products, money.RoundHalfUp)
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Supplier: usecase.NewSupplierUseCase(suppliers, products, pricingUC),
+			Sl:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/suppliers", h.CreateSupplier)
+	router.GET("/suppliers/:id", h.GetSupplier)
+	router.PUT("/suppliers/:id", h.UpdateSupplier)
+	router.DELETE("/suppliers/:id", h.DeleteSupplier)
+	router.GET("/products/:id/suppliers", h.ListProductSuppliers)
+	router.PUT("/products/:id/suppliers/:supplierId", h.SetProductSupplier)
+	return router, products, prices
+}
+
+func createSupplier(t *testing.T, router *gin.Engine, name string, leadTime int) string {
+	body := `{"name":"` + name + `","email":"sales@example.com","lead_time_days":` + itoa(int32(leadTime)) + `,"payment_terms":"NET30"}`
+	resp := performRequest(router, "POST", "/suppliers", []byte(body))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	id := strings.TrimSuffix(strings.TrimPrefix(resp.Body.String(), `{"id":`), "}")
+	return id
+}
+
+func TestSupplierCRUD(t *testing.T) {
+	router, _, _ := setupSupplierHandlerWithMock()
+	id := createSupplier(t, router, "Acme", 7)
+
+	resp := performRequest(router, "GET", "/suppliers/"+id, nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"payment_terms":"NET30"`)
+
+	resp = performRequest(router, "PUT", "/suppliers/"+id, []byte(`{"name":"Acme Ltd","lead_time_days":5}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "PUT", "/suppliers/99", []byte(`{"name":"Nobody"}`))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = performRequest(router, "POST", "/suppliers", []byte(`{"name":"Bad","email":"not-an-email"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(router, "POST", "/suppliers", []byte(`{"name":"Bad","lead_time_days":-1}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(router, "DELETE", "/suppliers/"+id, nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp = performRequest(router, "GET", "/suppliers/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestProductSuppliers_SortedByCostAndLeadTime(t *testing.T) {
+	router, products, prices := setupSupplierHandlerWithMock()
+	prices.rates = []pricing.ExchangeRate{{Base: "EUR", Quote: "USD", Rate: "1.10", ValidFrom: time.Now().Add(-time.Hour)}}
+	productID, _ := products.Create(context.TODO(), product.Product{Name: "Drill", Price: money.MustParse("100", "USD")})
+	path := "/products/" + itoa(productID) + "/suppliers/"
+
+	slow := createSupplier(t, router, "Slow", 30)
+	fast := createSupplier(t, router, "Fast", 3)
+	euro := createSupplier(t, router, "Euro", 10)
+	pound := createSupplier(t, router, "Pound", 1)
+
+	for id, body := range map[string]string{
+		slow:  `{"supplier_sku":"S-1","cost_price":{"amount":"50","currency":"USD"},"min_order_quantity":10}`,
+		fast:  `{"supplier_sku":"F-1","cost_price":{"amount":"50","currency":"USD"}}`,
+		euro:  `{"supplier_sku":"E-1","cost_price":{"amount":"40","currency":"EUR"}}`,
+		pound: `{"supplier_sku":"P-1","cost_price":{"amount":"10","currency":"GBP"}}`,
+	} {
+		resp := performRequest(router, "PUT", path+id, []byte(body))
+		assert.Equal(t, http.StatusOK, resp.Code)
+	}
+
+	resp := performRequest(router, "GET", "/products/"+itoa(productID)+"/suppliers", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	body := resp.Body.String()
+	euroAt := strings.Index(body, `"supplier_sku":"E-1"`)
+	fastAt := strings.Index(body, `"supplier_sku":"F-1"`)
+	slowAt := strings.Index(body, `"supplier_sku":"S-1"`)
+	poundAt := strings.Index(body, `"supplier_sku":"P-1"`)
+	assert.True(t, euroAt < fastAt && fastAt < slowAt && slowAt < poundAt, body)
+	assert.Contains(t, body, `"comparable_cost":{"amount":"44.00","currency":"USD"}`)
+	assert.Contains(t, body, `"min_order_quantity":1,`)
+}
+
+func TestSetProductSupplier_Rejected(t *testing.T) {
+	router, products, _ := setupSupplierHandlerWithMock()
+	productID, _ := products.Create(context.TODO(), product.Product{Name: "Drill", Price: money.MustParse("100", "USD")})
+	supplierID := createSupplier(t, router, "Acme", 7)
+	path := "/products/" + itoa(productID) + "/suppliers/"
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		code     int
+		expected string
+	}{
+		{"Missing cost", path + supplierID, `{"supplier_sku":"A-1"}`, http.StatusBadRequest, "cost price must be greater than zero"},
+		{"Unknown currency", path + supplierID, `{"cost_price":{"amount":"10","currency":"XXX"}}`, http.StatusBadRequest, "unknown currency"},
+		{"Negative MOQ", path + supplierID, `{"cost_price":{"amount":"10","currency":"USD"},"min_order_quantity":-5}`, http.StatusBadRequest, "MinOrderQty"},
+		{"Unknown supplier", path + "99", `{"cost_price":{"amount":"10","currency":"USD"}}`, http.StatusNotFound, "supplier not found"},
+		{"Unknown product", "/products/99/suppliers/" + supplierID, `{"cost_price":{"amount":"10","currency":"USD"}}`, http.StatusNotFound, "product not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "PUT", tt.path, []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/tracing"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Juice", Price: money.MustParse("1.20", "USD")})
+
+	gin.SetMode(gin.TestMode)
+	router := NewHandler(HandlerConfig{Dep: &scope.Dependencies{
+		Product: usecase.NewProductUseCase(products),
+		Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}})
+
+	req := httptest.NewRequest("GET", "/products/"+itoa(id), nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		uc, server := spans[0], spans[1]
+		assert.Equal(t, "ProductUseCase.GetByID", uc.Name)
+		assert.Equal(t, "/products/:id", server.Name)
+
+		// The request joins the trace of the caller and the use case span is its child.
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+		assert.True(t, server.Parent.IsRemote())
+		assert.Equal(t, server.SpanContext.SpanID(), uc.Parent.SpanID())
+	}
+}
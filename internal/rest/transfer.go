@@ -0,0 +1,310 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type TransferRequest struct {
+	FromWarehouseID int32                 `json:"from_warehouse_id" binding:"required"`
+	ToWarehouseID   int32                 `json:"to_warehouse_id" binding:"required"`
+	Reference       string                `json:"reference" binding:"max=255"`
+	Lines           []TransferLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type TransferLineRequest struct {
+	ProductID int32 `json:"product_id" binding:"required"`
+	Quantity  int32 `json:"quantity" binding:"required,gt=0"`
+}
+
+type ShipTransferRequest struct {
+	Carrier        string `json:"carrier" binding:"max=100" example:"DHL"`
+	TrackingNumber string `json:"tracking_number" binding:"max=100" example:"JD014600003828"`
+}
+
+// ReceiveTransferRequest lists the quantities counted at the destination; lines
+// left out were not received at all.
+type ReceiveTransferRequest struct {
+	Lines []ReceiveTransferLineRequest `json:"lines" binding:"omitempty,dive"`
+}
+
+// ReceiveTransferLineRequest needs a reason when the quantity differs from the
+// shipped one.
+type ReceiveTransferLineRequest struct {
+	LineID   int32  `json:"line_id" binding:"required"`
+	Quantity int32  `json:"quantity" binding:"gte=0"`
+	Reason   string `json:"reason" binding:"max=1000" example:"Two cases damaged in transit"`
+}
+
+// CreateTransfer godoc
+// @Summary Create a transfer order
+// @Description Create a draft transfer of stock from one warehouse to another. Lot-tracked and serialized products cannot be transferred
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param transfer body TransferRequest true "Warehouses and lines"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created transfer"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Warehouse or product not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers [post]
+func (h *HandlerConfig) CreateTransfer(c *gin.Context) {
+	const op = "rest.transfer.create"
+
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	t := transfer.Transfer{
+		FromWarehouseID: req.FromWarehouseID,
+		ToWarehouseID:   req.ToWarehouseID,
+		Reference:       req.Reference,
+	}
+	for _, line := range req.Lines {
+		t.Lines = append(t.Lines, transfer.Line{ProductID: line.ProductID, Quantity: line.Quantity})
+	}
+
+	id, err := h.Dep.Transfer.Create(c.Request.Context(), t)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating transfer: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListTransfers godoc
+// @Summary List transfer orders
+// @Description Get transfers without lines, newest first
+// @Tags transfers
+// @Produce json
+// @Param status query string false "Status" Enums(draft, in_transit, received, cancelled)
+// @Param warehouse_id query int false "Source or destination warehouse ID"
+// @Success 200 {object} map[string]interface{} "List of transfers"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers [get]
+func (h *HandlerConfig) ListTransfers(c *gin.Context) {
+	const op = "rest.transfer.list"
+
+	warehouseID, err := queryID(c, "warehouse_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid warehouse ID", ErrorCode: 400})
+		return
+	}
+
+	transfers, err := h.Dep.Transfer.List(c.Request.Context(), transfer.Status(c.Query("status")), warehouseID)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list transfers: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": transfers})
+}
+
+// ListOpenTransfers godoc
+// @Summary List open transfer orders
+// @Description Get draft and in-transit transfers with their lines, oldest first
+// @Tags transfers
+// @Produce json
+// @Param warehouse_id query int false "Source or destination warehouse ID"
+// @Success 200 {object} map[string]interface{} "List of open transfers"
+// @Failure 400 {object} BaseResponse "Invalid filter"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers/open [get]
+func (h *HandlerConfig) ListOpenTransfers(c *gin.Context) {
+	const op = "rest.transfer.listOpen"
+
+	warehouseID, err := queryID(c, "warehouse_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid warehouse ID", ErrorCode: 400})
+		return
+	}
+
+	transfers, err := h.Dep.Transfer.Open(c.Request.Context(), warehouseID)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list open transfers: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": transfers})
+}
+
+// GetTransfer godoc
+// @Summary Get transfer order by ID
+// @Description Retrieve a transfer with its lines and discrepancies
+// @Tags transfers
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} map[string]interface{} "Transfer data"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Transfer not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers/{id} [get]
+func (h *HandlerConfig) GetTransfer(c *gin.Context) {
+	const op = "rest.transfer.get"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	t, err := h.Dep.Transfer.Get(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get transfer: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": t})
+}
+
+// ShipTransfer godoc
+// @Summary Ship a transfer order
+// @Description Issue every line from the source warehouse; the goods are in transit until received
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param shipment body ShipTransferRequest false "Carrier and tracking number"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Shipped transfer"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Transfer not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers/{id}/ship [post]
+func (h *HandlerConfig) ShipTransfer(c *gin.Context) {
+	const op = "rest.transfer.ship"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req ShipTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	t, err := h.Dep.Transfer.Ship(c.Request.Context(), id, transfer.Shipment{
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to ship transfer: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": t})
+}
+
+// ReceiveTransfer godoc
+// @Summary Receive a transfer order
+// @Description Book the counted quantities into the destination warehouse and complete the transfer.
+// @Description A line received short or in excess records the difference as its discrepancy and needs a reason; the discrepancy is posted as a stock adjustment at the destination
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param receipt body ReceiveTransferRequest true "Received quantities"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Received transfer"
+// @Failure 400 {object} BaseResponse "Invalid input or business rule failed"
+// @Failure 404 {object} BaseResponse "Transfer not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers/{id}/receipts [post]
+func (h *HandlerConfig) ReceiveTransfer(c *gin.Context) {
+	const op = "rest.transfer.receive"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	var req ReceiveTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	var receipt transfer.Receipt
+	for _, line := range req.Lines {
+		receipt.Lines = append(receipt.Lines, transfer.ReceivedLine{
+			LineID:   line.LineID,
+			Quantity: line.Quantity,
+			Reason:   line.Reason,
+		})
+	}
+
+	t, err := h.Dep.Transfer.Receive(c.Request.Context(), id, receipt)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to receive transfer: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": t})
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a transfer order
+// @Description Cancel a transfer that has not been shipped
+// @Tags transfers
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]interface{} "Cancelled transfer"
+// @Failure 400 {object} BaseResponse "Transfer is not a draft"
+// @Failure 404 {object} BaseResponse "Transfer not found"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /transfers/{id}/cancel [post]
+func (h *HandlerConfig) CancelTransfer(c *gin.Context) {
+	const op = "rest.transfer.cancel"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	t, err := h.Dep.Transfer.Cancel(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to cancel transfer: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": t})
+}
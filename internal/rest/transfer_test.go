@@ -0,0 +1,299 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWarehouseRepo struct {
+	warehouses map[int32]warehouse.Warehouse
+	stock      *mockStockRepo
+	transfers  *mockTransferRepo
+}
+
+func (m *mockWarehouseRepo) Create(ctx context.Context, w warehouse.Warehouse) (int32, error) {
+	for _, other := range m.warehouses {
+		if other.Code == w.Code {
+			return 0, warehouse.ErrCodeTaken
+		}
+	}
+	w.ID = int32(len(m.warehouses) + 1)
+	w.CreatedAt = time.Now()
+	m.warehouses[w.ID] = w
+	return w.ID, nil
+}
+
+func (m *mockWarehouseRepo) Get(ctx context.Context, id int32) (warehouse.Warehouse, error) {
+	w, ok := m.warehouses[id]
+	if !ok {
+		return warehouse.Warehouse{}, warehouse.ErrNotFound
+	}
+	return w, nil
+}
+
+func (m *mockWarehouseRepo) List(ctx context.Context) ([]warehouse.Warehouse, error) {
+	var list []warehouse.Warehouse
+	for id := int32(1); id <= int32(len(m.warehouses)); id++ {
+		list = append(list, m.warehouses[id])
+	}
+	return list, nil
+}
+
+func (m *mockWarehouseRepo) Stock(ctx context.Context, id int32) ([]warehouse.Stock, error) {
+	var list []warehouse.Stock
+	for _, p := range m.stock.products.products {
+		s := warehouse.Stock{ProductID: p.ID, ProductName: p.Name, Quantity: m.stock.siteQuantity(id, p.ID)}
+		for _, t := range m.transfers.transfers {
+			if t.ToWarehouseID != id || t.Status != transfer.StatusInTransit {
+				continue
+			}
+			for _, line := range t.Lines {
+				if line.ProductID == p.ID {
+					s.InboundQuantity += line.ShippedQuantity
+				}
+			}
+		}
+		if s.Quantity > 0 || s.InboundQuantity > 0 {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+type mockTransferRepo struct {
+	transfers map[int32]transfer.Transfer
+	stock     *mockStockRepo
+	nextID    int32
+	nextLine  int32
+}
+
+func (m *mockTransferRepo) Create(ctx context.Context, t transfer.Transfer) (int32, error) {
+	m.nextID++
+	t.ID = m.nextID
+	t.Status = transfer.StatusDraft
+	t.CreatedAt = time.Now()
+	for i := range t.Lines {
+		m.nextLine++
+		t.Lines[i].ID = m.nextLine
+	}
+	m.transfers[t.ID] = t
+	return t.ID, nil
+}
+
+func (m *mockTransferRepo) Get(ctx context.Context, id int32) (transfer.Transfer, error) {
+	t, ok := m.transfers[id]
+	if !ok {
+		return transfer.Transfer{}, transfer.ErrNotFound
+	}
+	t.Lines = slices.Clone(t.Lines)
+	return t, nil
+}
+
+func (m *mockTransferRepo) List(ctx context.Context, status transfer.Status, warehouseID *int32) ([]transfer.Transfer, error) {
+	var list []transfer.Transfer
+	for _, t := range m.transfers {
+		if (status == "" || t.Status == status) && matchesWarehouse(t, warehouseID) {
+			t.Lines = nil
+			list = append(list, t)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockTransferRepo) ListOpen(ctx context.Context, warehouseID *int32) ([]transfer.Transfer, error) {
+	var list []transfer.Transfer
+	for id := int32(1); id <= m.nextID; id++ {
+		if t, ok := m.transfers[id]; ok && t.Status.Open() && matchesWarehouse(t, warehouseID) {
+			list = append(list, t)
+		}
+	}
+	return list, nil
+}
+
+func matchesWarehouse(t transfer.Transfer, warehouseID *int32) bool {
+	return warehouseID == nil || t.FromWarehouseID == *warehouseID || t.ToWarehouseID == *warehouseID
+}
+
+func (m *mockTransferRepo) Update(ctx context.Context, id int32, fn func(t *transfer.Transfer) ([]stock.Movement, error)) (transfer.Transfer, error) {
+	t, err := m.Get(ctx, id)
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	movements, err := fn(&t)
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	for _, mv := range movements {
+		if _, err := m.stock.ApplyMovement(ctx, mv); err != nil {
+			return transfer.Transfer{}, err
+		}
+	}
+	m.transfers[id] = t
+	return t, nil
+}
+
+func setupTransferHandlerWithMock() (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+	transfers := &mockTransferRepo{transfers: make(map[int32]transfer.Transfer), stock: stockRepo}
+	warehouses := &mockWarehouseRepo{
+		warehouses: map[int32]warehouse.Warehouse{
+			defaultWarehouseID: {ID: defaultWarehouseID, Code: "MAIN", Name: "Main warehouse", Default: true},
+		},
+		stock:     stockRepo,
+		transfers: transfers,
+	}
+	stockUC := usecase.NewStockUseCase(stockRepo, products, stock.CostFIFO)
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock:     stockUC,
+			Warehouse: usecase.NewWarehouseUseCase(warehouses),
+			Transfer:  usecase.NewTransferUseCase(transfers, warehouses, products, stockUC),
+			Sl:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/products/:id/stock/receipts", h.ReceiveStock)
+	router.POST("/warehouses", h.CreateWarehouse)
+	router.GET("/warehouses", h.ListWarehouses)
+	router.GET("/warehouses/:id/stock", h.GetWarehouseStock)
+	router.POST("/transfers", h.CreateTransfer)
+	router.GET("/transfers", h.ListTransfers)
+	router.GET("/transfers/open", h.ListOpenTransfers)
+	router.GET("/transfers/:id", h.GetTransfer)
+	router.POST("/transfers/:id/ship", h.ShipTransfer)
+	router.POST("/transfers/:id/receipts", h.ReceiveTransfer)
+	router.POST("/transfers/:id/cancel", h.CancelTransfer)
+	return router, products, stockRepo
+}
+
+func TestTransferLifecycle(t *testing.T) {
+	router, products, stockRepo := setupTransferHandlerWithMock()
+	chairs, _ := products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 10})
+
+	resp := performRequest(router, "POST", "/warehouses", []byte(`{"code":"EAST","name":"East distribution center"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":2`)
+
+	resp = performRequest(router, "POST", "/transfers", []byte(`{"from_warehouse_id":1,"to_warehouse_id":2,"reference":"Restock east","lines":[{"product_id":`+itoa(chairs)+`,"quantity":6}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(router, "POST", "/transfers/1/ship", []byte(`{"carrier":"DHL","tracking_number":"JD01"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"in_transit"`)
+
+	// In transit the chairs are counted in neither warehouse.
+	assert.Equal(t, int32(4), products.products[chairs].Quantity)
+	assert.Equal(t, int32(4), stockRepo.siteQuantity(1, chairs))
+	assert.Equal(t, int32(0), stockRepo.siteQuantity(2, chairs))
+
+	resp = performRequest(router, "GET", "/warehouses/2/stock", nil)
+	assert.Contains(t, resp.Body.String(), `"quantity":0,"inbound_quantity":6`)
+
+	resp = performRequest(router, "GET", "/transfers/open?warehouse_id=2", nil)
+	assert.Contains(t, resp.Body.String(), `"shipped_quantity":6`)
+
+	resp = performRequest(router, "POST", "/transfers/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":5}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "line 1 shipped 6, received 5")
+
+	resp = performRequest(router, "POST", "/transfers/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":5,"reason":"One chair broken"}]}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"received"`)
+	assert.Contains(t, resp.Body.String(), `"received_quantity":5,"discrepancy":-1,"discrepancy_reason":"One chair broken"`)
+
+	assert.Equal(t, int32(9), products.products[chairs].Quantity)
+	assert.Equal(t, int32(5), stockRepo.siteQuantity(2, chairs))
+
+	var types []stock.MovementType
+	for _, mv := range stockRepo.movements {
+		if mv.Reference == "TR-1 Restock east" {
+			types = append(types, mv.Type)
+		}
+	}
+	assert.Equal(t, []stock.MovementType{stock.MovementTransferOut, stock.MovementTransferIn, stock.MovementAdjustment}, types)
+
+	resp = performRequest(router, "GET", "/transfers/open", nil)
+	assert.Equal(t, `{"data":null}`, resp.Body.String())
+
+	resp = performRequest(router, "POST", "/transfers/1/receipts", []byte(`{"lines":[{"line_id":1,"quantity":6}]}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "transfer is received")
+}
+
+func TestShipTransfer_InsufficientStockAtSource(t *testing.T) {
+	router, products, stockRepo := setupTransferHandlerWithMock()
+	chairs, _ := products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 10})
+	performRequest(router, "POST", "/warehouses", []byte(`{"code":"EAST","name":"East"}`))
+
+	resp := performRequest(router, "POST", "/products/"+itoa(chairs)+"/stock/receipts", []byte(`{"quantity":"2","warehouse_id":2}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"warehouse_id":2`)
+
+	performRequest(router, "POST", "/transfers", []byte(`{"from_warehouse_id":2,"to_warehouse_id":1,"lines":[{"product_id":`+itoa(chairs)+`,"quantity":3}]}`))
+	resp = performRequest(router, "POST", "/transfers/1/ship", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "insufficient stock")
+
+	assert.Equal(t, int32(12), products.products[chairs].Quantity)
+	assert.Equal(t, int32(2), stockRepo.siteQuantity(2, chairs))
+
+	resp = performRequest(router, "POST", "/transfers/1/cancel", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"cancelled"`)
+}
+
+func TestCreateTransfer_Rejected(t *testing.T) {
+	router, products, _ := setupTransferHandlerWithMock()
+	chairs, _ := products.Create(context.TODO(), product.Product{Name: "Chair", Price: money.MustParse("25", "USD"), Quantity: 10})
+	laptops, _ := products.Create(context.TODO(), product.Product{Name: "Laptop", Price: money.MustParse("900", "USD"), Serialized: true})
+	performRequest(router, "POST", "/warehouses", []byte(`{"code":"EAST","name":"East"}`))
+
+	line := func(productID int32) string {
+		return `{"product_id":` + itoa(productID) + `,"quantity":1}`
+	}
+	tests := []struct {
+		name string
+		body string
+		code int
+		want string
+	}{
+		{"same warehouse", `{"from_warehouse_id":1,"to_warehouse_id":1,"lines":[` + line(chairs) + `]}`, http.StatusBadRequest, "another warehouse"},
+		{"unknown warehouse", `{"from_warehouse_id":1,"to_warehouse_id":9,"lines":[` + line(chairs) + `]}`, http.StatusNotFound, "warehouse not found: 9"},
+		{"serialized product", `{"from_warehouse_id":1,"to_warehouse_id":2,"lines":[` + line(laptops) + `]}`, http.StatusBadRequest, "cannot be transferred"},
+		{"duplicate product", `{"from_warehouse_id":1,"to_warehouse_id":2,"lines":[` + line(chairs) + `,` + line(chairs) + `]}`, http.StatusBadRequest, "more than once"},
+		{"no lines", `{"from_warehouse_id":1,"to_warehouse_id":2,"lines":[]}`, http.StatusBadRequest, "Lines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/transfers", []byte(tt.body))
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.want)
+		})
+	}
+
+	resp := performRequest(router, "POST", "/warehouses", []byte(`{"code":"EAST","name":"Another"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "already in use")
+
+	resp = performRequest(router, "GET", "/transfers?status=lost", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
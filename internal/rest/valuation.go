@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+// ValuationReport godoc
+// @Summary Inventory valuation report
+// @Description Value the stock of every product as of a date with the configured cost method (fifo, lifo or average),
+// @Description with totals per currency. A date without a time means the end of that day
+// @Tags reports
+// @Produce json
+// @Param as_of query string false "Valuation date (date or RFC 3339); now if omitted"
+// @Success 200 {object} map[string]interface{} "Stock value per product and totals"
+// @Failure 400 {object} BaseResponse "Invalid date"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /reports/valuation [get]
+func (h *HandlerConfig) ValuationReport(c *gin.Context) {
+	const op = "rest.valuation.report"
+
+	asOf := time.Now()
+	t, err := queryTime(c, "as_of")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid 'as_of' time", ErrorCode: 400})
+		return
+	}
+	if t != nil {
+		asOf = *t
+		if len(c.Query("as_of")) == len(time.DateOnly) {
+			asOf = asOf.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+	}
+
+	report, err := h.Dep.Valuation.Report(c.Request.Context(), asOf)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to build valuation report: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/valuation"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockValuationRepo struct {
+	stock *mockStockRepo
+}
+
+func (m *mockValuationRepo) ProductValues(ctx context.Context, asOf time.Time) ([]valuation.ProductValue, error) {
+	byProduct := make(map[int32]*valuation.ProductValue)
+	for _, e := range m.stock.costs {
+		if e.createdAt.After(asOf) {
+			continue
+		}
+		v, ok := byProduct[e.productID]
+		if !ok {
+			v = &valuation.ProductValue{
+				ProductID:   e.productID,
+				ProductName: m.stock.products.products[e.productID].Name,
+				Value:       money.Money{Currency: e.value.Currency},
+			}
+			byProduct[e.productID] = v
+		}
+		v.Quantity += e.quantity
+		v.Value.Amount += e.value.Amount
+	}
+
+	var list []valuation.ProductValue
+	for _, v := range byProduct {
+		if v.Quantity != 0 || v.Value.Amount != 0 {
+			list = append(list, *v)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ProductName < list[j].ProductName })
+	return list, nil
+}
+
+func setupValuationHandlerWithMock(method stock.CostMethod) (*gin.Engine, *mockProductUseCase, *mockStockRepo) {
+	products := &mockProductUseCase{products: make(map[int32]product.Product)}
+	stockRepo := &mockStockRepo{units: make(map[int32][]stock.Unit), products: products}
+
+	h := &HandlerConfig{
+		Dep: &scope.Dependencies{
+			Stock:     usecase.NewStockUseCase(stockRepo, products, method),
+			Valuation: usecase.NewValuationUseCase(&mockValuationRepo{stock: stockRepo}, method),
+			Sl:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.POST("/products/:id/stock/receipts", h.ReceiveStock)
+	router.POST("/products/:id/stock/issues", h.IssueStock)
+	router.POST("/products/:id/stock/adjustments", h.AdjustStock)
+	router.GET("/reports/valuation", h.ValuationReport)
+	return router, products, stockRepo
+}
+
+// receiveTwoLayers receives 10 widgets at 1.00 and 10 at 1.30.
+func receiveTwoLayers(router *gin.Engine, products *mockProductUseCase) int32 {
+	id, _ := products.Create(context.TODO(), product.Product{Name: "Widget", Price: money.MustParse("2.50", "USD")})
+	performRequest(router, "POST", "/products/"+itoa(id)+"/stock/receipts", []byte(`{"quantity":"10","unit_cost":{"amount":"1.00","currency":"USD"}}`))
+	performRequest(router, "POST", "/products/"+itoa(id)+"/stock/receipts", []byte(`{"quantity":"10","unit_cost":{"amount":"1.30","currency":"USD"}}`))
+	return id
+}
+
+func TestValuation_CostMethods(t *testing.T) {
+	tests := []struct {
+		method    stock.CostMethod
+		issueCost string
+		remaining string
+		unitCost  string
+	}{
+		{stock.CostFIFO, "-16.50", "6.50", "1.30"},
+		{stock.CostLIFO, "-18.00", "5.00", "1.00"},
+		{stock.CostAverage, "-17.25", "5.75", "1.15"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			router, products, _ := setupValuationHandlerWithMock(tt.method)
+			id := receiveTwoLayers(router, products)
+
+			resp := performRequest(router, "POST", "/products/"+itoa(id)+"/stock/issues", []byte(`{"quantity":"15"}`))
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), `"cost":{"amount":"`+tt.issueCost+`","currency":"USD"}`)
+
+			resp = performRequest(router, "GET", "/reports/valuation", nil)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Contains(t, resp.Body.String(), `"method":"`+string(tt.method)+`"`)
+			assert.Contains(t, resp.Body.String(), `"totals":[{"amount":"`+tt.remaining+`","currency":"USD"}]`)
+			assert.Contains(t, resp.Body.String(), `"product_name":"Widget","quantity":5,"value":{"amount":"`+tt.remaining+`","currency":"USD"},"unit_cost":{"amount":"`+tt.unitCost+`","currency":"USD"}`)
+		})
+	}
+}
+
+func TestValuation_AsOf(t *testing.T) {
+	router, products, stockRepo := setupValuationHandlerWithMock(stock.CostFIFO)
+	id := receiveTwoLayers(router, products)
+	stockRepo.costs[0].createdAt = time.Now().AddDate(0, 0, -10)
+	stockRepo.costs[1].createdAt = time.Now().AddDate(0, 0, -3)
+
+	// Stock received without a cost is valued at the average cost.
+	resp := performRequest(router, "POST", "/products/"+itoa(id)+"/stock/adjustments", []byte(`{"quantity":"4"}`))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"cost":{"amount":"4.60","currency":"USD"}`)
+
+	resp = performRequest(router, "GET", "/reports/valuation?as_of="+time.Now().AddDate(0, 0, -5).Format(time.DateOnly), nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"quantity":10,"value":{"amount":"10.00","currency":"USD"}`)
+
+	resp = performRequest(router, "GET", "/reports/valuation?as_of="+time.Now().AddDate(0, 0, -20).Format(time.RFC3339), nil)
+	assert.Contains(t, resp.Body.String(), `"totals":[],"products":[]`)
+
+	resp = performRequest(router, "GET", "/reports/valuation", nil)
+	assert.Contains(t, resp.Body.String(), `"quantity":24,"value":{"amount":"27.60","currency":"USD"}`)
+
+	resp = performRequest(router, "GET", "/reports/valuation?as_of=yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestValuation_UnitCostRejected(t *testing.T) {
+	router, products, _ := setupValuationHandlerWithMock(stock.CostFIFO)
+	id := receiveTwoLayers(router, products)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{"outgoing stock", "/stock/issues", `{"quantity":"1","unit_cost":{"amount":"1.00","currency":"USD"}}`, "incoming stock"},
+		{"negative adjustment", "/stock/adjustments", `{"quantity":"-1","unit_cost":{"amount":"1.00","currency":"USD"}}`, "incoming stock"},
+		{"other currency", "/stock/receipts", `{"quantity":"1","unit_cost":{"amount":"1.00","currency":"EUR"}}`, "product currency USD"},
+		{"negative cost", "/stock/receipts", `{"quantity":"1","unit_cost":{"amount":"-1.00","currency":"USD"}}`, "must not be negative"},
+		{"unknown currency", "/stock/receipts", `{"quantity":"1","unit_cost":{"amount":"1.00","currency":"XXX"}}`, "unknown currency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := performRequest(router, "POST", "/products/"+itoa(id)+tt.path, []byte(tt.body))
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+			assert.Contains(t, resp.Body.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseRequest struct {
+	Code string `json:"code" binding:"required,max=20" example:"EAST"`
+	Name string `json:"name" binding:"required,max=255" example:"East distribution center"`
+}
+
+// CreateWarehouse godoc
+// @Summary Create a warehouse
+// @Description Add a site that holds stock; the code must be unique
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param warehouse body WarehouseRequest true "Warehouse code and name"
+// @Param Idempotency-Key header string false "Retries with the same key get the response of the first request"
+// @Success 200 {object} map[string]int "Returns ID of created warehouse"
+// @Failure 400 {object} BaseResponse "Invalid input or code already in use"
+// @Failure 409 {object} BaseResponse "A request with the same idempotency key is in progress"
+// @Failure 422 {object} BaseResponse "Idempotency key was used with a different request"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /warehouses [post]
+func (h *HandlerConfig) CreateWarehouse(c *gin.Context) {
+	const op = "rest.warehouse.create"
+
+	var req WarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: err.Error(), ErrorCode: 400})
+		return
+	}
+
+	id, err := h.Dep.Warehouse.Create(c.Request.Context(), warehouse.Warehouse{Code: req.Code, Name: req.Name})
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Error creating warehouse: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// ListWarehouses godoc
+// @Summary List warehouses
+// @Description Get all warehouses; movements without a warehouse belong to the default one
+// @Tags warehouses
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of warehouses"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /warehouses [get]
+func (h *HandlerConfig) ListWarehouses(c *gin.Context) {
+	const op = "rest.warehouse.list"
+
+	warehouses, err := h.Dep.Warehouse.List(c.Request.Context())
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to list warehouses: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": warehouses})
+}
+
+// GetWarehouseStock godoc
+// @Summary Get the stock of a warehouse
+// @Description Get the quantity of every product on hand at a warehouse and the quantity in transit to it.
+// @Description Stock in transit is not part of the on-hand quantity of any warehouse
+// @Tags warehouses
+// @Produce json
+// @Param id path int true "Warehouse ID"
+// @Success 200 {object} map[string]interface{} "Stock per product"
+// @Failure 400 {object} BaseResponse "Invalid ID"
+// @Failure 404 {object} BaseResponse "Warehouse not found"
+// @Failure 500 {object} BaseResponse "Server error"
+// @Router /warehouses/{id}/stock [get]
+func (h *HandlerConfig) GetWarehouseStock(c *gin.Context) {
+	const op = "rest.warehouse.stock"
+
+	id, err := pathID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, BaseResponse{Error: "Invalid ID", ErrorCode: 400})
+		return
+	}
+
+	stock, err := h.Dep.Warehouse.Stock(c.Request.Context(), id)
+	if err != nil {
+		h.logger(c).Error(fmt.Sprintf("%s | Failed to get warehouse stock: ", op), sl.Err(err))
+		code := h.errorStatus(c, err)
+		c.JSON(code, BaseResponse{Error: err.Error(), ErrorCode: code})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": stock})
+}
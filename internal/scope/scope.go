@@ -3,10 +3,27 @@ package scope
 import (
 	"log/slog"
 
+	"github.com/Gen1usBruh/warehouse-api/internal/metrics"
 	usecase "github.com/Gen1usBruh/warehouse-api/internal/usecase"
 )
 
 type Dependencies struct {
-	Sl      *slog.Logger
-	Product *usecase.ProductUseCase
+	Sl            *slog.Logger
+	Metrics       *metrics.Metrics
+	Product       *usecase.ProductUseCase
+	Pricing       *usecase.PricingUseCase
+	Stock         *usecase.StockUseCase
+	Supplier      *usecase.SupplierUseCase
+	Purchase      *usecase.PurchaseUseCase
+	Sales         *usecase.SalesUseCase
+	Return        *usecase.ReturnUseCase
+	Warehouse     *usecase.WarehouseUseCase
+	Transfer      *usecase.TransferUseCase
+	Count         *usecase.CountUseCase
+	Valuation     *usecase.ValuationUseCase
+	Report        *usecase.ReportUseCase
+	Replenishment *usecase.ReplenishmentUseCase
+	// Idempotency replays responses to POST requests retried with the same
+	// Idempotency-Key header; the header is ignored when it is nil.
+	Idempotency *usecase.IdempotencyUseCase
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/Gen1usBruh/warehouse-api/internal/tracing"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -46,6 +47,8 @@ func ConnectDB(dbConfig *config.Database) (*pgxpool.Pool, error) {
 		pgxConfig.MaxConnLifetime = 5 * time.Minute
 	}
 
+	pgxConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+
 	dbPool, err := pgxpool.NewWithConfig(context.Background(), pgxConfig)
 	if err != nil {
 		return nil, fmt.Errorf("%s | %w", op, err)
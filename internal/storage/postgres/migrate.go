@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Migrator applies the migrations embedded in the binary. Every run holds a
+// Postgres advisory lock, so replicas starting together migrate one after the
+// other and the later ones find nothing left to do.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// MigrationStatus is the version the schema is at and the newest embedded one.
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+}
+
+func NewMigrator(dbConfig *config.Database) (*Migrator, error) {
+	const op = "storage.postgres.NewMigrator"
+
+	if dbConfig == nil {
+		return nil, errors.New("missing config")
+	}
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s | %w", op, err)
+	}
+	url := "pgx5://" + strings.TrimPrefix(dsn(dbConfig), "postgresql://")
+	m, err := migrate.NewWithSourceInstance("iofs", source, url)
+	if err != nil {
+		return nil, fmt.Errorf("%s | %w", op, err)
+	}
+	m.Log = migrateLogger{}
+	return &Migrator{m: m}, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down rolls back the given number of migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return errors.New("number of migrations to roll back must be positive")
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// Goto migrates up or down to the given version.
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+func (m *Migrator) Status() (MigrationStatus, error) {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{Latest: latest}, nil
+	}
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	return MigrationStatus{Version: version, Dirty: dirty, Latest: latest}, nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrateLogger prints each applied migration.
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...any) {
+	log.Printf(strings.TrimSuffix(format, "\n"), v...)
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CheckMigrations reports an error unless the database schema is at the
+// version of the newest embedded migration and no migration failed halfway.
+func CheckMigrations(ctx context.Context, pool *pgxpool.Pool) error {
+	const op = "storage.postgres.CheckMigrations"
+
+	expected, err := migrations.Latest()
+	if err != nil {
+		return fmt.Errorf("%s | %w", op, err)
+	}
+
+	var version int64
+	var dirty bool
+	err = pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("no migrations applied, expected version %d", expected)
+	}
+	if err != nil {
+		return fmt.Errorf("%s | %w", op, err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed and left the schema dirty", version)
+	}
+	if uint(version) != expected {
+		return fmt.Errorf("schema is at version %d, expected %d", version, expected)
+	}
+	return nil
+}
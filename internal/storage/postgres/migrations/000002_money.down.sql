@@ -0,0 +1,12 @@
+-- The old schema has no currency, so prices in other currencies cannot be kept.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM products WHERE price_currency <> 'USD') THEN
+        RAISE EXCEPTION 'products priced in currencies other than USD cannot be migrated down';
+    END IF;
+END $$;
+
+-- Whole dollars only: cents are truncated.
+ALTER TABLE products
+    DROP COLUMN price_currency,
+    ALTER COLUMN price TYPE INTEGER USING (price / 100)::INTEGER;
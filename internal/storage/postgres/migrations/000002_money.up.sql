@@ -0,0 +1,5 @@
+ALTER TABLE products
+    ALTER COLUMN price TYPE BIGINT USING price::BIGINT * 100,
+    ADD COLUMN price_currency TEXT NOT NULL DEFAULT 'USD' CHECK (price_currency ~ '^[A-Z]{3}$');
+
+COMMENT ON COLUMN products.price IS 'Price in minor units of price_currency';
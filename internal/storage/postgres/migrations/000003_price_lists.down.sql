@@ -0,0 +1,3 @@
+DROP TABLE exchange_rates;
+DROP TABLE price_list_items;
+DROP TABLE price_lists;
@@ -0,0 +1,27 @@
+CREATE TABLE price_lists (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    customer_group TEXT NOT NULL DEFAULT '',
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    valid_to TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE TABLE price_list_items (
+    price_list_id INTEGER NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price BIGINT NOT NULL CHECK (price > 0),
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+CREATE INDEX idx_price_list_items_product_id ON price_list_items(product_id);
+
+CREATE TABLE exchange_rates (
+    base_currency TEXT NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency TEXT NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency, valid_from)
+);
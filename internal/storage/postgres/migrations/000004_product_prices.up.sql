@@ -0,0 +1,21 @@
+CREATE TABLE product_prices (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price BIGINT NOT NULL CHECK (price > 0),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    status TEXT NOT NULL CHECK (status IN ('pending', 'active', 'superseded', 'cancelled')),
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_to TIMESTAMP WITH TIME ZONE,
+    created_by TEXT NOT NULL DEFAULT '',
+    approved_by TEXT NOT NULL DEFAULT '',
+    activated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE INDEX idx_product_prices_product_id ON product_prices(product_id, valid_from);
+CREATE INDEX idx_product_prices_pending ON product_prices(valid_from) WHERE status = 'pending';
+
+INSERT INTO product_prices (product_id, price, currency, status, valid_from, activated_at)
+SELECT id, price, price_currency, 'active', created_at, created_at
+FROM products;
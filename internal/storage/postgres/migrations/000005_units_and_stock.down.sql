@@ -0,0 +1,3 @@
+DROP TABLE stock_movements;
+DROP TABLE product_units;
+ALTER TABLE products DROP COLUMN base_unit;
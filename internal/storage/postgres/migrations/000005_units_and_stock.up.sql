@@ -0,0 +1,26 @@
+ALTER TABLE products ADD COLUMN base_unit TEXT NOT NULL DEFAULT 'pcs';
+
+-- One alternate unit equals factor_num / factor_den base units.
+CREATE TABLE product_units (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    factor_num BIGINT NOT NULL CHECK (factor_num > 0),
+    factor_den BIGINT NOT NULL DEFAULT 1 CHECK (factor_den > 0),
+    rounding TEXT NOT NULL DEFAULT 'reject' CHECK (rounding IN ('reject', 'down', 'up', 'half_up')),
+    PRIMARY KEY (product_id, code)
+);
+
+CREATE TABLE stock_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CONSTRAINT stock_movements_type_check CHECK (type IN ('receipt', 'issue', 'adjustment')),
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    entered_quantity TEXT NOT NULL,
+    entered_unit TEXT NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_stock_movements_product_id ON stock_movements(product_id, created_at);
+
+COMMENT ON COLUMN stock_movements.quantity IS 'Signed quantity in the product base unit';
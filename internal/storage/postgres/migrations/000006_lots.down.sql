@@ -0,0 +1,3 @@
+DROP TABLE stock_movement_lots;
+DROP TABLE lots;
+ALTER TABLE products DROP COLUMN lot_tracked;
@@ -0,0 +1,22 @@
+ALTER TABLE products ADD COLUMN lot_tracked BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE lots (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    lot_number TEXT NOT NULL,
+    manufactured_at DATE,
+    expires_at DATE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    UNIQUE (product_id, lot_number),
+    CHECK (expires_at IS NULL OR manufactured_at IS NULL OR expires_at >= manufactured_at)
+);
+
+CREATE INDEX idx_lots_expires_at ON lots(expires_at) WHERE quantity > 0;
+
+CREATE TABLE stock_movement_lots (
+    movement_id INTEGER NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    lot_id INTEGER NOT NULL REFERENCES lots(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    PRIMARY KEY (movement_id, lot_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE stock_movement_serials;
+DROP TABLE serial_numbers;
+ALTER TABLE products DROP COLUMN serialized;
@@ -0,0 +1,20 @@
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE serial_numbers (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    serial_number TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL CHECK (status IN ('in_stock', 'reserved', 'shipped', 'returned', 'written_off')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_serial_numbers_product_id ON serial_numbers(product_id, status);
+
+CREATE TABLE stock_movement_serials (
+    movement_id INTEGER NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    serial_id INTEGER NOT NULL REFERENCES serial_numbers(id) ON DELETE CASCADE,
+    PRIMARY KEY (movement_id, serial_id)
+);
+
+CREATE INDEX idx_stock_movement_serials_serial_id ON stock_movement_serials(serial_id);
@@ -0,0 +1,2 @@
+DROP TABLE product_suppliers;
+DROP TABLE suppliers;
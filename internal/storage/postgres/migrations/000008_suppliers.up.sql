@@ -0,0 +1,23 @@
+CREATE TABLE suppliers (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    contact_name TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    phone TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    payment_terms TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE product_suppliers (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    supplier_id INTEGER NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    supplier_sku TEXT NOT NULL DEFAULT '',
+    cost_price BIGINT NOT NULL CHECK (cost_price > 0),
+    cost_currency TEXT NOT NULL CHECK (cost_currency ~ '^[A-Z]{3}$'),
+    min_order_quantity INTEGER NOT NULL DEFAULT 1 CHECK (min_order_quantity > 0),
+    PRIMARY KEY (product_id, supplier_id)
+);
+
+CREATE INDEX idx_product_suppliers_supplier_id ON product_suppliers(supplier_id);
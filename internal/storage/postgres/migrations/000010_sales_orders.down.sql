@@ -0,0 +1,3 @@
+DROP TABLE sales_order_lines;
+DROP TABLE sales_orders;
+ALTER TABLE products DROP COLUMN bin_location;
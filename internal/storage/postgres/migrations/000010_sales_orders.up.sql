@@ -0,0 +1,36 @@
+ALTER TABLE products ADD COLUMN bin_location TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE sales_orders (
+    id SERIAL PRIMARY KEY,
+    customer TEXT NOT NULL,
+    customer_group TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'allocated', 'picking', 'packed', 'shipped', 'cancelled')),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    reference TEXT NOT NULL DEFAULT '',
+    packages INTEGER NOT NULL DEFAULT 0 CHECK (packages >= 0),
+    carrier TEXT NOT NULL DEFAULT '',
+    tracking_number TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    allocated_at TIMESTAMP WITH TIME ZONE,
+    packed_at TIMESTAMP WITH TIME ZONE,
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_sales_orders_status ON sales_orders(status);
+
+-- allocated_quantity is reserved from on-hand stock while the order is
+-- allocated, picking or packed; stock itself only moves at shipment.
+CREATE TABLE sales_order_lines (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES sales_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    allocated_quantity INTEGER NOT NULL DEFAULT 0 CHECK (allocated_quantity >= 0),
+    shipped_quantity INTEGER NOT NULL DEFAULT 0 CHECK (shipped_quantity >= 0),
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    UNIQUE (order_id, product_id)
+);
+
+CREATE INDEX idx_sales_order_lines_product_id ON sales_order_lines(product_id);
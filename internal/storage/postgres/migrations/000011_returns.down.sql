@@ -0,0 +1,14 @@
+DROP TABLE return_lines;
+DROP TABLE returns;
+
+-- Movements and serials created by returns are kept, so the old checks are
+-- restored without validating existing rows.
+ALTER TABLE serial_numbers DROP CONSTRAINT serial_numbers_status_check;
+ALTER TABLE serial_numbers ADD CONSTRAINT serial_numbers_status_check
+    CHECK (status IN ('in_stock', 'reserved', 'shipped', 'returned', 'written_off')) NOT VALID;
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'issue', 'adjustment')) NOT VALID;
+
+ALTER TABLE products DROP COLUMN quarantined_quantity;
@@ -0,0 +1,36 @@
+ALTER TABLE products ADD COLUMN quarantined_quantity INTEGER NOT NULL DEFAULT 0
+    CHECK (quarantined_quantity >= 0);
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'issue', 'adjustment', 'return', 'quarantine'));
+
+ALTER TABLE serial_numbers DROP CONSTRAINT serial_numbers_status_check;
+ALTER TABLE serial_numbers ADD CONSTRAINT serial_numbers_status_check
+    CHECK (status IN ('in_stock', 'reserved', 'shipped', 'returned', 'quarantined', 'written_off'));
+
+CREATE TABLE returns (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES sales_orders(id) ON DELETE RESTRICT,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'inspected', 'cancelled')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    inspected_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_returns_order_id ON returns(order_id);
+
+-- The disposition quantities add up to quantity once the return is inspected.
+CREATE TABLE return_lines (
+    id SERIAL PRIMARY KEY,
+    return_id INTEGER NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    order_line_id INTEGER NOT NULL REFERENCES sales_order_lines(id) ON DELETE RESTRICT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    serials TEXT[] NOT NULL DEFAULT '{}',
+    restocked_quantity INTEGER NOT NULL DEFAULT 0 CHECK (restocked_quantity >= 0),
+    quarantined_quantity INTEGER NOT NULL DEFAULT 0 CHECK (quarantined_quantity >= 0),
+    scrapped_quantity INTEGER NOT NULL DEFAULT 0 CHECK (scrapped_quantity >= 0),
+    UNIQUE (return_id, order_line_id)
+);
@@ -0,0 +1,13 @@
+DROP TABLE transfer_lines;
+DROP TABLE transfer_orders;
+
+-- Movements created by transfers are kept, so the old check is restored without
+-- validating existing rows.
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'issue', 'adjustment', 'return', 'quarantine')) NOT VALID;
+
+ALTER TABLE stock_movements DROP COLUMN warehouse_id;
+
+DROP TABLE warehouse_stock;
+DROP TABLE warehouses;
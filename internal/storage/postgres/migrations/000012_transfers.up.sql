@@ -0,0 +1,67 @@
+CREATE TABLE warehouses (
+    id SERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- Stock movements that do not name a warehouse belong to the default one.
+CREATE UNIQUE INDEX idx_warehouses_default ON warehouses(is_default) WHERE is_default;
+
+INSERT INTO warehouses (code, name, is_default) VALUES ('MAIN', 'Main warehouse', TRUE);
+
+-- products.quantity is the sum of a product's warehouse_stock rows; stock in
+-- transit between warehouses is in neither.
+CREATE TABLE warehouse_stock (
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    PRIMARY KEY (warehouse_id, product_id)
+);
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, quantity)
+SELECT w.id, p.id, p.quantity
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default AND p.quantity > 0;
+
+-- Quarantine movements change stock that is not kept per warehouse and have none.
+ALTER TABLE stock_movements ADD COLUMN warehouse_id INTEGER REFERENCES warehouses(id) ON DELETE RESTRICT;
+UPDATE stock_movements
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default)
+WHERE type <> 'quarantine';
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_type_check
+    CHECK (type IN ('receipt', 'issue', 'adjustment', 'return', 'quarantine', 'transfer_out', 'transfer_in'));
+
+CREATE TABLE transfer_orders (
+    id SERIAL PRIMARY KEY,
+    from_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    to_warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'in_transit', 'received', 'cancelled')),
+    reference TEXT NOT NULL DEFAULT '',
+    carrier TEXT NOT NULL DEFAULT '',
+    tracking_number TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    received_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+CREATE INDEX idx_transfer_orders_status ON transfer_orders(status);
+
+-- discrepancy is received_quantity - shipped_quantity once the transfer is received.
+CREATE TABLE transfer_lines (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER NOT NULL REFERENCES transfer_orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    shipped_quantity INTEGER NOT NULL DEFAULT 0 CHECK (shipped_quantity >= 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    discrepancy INTEGER NOT NULL DEFAULT 0,
+    discrepancy_reason TEXT NOT NULL DEFAULT '',
+    UNIQUE (transfer_id, product_id)
+);
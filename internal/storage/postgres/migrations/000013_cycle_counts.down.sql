@@ -0,0 +1,4 @@
+DROP TABLE count_lines;
+DROP TABLE count_sessions;
+
+ALTER TABLE products DROP COLUMN category;
@@ -0,0 +1,33 @@
+ALTER TABLE products ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+-- A count session covers the products of one warehouse matching its filters.
+-- An empty filter matches every product.
+CREATE TABLE count_sessions (
+    id SERIAL PRIMARY KEY,
+    warehouse_id INTEGER NOT NULL REFERENCES warehouses(id) ON DELETE RESTRICT,
+    bin_location TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL DEFAULT '',
+    abc_class TEXT NOT NULL DEFAULT '' CHECK (abc_class IN ('', 'A', 'B', 'C')),
+    blind BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'pending_approval', 'approved', 'cancelled')),
+    approved_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    approved_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_count_sessions_status ON count_sessions(status);
+
+-- system_quantity is the warehouse stock when the count sheet was generated,
+-- and then when the line was last counted; counted_quantity stays NULL until
+-- the line is counted.
+CREATE TABLE count_lines (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES count_sessions(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    bin_location TEXT NOT NULL DEFAULT '',
+    system_quantity INTEGER NOT NULL,
+    counted_quantity INTEGER CHECK (counted_quantity >= 0),
+    UNIQUE (session_id, product_id)
+);
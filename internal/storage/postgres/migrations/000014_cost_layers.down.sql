@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cost_entries;
+DROP TABLE IF EXISTS cost_layers;
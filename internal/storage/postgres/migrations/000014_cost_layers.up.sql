@@ -0,0 +1,27 @@
+-- A cost layer is stock received at one unit cost; remaining is what has not
+-- been issued yet. Costs are in minor units of the product currency.
+CREATE TABLE cost_layers (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    movement_id INTEGER NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    unit_cost BIGINT NOT NULL CHECK (unit_cost >= 0),
+    currency TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining INTEGER NOT NULL CHECK (remaining >= 0 AND remaining <= quantity),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_cost_layers_open ON cost_layers(product_id, created_at, id) WHERE remaining > 0;
+
+-- Every valued stock movement records the quantity and value it added or took
+-- out, so the stock value at any date is the sum of the entries up to it.
+CREATE TABLE cost_entries (
+    movement_id INTEGER PRIMARY KEY REFERENCES stock_movements(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    value BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_cost_entries_created_at ON cost_entries(created_at, product_id);
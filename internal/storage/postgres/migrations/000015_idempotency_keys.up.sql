@@ -0,0 +1,15 @@
+-- A POST request sent with an Idempotency-Key header is stored with a hash of
+-- its method, path and body. The response is saved once the request is served,
+-- so a retry with the same key replays it; status_code is NULL while the first
+-- request is still running.
+CREATE TABLE idempotency_keys (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER,
+    content_type TEXT NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
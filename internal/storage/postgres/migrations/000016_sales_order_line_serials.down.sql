@@ -0,0 +1 @@
+ALTER TABLE sales_order_lines DROP COLUMN serials;
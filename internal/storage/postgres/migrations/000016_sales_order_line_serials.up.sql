@@ -0,0 +1,19 @@
+-- Serials lists the units shipped on a line of a serialized product, so returns
+-- can only take back units that left on the order they are returned against.
+ALTER TABLE sales_order_lines ADD COLUMN serials TEXT[] NOT NULL DEFAULT '{}';
+
+-- Shipments issued each line with a movement referenced 'SO-<order id>',
+-- followed by the order reference if it had one.
+UPDATE sales_order_lines l
+SET serials = shipped.serials
+FROM (
+    SELECT l.id AS line_id, array_agg(s.serial_number ORDER BY s.serial_number) AS serials
+    FROM sales_order_lines l
+    JOIN stock_movements m ON m.product_id = l.product_id
+        AND m.type = 'issue'
+        AND (m.reference = 'SO-' || l.order_id OR m.reference LIKE 'SO-' || l.order_id || ' %')
+    JOIN stock_movement_serials ms ON ms.movement_id = m.id
+    JOIN serial_numbers s ON s.id = ms.serial_id
+    GROUP BY l.id
+) shipped
+WHERE l.id = shipped.line_id;
@@ -0,0 +1,9 @@
+DELETE FROM cost_entries WHERE movement_id IS NULL;
+DELETE FROM cost_layers WHERE movement_id IS NULL;
+
+ALTER TABLE cost_entries DROP CONSTRAINT cost_entries_movement_id_key;
+ALTER TABLE cost_entries DROP COLUMN id;
+ALTER TABLE cost_entries ALTER COLUMN movement_id SET NOT NULL;
+ALTER TABLE cost_entries ADD PRIMARY KEY (movement_id);
+
+ALTER TABLE cost_layers ALTER COLUMN movement_id SET NOT NULL;
@@ -0,0 +1,33 @@
+-- Stock on hand before cost layers existed was never valued. It gets an opening
+-- layer and entry, which belong to no movement, at the cheapest supplier cost
+-- price in the product currency. Only a product without such a supplier cost is
+-- valued at its sale price, the one figure known for it; correct that value with
+-- an adjustment if it matters.
+ALTER TABLE cost_layers ALTER COLUMN movement_id DROP NOT NULL;
+
+ALTER TABLE cost_entries DROP CONSTRAINT cost_entries_pkey;
+ALTER TABLE cost_entries ADD COLUMN id SERIAL PRIMARY KEY;
+ALTER TABLE cost_entries ALTER COLUMN movement_id DROP NOT NULL;
+ALTER TABLE cost_entries ADD CONSTRAINT cost_entries_movement_id_key UNIQUE (movement_id);
+
+-- The opening stock is what the entries do not account for yet. It dates from
+-- the product creation, so FIFO issues it first.
+WITH opening AS (
+    SELECT p.id AS product_id,
+           COALESCE((SELECT MIN(ps.cost_price) FROM product_suppliers ps
+                     WHERE ps.product_id = p.id AND ps.cost_currency = p.price_currency),
+                    p.price) AS unit_cost,
+           p.price_currency,
+           p.created_at,
+           p.quantity - COALESCE((SELECT SUM(e.quantity) FROM cost_entries e WHERE e.product_id = p.id), 0)::int AS quantity
+    FROM products p
+), layers AS (
+    INSERT INTO cost_layers (product_id, unit_cost, currency, quantity, remaining, created_at)
+    SELECT product_id, unit_cost, price_currency, quantity, quantity, created_at
+    FROM opening
+    WHERE quantity > 0
+)
+INSERT INTO cost_entries (product_id, quantity, value, currency, created_at)
+SELECT product_id, quantity, quantity::bigint * unit_cost, price_currency, created_at
+FROM opening
+WHERE quantity > 0;
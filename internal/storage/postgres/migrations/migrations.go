@@ -0,0 +1,34 @@
+// Package migrations embeds the SQL migrations of the database schema.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// FS holds the migration files, named "<version>_<title>.up.sql" and
+// "<version>_<title>.down.sql".
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Latest returns the version of the newest migration, which a database that is
+// up to date is at.
+func Latest() (uint, error) {
+	names, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, uint(v))
+	}
+	return latest, nil
+}
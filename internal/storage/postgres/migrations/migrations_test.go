@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	latest, err := Latest()
+	assert.NoError(t, err)
+	assert.NotZero(t, latest)
+
+	// Versions are sequential, and each migration can be rolled back.
+	source, err := iofs.New(FS, ".")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	assert.NoError(t, err)
+	for v := uint(1); ; v++ {
+		assert.Equal(t, v, version)
+		for _, suffix := range []string{"up", "down"} {
+			names, _ := fs.Glob(FS, fmt.Sprintf("%06d_*.%s.sql", v, suffix))
+			assert.Len(t, names, 1, "version %d %s", v, suffix)
+		}
+		if version, err = source.Next(version); err != nil {
+			assert.Equal(t, latest, v)
+			break
+		}
+	}
+}
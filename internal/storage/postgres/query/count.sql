@@ -0,0 +1,77 @@
+-- name: ListCountCandidates :many
+-- ListCountCandidates returns every product whose stock is not tracked per lot
+-- or serial, with its stock at the warehouse.
+SELECT p.id,
+       p.name,
+       p.bin_location,
+       p.category,
+       COALESCE(ws.quantity, 0)::int AS quantity
+FROM products p
+LEFT JOIN warehouse_stock ws ON ws.product_id = p.id AND ws.warehouse_id = @warehouse_id
+WHERE NOT p.lot_tracked AND NOT p.serialized
+ORDER BY p.bin_location, p.name, p.id;
+
+-- name: CreateCountSession :one
+INSERT INTO count_sessions (
+    warehouse_id,
+    bin_location,
+    category,
+    abc_class,
+    blind
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id;
+
+-- name: GetCountSession :one
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE id = $1;
+
+-- name: LockCountSession :one
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListCountSessions :many
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE (@status::text = '' OR status = @status)
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateCountSessionState :exec
+UPDATE count_sessions
+SET status = @status,
+    approved_by = @approved_by,
+    submitted_at = @submitted_at,
+    approved_at = @approved_at,
+    cancelled_at = @cancelled_at
+WHERE id = @id;
+
+-- name: CreateCountLine :exec
+INSERT INTO count_lines (
+    session_id,
+    product_id,
+    bin_location,
+    system_quantity
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: ListCountLines :many
+SELECT l.id, l.session_id, l.product_id, p.name AS product_name, l.bin_location,
+       l.system_quantity, l.counted_quantity
+FROM count_lines l
+JOIN products p ON p.id = l.product_id
+WHERE l.session_id = $1
+ORDER BY l.bin_location, p.name, l.id;
+
+-- name: SetCountLineQuantity :exec
+UPDATE count_lines
+SET counted_quantity = $2,
+    system_quantity = $3
+WHERE id = $1;
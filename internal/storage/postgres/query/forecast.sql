@@ -0,0 +1,33 @@
+-- name: DailyDemand :many
+SELECT sm.product_id,
+       sm.created_at::date AS day,
+       SUM(-sm.quantity)::int AS quantity
+FROM stock_movements sm
+WHERE sm.type = 'issue'
+  AND sm.created_at >= sqlc.arg(from_date)::date
+  AND sm.created_at < sqlc.arg(to_date)::date
+  AND (sqlc.narg('product_id')::int IS NULL OR sm.product_id = sqlc.narg('product_id'))
+GROUP BY sm.product_id, sm.created_at::date
+ORDER BY sm.product_id, day;
+
+-- name: StockPositions :many
+-- StockPositions counts stock allocated to open sales orders and the quantity
+-- still to be delivered on submitted purchase orders.
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity AS on_hand,
+       COALESCE((
+           SELECT SUM(l.allocated_quantity)
+           FROM sales_order_lines l
+           JOIN sales_orders o ON o.id = l.order_id
+           WHERE l.product_id = p.id AND o.status IN ('allocated', 'picking', 'packed')
+       ), 0)::int AS allocated,
+       COALESCE((
+           SELECT SUM(GREATEST(l.quantity - l.received_quantity, 0))
+           FROM purchase_order_lines l
+           JOIN purchase_orders po ON po.id = l.order_id
+           WHERE l.product_id = p.id AND po.status IN ('submitted', 'partially_received')
+       ), 0)::int AS on_order
+FROM products p
+WHERE sqlc.narg('product_id')::int IS NULL OR p.id = sqlc.narg('product_id')
+ORDER BY p.name, p.id;
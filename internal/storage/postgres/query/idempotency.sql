@@ -0,0 +1,44 @@
+-- name: ReserveIdempotencyKey :one
+-- ReserveIdempotencyKey stores a pending request for a key. A key that expired
+-- or whose request has been pending since before stale_before is taken over;
+-- otherwise no row is returned.
+INSERT INTO idempotency_keys (
+    key,
+    request_hash,
+    created_at,
+    expires_at
+) VALUES (
+    @key, @request_hash, @now, @expires_at
+)
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    content_type = '',
+    response_body = NULL,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= @now
+   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < @stale_before)
+RETURNING created_at;
+
+-- name: GetIdempotencyKey :one
+SELECT key, request_hash, status_code, content_type, response_body, created_at, expires_at
+FROM idempotency_keys
+WHERE key = $1;
+
+-- name: CompleteIdempotencyKey :exec
+-- CompleteIdempotencyKey and DeleteIdempotencyKey only match the reservation
+-- created at created_at, not one that took the key over since.
+UPDATE idempotency_keys
+SET status_code = @status_code,
+    content_type = @content_type,
+    response_body = @response_body
+WHERE key = @key AND created_at = @created_at;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = @key AND created_at = @created_at;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
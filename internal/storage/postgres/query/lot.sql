@@ -0,0 +1,52 @@
+-- name: EnsureLot :one
+-- EnsureLot returns the lot with the given number, creating it on first receipt;
+-- an existing lot keeps its dates.
+INSERT INTO lots (
+    product_id,
+    lot_number,
+    manufactured_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (product_id, lot_number) DO UPDATE SET lot_number = EXCLUDED.lot_number
+RETURNING id, manufactured_at, expires_at;
+
+-- name: GetLotByNumber :one
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE product_id = $1 AND lot_number = $2;
+
+-- name: ListLots :many
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE product_id = $1
+ORDER BY expires_at NULLS LAST, id;
+
+-- name: ListExpiringLots :many
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE quantity > 0 AND expires_at <= $1
+ORDER BY expires_at, product_id, id;
+
+-- name: AddLotQuantity :one
+UPDATE lots
+SET quantity = quantity + @quantity
+WHERE id = @id AND quantity + @quantity >= 0
+RETURNING quantity;
+
+-- name: CreateStockMovementLot :exec
+INSERT INTO stock_movement_lots (
+    movement_id,
+    lot_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListStockMovementLots :many
+SELECT sml.movement_id, sml.lot_id, l.lot_number, l.expires_at, sml.quantity
+FROM stock_movement_lots sml
+JOIN lots l ON l.id = sml.lot_id
+WHERE l.product_id = $1
+ORDER BY sml.movement_id, l.expires_at NULLS LAST, l.id;
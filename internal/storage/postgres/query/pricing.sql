@@ -0,0 +1,84 @@
+-- name: CreatePriceList :one
+INSERT INTO price_lists (
+    name,
+    currency,
+    customer_group,
+    valid_from,
+    valid_to
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id;
+
+-- name: GetPriceList :one
+SELECT id, name, currency, customer_group, valid_from, valid_to
+FROM price_lists
+WHERE id = $1;
+
+-- name: ListPriceLists :many
+SELECT id, name, currency, customer_group, valid_from, valid_to
+FROM price_lists
+ORDER BY id;
+
+-- name: DeletePriceList :exec
+DELETE FROM price_lists
+WHERE id = $1;
+
+-- name: UpsertPriceListItem :exec
+INSERT INTO price_list_items (
+    price_list_id,
+    product_id,
+    price
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (price_list_id, product_id) DO UPDATE
+SET price = EXCLUDED.price;
+
+-- name: DeletePriceListItem :exec
+DELETE FROM price_list_items
+WHERE price_list_id = $1 AND product_id = $2;
+
+-- name: ListPriceListItems :many
+SELECT price_list_id, product_id, price
+FROM price_list_items
+WHERE price_list_id = $1
+ORDER BY product_id;
+
+-- name: FindApplicablePrice :one
+SELECT pl.id, pli.price
+FROM price_list_items pli
+JOIN price_lists pl ON pl.id = pli.price_list_id
+WHERE pli.product_id = @product_id
+  AND pl.currency = @currency
+  AND pl.customer_group IN ('', @customer_group::text)
+  AND pl.valid_from <= @at
+  AND (pl.valid_to IS NULL OR pl.valid_to > @at)
+ORDER BY pl.customer_group = @customer_group::text DESC, pl.valid_from DESC, pl.id DESC
+LIMIT 1;
+
+-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (
+    base_currency,
+    quote_currency,
+    rate,
+    valid_from
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (base_currency, quote_currency, valid_from) DO UPDATE
+SET rate = EXCLUDED.rate;
+
+-- name: GetExchangeRate :one
+SELECT base_currency, quote_currency, rate, valid_from
+FROM exchange_rates
+WHERE base_currency = @base_currency
+  AND quote_currency = @quote_currency
+  AND valid_from <= @at
+ORDER BY valid_from DESC
+LIMIT 1;
+
+-- name: ListExchangeRates :many
+SELECT base_currency, quote_currency, rate, valid_from
+FROM exchange_rates
+ORDER BY base_currency, quote_currency, valid_from DESC;
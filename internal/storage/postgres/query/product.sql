@@ -3,31 +3,59 @@ INSERT INTO products (
     name,
     description,
     price,
-    quantity
+    price_currency,
+    quantity,
+    base_unit,
+    lot_tracked,
+    serialized,
+    bin_location,
+    category
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 RETURNING id;
 
 -- name: GetProductByID :one
-SELECT id, name, description, price, quantity
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
 FROM products
 WHERE id = $1;
 
 -- name: ListProducts :many
-SELECT id, name, description, price, quantity
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
 FROM products
 ORDER BY id;
 
+-- name: SearchProducts :many
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
+FROM products
+WHERE (@search::text = '' OR name ILIKE '%' || @search || '%' OR description ILIKE '%' || @search || '%')
+  AND (@category::text = '' OR category = @category)
+  AND (@currency::text = '' OR price_currency = @currency)
+  AND (sqlc.narg('in_stock')::bool IS NULL OR (quantity > 0) = sqlc.narg('in_stock'))
+ORDER BY id
+LIMIT @page_limit OFFSET @page_offset;
+
+-- name: CountProducts :one
+SELECT COUNT(*)::int
+FROM products
+WHERE (@search::text = '' OR name ILIKE '%' || @search || '%' OR description ILIKE '%' || @search || '%')
+  AND (@category::text = '' OR category = @category)
+  AND (@currency::text = '' OR price_currency = @currency)
+  AND (sqlc.narg('in_stock')::bool IS NULL OR (quantity > 0) = sqlc.narg('in_stock'));
+
 -- name: UpdateProduct :exec
 UPDATE products
 SET
     name = $2,
     description = $3,
     price = $4,
-    quantity = $5
+    price_currency = $5,
+    lot_tracked = $6,
+    serialized = $7,
+    bin_location = $8,
+    category = $9
 WHERE id = $1;
 
--- name: DeleteProduct :exec
+-- name: DeleteProduct :execrows
 DELETE FROM products
 WHERE id = $1;
@@ -0,0 +1,68 @@
+-- name: CreatePriceChange :one
+INSERT INTO product_prices (
+    product_id,
+    price,
+    currency,
+    status,
+    valid_from,
+    valid_to,
+    created_by,
+    approved_by,
+    activated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id;
+
+-- name: GetPriceChange :one
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM product_prices
+WHERE id = $1;
+
+-- name: ListPriceChanges :many
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM product_prices
+WHERE product_id = $1
+ORDER BY valid_from DESC, id DESC;
+
+-- name: SetPriceChangeStatus :exec
+UPDATE product_prices
+SET status = $2
+WHERE id = $1;
+
+-- name: ActivatePriceChange :exec
+UPDATE product_prices
+SET status = 'active', activated_at = $2
+WHERE id = $1;
+
+-- name: SupersedeActivePrices :exec
+UPDATE product_prices
+SET status = 'superseded'
+WHERE product_id = $1 AND status = 'active';
+
+-- name: CloseOpenPrices :exec
+UPDATE product_prices
+SET valid_to = @now::timestamptz
+WHERE product_id = @product_id
+  AND status IN ('active', 'superseded')
+  AND valid_from < @now::timestamptz
+  AND (valid_to IS NULL OR valid_to > @now::timestamptz);
+
+-- name: ListDuePriceChanges :many
+-- Returns, per product, the price period that should be in effect at @now
+-- when it is not the active one yet.
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM (
+    SELECT DISTINCT ON (product_id) *
+    FROM product_prices
+    WHERE status <> 'cancelled'
+      AND valid_from <= @now
+      AND (valid_to IS NULL OR valid_to > @now)
+    ORDER BY product_id, valid_from DESC, id DESC
+) effective
+WHERE effective.status <> 'active';
+
+-- name: SetProductPrice :exec
+UPDATE products
+SET price = $2, price_currency = $3
+WHERE id = $1;
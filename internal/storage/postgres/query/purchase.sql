@@ -0,0 +1,94 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+    supplier_id,
+    currency,
+    reference
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id;
+
+-- name: GetPurchaseOrder :one
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE id = $1;
+
+-- name: LockPurchaseOrder :one
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE (@status::text = '' OR status = @status)
+  AND (sqlc.narg('supplier_id')::int IS NULL OR supplier_id = sqlc.narg('supplier_id'))
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateDraftPurchaseOrder :one
+UPDATE purchase_orders
+SET supplier_id = $2, currency = $3, reference = $4
+WHERE id = $1 AND status = 'draft'
+RETURNING id;
+
+-- name: SubmitPurchaseOrder :one
+UPDATE purchase_orders
+SET status = 'submitted', submitted_at = @now, expected_at = @expected_at
+WHERE id = @id AND status = 'draft'
+RETURNING id;
+
+-- name: SetPurchaseOrderStatus :exec
+UPDATE purchase_orders
+SET status = $2
+WHERE id = $1;
+
+-- name: ClosePurchaseOrder :one
+UPDATE purchase_orders
+SET status = 'closed', closed_at = @now
+WHERE id = @id AND status IN ('partially_received', 'received')
+RETURNING id;
+
+-- name: CreatePurchaseOrderLine :exec
+INSERT INTO purchase_order_lines (
+    order_id,
+    product_id,
+    quantity,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: DeletePurchaseOrderLines :exec
+DELETE FROM purchase_order_lines
+WHERE order_id = $1;
+
+-- name: ListPurchaseOrderLines :many
+SELECT id, order_id, product_id, quantity, received_quantity, unit_cost
+FROM purchase_order_lines
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: SetPurchaseOrderLineReceived :exec
+UPDATE purchase_order_lines
+SET received_quantity = $2
+WHERE id = $1;
+
+-- name: PurchaseOrderStatusReport :many
+SELECT po.status, po.currency,
+       COUNT(DISTINCT po.id)::int AS orders,
+       COUNT(l.id)::int AS lines,
+       COALESCE(SUM(l.quantity), 0)::bigint AS ordered_quantity,
+       COALESCE(SUM(l.received_quantity), 0)::bigint AS received_quantity,
+       COALESCE(SUM(l.quantity::bigint * l.unit_cost), 0)::bigint AS ordered_value,
+       COALESCE(SUM(l.received_quantity::bigint * l.unit_cost), 0)::bigint AS received_value,
+       COUNT(DISTINCT po.id) FILTER (
+           WHERE po.expected_at < @now AND po.status IN ('submitted', 'partially_received')
+       )::int AS overdue
+FROM purchase_orders po
+LEFT JOIN purchase_order_lines l ON l.order_id = po.id
+WHERE (sqlc.narg('supplier_id')::int IS NULL OR po.supplier_id = sqlc.narg('supplier_id'))
+  AND (sqlc.narg('from')::timestamptz IS NULL OR po.created_at >= sqlc.narg('from'))
+  AND (sqlc.narg('to')::timestamptz IS NULL OR po.created_at < sqlc.narg('to'))
+GROUP BY po.status, po.currency
+ORDER BY array_position(ARRAY['draft', 'submitted', 'partially_received', 'received', 'closed'], po.status), po.currency;
@@ -0,0 +1,73 @@
+-- name: TurnoverReport :many
+-- TurnoverReport works out the stock at a past time as the current stock less
+-- every movement since.
+WITH moves AS (
+    SELECT sm.product_id,
+           SUM(sm.quantity) AS since_from,
+           SUM(CASE WHEN sm.created_at >= @to_time THEN sm.quantity ELSE 0 END) AS since_to,
+           SUM(CASE WHEN sm.type = 'issue' AND sm.created_at < @to_time THEN -sm.quantity ELSE 0 END) AS issued
+    FROM stock_movements sm
+    WHERE sm.type <> 'quarantine' AND sm.created_at >= @from_time
+    GROUP BY sm.product_id
+)
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       (p.quantity - COALESCE(m.since_from, 0))::int AS opening_quantity,
+       (p.quantity - COALESCE(m.since_to, 0))::int AS closing_quantity,
+       COALESCE(m.issued, 0)::int AS issued_quantity
+FROM products p
+LEFT JOIN moves m ON m.product_id = p.id
+ORDER BY p.name, p.id;
+
+-- name: StockAgingReport :many
+-- StockAgingReport matches the stock on hand to the newest incoming movements,
+-- so the oldest stock is taken to have left first, and counts it per age band.
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity,
+       COALESCE(width_bucket(sqlc.arg(now)::date - a.created_at::date, sqlc.arg(bounds)::int[]), 0)::int AS bucket,
+       COALESCE(SUM(a.quantity), 0)::int AS bucket_quantity
+FROM products p
+LEFT JOIN (
+    SELECT i.product_id, i.created_at,
+           GREATEST(LEAST(i.quantity, pr.quantity - i.newer), 0) AS quantity
+    FROM (
+        SELECT m.product_id, m.created_at, m.quantity,
+               SUM(m.quantity) OVER (
+                   PARTITION BY m.product_id ORDER BY m.created_at DESC, m.id DESC
+               ) - m.quantity AS newer
+        FROM stock_movements m
+        WHERE m.quantity > 0 AND m.type IN ('receipt', 'return', 'adjustment')
+    ) i
+    JOIN products pr ON pr.id = i.product_id
+) a ON a.product_id = p.id AND a.quantity > 0
+WHERE p.quantity > 0
+GROUP BY p.id, p.name, p.quantity, 4
+ORDER BY p.name, p.id, 4;
+
+-- name: DeadStockReport :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity,
+       MAX(m.created_at)::timestamptz AS last_movement_at
+FROM products p
+LEFT JOIN stock_movements m ON m.product_id = p.id
+WHERE p.quantity > 0
+GROUP BY p.id, p.name, p.quantity
+HAVING MAX(m.created_at) IS NULL OR MAX(m.created_at) < @since
+ORDER BY MAX(m.created_at) NULLS FIRST, p.name;
+
+-- name: ConsumptionReport :many
+-- ConsumptionReport values issues at their recorded cost, or at the current
+-- price where no cost was recorded.
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       COALESCE(e.currency, p.price_currency)::text AS currency,
+       (-SUM(m.quantity))::int AS issued_quantity,
+       (-SUM(COALESCE(e.value, m.quantity::bigint * p.price)))::bigint AS value
+FROM stock_movements m
+JOIN products p ON p.id = m.product_id
+LEFT JOIN cost_entries e ON e.movement_id = m.id
+WHERE m.type = 'issue' AND m.created_at >= @from_time AND m.created_at < @to_time
+GROUP BY p.id, p.name, COALESCE(e.currency, p.price_currency)
+ORDER BY p.name, p.id;
@@ -0,0 +1,62 @@
+-- name: CreateReturn :one
+INSERT INTO returns (
+    order_id,
+    reason
+) VALUES (
+    $1, $2
+)
+RETURNING id;
+
+-- name: GetReturn :one
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE id = $1;
+
+-- name: LockReturn :one
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListReturns :many
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE (@status::text = '' OR status = @status)
+  AND (sqlc.narg('order_id')::int IS NULL OR order_id = sqlc.narg('order_id'))
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateReturnState :exec
+UPDATE returns
+SET status = @status, inspected_at = @inspected_at, cancelled_at = @cancelled_at
+WHERE id = @id;
+
+-- name: CreateReturnLine :exec
+INSERT INTO return_lines (
+    return_id,
+    order_line_id,
+    product_id,
+    quantity,
+    serials
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: ListReturnLines :many
+SELECT id, return_id, order_line_id, product_id, quantity, serials,
+       restocked_quantity, quarantined_quantity, scrapped_quantity
+FROM return_lines
+WHERE return_id = $1
+ORDER BY id;
+
+-- name: SetReturnLineDisposition :exec
+UPDATE return_lines
+SET restocked_quantity = $2, quarantined_quantity = $3, scrapped_quantity = $4
+WHERE id = $1;
+
+-- name: ListReturnedLines :many
+-- ListReturnedLines returns the lines of an order's returns that were not
+-- cancelled.
+SELECT l.order_line_id, l.quantity, l.serials
+FROM return_lines l
+JOIN returns r ON r.id = l.return_id
+WHERE r.order_id = $1 AND r.status <> 'cancelled';
@@ -0,0 +1,73 @@
+-- name: CreateSalesOrder :one
+INSERT INTO sales_orders (
+    customer,
+    customer_group,
+    currency,
+    reference
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id;
+
+-- name: GetSalesOrder :one
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE id = $1;
+
+-- name: LockSalesOrder :one
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSalesOrders :many
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE (@status::text = '' OR status = @status)
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateSalesOrderState :exec
+UPDATE sales_orders
+SET status = @status,
+    packages = @packages,
+    carrier = @carrier,
+    tracking_number = @tracking_number,
+    allocated_at = @allocated_at,
+    packed_at = @packed_at,
+    shipped_at = @shipped_at,
+    cancelled_at = @cancelled_at
+WHERE id = @id;
+
+-- name: CreateSalesOrderLine :exec
+INSERT INTO sales_order_lines (
+    order_id,
+    product_id,
+    quantity,
+    unit_price
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: ListSalesOrderLines :many
+SELECT id, order_id, product_id, quantity, allocated_quantity, shipped_quantity, unit_price, serials
+FROM sales_order_lines
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: UpdateSalesOrderLine :exec
+UPDATE sales_order_lines
+SET allocated_quantity = $2, shipped_quantity = $3, serials = $4
+WHERE id = $1;
+
+-- name: AllocatedQuantity :one
+-- AllocatedQuantity is the stock of a product reserved by open orders other
+-- than the given one; order 0 counts all open orders.
+SELECT COALESCE(SUM(l.allocated_quantity), 0)::int AS allocated
+FROM sales_order_lines l
+JOIN sales_orders o ON o.id = l.order_id
+WHERE l.product_id = @product_id
+  AND l.order_id <> @order_id
+  AND o.status IN ('allocated', 'picking', 'packed');
@@ -0,0 +1,48 @@
+-- name: ListSerialsByNumber :many
+SELECT id, product_id, serial_number, status, updated_at
+FROM serial_numbers
+WHERE serial_number = ANY(@numbers::text[]);
+
+-- name: GetSerialByNumber :one
+SELECT id, product_id, serial_number, status, updated_at
+FROM serial_numbers
+WHERE serial_number = $1;
+
+-- name: CreateSerial :one
+INSERT INTO serial_numbers (
+    product_id,
+    serial_number,
+    status
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (serial_number) DO NOTHING
+RETURNING id;
+
+-- name: SetSerialStatus :one
+UPDATE serial_numbers
+SET status = @status, updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = @from_status
+RETURNING id;
+
+-- name: CreateStockMovementSerial :exec
+INSERT INTO stock_movement_serials (
+    movement_id,
+    serial_id
+) VALUES (
+    $1, $2
+);
+
+-- name: ListStockMovementSerials :many
+SELECT sms.movement_id, s.serial_number
+FROM stock_movement_serials sms
+JOIN serial_numbers s ON s.id = sms.serial_id
+WHERE s.product_id = $1
+ORDER BY sms.movement_id, s.serial_number;
+
+-- name: ListSerialMovements :many
+SELECT m.id, m.product_id, m.type, m.quantity, m.entered_quantity, m.entered_unit, m.reference, m.created_at, m.warehouse_id
+FROM stock_movements m
+JOIN stock_movement_serials sms ON sms.movement_id = m.id
+WHERE sms.serial_id = $1
+ORDER BY m.created_at, m.id;
@@ -0,0 +1,72 @@
+-- name: ListProductUnits :many
+SELECT product_id, code, factor_num, factor_den, rounding
+FROM product_units
+WHERE product_id = $1
+ORDER BY code;
+
+-- name: UpsertProductUnit :exec
+INSERT INTO product_units (
+    product_id,
+    code,
+    factor_num,
+    factor_den,
+    rounding
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (product_id, code) DO UPDATE
+SET factor_num = EXCLUDED.factor_num,
+    factor_den = EXCLUDED.factor_den,
+    rounding = EXCLUDED.rounding;
+
+-- name: DeleteProductUnit :exec
+DELETE FROM product_units
+WHERE product_id = $1 AND code = $2;
+
+-- name: LockProductQuantity :one
+SELECT quantity
+FROM products
+WHERE id = $1
+FOR UPDATE;
+
+-- name: AddProductQuantity :exec
+UPDATE products
+SET quantity = quantity + $2
+WHERE id = $1;
+
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (
+    product_id,
+    type,
+    quantity,
+    entered_quantity,
+    entered_unit,
+    reference,
+    warehouse_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, created_at;
+
+-- name: ListStockMovements :many
+SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id
+FROM stock_movements
+WHERE product_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: AddProductQuarantinedQuantity :one
+UPDATE products
+SET quarantined_quantity = quarantined_quantity + $2
+WHERE id = $1 AND quarantined_quantity + $2 >= 0
+RETURNING id;
+
+-- name: ListRecentStockMovements :many
+SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id
+FROM (
+    SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id,
+           ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY created_at DESC, id DESC) AS position
+    FROM stock_movements
+    WHERE product_id = ANY(@product_ids::int[])
+) m
+WHERE position <= @per_product::int
+ORDER BY product_id, created_at DESC, id DESC;
@@ -0,0 +1,76 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (
+    name,
+    contact_name,
+    email,
+    phone,
+    address,
+    lead_time_days,
+    payment_terms
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id;
+
+-- name: GetSupplier :one
+SELECT id, name, contact_name, email, phone, address, lead_time_days, payment_terms
+FROM suppliers
+WHERE id = $1;
+
+-- name: ListSuppliers :many
+SELECT id, name, contact_name, email, phone, address, lead_time_days, payment_terms
+FROM suppliers
+ORDER BY name, id;
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+    name = $2,
+    contact_name = $3,
+    email = $4,
+    phone = $5,
+    address = $6,
+    lead_time_days = $7,
+    payment_terms = $8
+WHERE id = $1
+RETURNING id;
+
+-- name: DeleteSupplier :exec
+DELETE FROM suppliers
+WHERE id = $1;
+
+-- name: UpsertProductSupplier :exec
+INSERT INTO product_suppliers (
+    product_id,
+    supplier_id,
+    supplier_sku,
+    cost_price,
+    cost_currency,
+    min_order_quantity
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (product_id, supplier_id) DO UPDATE
+SET supplier_sku = EXCLUDED.supplier_sku,
+    cost_price = EXCLUDED.cost_price,
+    cost_currency = EXCLUDED.cost_currency,
+    min_order_quantity = EXCLUDED.min_order_quantity;
+
+-- name: DeleteProductSupplier :exec
+DELETE FROM product_suppliers
+WHERE product_id = $1 AND supplier_id = $2;
+
+-- name: ListProductSuppliers :many
+SELECT ps.product_id, ps.supplier_id, s.name AS supplier_name, ps.supplier_sku,
+       ps.cost_price, ps.cost_currency, ps.min_order_quantity, s.lead_time_days
+FROM product_suppliers ps
+JOIN suppliers s ON s.id = ps.supplier_id
+WHERE ps.product_id = $1
+ORDER BY ps.cost_currency, ps.cost_price, s.lead_time_days, s.id;
+
+-- name: ListAllProductSuppliers :many
+SELECT ps.product_id, ps.supplier_id, s.name AS supplier_name, ps.supplier_sku,
+       ps.cost_price, ps.cost_currency, ps.min_order_quantity, s.lead_time_days
+FROM product_suppliers ps
+JOIN suppliers s ON s.id = ps.supplier_id
+ORDER BY ps.product_id, ps.cost_currency, ps.cost_price, s.lead_time_days, s.id;
@@ -0,0 +1,72 @@
+-- name: CreateTransfer :one
+INSERT INTO transfer_orders (
+    from_warehouse_id,
+    to_warehouse_id,
+    reference
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id;
+
+-- name: GetTransfer :one
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE id = $1;
+
+-- name: LockTransfer :one
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListTransfers :many
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE (@status::text = '' OR status = @status)
+  AND (sqlc.narg('warehouse_id')::int IS NULL
+       OR from_warehouse_id = sqlc.narg('warehouse_id')
+       OR to_warehouse_id = sqlc.narg('warehouse_id'))
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListOpenTransfers :many
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE status IN ('draft', 'in_transit')
+  AND (sqlc.narg('warehouse_id')::int IS NULL
+       OR from_warehouse_id = sqlc.narg('warehouse_id')
+       OR to_warehouse_id = sqlc.narg('warehouse_id'))
+ORDER BY created_at, id;
+
+-- name: UpdateTransferState :exec
+UPDATE transfer_orders
+SET status = @status,
+    carrier = @carrier,
+    tracking_number = @tracking_number,
+    shipped_at = @shipped_at,
+    received_at = @received_at,
+    cancelled_at = @cancelled_at
+WHERE id = @id;
+
+-- name: CreateTransferLine :exec
+INSERT INTO transfer_lines (
+    transfer_id,
+    product_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListTransferLines :many
+SELECT id, transfer_id, product_id, quantity, shipped_quantity, received_quantity, discrepancy, discrepancy_reason
+FROM transfer_lines
+WHERE transfer_id = $1
+ORDER BY id;
+
+-- name: SetTransferLineQuantities :exec
+UPDATE transfer_lines
+SET shipped_quantity = $2, received_quantity = $3, discrepancy = $4, discrepancy_reason = $5
+WHERE id = $1;
@@ -0,0 +1,52 @@
+-- name: CreateCostLayer :exec
+INSERT INTO cost_layers (
+    product_id,
+    movement_id,
+    unit_cost,
+    currency,
+    quantity,
+    remaining
+) VALUES (
+    $1, $2, $3, $4, $5, $5
+);
+
+-- name: ListOpenCostLayers :many
+SELECT id, unit_cost, remaining
+FROM cost_layers
+WHERE product_id = $1 AND remaining > 0
+ORDER BY created_at, id;
+
+-- name: ConsumeCostLayer :exec
+UPDATE cost_layers
+SET remaining = remaining - @quantity
+WHERE id = @id;
+
+-- name: GetCostTotals :one
+SELECT COALESCE(SUM(quantity), 0)::int AS quantity,
+       COALESCE(SUM(value), 0)::bigint AS value
+FROM cost_entries
+WHERE product_id = $1;
+
+-- name: CreateCostEntry :exec
+INSERT INTO cost_entries (
+    movement_id,
+    product_id,
+    quantity,
+    value,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: ListProductValues :many
+SELECT e.product_id,
+       p.name AS product_name,
+       e.currency,
+       SUM(e.quantity)::int AS quantity,
+       SUM(e.value)::bigint AS value
+FROM cost_entries e
+JOIN products p ON p.id = e.product_id
+WHERE e.created_at <= @as_of
+GROUP BY e.product_id, p.name, e.currency
+HAVING SUM(e.quantity) <> 0 OR SUM(e.value) <> 0
+ORDER BY p.name, e.product_id;
@@ -0,0 +1,67 @@
+-- name: CreateWarehouse :one
+INSERT INTO warehouses (
+    code,
+    name
+) VALUES (
+    $1, $2
+)
+RETURNING id;
+
+-- name: GetWarehouse :one
+SELECT id, code, name, is_default, created_at
+FROM warehouses
+WHERE id = $1;
+
+-- name: ListWarehouses :many
+SELECT id, code, name, is_default, created_at
+FROM warehouses
+ORDER BY id;
+
+-- name: GetDefaultWarehouseID :one
+SELECT id
+FROM warehouses
+WHERE is_default;
+
+-- name: GetWarehouseQuantity :one
+SELECT COALESCE((
+    SELECT quantity
+    FROM warehouse_stock
+    WHERE warehouse_id = @warehouse_id AND product_id = @product_id
+), 0)::int AS quantity;
+
+-- name: AddWarehouseQuantity :one
+-- AddWarehouseQuantity changes the stock of a product at a warehouse; the
+-- check constraint rejects a negative result.
+INSERT INTO warehouse_stock (
+    warehouse_id,
+    product_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (warehouse_id, product_id) DO UPDATE
+SET quantity = warehouse_stock.quantity + EXCLUDED.quantity
+RETURNING quantity;
+
+-- name: ListWarehouseStock :many
+-- ListWarehouseStock returns the products on hand at a warehouse or in transit
+-- to it.
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       COALESCE(ws.quantity, 0)::int AS quantity,
+       COALESCE((
+           SELECT SUM(l.shipped_quantity)
+           FROM transfer_lines l
+           JOIN transfer_orders t ON t.id = l.transfer_id
+           WHERE t.to_warehouse_id = @warehouse_id AND t.status = 'in_transit' AND l.product_id = p.id
+       ), 0)::int AS inbound_quantity
+FROM products p
+LEFT JOIN warehouse_stock ws ON ws.product_id = p.id AND ws.warehouse_id = @warehouse_id
+WHERE ws.quantity > 0
+   OR EXISTS (
+       SELECT 1
+       FROM transfer_lines l
+       JOIN transfer_orders t ON t.id = l.transfer_id
+       WHERE t.to_warehouse_id = @warehouse_id AND t.status = 'in_transit' AND l.product_id = p.id
+   )
+ORDER BY p.id;
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CountRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewCountRepo(pool *pgxpool.Pool) *CountRepo {
+	return &CountRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *CountRepo) Candidates(ctx context.Context, warehouseID int32) ([]cyclecount.Candidate, error) {
+	rows, err := r.q.ListCountCandidates(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	var result []cyclecount.Candidate
+	for _, row := range rows {
+		result = append(result, cyclecount.Candidate{
+			ProductID:   row.ID,
+			ProductName: row.Name,
+			BinLocation: row.BinLocation,
+			Category:    row.Category,
+			Quantity:    row.Quantity,
+		})
+	}
+	return result, nil
+}
+
+func (r *CountRepo) Create(ctx context.Context, s cyclecount.Session) (int32, error) {
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		id, err = q.CreateCountSession(ctx, db.CreateCountSessionParams{
+			WarehouseID: s.WarehouseID,
+			BinLocation: s.BinLocation,
+			Category:    s.Category,
+			AbcClass:    string(s.ABCClass),
+			Blind:       s.Blind,
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range s.Lines {
+			err := q.CreateCountLine(ctx, db.CreateCountLineParams{
+				SessionID:      id,
+				ProductID:      line.ProductID,
+				BinLocation:    line.BinLocation,
+				SystemQuantity: *line.SystemQuantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+func (r *CountRepo) Get(ctx context.Context, id int32) (cyclecount.Session, error) {
+	row, err := r.q.GetCountSession(ctx, id)
+	if isNoRows(err) {
+		return cyclecount.Session{}, cyclecount.ErrNotFound
+	}
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	return withCountLines(ctx, r.q, toCountSession(row))
+}
+
+func (r *CountRepo) List(ctx context.Context, status cyclecount.Status) ([]cyclecount.Session, error) {
+	rows, err := r.q.ListCountSessions(ctx, string(status))
+	if err != nil {
+		return nil, err
+	}
+	var result []cyclecount.Session
+	for _, row := range rows {
+		result = append(result, toCountSession(row))
+	}
+	return result, nil
+}
+
+func (r *CountRepo) Update(ctx context.Context, id int32, fn func(s *cyclecount.Session) ([]stock.Movement, error)) (cyclecount.Session, error) {
+	var s cyclecount.Session
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockCountSession(ctx, id)
+		if isNoRows(err) {
+			return cyclecount.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		s, err = withCountLines(ctx, q, toCountSession(row))
+		if err != nil {
+			return err
+		}
+
+		movements, err := fn(&s)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateCountSessionState(ctx, db.UpdateCountSessionStateParams{
+			ID:          id,
+			Status:      string(s.Status),
+			ApprovedBy:  s.ApprovedBy,
+			SubmittedAt: nullTimestamptz(s.SubmittedAt),
+			ApprovedAt:  nullTimestamptz(s.ApprovedAt),
+			CancelledAt: nullTimestamptz(s.CancelledAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range s.Lines {
+			err := q.SetCountLineQuantity(ctx, db.SetCountLineQuantityParams{
+				ID:              line.ID,
+				CountedQuantity: nullInt4(line.CountedQuantity),
+				SystemQuantity:  *line.SystemQuantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if _, err := applyMovement(ctx, q, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return s, err
+}
+
+func withCountLines(ctx context.Context, q *db.Queries, s cyclecount.Session) (cyclecount.Session, error) {
+	rows, err := q.ListCountLines(ctx, s.ID)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	for _, row := range rows {
+		line := cyclecount.Line{
+			ID:              row.ID,
+			ProductID:       row.ProductID,
+			ProductName:     row.ProductName,
+			BinLocation:     row.BinLocation,
+			SystemQuantity:  &row.SystemQuantity,
+			CountedQuantity: int4Ptr(row.CountedQuantity),
+		}
+		if line.CountedQuantity != nil {
+			variance := *line.CountedQuantity - row.SystemQuantity
+			line.Variance = &variance
+		}
+		s.Lines = append(s.Lines, line)
+	}
+	return s, nil
+}
+
+func toCountSession(row db.CountSession) cyclecount.Session {
+	return cyclecount.Session{
+		ID:          row.ID,
+		WarehouseID: row.WarehouseID,
+		BinLocation: row.BinLocation,
+		Category:    row.Category,
+		ABCClass:    stock.ABCClass(row.AbcClass),
+		Blind:       row.Blind,
+		Status:      cyclecount.Status(row.Status),
+		ApprovedBy:  row.ApprovedBy,
+		CreatedAt:   row.CreatedAt.Time,
+		SubmittedAt: timePtr(row.SubmittedAt),
+		ApprovedAt:  timePtr(row.ApprovedAt),
+		CancelledAt: timePtr(row.CancelledAt),
+	}
+}
@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountRepo_Approve(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r, stocks := NewCountRepo(pool), NewStockRepo(pool)
+	warehouseID := createWarehouse(t, pool)
+	id := createProduct(t, pool, product.Product{})
+
+	apply := func(m stock.Movement) {
+		m.WarehouseID = warehouseID
+		_, err := stocks.ApplyMovement(ctx, m)
+		assert.NoError(t, err)
+	}
+	apply(receipt(id, 100, "1.00"))
+
+	sheet := int32(100)
+	sessionID, err := r.Create(ctx, cyclecount.Session{
+		WarehouseID: warehouseID,
+		Lines:       []cyclecount.Line{{ProductID: id, SystemQuantity: &sheet}},
+	})
+	assert.NoError(t, err)
+
+	// 4 are issued before the line is counted at 90.
+	apply(movement(id, stock.MovementIssue, -4))
+	_, err = r.Update(ctx, sessionID, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		counted, system := int32(90), int32(96)
+		s.Lines[0].CountedQuantity = &counted
+		s.Lines[0].SystemQuantity = &system
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	s, err := r.Get(ctx, sessionID)
+	assert.NoError(t, err)
+	if assert.Len(t, s.Lines, 1) {
+		assert.Equal(t, int32(96), *s.Lines[0].SystemQuantity)
+		assert.Equal(t, int32(-6), *s.Lines[0].Variance)
+	}
+
+	// 2 more are issued before approval, which posts the variance as a costed
+	// adjustment.
+	apply(movement(id, stock.MovementIssue, -2))
+	_, err = r.Update(ctx, sessionID, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		s.Status = cyclecount.StatusApproved
+		m := movement(id, stock.MovementAdjustment, *s.Lines[0].Variance)
+		m.WarehouseID = warehouseID
+		return []stock.Movement{m}, nil
+	})
+	assert.NoError(t, err)
+
+	quantity, err := db.New(pool).GetWarehouseQuantity(ctx, db.GetWarehouseQuantityParams{WarehouseID: warehouseID, ProductID: id})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(88), quantity)
+	valued, value := costTotals(t, pool, id)
+	assert.Equal(t, int32(88), valued)
+	assert.Equal(t, int64(8800), value)
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ForecastRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewForecastRepo(pool *pgxpool.Pool) *ForecastRepo {
+	return &ForecastRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *ForecastRepo) Demand(ctx context.Context, productID *int32, from, to time.Time) ([]forecast.DailyDemand, error) {
+	rows, err := r.q.DailyDemand(ctx, db.DailyDemandParams{FromDate: date(from), ToDate: date(to), ProductID: nullInt4(productID)})
+	if err != nil {
+		return nil, err
+	}
+	var result []forecast.DailyDemand
+	for _, row := range rows {
+		result = append(result, forecast.DailyDemand{ProductID: row.ProductID, Day: row.Day.Time, Quantity: row.Quantity})
+	}
+	return result, nil
+}
+
+func (r *ForecastRepo) Positions(ctx context.Context, productID *int32) ([]forecast.Position, error) {
+	rows, err := r.q.StockPositions(ctx, nullInt4(productID))
+	if err != nil {
+		return nil, err
+	}
+	var result []forecast.Position
+	for _, row := range rows {
+		result = append(result, forecast.Position{
+			ProductID:   row.ProductID,
+			ProductName: row.ProductName,
+			OnHand:      row.OnHand,
+			Allocated:   row.Allocated,
+			OnOrder:     row.OnOrder,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/idempotency"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyRepo struct {
+	q *db.Queries
+}
+
+func NewIdempotencyRepo(pool *pgxpool.Pool) *IdempotencyRepo {
+	return &IdempotencyRepo{q: db.New(pool)}
+}
+
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec idempotency.Record, now, staleBefore time.Time) (idempotency.Record, bool, error) {
+	// The record that kept the key from being reserved may be deleted before it
+	// is read, in which case the key is free again.
+	for range 2 {
+		createdAt, err := r.q.ReserveIdempotencyKey(ctx, db.ReserveIdempotencyKeyParams{
+			Key:         rec.Key,
+			RequestHash: rec.RequestHash,
+			Now:         timestamptz(now),
+			ExpiresAt:   timestamptz(rec.ExpiresAt),
+			StaleBefore: timestamptz(staleBefore),
+		})
+		if err == nil {
+			rec.CreatedAt = createdAt.Time
+			return rec, true, nil
+		}
+		if !isNoRows(err) {
+			return idempotency.Record{}, false, err
+		}
+
+		row, err := r.q.GetIdempotencyKey(ctx, rec.Key)
+		if isNoRows(err) {
+			continue
+		}
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		return toIdempotencyRecord(row), false, nil
+	}
+	return idempotency.Record{}, false, idempotency.ErrInProgress
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, res idempotency.Reservation, resp idempotency.Response) error {
+	return r.q.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		Key:          res.Key,
+		CreatedAt:    timestamptz(res.CreatedAt),
+		StatusCode:   pgtype.Int4{Int32: int32(resp.StatusCode), Valid: true},
+		ContentType:  resp.ContentType,
+		ResponseBody: resp.Body,
+	})
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, res idempotency.Reservation) error {
+	return r.q.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{Key: res.Key, CreatedAt: timestamptz(res.CreatedAt)})
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.q.DeleteExpiredIdempotencyKeys(ctx, timestamptz(now))
+}
+
+func toIdempotencyRecord(row db.IdempotencyKey) idempotency.Record {
+	rec := idempotency.Record{
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		CreatedAt:   row.CreatedAt.Time,
+		ExpiresAt:   row.ExpiresAt.Time,
+	}
+	if row.StatusCode.Valid {
+		rec.Response = &idempotency.Response{
+			StatusCode:  int(row.StatusCode.Int32),
+			ContentType: row.ContentType,
+			Body:        row.ResponseBody,
+		}
+	}
+	return rec
+}
@@ -0,0 +1,302 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PricingRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewPricingRepo(pool *pgxpool.Pool) *PricingRepo {
+	return &PricingRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *PricingRepo) CreatePriceList(ctx context.Context, l pricing.PriceList) (int32, error) {
+	return r.q.CreatePriceList(ctx, db.CreatePriceListParams{
+		Name:          l.Name,
+		Currency:      l.Currency,
+		CustomerGroup: l.CustomerGroup,
+		ValidFrom:     timestamptz(l.ValidFrom),
+		ValidTo:       nullTimestamptz(l.ValidTo),
+	})
+}
+
+func (r *PricingRepo) GetPriceList(ctx context.Context, id int32) (pricing.PriceList, error) {
+	row, err := r.q.GetPriceList(ctx, id)
+	if isNoRows(err) {
+		return pricing.PriceList{}, pricing.ErrNotFound
+	}
+	if err != nil {
+		return pricing.PriceList{}, err
+	}
+
+	list := toPriceList(db.ListPriceListsRow(row))
+	items, err := r.q.ListPriceListItems(ctx, id)
+	if err != nil {
+		return pricing.PriceList{}, err
+	}
+	for _, item := range items {
+		list.Items = append(list.Items, pricing.Item{
+			PriceListID: item.PriceListID,
+			ProductID:   item.ProductID,
+			Price:       money.Money{Amount: item.Price, Currency: list.Currency},
+		})
+	}
+	return list, nil
+}
+
+func (r *PricingRepo) ListPriceLists(ctx context.Context) ([]pricing.PriceList, error) {
+	rows, err := r.q.ListPriceLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []pricing.PriceList
+	for _, row := range rows {
+		result = append(result, toPriceList(row))
+	}
+	return result, nil
+}
+
+func (r *PricingRepo) DeletePriceList(ctx context.Context, id int32) error {
+	return r.q.DeletePriceList(ctx, id)
+}
+
+func (r *PricingRepo) SetItem(ctx context.Context, item pricing.Item) error {
+	return r.q.UpsertPriceListItem(ctx, db.UpsertPriceListItemParams{
+		PriceListID: item.PriceListID,
+		ProductID:   item.ProductID,
+		Price:       item.Price.Amount,
+	})
+}
+
+func (r *PricingRepo) DeleteItem(ctx context.Context, priceListID, productID int32) error {
+	return r.q.DeletePriceListItem(ctx, db.DeletePriceListItemParams{
+		PriceListID: priceListID,
+		ProductID:   productID,
+	})
+}
+
+func (r *PricingRepo) FindPrice(ctx context.Context, productID int32, currency, customerGroup string, at time.Time) (pricing.Item, error) {
+	row, err := r.q.FindApplicablePrice(ctx, db.FindApplicablePriceParams{
+		ProductID:     productID,
+		Currency:      currency,
+		CustomerGroup: customerGroup,
+		At:            timestamptz(at),
+	})
+	if isNoRows(err) {
+		return pricing.Item{}, pricing.ErrNotFound
+	}
+	if err != nil {
+		return pricing.Item{}, err
+	}
+	return pricing.Item{
+		PriceListID: row.ID,
+		ProductID:   productID,
+		Price:       money.Money{Amount: row.Price, Currency: currency},
+	}, nil
+}
+
+func (r *PricingRepo) SaveExchangeRates(ctx context.Context, rates []pricing.ExchangeRate) error {
+	return inTx(ctx, r.pool, func(q *db.Queries) error {
+		for _, rate := range rates {
+			var numeric pgtype.Numeric
+			if err := numeric.Scan(rate.Rate); err != nil {
+				return fmt.Errorf("rate %s/%s: %w", rate.Base, rate.Quote, err)
+			}
+			err := q.UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+				BaseCurrency:  rate.Base,
+				QuoteCurrency: rate.Quote,
+				Rate:          numeric,
+				ValidFrom:     timestamptz(rate.ValidFrom),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *PricingRepo) GetExchangeRate(ctx context.Context, base, quote string, at time.Time) (pricing.ExchangeRate, error) {
+	row, err := r.q.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		At:            timestamptz(at),
+	})
+	if isNoRows(err) {
+		return pricing.ExchangeRate{}, pricing.ErrNotFound
+	}
+	if err != nil {
+		return pricing.ExchangeRate{}, err
+	}
+	return toExchangeRate(row)
+}
+
+func (r *PricingRepo) ListExchangeRates(ctx context.Context) ([]pricing.ExchangeRate, error) {
+	rows, err := r.q.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []pricing.ExchangeRate
+	for _, row := range rows {
+		rate, err := toExchangeRate(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rate)
+	}
+	return result, nil
+}
+
+func toPriceList(row db.ListPriceListsRow) pricing.PriceList {
+	return pricing.PriceList{
+		ID:            row.ID,
+		Name:          row.Name,
+		Currency:      row.Currency,
+		CustomerGroup: row.CustomerGroup,
+		ValidFrom:     row.ValidFrom.Time,
+		ValidTo:       timePtr(row.ValidTo),
+	}
+}
+
+func toExchangeRate(row db.ExchangeRate) (pricing.ExchangeRate, error) {
+	rate, err := numericString(row.Rate)
+	if err != nil {
+		return pricing.ExchangeRate{}, err
+	}
+	return pricing.ExchangeRate{
+		Base:      row.BaseCurrency,
+		Quote:     row.QuoteCurrency,
+		Rate:      rate,
+		ValidFrom: row.ValidFrom.Time,
+	}, nil
+}
+
+func (r *PricingRepo) ListPriceChanges(ctx context.Context, productID int32) ([]pricing.PriceChange, error) {
+	rows, err := r.q.ListPriceChanges(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	var result []pricing.PriceChange
+	for _, row := range rows {
+		result = append(result, toPriceChange(db.GetPriceChangeRow(row)))
+	}
+	return result, nil
+}
+
+func (r *PricingRepo) GetPriceChange(ctx context.Context, id int32) (pricing.PriceChange, error) {
+	row, err := r.q.GetPriceChange(ctx, id)
+	if isNoRows(err) {
+		return pricing.PriceChange{}, pricing.ErrNotFound
+	}
+	if err != nil {
+		return pricing.PriceChange{}, err
+	}
+	return toPriceChange(row), nil
+}
+
+func (r *PricingRepo) SchedulePriceChange(ctx context.Context, pc pricing.PriceChange) (int32, error) {
+	return r.q.CreatePriceChange(ctx, db.CreatePriceChangeParams{
+		ProductID:  pc.ProductID,
+		Price:      pc.Price.Amount,
+		Currency:   pc.Price.Currency,
+		Status:     string(pricing.ChangePending),
+		ValidFrom:  timestamptz(pc.ValidFrom),
+		ValidTo:    nullTimestamptz(pc.ValidTo),
+		CreatedBy:  pc.CreatedBy,
+		ApprovedBy: pc.ApprovedBy,
+	})
+}
+
+func (r *PricingRepo) CancelPriceChange(ctx context.Context, id int32) error {
+	return r.q.SetPriceChangeStatus(ctx, db.SetPriceChangeStatusParams{
+		ID:     id,
+		Status: string(pricing.ChangeCancelled),
+	})
+}
+
+func (r *PricingRepo) ActivateDuePriceChanges(ctx context.Context, now time.Time) ([]pricing.PriceChange, error) {
+	var activated []pricing.PriceChange
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		rows, err := q.ListDuePriceChanges(ctx, timestamptz(now))
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			pc := toPriceChange(db.GetPriceChangeRow(row))
+
+			// Lock the product so its stock cannot change before the price does.
+			onHand, err := q.LockProductQuantity(ctx, row.ProductID)
+			if err != nil {
+				return err
+			}
+			current, err := q.GetProductByID(ctx, row.ProductID)
+			if err != nil {
+				return err
+			}
+			err = checkCurrencyChange(ctx, q, row.ProductID, onHand, current.PriceCurrency, row.Currency)
+			if errors.Is(err, product.ErrCurrencyChange) {
+				if err := q.SetPriceChangeStatus(ctx, db.SetPriceChangeStatusParams{ID: row.ID, Status: string(pricing.ChangeCancelled)}); err != nil {
+					return err
+				}
+				pc.Status = pricing.ChangeCancelled
+				activated = append(activated, pc)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := q.SupersedeActivePrices(ctx, row.ProductID); err != nil {
+				return err
+			}
+			err = q.ActivatePriceChange(ctx, db.ActivatePriceChangeParams{
+				ID:          row.ID,
+				ActivatedAt: timestamptz(now),
+			})
+			if err != nil {
+				return err
+			}
+			err = q.SetProductPrice(ctx, db.SetProductPriceParams{
+				ID:            row.ProductID,
+				Price:         row.Price,
+				PriceCurrency: row.Currency,
+			})
+			if err != nil {
+				return err
+			}
+
+			pc.Status = pricing.ChangeActive
+			pc.ActivatedAt = &now
+			activated = append(activated, pc)
+		}
+		return nil
+	})
+	return activated, err
+}
+
+func toPriceChange(row db.GetPriceChangeRow) pricing.PriceChange {
+	return pricing.PriceChange{
+		ID:          row.ID,
+		ProductID:   row.ProductID,
+		Price:       money.Money{Amount: row.Price, Currency: row.Currency},
+		Status:      pricing.ChangeStatus(row.Status),
+		ValidFrom:   row.ValidFrom.Time,
+		ValidTo:     timePtr(row.ValidTo),
+		CreatedBy:   row.CreatedBy,
+		ApprovedBy:  row.ApprovedBy,
+		ActivatedAt: timePtr(row.ActivatedAt),
+	}
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingRepo_ActivateCurrencyChange(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewPricingRepo(pool)
+	stocked := createProduct(t, pool, product.Product{})
+	empty := createProduct(t, pool, product.Product{})
+
+	_, err := NewStockRepo(pool).ApplyMovement(ctx, receipt(stocked, 3, "1.00"))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	ids := make(map[int32]int32)
+	for _, productID := range []int32{stocked, empty} {
+		ids[productID], err = r.SchedulePriceChange(ctx, pricing.PriceChange{
+			ProductID:  productID,
+			Price:      money.MustParse("2.30", "EUR"),
+			ValidFrom:  now,
+			ApprovedBy: "boss",
+		})
+		assert.NoError(t, err)
+	}
+
+	changes, err := r.ActivateDuePriceChanges(ctx, now.Add(time.Second))
+	assert.NoError(t, err)
+	statuses := make(map[int32]pricing.ChangeStatus)
+	for _, pc := range changes {
+		statuses[pc.ID] = pc.Status
+	}
+	assert.Equal(t, pricing.ChangeCancelled, statuses[ids[stocked]])
+	assert.Equal(t, pricing.ChangeActive, statuses[ids[empty]])
+
+	products := NewProductRepo(pool)
+	p, err := products.GetByID(ctx, stocked)
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", p.Price.Currency)
+	p, err = products.GetByID(ctx, empty)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", p.Price.Currency)
+
+	// The cancelled change is not tried again.
+	changes, err = r.ActivateDuePriceChanges(ctx, now.Add(2*time.Second))
+	assert.NoError(t, err)
+	for _, pc := range changes {
+		assert.NotEqual(t, ids[stocked], pc.ID)
+	}
+}
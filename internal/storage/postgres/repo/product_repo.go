@@ -2,42 +2,167 @@ package repo
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
 	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
 	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type ProductRepo struct {
-	q *db.Queries
+	pool *pgxpool.Pool
+	q    *db.Queries
 }
 
-func NewProductRepo(q *db.Queries) *ProductRepo {
-	return &ProductRepo{q: q}
+func NewProductRepo(pool *pgxpool.Pool) *ProductRepo {
+	return &ProductRepo{pool: pool, q: db.New(pool)}
 }
 
+// Create inserts the product, receives its initial quantity into the default
+// warehouse as opening stock and opens its price history in one transaction.
 func (r *ProductRepo) Create(ctx context.Context, p product.Product) (int32, error) {
-	return r.q.CreateProduct(ctx, db.CreateProductParams{
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		Quantity:    p.Quantity,
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		id, err = q.CreateProduct(ctx, db.CreateProductParams{
+			Name:          p.Name,
+			Description:   p.Description,
+			Price:         p.Price.Amount,
+			PriceCurrency: p.Price.Currency,
+			BaseUnit:      p.BaseUnit,
+			LotTracked:    p.LotTracked,
+			Serialized:    p.Serialized,
+			BinLocation:   p.BinLocation,
+			Category:      p.Category,
+		})
+		if err != nil {
+			return err
+		}
+		if p.Quantity != 0 {
+			unitCost := p.Price
+			if p.OpeningUnitCost != nil {
+				unitCost = *p.OpeningUnitCost
+			}
+			_, err := applyMovement(ctx, q, stock.Movement{
+				ProductID:       id,
+				Type:            stock.MovementReceipt,
+				Quantity:        p.Quantity,
+				EnteredQuantity: strconv.Itoa(int(p.Quantity)),
+				EnteredUnit:     p.BaseUnit,
+				Reference:       "Opening stock",
+				UnitCost:        &unitCost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return openPricePeriod(ctx, q, id, p.Price, time.Now())
 	})
+	return id, err
 }
 
 func (r *ProductRepo) GetByID(ctx context.Context, id int32) (product.Product, error) {
 	row, err := r.q.GetProductByID(ctx, id)
+	if isNoRows(err) {
+		return product.Product{}, product.ErrNotFound
+	}
 	if err != nil {
 		return product.Product{}, err
 	}
-	return product.Product(row), nil
+	return toProduct(db.ListProductsRow(row)), nil
 }
 
-func (r *ProductRepo) Update(ctx context.Context, p product.Product) error {
-	return r.q.UpdateProduct(ctx, db.UpdateProductParams(p))
+// Update saves the product except its quantity, which only changes through stock
+// movements. A changed price closes the current price period and opens a new one
+// so the previous value stays in the history.
+func (r *ProductRepo) Update(ctx context.Context, id int32, fn func(p *product.Product) error) error {
+	return inTx(ctx, r.pool, func(q *db.Queries) error {
+		// Lock the row so fn sees the stock that concurrent movements leave behind
+		// and concurrent price changes close each other's periods in turn.
+		onHand, err := q.LockProductQuantity(ctx, id)
+		if isNoRows(err) {
+			return product.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		row, err := q.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		current := toProduct(db.ListProductsRow(row))
+
+		p := current
+		if err := fn(&p); err != nil {
+			return err
+		}
+		if err := checkCurrencyChange(ctx, q, id, onHand, current.Price.Currency, p.Price.Currency); err != nil {
+			return err
+		}
+
+		err = q.UpdateProduct(ctx, db.UpdateProductParams{
+			ID:            id,
+			Name:          p.Name,
+			Description:   p.Description,
+			Price:         p.Price.Amount,
+			PriceCurrency: p.Price.Currency,
+			LotTracked:    p.LotTracked,
+			Serialized:    p.Serialized,
+			BinLocation:   p.BinLocation,
+			Category:      p.Category,
+		})
+		if err != nil {
+			return err
+		}
+
+		if current.Price == p.Price {
+			return nil
+		}
+
+		now := time.Now()
+		if err := q.CloseOpenPrices(ctx, db.CloseOpenPricesParams{Now: timestamptz(now), ProductID: id}); err != nil {
+			return err
+		}
+		if err := q.SupersedeActivePrices(ctx, id); err != nil {
+			return err
+		}
+		return openPricePeriod(ctx, q, id, p.Price, now)
+	})
 }
 
 func (r *ProductRepo) Delete(ctx context.Context, id int32) error {
-	return r.q.DeleteProduct(ctx, id)
+	return inTx(ctx, r.pool, func(q *db.Queries) error {
+		// Lock the row so no movement adds stock between the check and the delete.
+		onHand, err := q.LockProductQuantity(ctx, id)
+		if isNoRows(err) {
+			return product.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		row, err := q.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		totals, err := q.GetCostTotals(ctx, id)
+		if err != nil {
+			return err
+		}
+		if onHand != 0 || row.QuarantinedQuantity != 0 || totals.Quantity != 0 || totals.Value != 0 {
+			return fmt.Errorf("%w: %d on hand, %d quarantined", product.ErrInUse, onHand, row.QuarantinedQuantity)
+		}
+
+		_, err = q.DeleteProduct(ctx, id)
+		if isForeignKeyViolation(err) {
+			return product.ErrInUse
+		}
+		return err
+	})
 }
 
 func (r *ProductRepo) List(ctx context.Context) ([]product.Product, error) {
@@ -47,7 +172,80 @@ func (r *ProductRepo) List(ctx context.Context) ([]product.Product, error) {
 	}
 	var result []product.Product
 	for _, row := range rows {
-		result = append(result, product.Product(row))
+		result = append(result, toProduct(row))
 	}
 	return result, nil
 }
+
+func (r *ProductRepo) Search(ctx context.Context, f product.Filter) ([]product.Product, int32, error) {
+	rows, err := r.q.SearchProducts(ctx, db.SearchProductsParams{
+		Search:     f.Search,
+		Category:   f.Category,
+		Currency:   f.Currency,
+		InStock:    nullBool(f.InStock),
+		PageLimit:  f.Limit,
+		PageOffset: f.Offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.q.CountProducts(ctx, db.CountProductsParams{
+		Search:   f.Search,
+		Category: f.Category,
+		Currency: f.Currency,
+		InStock:  nullBool(f.InStock),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]product.Product, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toProduct(db.ListProductsRow(row)))
+	}
+	return result, total, nil
+}
+
+// checkCurrencyChange rejects a new price currency for a product that still has
+// stock or stock value, because its cost layers and entries are kept in the
+// current currency. The product must be locked.
+func checkCurrencyChange(ctx context.Context, q *db.Queries, productID, onHand int32, from, to string) error {
+	if from == to {
+		return nil
+	}
+	totals, err := q.GetCostTotals(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if onHand != 0 || totals.Quantity != 0 || totals.Value != 0 {
+		return fmt.Errorf("%w: %s to %s", product.ErrCurrencyChange, from, to)
+	}
+	return nil
+}
+
+func openPricePeriod(ctx context.Context, q *db.Queries, productID int32, price money.Money, now time.Time) error {
+	_, err := q.CreatePriceChange(ctx, db.CreatePriceChangeParams{
+		ProductID:   productID,
+		Price:       price.Amount,
+		Currency:    price.Currency,
+		Status:      string(pricing.ChangeActive),
+		ValidFrom:   timestamptz(now),
+		ActivatedAt: timestamptz(now),
+	})
+	return err
+}
+
+func toProduct(row db.ListProductsRow) product.Product {
+	return product.Product{
+		ID:                  row.ID,
+		Name:                row.Name,
+		Description:         row.Description,
+		Price:               money.Money{Amount: row.Price, Currency: row.PriceCurrency},
+		Quantity:            row.Quantity,
+		BaseUnit:            row.BaseUnit,
+		LotTracked:          row.LotTracked,
+		Serialized:          row.Serialized,
+		BinLocation:         row.BinLocation,
+		QuarantinedQuantity: row.QuarantinedQuantity,
+		Category:            row.Category,
+	}
+}
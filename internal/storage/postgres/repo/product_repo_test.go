@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setPrice(price money.Money) func(p *product.Product) error {
+	return func(p *product.Product) error {
+		p.Price = price
+		return nil
+	}
+}
+
+func TestProductRepo_UpdateCurrency(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	products, stocks := NewProductRepo(pool), NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+
+	_, err := stocks.ApplyMovement(ctx, receipt(id, 2, "1.00"))
+	assert.NoError(t, err)
+	err = products.Update(ctx, id, setPrice(money.MustParse("2.30", "EUR")))
+	assert.ErrorIs(t, err, product.ErrCurrencyChange)
+
+	// Issued stock leaves no value behind, so the currency can change.
+	_, err = stocks.ApplyMovement(ctx, movement(id, stock.MovementIssue, -2))
+	assert.NoError(t, err)
+	err = products.Update(ctx, id, setPrice(money.MustParse("2.30", "EUR")))
+	assert.NoError(t, err)
+
+	p, err := products.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, money.MustParse("2.30", "EUR"), p.Price)
+}
+
+func TestProductRepo_Delete(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	products := NewProductRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+
+	assert.NoError(t, products.Delete(ctx, id))
+	_, err := products.GetByID(ctx, id)
+	assert.ErrorIs(t, err, product.ErrNotFound)
+	assert.ErrorIs(t, products.Delete(ctx, id), product.ErrNotFound)
+}
+
+func TestProductRepo_DeleteInUse(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	products := NewProductRepo(pool)
+	stocks := NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+
+	_, err := stocks.ApplyMovement(ctx, receipt(id, 2, "1.00"))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, products.Delete(ctx, id), product.ErrInUse)
+
+	// Without stock, an order line still refers to the product.
+	_, err = stocks.ApplyMovement(ctx, movement(id, stock.MovementIssue, -2))
+	assert.NoError(t, err)
+	var orderID int32
+	err = pool.QueryRow(ctx, `INSERT INTO sales_orders (customer, status, currency) VALUES ('Acme', 'draft', 'USD') RETURNING id`).Scan(&orderID)
+	assert.NoError(t, err)
+	_, err = pool.Exec(ctx, `INSERT INTO sales_order_lines (order_id, product_id, quantity, unit_price) VALUES ($1, $2, 1, 250)`, orderID, id)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, products.Delete(ctx, id), product.ErrInUse)
+
+	_, err = products.GetByID(ctx, id)
+	assert.NoError(t, err)
+}
+
+func TestProductRepo_CreateOpeningCost(t *testing.T) {
+	pool := testPool(t)
+	cost := money.MustParse("1.20", "USD")
+
+	// The opening stock is valued at its unit cost, or at the price without one.
+	id := createProduct(t, pool, product.Product{Quantity: 5, OpeningUnitCost: &cost})
+	quantity, value := costTotals(t, pool, id)
+	assert.Equal(t, int32(5), quantity)
+	assert.Equal(t, int64(600), value)
+
+	id = createProduct(t, pool, product.Product{Quantity: 2})
+	quantity, value = costTotals(t, pool, id)
+	assert.Equal(t, int32(2), quantity)
+	assert.Equal(t, int64(500), value)
+}
@@ -0,0 +1,219 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PurchaseRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewPurchaseRepo(pool *pgxpool.Pool) *PurchaseRepo {
+	return &PurchaseRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *PurchaseRepo) Create(ctx context.Context, o purchase.Order) (int32, error) {
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		id, err = q.CreatePurchaseOrder(ctx, db.CreatePurchaseOrderParams{
+			SupplierID: o.SupplierID,
+			Currency:   o.Currency,
+			Reference:  o.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		return createPurchaseLines(ctx, q, id, o.Lines)
+	})
+	return id, err
+}
+
+func (r *PurchaseRepo) Get(ctx context.Context, id int32) (purchase.Order, error) {
+	row, err := r.q.GetPurchaseOrder(ctx, id)
+	if isNoRows(err) {
+		return purchase.Order{}, purchase.ErrNotFound
+	}
+	if err != nil {
+		return purchase.Order{}, err
+	}
+	return withPurchaseLines(ctx, r.q, toPurchaseOrder(row))
+}
+
+func (r *PurchaseRepo) List(ctx context.Context, status purchase.Status, supplierID *int32) ([]purchase.Order, error) {
+	rows, err := r.q.ListPurchaseOrders(ctx, db.ListPurchaseOrdersParams{
+		Status:     string(status),
+		SupplierID: nullInt4(supplierID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []purchase.Order
+	for _, row := range rows {
+		result = append(result, toPurchaseOrder(row))
+	}
+	return result, nil
+}
+
+func (r *PurchaseRepo) UpdateDraft(ctx context.Context, o purchase.Order) error {
+	return inTx(ctx, r.pool, func(q *db.Queries) error {
+		_, err := q.UpdateDraftPurchaseOrder(ctx, db.UpdateDraftPurchaseOrderParams{
+			ID:         o.ID,
+			SupplierID: o.SupplierID,
+			Currency:   o.Currency,
+			Reference:  o.Reference,
+		})
+		if isNoRows(err) {
+			return purchase.ErrStatus
+		}
+		if err != nil {
+			return err
+		}
+		if err := q.DeletePurchaseOrderLines(ctx, o.ID); err != nil {
+			return err
+		}
+		return createPurchaseLines(ctx, q, o.ID, o.Lines)
+	})
+}
+
+func (r *PurchaseRepo) Submit(ctx context.Context, id int32, expectedAt time.Time) error {
+	_, err := r.q.SubmitPurchaseOrder(ctx, db.SubmitPurchaseOrderParams{
+		ID:         id,
+		Now:        timestamptz(time.Now()),
+		ExpectedAt: timestamptz(expectedAt),
+	})
+	if isNoRows(err) {
+		return purchase.ErrStatus
+	}
+	return err
+}
+
+func (r *PurchaseRepo) Close(ctx context.Context, id int32) error {
+	_, err := r.q.ClosePurchaseOrder(ctx, db.ClosePurchaseOrderParams{ID: id, Now: timestamptz(time.Now())})
+	if isNoRows(err) {
+		return purchase.ErrStatus
+	}
+	return err
+}
+
+func (r *PurchaseRepo) Receive(ctx context.Context, id int32, fn func(o *purchase.Order) ([]stock.Movement, error)) (purchase.Order, error) {
+	var order purchase.Order
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockPurchaseOrder(ctx, id)
+		if isNoRows(err) {
+			return purchase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		order, err = withPurchaseLines(ctx, q, toPurchaseOrder(row))
+		if err != nil {
+			return err
+		}
+
+		movements, err := fn(&order)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range order.Lines {
+			err := q.SetPurchaseOrderLineReceived(ctx, db.SetPurchaseOrderLineReceivedParams{
+				ID:               line.ID,
+				ReceivedQuantity: line.ReceivedQuantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := q.SetPurchaseOrderStatus(ctx, db.SetPurchaseOrderStatusParams{ID: id, Status: string(order.Status)}); err != nil {
+			return err
+		}
+		for _, m := range movements {
+			if _, err := applyMovement(ctx, q, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return order, err
+}
+
+func (r *PurchaseRepo) StatusReport(ctx context.Context, f purchase.ReportFilter) ([]purchase.StatusSummary, error) {
+	rows, err := r.q.PurchaseOrderStatusReport(ctx, db.PurchaseOrderStatusReportParams{
+		Now:        timestamptz(time.Now()),
+		SupplierID: nullInt4(f.SupplierID),
+		From:       nullTimestamptz(f.From),
+		To:         nullTimestamptz(f.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []purchase.StatusSummary
+	for _, row := range rows {
+		result = append(result, purchase.StatusSummary{
+			Status:           purchase.Status(row.Status),
+			Orders:           row.Orders,
+			Lines:            row.Lines,
+			OrderedQuantity:  row.OrderedQuantity,
+			ReceivedQuantity: row.ReceivedQuantity,
+			OrderedValue:     money.Money{Amount: row.OrderedValue, Currency: row.Currency},
+			ReceivedValue:    money.Money{Amount: row.ReceivedValue, Currency: row.Currency},
+			Overdue:          row.Overdue,
+		})
+	}
+	return result, nil
+}
+
+func createPurchaseLines(ctx context.Context, q *db.Queries, orderID int32, lines []purchase.Line) error {
+	for _, line := range lines {
+		err := q.CreatePurchaseOrderLine(ctx, db.CreatePurchaseOrderLineParams{
+			OrderID:   orderID,
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  line.UnitCost.Amount,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withPurchaseLines(ctx context.Context, q *db.Queries, o purchase.Order) (purchase.Order, error) {
+	rows, err := q.ListPurchaseOrderLines(ctx, o.ID)
+	if err != nil {
+		return purchase.Order{}, err
+	}
+	for _, row := range rows {
+		o.Lines = append(o.Lines, purchase.Line{
+			ID:               row.ID,
+			ProductID:        row.ProductID,
+			Quantity:         row.Quantity,
+			ReceivedQuantity: row.ReceivedQuantity,
+			UnitCost:         money.Money{Amount: row.UnitCost, Currency: o.Currency},
+		})
+	}
+	return o, nil
+}
+
+func toPurchaseOrder(row db.PurchaseOrder) purchase.Order {
+	return purchase.Order{
+		ID:          row.ID,
+		SupplierID:  row.SupplierID,
+		Status:      purchase.Status(row.Status),
+		Currency:    row.Currency,
+		Reference:   row.Reference,
+		ExpectedAt:  timePtr(row.ExpectedAt),
+		CreatedAt:   row.CreatedAt.Time,
+		SubmittedAt: timePtr(row.SubmittedAt),
+		ClosedAt:    timePtr(row.ClosedAt),
+	}
+}
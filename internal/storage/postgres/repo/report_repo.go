@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/report"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReportRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewReportRepo(pool *pgxpool.Pool) *ReportRepo {
+	return &ReportRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *ReportRepo) Turnover(ctx context.Context, from, to time.Time) ([]report.Turnover, error) {
+	rows, err := r.q.TurnoverReport(ctx, db.TurnoverReportParams{FromTime: timestamptz(from), ToTime: timestamptz(to)})
+	if err != nil {
+		return nil, err
+	}
+	var result []report.Turnover
+	for _, row := range rows {
+		result = append(result, report.Turnover{
+			ProductID:       row.ProductID,
+			ProductName:     row.ProductName,
+			OpeningQuantity: row.OpeningQuantity,
+			ClosingQuantity: row.ClosingQuantity,
+			IssuedQuantity:  row.IssuedQuantity,
+		})
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) Aging(ctx context.Context, now time.Time, bounds []int32) ([]report.Aging, error) {
+	rows, err := r.q.StockAgingReport(ctx, db.StockAgingReportParams{Now: date(now), Bounds: bounds})
+	if err != nil {
+		return nil, err
+	}
+	var result []report.Aging
+	for _, row := range rows {
+		if len(result) == 0 || result[len(result)-1].ProductID != row.ProductID {
+			result = append(result, report.Aging{
+				ProductID:   row.ProductID,
+				ProductName: row.ProductName,
+				Quantity:    row.Quantity,
+				Buckets:     make([]report.AgingBucket, len(bounds)+1),
+			})
+		}
+		result[len(result)-1].Buckets[row.Bucket].Quantity += row.BucketQuantity
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) DeadStock(ctx context.Context, since time.Time) ([]report.DeadStock, error) {
+	rows, err := r.q.DeadStockReport(ctx, timestamptz(since))
+	if err != nil {
+		return nil, err
+	}
+	var result []report.DeadStock
+	for _, row := range rows {
+		result = append(result, report.DeadStock{
+			ProductID:      row.ProductID,
+			ProductName:    row.ProductName,
+			Quantity:       row.Quantity,
+			LastMovementAt: timePtr(row.LastMovementAt),
+		})
+	}
+	return result, nil
+}
+
+func (r *ReportRepo) Consumption(ctx context.Context, from, to time.Time) ([]report.Consumption, error) {
+	rows, err := r.q.ConsumptionReport(ctx, db.ConsumptionReportParams{FromTime: timestamptz(from), ToTime: timestamptz(to)})
+	if err != nil {
+		return nil, err
+	}
+	var result []report.Consumption
+	for _, row := range rows {
+		result = append(result, report.Consumption{
+			ProductID:      row.ProductID,
+			ProductName:    row.ProductName,
+			IssuedQuantity: row.IssuedQuantity,
+			Value:          money.Money{Amount: row.Value, Currency: row.Currency},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReturnRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewReturnRepo(pool *pgxpool.Pool) *ReturnRepo {
+	return &ReturnRepo{pool: pool, q: db.New(pool)}
+}
+
+// Create locks the sales order so concurrent returns against the same lines
+// cannot exceed what was shipped.
+func (r *ReturnRepo) Create(ctx context.Context, ret rma.Return, fn func(r *rma.Return, o sales.Order, returned map[int32]rma.Returned) error) (int32, error) {
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockSalesOrder(ctx, ret.OrderID)
+		if isNoRows(err) {
+			return sales.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		order, err := withSalesLines(ctx, q, toSalesOrder(row))
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.ListReturnedLines(ctx, ret.OrderID)
+		if err != nil {
+			return err
+		}
+		returned := make(map[int32]rma.Returned, len(rows))
+		for _, row := range rows {
+			r := returned[row.OrderLineID]
+			r.Quantity += row.Quantity
+			r.Serials = append(r.Serials, row.Serials...)
+			returned[row.OrderLineID] = r
+		}
+
+		if err := fn(&ret, order, returned); err != nil {
+			return err
+		}
+
+		id, err = q.CreateReturn(ctx, db.CreateReturnParams{OrderID: ret.OrderID, Reason: ret.Reason})
+		if err != nil {
+			return err
+		}
+		for _, line := range ret.Lines {
+			err := q.CreateReturnLine(ctx, db.CreateReturnLineParams{
+				ReturnID:    id,
+				OrderLineID: line.OrderLineID,
+				ProductID:   line.ProductID,
+				Quantity:    line.Quantity,
+				// A nil slice would be written as NULL.
+				Serials: append([]string{}, line.Serials...),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+func (r *ReturnRepo) Get(ctx context.Context, id int32) (rma.Return, error) {
+	row, err := r.q.GetReturn(ctx, id)
+	if isNoRows(err) {
+		return rma.Return{}, rma.ErrNotFound
+	}
+	if err != nil {
+		return rma.Return{}, err
+	}
+	return withReturnLines(ctx, r.q, toReturn(row))
+}
+
+func (r *ReturnRepo) List(ctx context.Context, status rma.Status, orderID *int32) ([]rma.Return, error) {
+	rows, err := r.q.ListReturns(ctx, db.ListReturnsParams{
+		Status:  string(status),
+		OrderID: nullInt4(orderID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []rma.Return
+	for _, row := range rows {
+		result = append(result, toReturn(row))
+	}
+	return result, nil
+}
+
+func (r *ReturnRepo) Update(ctx context.Context, id int32, fn func(r *rma.Return) ([]stock.Movement, []stock.SerialUpdate, error)) (rma.Return, error) {
+	var ret rma.Return
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockReturn(ctx, id)
+		if isNoRows(err) {
+			return rma.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		ret, err = withReturnLines(ctx, q, toReturn(row))
+		if err != nil {
+			return err
+		}
+
+		movements, writeOffs, err := fn(&ret)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateReturnState(ctx, db.UpdateReturnStateParams{
+			ID:          id,
+			Status:      string(ret.Status),
+			InspectedAt: nullTimestamptz(ret.InspectedAt),
+			CancelledAt: nullTimestamptz(ret.CancelledAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range ret.Lines {
+			err := q.SetReturnLineDisposition(ctx, db.SetReturnLineDispositionParams{
+				ID:                  line.ID,
+				RestockedQuantity:   line.RestockedQuantity,
+				QuarantinedQuantity: line.QuarantinedQuantity,
+				ScrappedQuantity:    line.ScrappedQuantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if _, err := applyMovement(ctx, q, m); err != nil {
+				return err
+			}
+		}
+		for _, u := range writeOffs {
+			// Scrapped units already exist, so no product is needed to create them.
+			if _, err := applySerialUpdate(ctx, q, 0, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return ret, err
+}
+
+func withReturnLines(ctx context.Context, q *db.Queries, ret rma.Return) (rma.Return, error) {
+	rows, err := q.ListReturnLines(ctx, ret.ID)
+	if err != nil {
+		return rma.Return{}, err
+	}
+	for _, row := range rows {
+		ret.Lines = append(ret.Lines, rma.Line{
+			ID:                  row.ID,
+			OrderLineID:         row.OrderLineID,
+			ProductID:           row.ProductID,
+			Quantity:            row.Quantity,
+			Serials:             row.Serials,
+			RestockedQuantity:   row.RestockedQuantity,
+			QuarantinedQuantity: row.QuarantinedQuantity,
+			ScrappedQuantity:    row.ScrappedQuantity,
+		})
+	}
+	return ret, nil
+}
+
+func toReturn(row db.Return) rma.Return {
+	return rma.Return{
+		ID:          row.ID,
+		OrderID:     row.OrderID,
+		Status:      rma.Status(row.Status),
+		Reason:      row.Reason,
+		CreatedAt:   row.CreatedAt.Time,
+		InspectedAt: timePtr(row.InspectedAt),
+		CancelledAt: timePtr(row.CancelledAt),
+	}
+}
@@ -0,0 +1,197 @@
+package repo
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SalesRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewSalesRepo(pool *pgxpool.Pool) *SalesRepo {
+	return &SalesRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *SalesRepo) Create(ctx context.Context, o sales.Order) (int32, error) {
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		id, err = q.CreateSalesOrder(ctx, db.CreateSalesOrderParams{
+			Customer:      o.Customer,
+			CustomerGroup: o.CustomerGroup,
+			Currency:      o.Currency,
+			Reference:     o.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range o.Lines {
+			err := q.CreateSalesOrderLine(ctx, db.CreateSalesOrderLineParams{
+				OrderID:   id,
+				ProductID: line.ProductID,
+				Quantity:  line.Quantity,
+				UnitPrice: line.UnitPrice.Amount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+func (r *SalesRepo) Get(ctx context.Context, id int32) (sales.Order, error) {
+	row, err := r.q.GetSalesOrder(ctx, id)
+	if isNoRows(err) {
+		return sales.Order{}, sales.ErrNotFound
+	}
+	if err != nil {
+		return sales.Order{}, err
+	}
+	return withSalesLines(ctx, r.q, toSalesOrder(row))
+}
+
+func (r *SalesRepo) List(ctx context.Context, status sales.Status) ([]sales.Order, error) {
+	rows, err := r.q.ListSalesOrders(ctx, string(status))
+	if err != nil {
+		return nil, err
+	}
+	var result []sales.Order
+	for _, row := range rows {
+		result = append(result, toSalesOrder(row))
+	}
+	return result, nil
+}
+
+// Update locks the order row, then the product rows in ID order, so two
+// allocations of the same product see each other's reservations and concurrent
+// orders cannot deadlock on their products.
+func (r *SalesRepo) Update(ctx context.Context, id int32, fn func(o *sales.Order, available sales.Available) ([]stock.Movement, error)) (sales.Order, error) {
+	var order sales.Order
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockSalesOrder(ctx, id)
+		if isNoRows(err) {
+			return sales.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		order, err = withSalesLines(ctx, q, toSalesOrder(row))
+		if err != nil {
+			return err
+		}
+
+		productIDs := make([]int32, 0, len(order.Lines))
+		for _, line := range order.Lines {
+			productIDs = append(productIDs, line.ProductID)
+		}
+		slices.Sort(productIDs)
+
+		// Orders ship from the default warehouse, so only its stock can be allocated.
+		warehouseID, err := q.GetDefaultWarehouseID(ctx)
+		if err != nil {
+			return err
+		}
+		available := make(sales.Available, len(productIDs))
+		for _, productID := range productIDs {
+			if _, err := q.LockProductQuantity(ctx, productID); err != nil {
+				return err
+			}
+			onHand, err := q.GetWarehouseQuantity(ctx, db.GetWarehouseQuantityParams{WarehouseID: warehouseID, ProductID: productID})
+			if err != nil {
+				return err
+			}
+			allocated, err := q.AllocatedQuantity(ctx, db.AllocatedQuantityParams{ProductID: productID, OrderID: id})
+			if err != nil {
+				return err
+			}
+			available[productID] = onHand - allocated
+		}
+
+		movements, err := fn(&order, available)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateSalesOrderState(ctx, db.UpdateSalesOrderStateParams{
+			ID:             id,
+			Status:         string(order.Status),
+			Packages:       order.Packages,
+			Carrier:        order.Carrier,
+			TrackingNumber: order.TrackingNumber,
+			AllocatedAt:    nullTimestamptz(order.AllocatedAt),
+			PackedAt:       nullTimestamptz(order.PackedAt),
+			ShippedAt:      nullTimestamptz(order.ShippedAt),
+			CancelledAt:    nullTimestamptz(order.CancelledAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range order.Lines {
+			err := q.UpdateSalesOrderLine(ctx, db.UpdateSalesOrderLineParams{
+				ID:                line.ID,
+				AllocatedQuantity: line.AllocatedQuantity,
+				ShippedQuantity:   line.ShippedQuantity,
+				// A nil slice would be written as NULL.
+				Serials: append([]string{}, line.Serials...),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if _, err := applyMovement(ctx, q, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return order, err
+}
+
+func withSalesLines(ctx context.Context, q *db.Queries, o sales.Order) (sales.Order, error) {
+	rows, err := q.ListSalesOrderLines(ctx, o.ID)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	for _, row := range rows {
+		o.Lines = append(o.Lines, sales.Line{
+			ID:                row.ID,
+			ProductID:         row.ProductID,
+			Quantity:          row.Quantity,
+			AllocatedQuantity: row.AllocatedQuantity,
+			ShippedQuantity:   row.ShippedQuantity,
+			UnitPrice:         money.Money{Amount: row.UnitPrice, Currency: o.Currency},
+			Serials:           row.Serials,
+		})
+	}
+	return o, nil
+}
+
+func toSalesOrder(row db.SalesOrder) sales.Order {
+	return sales.Order{
+		ID:             row.ID,
+		Customer:       row.Customer,
+		CustomerGroup:  row.CustomerGroup,
+		Status:         sales.Status(row.Status),
+		Currency:       row.Currency,
+		Reference:      row.Reference,
+		Packages:       row.Packages,
+		Carrier:        row.Carrier,
+		TrackingNumber: row.TrackingNumber,
+		CreatedAt:      row.CreatedAt.Time,
+		AllocatedAt:    timePtr(row.AllocatedAt),
+		PackedAt:       timePtr(row.PackedAt),
+		ShippedAt:      timePtr(row.ShippedAt),
+		CancelledAt:    timePtr(row.CancelledAt),
+	}
+}
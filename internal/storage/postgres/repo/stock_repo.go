@@ -0,0 +1,489 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StockRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewStockRepo(pool *pgxpool.Pool) *StockRepo {
+	return &StockRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *StockRepo) ListUnits(ctx context.Context, productID int32) ([]stock.Unit, error) {
+	rows, err := r.q.ListProductUnits(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	var result []stock.Unit
+	for _, row := range rows {
+		result = append(result, stock.Unit{
+			ProductID: row.ProductID,
+			Code:      row.Code,
+			Factor:    stock.Factor{Num: row.FactorNum, Den: row.FactorDen},
+			Rounding:  stock.RoundingRule(row.Rounding),
+		})
+	}
+	return result, nil
+}
+
+func (r *StockRepo) SetUnit(ctx context.Context, u stock.Unit) error {
+	return r.q.UpsertProductUnit(ctx, db.UpsertProductUnitParams{
+		ProductID: u.ProductID,
+		Code:      u.Code,
+		FactorNum: u.Factor.Num,
+		FactorDen: u.Factor.Den,
+		Rounding:  string(u.Rounding),
+	})
+}
+
+func (r *StockRepo) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	return r.q.DeleteProductUnit(ctx, db.DeleteProductUnitParams{ProductID: productID, Code: code})
+}
+
+func (r *StockRepo) ApplyMovement(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		m, err = applyMovement(ctx, q, m)
+		return err
+	})
+	return m, err
+}
+
+func (r *StockRepo) ListMovements(ctx context.Context, productID int32) ([]stock.Movement, error) {
+	rows, err := r.q.ListStockMovements(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	lotRows, err := r.q.ListStockMovementLots(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	serialRows, err := r.q.ListStockMovementSerials(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make(map[int32][]string)
+	for _, row := range serialRows {
+		serials[row.MovementID] = append(serials[row.MovementID], row.SerialNumber)
+	}
+
+	allocations := make(map[int32][]stock.LotAllocation)
+	for _, row := range lotRows {
+		allocations[row.MovementID] = append(allocations[row.MovementID], stock.LotAllocation{
+			LotID:     row.LotID,
+			LotNumber: row.LotNumber,
+			ExpiresAt: datePtr(row.ExpiresAt),
+			Quantity:  row.Quantity,
+		})
+	}
+
+	var result []stock.Movement
+	for _, row := range rows {
+		m := toMovement(row)
+		m.Lots = allocations[m.ID]
+		m.Serials = serials[m.ID]
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (r *StockRepo) ListRecentMovements(ctx context.Context, productIDs []int32, limit int32) ([]stock.Movement, error) {
+	rows, err := r.q.ListRecentStockMovements(ctx, db.ListRecentStockMovementsParams{
+		ProductIds: productIDs,
+		PerProduct: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]stock.Movement, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toMovement(row))
+	}
+	return result, nil
+}
+
+func (r *StockRepo) ListLots(ctx context.Context, productID int32) ([]stock.Lot, error) {
+	rows, err := r.q.ListLots(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	var result []stock.Lot
+	for _, row := range rows {
+		result = append(result, toLot(row))
+	}
+	return result, nil
+}
+
+func (r *StockRepo) FindLot(ctx context.Context, productID int32, number string) (stock.Lot, error) {
+	row, err := r.q.GetLotByNumber(ctx, db.GetLotByNumberParams{ProductID: productID, LotNumber: number})
+	if isNoRows(err) {
+		return stock.Lot{}, stock.ErrLotNotFound
+	}
+	if err != nil {
+		return stock.Lot{}, err
+	}
+	return toLot(db.ListLotsRow(row)), nil
+}
+
+func (r *StockRepo) ListExpiringLots(ctx context.Context, before time.Time) ([]stock.Lot, error) {
+	rows, err := r.q.ListExpiringLots(ctx, date(before))
+	if err != nil {
+		return nil, err
+	}
+	var result []stock.Lot
+	for _, row := range rows {
+		result = append(result, toLot(db.ListLotsRow(row)))
+	}
+	return result, nil
+}
+
+func (r *StockRepo) FindSerials(ctx context.Context, numbers []string) ([]stock.Serial, error) {
+	rows, err := r.q.ListSerialsByNumber(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+	var result []stock.Serial
+	for _, row := range rows {
+		result = append(result, toSerial(db.GetSerialByNumberRow(row)))
+	}
+	return result, nil
+}
+
+func (r *StockRepo) GetSerial(ctx context.Context, number string) (stock.Serial, error) {
+	row, err := r.q.GetSerialByNumber(ctx, number)
+	if isNoRows(err) {
+		return stock.Serial{}, stock.ErrSerialNotFound
+	}
+	if err != nil {
+		return stock.Serial{}, err
+	}
+	return toSerial(row), nil
+}
+
+func (r *StockRepo) ListSerialMovements(ctx context.Context, serialID int32) ([]stock.Movement, error) {
+	rows, err := r.q.ListSerialMovements(ctx, serialID)
+	if err != nil {
+		return nil, err
+	}
+	var result []stock.Movement
+	for _, row := range rows {
+		result = append(result, toMovement(row))
+	}
+	return result, nil
+}
+
+// applyMovement locks the product row, checks the resulting quantity and records
+// the movement. Quarantine movements change the quarantined quantity instead of
+// the sellable one; all other movements also change the stock of their warehouse.
+// Outgoing movements may not take stock allocated to open sales orders; a
+// shipment saves its order as shipped first, so it takes its own allocation.
+// It must run inside a transaction; other repos reuse it so that their documents
+// and the stock change commit together.
+func applyMovement(ctx context.Context, q *db.Queries, m stock.Movement) (stock.Movement, error) {
+	onHand, err := q.LockProductQuantity(ctx, m.ProductID)
+	if isNoRows(err) {
+		return stock.Movement{}, product.ErrNotFound
+	}
+	if err != nil {
+		return stock.Movement{}, err
+	}
+
+	if m.Type == stock.MovementQuarantine {
+		_, err := q.AddProductQuarantinedQuantity(ctx, db.AddProductQuarantinedQuantityParams{ID: m.ProductID, QuarantinedQuantity: m.Quantity})
+		if isNoRows(err) {
+			return stock.Movement{}, fmt.Errorf("%w in quarantine", stock.ErrInsufficientStock)
+		}
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	} else {
+		if int64(onHand)+int64(m.Quantity) < 0 {
+			return stock.Movement{}, stock.ErrInsufficientStock
+		}
+		if err := q.AddProductQuantity(ctx, db.AddProductQuantityParams{ID: m.ProductID, Quantity: m.Quantity}); err != nil {
+			return stock.Movement{}, err
+		}
+		var remaining int32
+		m.WarehouseID, remaining, err = addWarehouseQuantity(ctx, q, m.WarehouseID, m.ProductID, m.Quantity)
+		if err != nil {
+			return stock.Movement{}, err
+		}
+		if m.Quantity < 0 {
+			if err := checkAllocated(ctx, q, m.WarehouseID, m.ProductID, remaining); err != nil {
+				return stock.Movement{}, err
+			}
+		}
+	}
+
+	var warehouseID pgtype.Int4
+	if m.WarehouseID != 0 {
+		warehouseID = pgtype.Int4{Int32: m.WarehouseID, Valid: true}
+	}
+
+	row, err := q.CreateStockMovement(ctx, db.CreateStockMovementParams{
+		ProductID:       m.ProductID,
+		Type:            string(m.Type),
+		Quantity:        m.Quantity,
+		EnteredQuantity: m.EnteredQuantity,
+		EnteredUnit:     m.EnteredUnit,
+		Reference:       m.Reference,
+		WarehouseID:     warehouseID,
+	})
+	if err != nil {
+		return stock.Movement{}, err
+	}
+
+	for i := range m.Lots {
+		alloc := &m.Lots[i]
+		if alloc.LotID == 0 {
+			alloc.LotID, err = ensureLot(ctx, q, m.ProductID, *m.Lot)
+			if err != nil {
+				return stock.Movement{}, err
+			}
+		}
+		_, err = q.AddLotQuantity(ctx, db.AddLotQuantityParams{ID: alloc.LotID, Quantity: alloc.Quantity})
+		if isNoRows(err) {
+			return stock.Movement{}, stock.ErrInsufficientStock
+		}
+		if err != nil {
+			return stock.Movement{}, err
+		}
+		err = q.CreateStockMovementLot(ctx, db.CreateStockMovementLotParams{
+			MovementID: row.ID,
+			LotID:      alloc.LotID,
+			Quantity:   alloc.Quantity,
+		})
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	}
+
+	for _, update := range m.SerialUpdates {
+		id, err := applySerialUpdate(ctx, q, m.ProductID, update)
+		if err != nil {
+			return stock.Movement{}, err
+		}
+		err = q.CreateStockMovementSerial(ctx, db.CreateStockMovementSerialParams{MovementID: row.ID, SerialID: id})
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	}
+
+	if m.Type.Costed() {
+		m.Cost, err = applyCost(ctx, q, m, row.ID)
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	}
+
+	m.ID = row.ID
+	m.CreatedAt = row.CreatedAt.Time
+	return m, nil
+}
+
+// applyCost values a movement in the product currency. Incoming stock opens a
+// cost layer at its unit cost, or at the average cost of the stock on hand if
+// it has none; outgoing stock is taken from the layers by the movement's cost
+// method. The quantity and value change is recorded as a cost entry.
+func applyCost(ctx context.Context, q *db.Queries, m stock.Movement, movementID int32) (*money.Money, error) {
+	p, err := q.GetProductByID(ctx, m.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := q.GetCostTotals(ctx, m.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	movement := pgtype.Int4{Int32: movementID, Valid: true}
+
+	var quantity int32
+	var value int64
+	if m.Quantity > 0 {
+		unitCost := stock.AverageCost(totals.Quantity, totals.Value)
+		if m.UnitCost != nil {
+			unitCost = m.UnitCost.Amount
+		}
+		err := q.CreateCostLayer(ctx, db.CreateCostLayerParams{
+			ProductID:  m.ProductID,
+			MovementID: movement,
+			UnitCost:   unitCost,
+			Currency:   p.PriceCurrency,
+			Quantity:   m.Quantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		quantity, value = m.Quantity, unitCost*int64(m.Quantity)
+	} else {
+		rows, err := q.ListOpenCostLayers(ctx, m.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		layers := make([]stock.CostLayer, 0, len(rows))
+		for _, row := range rows {
+			layers = append(layers, stock.CostLayer{ID: row.ID, UnitCost: row.UnitCost, Remaining: row.Remaining})
+		}
+		takes, costed, cost := stock.IssueCost(m.CostMethod, layers, -m.Quantity, totals.Quantity, totals.Value)
+		for _, take := range takes {
+			if err := q.ConsumeCostLayer(ctx, db.ConsumeCostLayerParams{ID: take.LayerID, Quantity: take.Quantity}); err != nil {
+				return nil, err
+			}
+		}
+		quantity, value = -costed, -cost
+	}
+
+	err = q.CreateCostEntry(ctx, db.CreateCostEntryParams{
+		MovementID: movement,
+		ProductID:  m.ProductID,
+		Quantity:   quantity,
+		Value:      value,
+		Currency:   p.PriceCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &money.Money{Amount: value, Currency: p.PriceCurrency}, nil
+}
+
+// addWarehouseQuantity changes the stock of a product at a warehouse, the
+// default one if none is given, and returns the warehouse and its remaining
+// stock of the product.
+func addWarehouseQuantity(ctx context.Context, q *db.Queries, warehouseID, productID, quantity int32) (int32, int32, error) {
+	if warehouseID == 0 {
+		var err error
+		warehouseID, err = q.GetDefaultWarehouseID(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	remaining, err := q.AddWarehouseQuantity(ctx, db.AddWarehouseQuantityParams{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Quantity:    quantity,
+	})
+	switch {
+	case isCheckViolation(err):
+		return 0, 0, fmt.Errorf("%w at warehouse %d", stock.ErrInsufficientStock, warehouseID)
+	case isForeignKeyViolation(err):
+		return 0, 0, warehouse.ErrNotFound
+	case err != nil:
+		return 0, 0, err
+	}
+	return warehouseID, remaining, nil
+}
+
+// checkAllocated rejects an outgoing movement that leaves less stock at the
+// default warehouse, which sales orders ship from, than open orders allocated.
+// The product must be locked.
+func checkAllocated(ctx context.Context, q *db.Queries, warehouseID, productID, remaining int32) error {
+	defaultID, err := q.GetDefaultWarehouseID(ctx)
+	if err != nil {
+		return err
+	}
+	if warehouseID != defaultID {
+		return nil
+	}
+	allocated, err := q.AllocatedQuantity(ctx, db.AllocatedQuantityParams{ProductID: productID})
+	if err != nil {
+		return err
+	}
+	if remaining < allocated {
+		return fmt.Errorf("%w: %d of the stock is allocated to sales orders", stock.ErrInsufficientStock, allocated)
+	}
+	return nil
+}
+
+// ensureLot returns the ID of the lot a receipt goes into, creating it on first
+// receipt. Concurrent first receipts of a lot share the row one of them inserts,
+// so both must agree on its dates.
+func ensureLot(ctx context.Context, q *db.Queries, productID int32, l stock.Lot) (int32, error) {
+	row, err := q.EnsureLot(ctx, db.EnsureLotParams{
+		ProductID:      productID,
+		LotNumber:      l.Number,
+		ManufacturedAt: nullDate(l.ManufacturedAt),
+		ExpiresAt:      nullDate(l.ExpiresAt),
+	})
+	if err != nil {
+		return 0, err
+	}
+	existing := stock.Lot{ManufacturedAt: datePtr(row.ManufacturedAt), ExpiresAt: datePtr(row.ExpiresAt)}
+	if !existing.Matches(l) {
+		return 0, fmt.Errorf("%w: %s", stock.ErrLotDatesMismatch, l.Number)
+	}
+	return row.ID, nil
+}
+
+// applySerialUpdate creates a new serial or moves an existing one from its expected
+// status, so a unit changed by a concurrent movement is not moved twice.
+func applySerialUpdate(ctx context.Context, q *db.Queries, productID int32, u stock.SerialUpdate) (int32, error) {
+	var id int32
+	var err error
+	if u.ID == 0 {
+		id, err = q.CreateSerial(ctx, db.CreateSerialParams{
+			ProductID:    productID,
+			SerialNumber: u.Number,
+			Status:       string(u.To),
+		})
+	} else {
+		id, err = q.SetSerialStatus(ctx, db.SetSerialStatusParams{
+			ID:         u.ID,
+			FromStatus: string(u.From),
+			Status:     string(u.To),
+		})
+	}
+	if isNoRows(err) {
+		return 0, fmt.Errorf("%w: %s was changed by another movement", stock.ErrSerialStatus, u.Number)
+	}
+	return id, err
+}
+
+func toLot(row db.ListLotsRow) stock.Lot {
+	return stock.Lot{
+		ID:             row.ID,
+		ProductID:      row.ProductID,
+		Number:         row.LotNumber,
+		ManufacturedAt: datePtr(row.ManufacturedAt),
+		ExpiresAt:      datePtr(row.ExpiresAt),
+		Quantity:       row.Quantity,
+	}
+}
+
+func toSerial(row db.GetSerialByNumberRow) stock.Serial {
+	return stock.Serial{
+		ID:        row.ID,
+		ProductID: row.ProductID,
+		Number:    row.SerialNumber,
+		Status:    stock.SerialStatus(row.Status),
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func toMovement(row db.StockMovement) stock.Movement {
+	return stock.Movement{
+		ID:              row.ID,
+		ProductID:       row.ProductID,
+		Type:            stock.MovementType(row.Type),
+		Quantity:        row.Quantity,
+		EnteredQuantity: row.EnteredQuantity,
+		EnteredUnit:     row.EnteredUnit,
+		Reference:       row.Reference,
+		WarehouseID:     row.WarehouseID.Int32,
+		CreatedAt:       row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,198 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/stretchr/testify/assert"
+)
+
+func movement(productID int32, t stock.MovementType, quantity int32) stock.Movement {
+	return stock.Movement{
+		ProductID:       productID,
+		Type:            t,
+		Quantity:        quantity,
+		EnteredQuantity: strconv.Itoa(int(quantity)),
+		EnteredUnit:     "pcs",
+		CostMethod:      stock.CostFIFO,
+	}
+}
+
+func receipt(productID, quantity int32, unitCost string) stock.Movement {
+	m := movement(productID, stock.MovementReceipt, quantity)
+	if unitCost != "" {
+		cost := money.MustParse(unitCost, "USD")
+		m.UnitCost = &cost
+	}
+	return m
+}
+
+func TestApplyMovement_CostMethods(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewStockRepo(pool)
+
+	// 10 at 1.00 and 10 at 1.30 are on hand; 15 are issued.
+	tests := []struct {
+		method    stock.CostMethod
+		cost      int64
+		remaining []int32
+	}{
+		{stock.CostFIFO, 1650, []int32{5}},
+		{stock.CostLIFO, 1800, []int32{5}},
+		{stock.CostAverage, 1725, []int32{5}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			id := createProduct(t, pool, product.Product{})
+			for _, m := range []stock.Movement{receipt(id, 10, "1.00"), receipt(id, 10, "1.30")} {
+				_, err := r.ApplyMovement(ctx, m)
+				assert.NoError(t, err)
+			}
+
+			issue := movement(id, stock.MovementIssue, -15)
+			issue.CostMethod = tt.method
+			m, err := r.ApplyMovement(ctx, issue)
+			assert.NoError(t, err)
+			if assert.NotNil(t, m.Cost) {
+				assert.Equal(t, money.Money{Amount: -tt.cost, Currency: "USD"}, *m.Cost)
+			}
+
+			quantity, value := costTotals(t, pool, id)
+			assert.Equal(t, int32(5), quantity)
+			assert.Equal(t, 2300-tt.cost, value)
+
+			layers, err := db.New(pool).ListOpenCostLayers(ctx, id)
+			assert.NoError(t, err)
+			var remaining []int32
+			for _, l := range layers {
+				remaining = append(remaining, l.Remaining)
+			}
+			assert.Equal(t, tt.remaining, remaining)
+		})
+	}
+}
+
+func TestApplyMovement_ReceiptAtAverageCost(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+
+	for _, m := range []stock.Movement{receipt(id, 10, "1.00"), receipt(id, 10, "1.30")} {
+		_, err := r.ApplyMovement(ctx, m)
+		assert.NoError(t, err)
+	}
+
+	// Without a unit cost the stock is valued at the average of 1.15.
+	m, err := r.ApplyMovement(ctx, receipt(id, 4, ""))
+	assert.NoError(t, err)
+	if assert.NotNil(t, m.Cost) {
+		assert.Equal(t, int64(460), m.Cost.Amount)
+	}
+
+	quantity, value := costTotals(t, pool, id)
+	assert.Equal(t, int32(24), quantity)
+	assert.Equal(t, int64(2760), value)
+}
+
+func TestApplyMovement_UncostedTypes(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+	other := createWarehouse(t, pool)
+
+	_, err := r.ApplyMovement(ctx, receipt(id, 10, "1.00"))
+	assert.NoError(t, err)
+
+	out, err := r.ApplyMovement(ctx, movement(id, stock.MovementTransferOut, -4))
+	assert.NoError(t, err)
+	assert.Nil(t, out.Cost)
+	in := movement(id, stock.MovementTransferIn, 4)
+	in.WarehouseID = other
+	_, err = r.ApplyMovement(ctx, in)
+	assert.NoError(t, err)
+	_, err = r.ApplyMovement(ctx, movement(id, stock.MovementQuarantine, 2))
+	assert.NoError(t, err)
+
+	// Moving stock between warehouses and quarantining returns leaves the value
+	// of the stock alone.
+	quantity, value := costTotals(t, pool, id)
+	assert.Equal(t, int32(10), quantity)
+	assert.Equal(t, int64(1000), value)
+
+	p, err := NewProductRepo(pool).GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(10), p.Quantity)
+	assert.Equal(t, int32(2), p.QuarantinedQuantity)
+}
+
+func TestApplyMovement_InsufficientStock(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+	other := createWarehouse(t, pool)
+
+	_, err := r.ApplyMovement(ctx, receipt(id, 5, "1.00"))
+	assert.NoError(t, err)
+
+	_, err = r.ApplyMovement(ctx, movement(id, stock.MovementIssue, -6))
+	assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+
+	// The product has stock, but not at the other warehouse; the change of the
+	// product quantity is rolled back with the rest of the movement.
+	issue := movement(id, stock.MovementIssue, -1)
+	issue.WarehouseID = other
+	_, err = r.ApplyMovement(ctx, issue)
+	assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+
+	p, err := NewProductRepo(pool).GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), p.Quantity)
+	movements, err := r.ListMovements(ctx, id)
+	assert.NoError(t, err)
+	assert.Len(t, movements, 1)
+	quantity, value := costTotals(t, pool, id)
+	assert.Equal(t, int32(5), quantity)
+	assert.Equal(t, int64(500), value)
+}
+
+func TestApplyMovement_AllocatedStock(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r := NewStockRepo(pool)
+	id := createProduct(t, pool, product.Product{})
+	other := createWarehouse(t, pool)
+
+	_, err := r.ApplyMovement(ctx, receipt(id, 5, "1.00"))
+	assert.NoError(t, err)
+	in := receipt(id, 5, "1.00")
+	in.WarehouseID = other
+	_, err = r.ApplyMovement(ctx, in)
+	assert.NoError(t, err)
+
+	var orderID int32
+	err = pool.QueryRow(ctx, `INSERT INTO sales_orders (customer, status, currency) VALUES ('Acme', 'allocated', 'USD') RETURNING id`).Scan(&orderID)
+	assert.NoError(t, err)
+	_, err = pool.Exec(ctx, `INSERT INTO sales_order_lines (order_id, product_id, quantity, allocated_quantity, unit_price) VALUES ($1, $2, 4, 4, 250)`, orderID, id)
+	assert.NoError(t, err)
+
+	// Four of the five units at the default warehouse are allocated.
+	_, err = r.ApplyMovement(ctx, movement(id, stock.MovementIssue, -2))
+	assert.ErrorIs(t, err, stock.ErrInsufficientStock)
+	_, err = r.ApplyMovement(ctx, movement(id, stock.MovementIssue, -1))
+	assert.NoError(t, err)
+
+	// Orders do not ship from the other warehouse, so its stock is free.
+	issue := movement(id, stock.MovementIssue, -5)
+	issue.WarehouseID = other
+	_, err = r.ApplyMovement(ctx, issue)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SupplierRepo struct {
+	q *db.Queries
+}
+
+func NewSupplierRepo(pool *pgxpool.Pool) *SupplierRepo {
+	return &SupplierRepo{q: db.New(pool)}
+}
+
+func (r *SupplierRepo) Create(ctx context.Context, s supplier.Supplier) (int32, error) {
+	return r.q.CreateSupplier(ctx, db.CreateSupplierParams{
+		Name:         s.Name,
+		ContactName:  s.ContactName,
+		Email:        s.Email,
+		Phone:        s.Phone,
+		Address:      s.Address,
+		LeadTimeDays: s.LeadTimeDays,
+		PaymentTerms: s.PaymentTerms,
+	})
+}
+
+func (r *SupplierRepo) GetByID(ctx context.Context, id int32) (supplier.Supplier, error) {
+	row, err := r.q.GetSupplier(ctx, id)
+	if isNoRows(err) {
+		return supplier.Supplier{}, supplier.ErrNotFound
+	}
+	if err != nil {
+		return supplier.Supplier{}, err
+	}
+	return toSupplier(db.ListSuppliersRow(row)), nil
+}
+
+func (r *SupplierRepo) Update(ctx context.Context, s supplier.Supplier) error {
+	_, err := r.q.UpdateSupplier(ctx, db.UpdateSupplierParams{
+		ID:           s.ID,
+		Name:         s.Name,
+		ContactName:  s.ContactName,
+		Email:        s.Email,
+		Phone:        s.Phone,
+		Address:      s.Address,
+		LeadTimeDays: s.LeadTimeDays,
+		PaymentTerms: s.PaymentTerms,
+	})
+	if isNoRows(err) {
+		return supplier.ErrNotFound
+	}
+	return err
+}
+
+func (r *SupplierRepo) Delete(ctx context.Context, id int32) error {
+	err := r.q.DeleteSupplier(ctx, id)
+	if isForeignKeyViolation(err) {
+		return supplier.ErrInUse
+	}
+	return err
+}
+
+func (r *SupplierRepo) List(ctx context.Context) ([]supplier.Supplier, error) {
+	rows, err := r.q.ListSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []supplier.Supplier
+	for _, row := range rows {
+		result = append(result, toSupplier(row))
+	}
+	return result, nil
+}
+
+func (r *SupplierRepo) SetProductSupplier(ctx context.Context, ps supplier.ProductSupplier) error {
+	return r.q.UpsertProductSupplier(ctx, db.UpsertProductSupplierParams{
+		ProductID:        ps.ProductID,
+		SupplierID:       ps.SupplierID,
+		SupplierSku:      ps.SupplierSKU,
+		CostPrice:        ps.CostPrice.Amount,
+		CostCurrency:     ps.CostPrice.Currency,
+		MinOrderQuantity: ps.MinOrderQty,
+	})
+}
+
+func (r *SupplierRepo) DeleteProductSupplier(ctx context.Context, productID, supplierID int32) error {
+	return r.q.DeleteProductSupplier(ctx, db.DeleteProductSupplierParams{ProductID: productID, SupplierID: supplierID})
+}
+
+func (r *SupplierRepo) ListProductSuppliers(ctx context.Context, productID int32) ([]supplier.ProductSupplier, error) {
+	rows, err := r.q.ListProductSuppliers(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	var result []supplier.ProductSupplier
+	for _, row := range rows {
+		result = append(result, toProductSupplier(db.ListAllProductSuppliersRow(row)))
+	}
+	return result, nil
+}
+
+func (r *SupplierRepo) ListAllProductSuppliers(ctx context.Context) ([]supplier.ProductSupplier, error) {
+	rows, err := r.q.ListAllProductSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []supplier.ProductSupplier
+	for _, row := range rows {
+		result = append(result, toProductSupplier(row))
+	}
+	return result, nil
+}
+
+func toProductSupplier(row db.ListAllProductSuppliersRow) supplier.ProductSupplier {
+	return supplier.ProductSupplier{
+		ProductID:    row.ProductID,
+		SupplierID:   row.SupplierID,
+		SupplierName: row.SupplierName,
+		SupplierSKU:  row.SupplierSku,
+		CostPrice:    money.Money{Amount: row.CostPrice, Currency: row.CostCurrency},
+		MinOrderQty:  row.MinOrderQuantity,
+		LeadTimeDays: row.LeadTimeDays,
+	}
+}
+
+func toSupplier(row db.ListSuppliersRow) supplier.Supplier {
+	return supplier.Supplier{
+		ID:           row.ID,
+		Name:         row.Name,
+		ContactName:  row.ContactName,
+		Email:        row.Email,
+		Phone:        row.Phone,
+		Address:      row.Address,
+		LeadTimeDays: row.LeadTimeDays,
+		PaymentTerms: row.PaymentTerms,
+	}
+}
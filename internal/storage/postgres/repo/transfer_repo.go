@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TransferRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewTransferRepo(pool *pgxpool.Pool) *TransferRepo {
+	return &TransferRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *TransferRepo) Create(ctx context.Context, t transfer.Transfer) (int32, error) {
+	var id int32
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		var err error
+		id, err = q.CreateTransfer(ctx, db.CreateTransferParams{
+			FromWarehouseID: t.FromWarehouseID,
+			ToWarehouseID:   t.ToWarehouseID,
+			Reference:       t.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range t.Lines {
+			err := q.CreateTransferLine(ctx, db.CreateTransferLineParams{
+				TransferID: id,
+				ProductID:  line.ProductID,
+				Quantity:   line.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+func (r *TransferRepo) Get(ctx context.Context, id int32) (transfer.Transfer, error) {
+	row, err := r.q.GetTransfer(ctx, id)
+	if isNoRows(err) {
+		return transfer.Transfer{}, transfer.ErrNotFound
+	}
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	return withTransferLines(ctx, r.q, toTransfer(row))
+}
+
+func (r *TransferRepo) List(ctx context.Context, status transfer.Status, warehouseID *int32) ([]transfer.Transfer, error) {
+	rows, err := r.q.ListTransfers(ctx, db.ListTransfersParams{
+		Status:      string(status),
+		WarehouseID: nullInt4(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []transfer.Transfer
+	for _, row := range rows {
+		result = append(result, toTransfer(row))
+	}
+	return result, nil
+}
+
+func (r *TransferRepo) ListOpen(ctx context.Context, warehouseID *int32) ([]transfer.Transfer, error) {
+	rows, err := r.q.ListOpenTransfers(ctx, nullInt4(warehouseID))
+	if err != nil {
+		return nil, err
+	}
+	var result []transfer.Transfer
+	for _, row := range rows {
+		t, err := withTransferLines(ctx, r.q, toTransfer(row))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (r *TransferRepo) Update(ctx context.Context, id int32, fn func(t *transfer.Transfer) ([]stock.Movement, error)) (transfer.Transfer, error) {
+	var t transfer.Transfer
+	err := inTx(ctx, r.pool, func(q *db.Queries) error {
+		row, err := q.LockTransfer(ctx, id)
+		if isNoRows(err) {
+			return transfer.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		t, err = withTransferLines(ctx, q, toTransfer(row))
+		if err != nil {
+			return err
+		}
+
+		movements, err := fn(&t)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateTransferState(ctx, db.UpdateTransferStateParams{
+			ID:             id,
+			Status:         string(t.Status),
+			Carrier:        t.Carrier,
+			TrackingNumber: t.TrackingNumber,
+			ShippedAt:      nullTimestamptz(t.ShippedAt),
+			ReceivedAt:     nullTimestamptz(t.ReceivedAt),
+			CancelledAt:    nullTimestamptz(t.CancelledAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, line := range t.Lines {
+			err := q.SetTransferLineQuantities(ctx, db.SetTransferLineQuantitiesParams{
+				ID:                line.ID,
+				ShippedQuantity:   line.ShippedQuantity,
+				ReceivedQuantity:  line.ReceivedQuantity,
+				Discrepancy:       line.Discrepancy,
+				DiscrepancyReason: line.DiscrepancyReason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range movements {
+			if _, err := applyMovement(ctx, q, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return t, err
+}
+
+func withTransferLines(ctx context.Context, q *db.Queries, t transfer.Transfer) (transfer.Transfer, error) {
+	rows, err := q.ListTransferLines(ctx, t.ID)
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	for _, row := range rows {
+		t.Lines = append(t.Lines, transfer.Line{
+			ID:                row.ID,
+			ProductID:         row.ProductID,
+			Quantity:          row.Quantity,
+			ShippedQuantity:   row.ShippedQuantity,
+			ReceivedQuantity:  row.ReceivedQuantity,
+			Discrepancy:       row.Discrepancy,
+			DiscrepancyReason: row.DiscrepancyReason,
+		})
+	}
+	return t, nil
+}
+
+func toTransfer(row db.TransferOrder) transfer.Transfer {
+	return transfer.Transfer{
+		ID:              row.ID,
+		FromWarehouseID: row.FromWarehouseID,
+		ToWarehouseID:   row.ToWarehouseID,
+		Status:          transfer.Status(row.Status),
+		Reference:       row.Reference,
+		Carrier:         row.Carrier,
+		TrackingNumber:  row.TrackingNumber,
+		CreatedAt:       row.CreatedAt.Time,
+		ShippedAt:       timePtr(row.ShippedAt),
+		ReceivedAt:      timePtr(row.ReceivedAt),
+		CancelledAt:     timePtr(row.CancelledAt),
+	}
+}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferRepo_ReceiveDiscrepancies(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	r, stocks := NewTransferRepo(pool), NewStockRepo(pool)
+	from, to := createWarehouse(t, pool), createWarehouse(t, pool)
+	short := createProduct(t, pool, product.Product{})
+	excess := createProduct(t, pool, product.Product{})
+
+	// Both products have 10 at 1.00 and 10 at 2.00 at the source.
+	at := func(m stock.Movement, warehouseID int32) stock.Movement {
+		m.WarehouseID = warehouseID
+		return m
+	}
+	for _, id := range []int32{short, excess} {
+		for _, m := range []stock.Movement{receipt(id, 10, "1.00"), receipt(id, 10, "2.00")} {
+			_, err := stocks.ApplyMovement(ctx, at(m, from))
+			assert.NoError(t, err)
+		}
+	}
+
+	id, err := r.Create(ctx, transfer.Transfer{
+		FromWarehouseID: from,
+		ToWarehouseID:   to,
+		Lines:           []transfer.Line{{ProductID: short, Quantity: 6}, {ProductID: excess, Quantity: 6}},
+	})
+	assert.NoError(t, err)
+	_, err = r.Update(ctx, id, func(tr *transfer.Transfer) ([]stock.Movement, error) {
+		tr.Status = transfer.StatusInTransit
+		var movements []stock.Movement
+		for i := range tr.Lines {
+			tr.Lines[i].ShippedQuantity = 6
+			movements = append(movements, at(movement(tr.Lines[i].ProductID, stock.MovementTransferOut, -6), from))
+		}
+		return movements, nil
+	})
+	assert.NoError(t, err)
+
+	// One unit of the first product is lost in transit and one extra of the
+	// second arrives.
+	tr, err := r.Update(ctx, id, func(tr *transfer.Transfer) ([]stock.Movement, error) {
+		tr.Status = transfer.StatusReceived
+		tr.Lines[0].ReceivedQuantity, tr.Lines[0].Discrepancy, tr.Lines[0].DiscrepancyReason = 5, -1, "Broken"
+		tr.Lines[1].ReceivedQuantity, tr.Lines[1].Discrepancy, tr.Lines[1].DiscrepancyReason = 7, 1, "Extra"
+		return []stock.Movement{
+			at(movement(short, stock.MovementTransferIn, 6), to),
+			at(movement(short, stock.MovementAdjustment, -1), to),
+			at(movement(excess, stock.MovementTransferIn, 6), to),
+			at(movement(excess, stock.MovementAdjustment, 1), to),
+		}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.StatusReceived, tr.Status)
+
+	products := NewProductRepo(pool)
+	p, err := products.GetByID(ctx, short)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(19), p.Quantity)
+	quantity, value := costTotals(t, pool, short)
+	assert.Equal(t, int32(19), quantity)
+	// The lost unit leaves at its FIFO cost of 1.00.
+	assert.Equal(t, int64(2900), value)
+
+	p, err = products.GetByID(ctx, excess)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(21), p.Quantity)
+	quantity, value = costTotals(t, pool, excess)
+	assert.Equal(t, int32(21), quantity)
+	// The extra unit enters at the average cost of 1.50.
+	assert.Equal(t, int64(3150), value)
+}
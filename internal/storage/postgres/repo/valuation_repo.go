@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/valuation"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ValuationRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewValuationRepo(pool *pgxpool.Pool) *ValuationRepo {
+	return &ValuationRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *ValuationRepo) ProductValues(ctx context.Context, asOf time.Time) ([]valuation.ProductValue, error) {
+	rows, err := r.q.ListProductValues(ctx, timestamptz(asOf))
+	if err != nil {
+		return nil, err
+	}
+	var result []valuation.ProductValue
+	for _, row := range rows {
+		result = append(result, valuation.ProductValue{
+			ProductID:   row.ProductID,
+			ProductName: row.ProductName,
+			Quantity:    row.Quantity,
+			Value:       money.Money{Amount: row.Value, Currency: row.Currency},
+		})
+	}
+	return result, nil
+}
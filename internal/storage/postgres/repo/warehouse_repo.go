@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	db "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WarehouseRepo struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func NewWarehouseRepo(pool *pgxpool.Pool) *WarehouseRepo {
+	return &WarehouseRepo{pool: pool, q: db.New(pool)}
+}
+
+func (r *WarehouseRepo) Create(ctx context.Context, w warehouse.Warehouse) (int32, error) {
+	id, err := r.q.CreateWarehouse(ctx, db.CreateWarehouseParams{Code: w.Code, Name: w.Name})
+	if isUniqueViolation(err) {
+		return 0, warehouse.ErrCodeTaken
+	}
+	return id, err
+}
+
+func (r *WarehouseRepo) Get(ctx context.Context, id int32) (warehouse.Warehouse, error) {
+	row, err := r.q.GetWarehouse(ctx, id)
+	if isNoRows(err) {
+		return warehouse.Warehouse{}, warehouse.ErrNotFound
+	}
+	if err != nil {
+		return warehouse.Warehouse{}, err
+	}
+	return toWarehouse(row), nil
+}
+
+func (r *WarehouseRepo) List(ctx context.Context) ([]warehouse.Warehouse, error) {
+	rows, err := r.q.ListWarehouses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []warehouse.Warehouse
+	for _, row := range rows {
+		result = append(result, toWarehouse(row))
+	}
+	return result, nil
+}
+
+func (r *WarehouseRepo) Stock(ctx context.Context, id int32) ([]warehouse.Stock, error) {
+	rows, err := r.q.ListWarehouseStock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var result []warehouse.Stock
+	for _, row := range rows {
+		result = append(result, warehouse.Stock(row))
+	}
+	return result, nil
+}
+
+func toWarehouse(row db.Warehouse) warehouse.Warehouse {
+	return warehouse.Warehouse{
+		ID:        row.ID,
+		Code:      row.Code,
+		Name:      row.Name,
+		Default:   row.IsDefault,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,314 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: count.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCountLine = `-- name: CreateCountLine :exec
+INSERT INTO count_lines (
+    session_id,
+    product_id,
+    bin_location,
+    system_quantity
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type CreateCountLineParams struct {
+	SessionID      int32  `json:"session_id"`
+	ProductID      int32  `json:"product_id"`
+	BinLocation    string `json:"bin_location"`
+	SystemQuantity int32  `json:"system_quantity"`
+}
+
+func (q *Queries) CreateCountLine(ctx context.Context, arg CreateCountLineParams) error {
+	_, err := q.db.Exec(ctx, createCountLine,
+		arg.SessionID,
+		arg.ProductID,
+		arg.BinLocation,
+		arg.SystemQuantity,
+	)
+	return err
+}
+
+const createCountSession = `-- name: CreateCountSession :one
+INSERT INTO count_sessions (
+    warehouse_id,
+    bin_location,
+    category,
+    abc_class,
+    blind
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id
+`
+
+type CreateCountSessionParams struct {
+	WarehouseID int32  `json:"warehouse_id"`
+	BinLocation string `json:"bin_location"`
+	Category    string `json:"category"`
+	AbcClass    string `json:"abc_class"`
+	Blind       bool   `json:"blind"`
+}
+
+func (q *Queries) CreateCountSession(ctx context.Context, arg CreateCountSessionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createCountSession,
+		arg.WarehouseID,
+		arg.BinLocation,
+		arg.Category,
+		arg.AbcClass,
+		arg.Blind,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getCountSession = `-- name: GetCountSession :one
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE id = $1
+`
+
+func (q *Queries) GetCountSession(ctx context.Context, id int32) (CountSession, error) {
+	row := q.db.QueryRow(ctx, getCountSession, id)
+	var i CountSession
+	err := row.Scan(
+		&i.ID,
+		&i.WarehouseID,
+		&i.BinLocation,
+		&i.Category,
+		&i.AbcClass,
+		&i.Blind,
+		&i.Status,
+		&i.ApprovedBy,
+		&i.CreatedAt,
+		&i.SubmittedAt,
+		&i.ApprovedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const listCountCandidates = `-- name: ListCountCandidates :many
+SELECT p.id,
+       p.name,
+       p.bin_location,
+       p.category,
+       COALESCE(ws.quantity, 0)::int AS quantity
+FROM products p
+LEFT JOIN warehouse_stock ws ON ws.product_id = p.id AND ws.warehouse_id = $1
+WHERE NOT p.lot_tracked AND NOT p.serialized
+ORDER BY p.bin_location, p.name, p.id
+`
+
+type ListCountCandidatesRow struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	BinLocation string `json:"bin_location"`
+	Category    string `json:"category"`
+	Quantity    int32  `json:"quantity"`
+}
+
+// ListCountCandidates returns every product whose stock is not tracked per lot
+// or serial, with its stock at the warehouse.
+func (q *Queries) ListCountCandidates(ctx context.Context, warehouseID int32) ([]ListCountCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listCountCandidates, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCountCandidatesRow{}
+	for rows.Next() {
+		var i ListCountCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.BinLocation,
+			&i.Category,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCountLines = `-- name: ListCountLines :many
+SELECT l.id, l.session_id, l.product_id, p.name AS product_name, l.bin_location,
+       l.system_quantity, l.counted_quantity
+FROM count_lines l
+JOIN products p ON p.id = l.product_id
+WHERE l.session_id = $1
+ORDER BY l.bin_location, p.name, l.id
+`
+
+type ListCountLinesRow struct {
+	ID              int32       `json:"id"`
+	SessionID       int32       `json:"session_id"`
+	ProductID       int32       `json:"product_id"`
+	ProductName     string      `json:"product_name"`
+	BinLocation     string      `json:"bin_location"`
+	SystemQuantity  int32       `json:"system_quantity"`
+	CountedQuantity pgtype.Int4 `json:"counted_quantity"`
+}
+
+func (q *Queries) ListCountLines(ctx context.Context, sessionID int32) ([]ListCountLinesRow, error) {
+	rows, err := q.db.Query(ctx, listCountLines, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCountLinesRow{}
+	for rows.Next() {
+		var i ListCountLinesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.BinLocation,
+			&i.SystemQuantity,
+			&i.CountedQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCountSessions = `-- name: ListCountSessions :many
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE ($1::text = '' OR status = $1)
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListCountSessions(ctx context.Context, status string) ([]CountSession, error) {
+	rows, err := q.db.Query(ctx, listCountSessions, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountSession{}
+	for rows.Next() {
+		var i CountSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.WarehouseID,
+			&i.BinLocation,
+			&i.Category,
+			&i.AbcClass,
+			&i.Blind,
+			&i.Status,
+			&i.ApprovedBy,
+			&i.CreatedAt,
+			&i.SubmittedAt,
+			&i.ApprovedAt,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCountSession = `-- name: LockCountSession :one
+SELECT id, warehouse_id, bin_location, category, abc_class, blind, status, approved_by,
+       created_at, submitted_at, approved_at, cancelled_at
+FROM count_sessions
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockCountSession(ctx context.Context, id int32) (CountSession, error) {
+	row := q.db.QueryRow(ctx, lockCountSession, id)
+	var i CountSession
+	err := row.Scan(
+		&i.ID,
+		&i.WarehouseID,
+		&i.BinLocation,
+		&i.Category,
+		&i.AbcClass,
+		&i.Blind,
+		&i.Status,
+		&i.ApprovedBy,
+		&i.CreatedAt,
+		&i.SubmittedAt,
+		&i.ApprovedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const setCountLineQuantity = `-- name: SetCountLineQuantity :exec
+UPDATE count_lines
+SET counted_quantity = $2,
+    system_quantity = $3
+WHERE id = $1
+`
+
+type SetCountLineQuantityParams struct {
+	ID              int32       `json:"id"`
+	CountedQuantity pgtype.Int4 `json:"counted_quantity"`
+	SystemQuantity  int32       `json:"system_quantity"`
+}
+
+func (q *Queries) SetCountLineQuantity(ctx context.Context, arg SetCountLineQuantityParams) error {
+	_, err := q.db.Exec(ctx, setCountLineQuantity, arg.ID, arg.CountedQuantity, arg.SystemQuantity)
+	return err
+}
+
+const updateCountSessionState = `-- name: UpdateCountSessionState :exec
+UPDATE count_sessions
+SET status = $1,
+    approved_by = $2,
+    submitted_at = $3,
+    approved_at = $4,
+    cancelled_at = $5
+WHERE id = $6
+`
+
+type UpdateCountSessionStateParams struct {
+	Status      string             `json:"status"`
+	ApprovedBy  string             `json:"approved_by"`
+	SubmittedAt pgtype.Timestamptz `json:"submitted_at"`
+	ApprovedAt  pgtype.Timestamptz `json:"approved_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	ID          int32              `json:"id"`
+}
+
+func (q *Queries) UpdateCountSessionState(ctx context.Context, arg UpdateCountSessionStateParams) error {
+	_, err := q.db.Exec(ctx, updateCountSessionState,
+		arg.Status,
+		arg.ApprovedBy,
+		arg.SubmittedAt,
+		arg.ApprovedAt,
+		arg.CancelledAt,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,114 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: forecast.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const dailyDemand = `-- name: DailyDemand :many
+SELECT sm.product_id,
+       sm.created_at::date AS day,
+       SUM(-sm.quantity)::int AS quantity
+FROM stock_movements sm
+WHERE sm.type = 'issue'
+  AND sm.created_at >= $1::date
+  AND sm.created_at < $2::date
+  AND ($3::int IS NULL OR sm.product_id = $3)
+GROUP BY sm.product_id, sm.created_at::date
+ORDER BY sm.product_id, day
+`
+
+type DailyDemandParams struct {
+	FromDate  pgtype.Date `json:"from_date"`
+	ToDate    pgtype.Date `json:"to_date"`
+	ProductID pgtype.Int4 `json:"product_id"`
+}
+
+type DailyDemandRow struct {
+	ProductID int32       `json:"product_id"`
+	Day       pgtype.Date `json:"day"`
+	Quantity  int32       `json:"quantity"`
+}
+
+func (q *Queries) DailyDemand(ctx context.Context, arg DailyDemandParams) ([]DailyDemandRow, error) {
+	rows, err := q.db.Query(ctx, dailyDemand, arg.FromDate, arg.ToDate, arg.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DailyDemandRow{}
+	for rows.Next() {
+		var i DailyDemandRow
+		if err := rows.Scan(&i.ProductID, &i.Day, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const stockPositions = `-- name: StockPositions :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity AS on_hand,
+       COALESCE((
+           SELECT SUM(l.allocated_quantity)
+           FROM sales_order_lines l
+           JOIN sales_orders o ON o.id = l.order_id
+           WHERE l.product_id = p.id AND o.status IN ('allocated', 'picking', 'packed')
+       ), 0)::int AS allocated,
+       COALESCE((
+           SELECT SUM(GREATEST(l.quantity - l.received_quantity, 0))
+           FROM purchase_order_lines l
+           JOIN purchase_orders po ON po.id = l.order_id
+           WHERE l.product_id = p.id AND po.status IN ('submitted', 'partially_received')
+       ), 0)::int AS on_order
+FROM products p
+WHERE $1::int IS NULL OR p.id = $1
+ORDER BY p.name, p.id
+`
+
+type StockPositionsRow struct {
+	ProductID   int32  `json:"product_id"`
+	ProductName string `json:"product_name"`
+	OnHand      int32  `json:"on_hand"`
+	Allocated   int32  `json:"allocated"`
+	OnOrder     int32  `json:"on_order"`
+}
+
+// StockPositions counts stock allocated to open sales orders and the quantity
+// still to be delivered on submitted purchase orders.
+func (q *Queries) StockPositions(ctx context.Context, productID pgtype.Int4) ([]StockPositionsRow, error) {
+	rows, err := q.db.Query(ctx, stockPositions, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockPositionsRow{}
+	for rows.Next() {
+		var i StockPositionsRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.OnHand,
+			&i.Allocated,
+			&i.OnOrder,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
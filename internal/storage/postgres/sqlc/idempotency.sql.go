@@ -0,0 +1,135 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $1,
+    content_type = $2,
+    response_body = $3
+WHERE key = $4 AND created_at = $5
+`
+
+type CompleteIdempotencyKeyParams struct {
+	StatusCode   pgtype.Int4        `json:"status_code"`
+	ContentType  string             `json:"content_type"`
+	ResponseBody []byte             `json:"response_body"`
+	Key          string             `json:"key"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+// CompleteIdempotencyKey and DeleteIdempotencyKey only match the reservation
+// created at created_at, not one that took the key over since.
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, completeIdempotencyKey,
+		arg.StatusCode,
+		arg.ContentType,
+		arg.ResponseBody,
+		arg.Key,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencyKeys, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND created_at = $2
+`
+
+type DeleteIdempotencyKeyParams struct {
+	Key       string             `json:"key"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, deleteIdempotencyKey, arg.Key, arg.CreatedAt)
+	return err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT key, request_hash, status_code, content_type, response_body, created_at, expires_at
+FROM idempotency_keys
+WHERE key = $1
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, key string) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Key,
+		&i.RequestHash,
+		&i.StatusCode,
+		&i.ContentType,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const reserveIdempotencyKey = `-- name: ReserveIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    key,
+    request_hash,
+    created_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    content_type = '',
+    response_body = NULL,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= $3
+   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $5)
+RETURNING created_at
+`
+
+type ReserveIdempotencyKeyParams struct {
+	Key         string             `json:"key"`
+	RequestHash string             `json:"request_hash"`
+	Now         pgtype.Timestamptz `json:"now"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	StaleBefore pgtype.Timestamptz `json:"stale_before"`
+}
+
+// ReserveIdempotencyKey stores a pending request for a key. A key that expired
+// or whose request has been pending since before stale_before is taken over;
+// otherwise no row is returned.
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, reserveIdempotencyKey,
+		arg.Key,
+		arg.RequestHash,
+		arg.Now,
+		arg.ExpiresAt,
+		arg.StaleBefore,
+	)
+	var created_at pgtype.Timestamptz
+	err := row.Scan(&created_at)
+	return created_at, err
+}
@@ -0,0 +1,254 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: lot.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addLotQuantity = `-- name: AddLotQuantity :one
+UPDATE lots
+SET quantity = quantity + $1
+WHERE id = $2 AND quantity + $1 >= 0
+RETURNING quantity
+`
+
+type AddLotQuantityParams struct {
+	Quantity int32 `json:"quantity"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) AddLotQuantity(ctx context.Context, arg AddLotQuantityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addLotQuantity, arg.Quantity, arg.ID)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const createStockMovementLot = `-- name: CreateStockMovementLot :exec
+INSERT INTO stock_movement_lots (
+    movement_id,
+    lot_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+)
+`
+
+type CreateStockMovementLotParams struct {
+	MovementID int32 `json:"movement_id"`
+	LotID      int32 `json:"lot_id"`
+	Quantity   int32 `json:"quantity"`
+}
+
+func (q *Queries) CreateStockMovementLot(ctx context.Context, arg CreateStockMovementLotParams) error {
+	_, err := q.db.Exec(ctx, createStockMovementLot, arg.MovementID, arg.LotID, arg.Quantity)
+	return err
+}
+
+const ensureLot = `-- name: EnsureLot :one
+INSERT INTO lots (
+    product_id,
+    lot_number,
+    manufactured_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (product_id, lot_number) DO UPDATE SET lot_number = EXCLUDED.lot_number
+RETURNING id, manufactured_at, expires_at
+`
+
+type EnsureLotParams struct {
+	ProductID      int32       `json:"product_id"`
+	LotNumber      string      `json:"lot_number"`
+	ManufacturedAt pgtype.Date `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date `json:"expires_at"`
+}
+
+type EnsureLotRow struct {
+	ID             int32       `json:"id"`
+	ManufacturedAt pgtype.Date `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date `json:"expires_at"`
+}
+
+// EnsureLot returns the lot with the given number, creating it on first receipt;
+// an existing lot keeps its dates.
+func (q *Queries) EnsureLot(ctx context.Context, arg EnsureLotParams) (EnsureLotRow, error) {
+	row := q.db.QueryRow(ctx, ensureLot,
+		arg.ProductID,
+		arg.LotNumber,
+		arg.ManufacturedAt,
+		arg.ExpiresAt,
+	)
+	var i EnsureLotRow
+	err := row.Scan(&i.ID, &i.ManufacturedAt, &i.ExpiresAt)
+	return i, err
+}
+
+const getLotByNumber = `-- name: GetLotByNumber :one
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE product_id = $1 AND lot_number = $2
+`
+
+type GetLotByNumberParams struct {
+	ProductID int32  `json:"product_id"`
+	LotNumber string `json:"lot_number"`
+}
+
+type GetLotByNumberRow struct {
+	ID             int32       `json:"id"`
+	ProductID      int32       `json:"product_id"`
+	LotNumber      string      `json:"lot_number"`
+	ManufacturedAt pgtype.Date `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date `json:"expires_at"`
+	Quantity       int32       `json:"quantity"`
+}
+
+func (q *Queries) GetLotByNumber(ctx context.Context, arg GetLotByNumberParams) (GetLotByNumberRow, error) {
+	row := q.db.QueryRow(ctx, getLotByNumber, arg.ProductID, arg.LotNumber)
+	var i GetLotByNumberRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.LotNumber,
+		&i.ManufacturedAt,
+		&i.ExpiresAt,
+		&i.Quantity,
+	)
+	return i, err
+}
+
+const listExpiringLots = `-- name: ListExpiringLots :many
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE quantity > 0 AND expires_at <= $1
+ORDER BY expires_at, product_id, id
+`
+
+type ListExpiringLotsRow struct {
+	ID             int32       `json:"id"`
+	ProductID      int32       `json:"product_id"`
+	LotNumber      string      `json:"lot_number"`
+	ManufacturedAt pgtype.Date `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date `json:"expires_at"`
+	Quantity       int32       `json:"quantity"`
+}
+
+func (q *Queries) ListExpiringLots(ctx context.Context, expiresAt pgtype.Date) ([]ListExpiringLotsRow, error) {
+	rows, err := q.db.Query(ctx, listExpiringLots, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExpiringLotsRow{}
+	for rows.Next() {
+		var i ListExpiringLotsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.LotNumber,
+			&i.ManufacturedAt,
+			&i.ExpiresAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLots = `-- name: ListLots :many
+SELECT id, product_id, lot_number, manufactured_at, expires_at, quantity
+FROM lots
+WHERE product_id = $1
+ORDER BY expires_at NULLS LAST, id
+`
+
+type ListLotsRow struct {
+	ID             int32       `json:"id"`
+	ProductID      int32       `json:"product_id"`
+	LotNumber      string      `json:"lot_number"`
+	ManufacturedAt pgtype.Date `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date `json:"expires_at"`
+	Quantity       int32       `json:"quantity"`
+}
+
+func (q *Queries) ListLots(ctx context.Context, productID int32) ([]ListLotsRow, error) {
+	rows, err := q.db.Query(ctx, listLots, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLotsRow{}
+	for rows.Next() {
+		var i ListLotsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.LotNumber,
+			&i.ManufacturedAt,
+			&i.ExpiresAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovementLots = `-- name: ListStockMovementLots :many
+SELECT sml.movement_id, sml.lot_id, l.lot_number, l.expires_at, sml.quantity
+FROM stock_movement_lots sml
+JOIN lots l ON l.id = sml.lot_id
+WHERE l.product_id = $1
+ORDER BY sml.movement_id, l.expires_at NULLS LAST, l.id
+`
+
+type ListStockMovementLotsRow struct {
+	MovementID int32       `json:"movement_id"`
+	LotID      int32       `json:"lot_id"`
+	LotNumber  string      `json:"lot_number"`
+	ExpiresAt  pgtype.Date `json:"expires_at"`
+	Quantity   int32       `json:"quantity"`
+}
+
+func (q *Queries) ListStockMovementLots(ctx context.Context, productID int32) ([]ListStockMovementLotsRow, error) {
+	rows, err := q.db.Query(ctx, listStockMovementLots, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStockMovementLotsRow{}
+	for rows.Next() {
+		var i ListStockMovementLotsRow
+		if err := rows.Scan(
+			&i.MovementID,
+			&i.LotID,
+			&i.LotNumber,
+			&i.ExpiresAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
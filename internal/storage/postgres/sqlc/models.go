@@ -8,11 +8,293 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CostEntry struct {
+	MovementID pgtype.Int4        `json:"movement_id"`
+	ProductID  int32              `json:"product_id"`
+	Quantity   int32              `json:"quantity"`
+	Value      int64              `json:"value"`
+	Currency   string             `json:"currency"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ID         int32              `json:"id"`
+}
+
+type CostLayer struct {
+	ID         int32              `json:"id"`
+	ProductID  int32              `json:"product_id"`
+	MovementID pgtype.Int4        `json:"movement_id"`
+	UnitCost   int64              `json:"unit_cost"`
+	Currency   string             `json:"currency"`
+	Quantity   int32              `json:"quantity"`
+	Remaining  int32              `json:"remaining"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type CountLine struct {
+	ID              int32       `json:"id"`
+	SessionID       int32       `json:"session_id"`
+	ProductID       int32       `json:"product_id"`
+	BinLocation     string      `json:"bin_location"`
+	SystemQuantity  int32       `json:"system_quantity"`
+	CountedQuantity pgtype.Int4 `json:"counted_quantity"`
+}
+
+type CountSession struct {
+	ID          int32              `json:"id"`
+	WarehouseID int32              `json:"warehouse_id"`
+	BinLocation string             `json:"bin_location"`
+	Category    string             `json:"category"`
+	AbcClass    string             `json:"abc_class"`
+	Blind       bool               `json:"blind"`
+	Status      string             `json:"status"`
+	ApprovedBy  string             `json:"approved_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	SubmittedAt pgtype.Timestamptz `json:"submitted_at"`
+	ApprovedAt  pgtype.Timestamptz `json:"approved_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+}
+
+type ExchangeRate struct {
+	BaseCurrency  string             `json:"base_currency"`
+	QuoteCurrency string             `json:"quote_currency"`
+	Rate          pgtype.Numeric     `json:"rate"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+}
+
+type IdempotencyKey struct {
+	Key          string             `json:"key"`
+	RequestHash  string             `json:"request_hash"`
+	StatusCode   pgtype.Int4        `json:"status_code"`
+	ContentType  string             `json:"content_type"`
+	ResponseBody []byte             `json:"response_body"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+}
+
+type Lot struct {
+	ID             int32              `json:"id"`
+	ProductID      int32              `json:"product_id"`
+	LotNumber      string             `json:"lot_number"`
+	ManufacturedAt pgtype.Date        `json:"manufactured_at"`
+	ExpiresAt      pgtype.Date        `json:"expires_at"`
+	Quantity       int32              `json:"quantity"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type PriceList struct {
+	ID            int32              `json:"id"`
+	Name          string             `json:"name"`
+	Currency      string             `json:"currency"`
+	CustomerGroup string             `json:"customer_group"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+	ValidTo       pgtype.Timestamptz `json:"valid_to"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type PriceListItem struct {
+	PriceListID int32 `json:"price_list_id"`
+	ProductID   int32 `json:"product_id"`
+	Price       int64 `json:"price"`
+}
+
 type Product struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Price in minor units of price_currency
+	Price               int64              `json:"price"`
+	Quantity            int32              `json:"quantity"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	PriceCurrency       string             `json:"price_currency"`
+	BaseUnit            string             `json:"base_unit"`
+	LotTracked          bool               `json:"lot_tracked"`
+	Serialized          bool               `json:"serialized"`
+	BinLocation         string             `json:"bin_location"`
+	QuarantinedQuantity int32              `json:"quarantined_quantity"`
+	Category            string             `json:"category"`
+}
+
+type ProductPrice struct {
+	ID          int32              `json:"id"`
+	ProductID   int32              `json:"product_id"`
+	Price       int64              `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ValidFrom   pgtype.Timestamptz `json:"valid_from"`
+	ValidTo     pgtype.Timestamptz `json:"valid_to"`
+	CreatedBy   string             `json:"created_by"`
+	ApprovedBy  string             `json:"approved_by"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type ProductSupplier struct {
+	ProductID        int32  `json:"product_id"`
+	SupplierID       int32  `json:"supplier_id"`
+	SupplierSku      string `json:"supplier_sku"`
+	CostPrice        int64  `json:"cost_price"`
+	CostCurrency     string `json:"cost_currency"`
+	MinOrderQuantity int32  `json:"min_order_quantity"`
+}
+
+type ProductUnit struct {
+	ProductID int32  `json:"product_id"`
+	Code      string `json:"code"`
+	FactorNum int64  `json:"factor_num"`
+	FactorDen int64  `json:"factor_den"`
+	Rounding  string `json:"rounding"`
+}
+
+type PurchaseOrder struct {
 	ID          int32              `json:"id"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Price       int32              `json:"price"`
-	Quantity    int32              `json:"quantity"`
+	SupplierID  int32              `json:"supplier_id"`
+	Status      string             `json:"status"`
+	Currency    string             `json:"currency"`
+	Reference   string             `json:"reference"`
+	ExpectedAt  pgtype.Timestamptz `json:"expected_at"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	SubmittedAt pgtype.Timestamptz `json:"submitted_at"`
+	ClosedAt    pgtype.Timestamptz `json:"closed_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID               int32 `json:"id"`
+	OrderID          int32 `json:"order_id"`
+	ProductID        int32 `json:"product_id"`
+	Quantity         int32 `json:"quantity"`
+	ReceivedQuantity int32 `json:"received_quantity"`
+	UnitCost         int64 `json:"unit_cost"`
+}
+
+type Return struct {
+	ID          int32              `json:"id"`
+	OrderID     int32              `json:"order_id"`
+	Status      string             `json:"status"`
+	Reason      string             `json:"reason"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	InspectedAt pgtype.Timestamptz `json:"inspected_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+}
+
+type ReturnLine struct {
+	ID                  int32    `json:"id"`
+	ReturnID            int32    `json:"return_id"`
+	OrderLineID         int32    `json:"order_line_id"`
+	ProductID           int32    `json:"product_id"`
+	Quantity            int32    `json:"quantity"`
+	Serials             []string `json:"serials"`
+	RestockedQuantity   int32    `json:"restocked_quantity"`
+	QuarantinedQuantity int32    `json:"quarantined_quantity"`
+	ScrappedQuantity    int32    `json:"scrapped_quantity"`
+}
+
+type SalesOrder struct {
+	ID             int32              `json:"id"`
+	Customer       string             `json:"customer"`
+	CustomerGroup  string             `json:"customer_group"`
+	Status         string             `json:"status"`
+	Currency       string             `json:"currency"`
+	Reference      string             `json:"reference"`
+	Packages       int32              `json:"packages"`
+	Carrier        string             `json:"carrier"`
+	TrackingNumber string             `json:"tracking_number"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt    pgtype.Timestamptz `json:"allocated_at"`
+	PackedAt       pgtype.Timestamptz `json:"packed_at"`
+	ShippedAt      pgtype.Timestamptz `json:"shipped_at"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
+}
+
+type SalesOrderLine struct {
+	ID                int32    `json:"id"`
+	OrderID           int32    `json:"order_id"`
+	ProductID         int32    `json:"product_id"`
+	Quantity          int32    `json:"quantity"`
+	AllocatedQuantity int32    `json:"allocated_quantity"`
+	ShippedQuantity   int32    `json:"shipped_quantity"`
+	UnitPrice         int64    `json:"unit_price"`
+	Serials           []string `json:"serials"`
+}
+
+type SerialNumber struct {
+	ID           int32              `json:"id"`
+	ProductID    int32              `json:"product_id"`
+	SerialNumber string             `json:"serial_number"`
+	Status       string             `json:"status"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockMovement struct {
+	ID        int32  `json:"id"`
+	ProductID int32  `json:"product_id"`
+	Type      string `json:"type"`
+	// Signed quantity in the product base unit
+	Quantity        int32              `json:"quantity"`
+	EnteredQuantity string             `json:"entered_quantity"`
+	EnteredUnit     string             `json:"entered_unit"`
+	Reference       string             `json:"reference"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	WarehouseID     pgtype.Int4        `json:"warehouse_id"`
+}
+
+type StockMovementLot struct {
+	MovementID int32 `json:"movement_id"`
+	LotID      int32 `json:"lot_id"`
+	Quantity   int32 `json:"quantity"`
+}
+
+type StockMovementSerial struct {
+	MovementID int32 `json:"movement_id"`
+	SerialID   int32 `json:"serial_id"`
+}
+
+type Supplier struct {
+	ID           int32              `json:"id"`
+	Name         string             `json:"name"`
+	ContactName  string             `json:"contact_name"`
+	Email        string             `json:"email"`
+	Phone        string             `json:"phone"`
+	Address      string             `json:"address"`
+	LeadTimeDays int32              `json:"lead_time_days"`
+	PaymentTerms string             `json:"payment_terms"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type TransferLine struct {
+	ID                int32  `json:"id"`
+	TransferID        int32  `json:"transfer_id"`
+	ProductID         int32  `json:"product_id"`
+	Quantity          int32  `json:"quantity"`
+	ShippedQuantity   int32  `json:"shipped_quantity"`
+	ReceivedQuantity  int32  `json:"received_quantity"`
+	Discrepancy       int32  `json:"discrepancy"`
+	DiscrepancyReason string `json:"discrepancy_reason"`
+}
+
+type TransferOrder struct {
+	ID              int32              `json:"id"`
+	FromWarehouseID int32              `json:"from_warehouse_id"`
+	ToWarehouseID   int32              `json:"to_warehouse_id"`
+	Status          string             `json:"status"`
+	Reference       string             `json:"reference"`
+	Carrier         string             `json:"carrier"`
+	TrackingNumber  string             `json:"tracking_number"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	ShippedAt       pgtype.Timestamptz `json:"shipped_at"`
+	ReceivedAt      pgtype.Timestamptz `json:"received_at"`
+	CancelledAt     pgtype.Timestamptz `json:"cancelled_at"`
+}
+
+type Warehouse struct {
+	ID        int32              `json:"id"`
+	Code      string             `json:"code"`
+	Name      string             `json:"name"`
+	IsDefault bool               `json:"is_default"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type WarehouseStock struct {
+	WarehouseID int32 `json:"warehouse_id"`
+	ProductID   int32 `json:"product_id"`
+	Quantity    int32 `json:"quantity"`
 }
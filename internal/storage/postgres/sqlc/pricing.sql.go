@@ -0,0 +1,318 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: pricing.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPriceList = `-- name: CreatePriceList :one
+INSERT INTO price_lists (
+    name,
+    currency,
+    customer_group,
+    valid_from,
+    valid_to
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id
+`
+
+type CreatePriceListParams struct {
+	Name          string             `json:"name"`
+	Currency      string             `json:"currency"`
+	CustomerGroup string             `json:"customer_group"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+	ValidTo       pgtype.Timestamptz `json:"valid_to"`
+}
+
+func (q *Queries) CreatePriceList(ctx context.Context, arg CreatePriceListParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createPriceList,
+		arg.Name,
+		arg.Currency,
+		arg.CustomerGroup,
+		arg.ValidFrom,
+		arg.ValidTo,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deletePriceList = `-- name: DeletePriceList :exec
+DELETE FROM price_lists
+WHERE id = $1
+`
+
+func (q *Queries) DeletePriceList(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deletePriceList, id)
+	return err
+}
+
+const deletePriceListItem = `-- name: DeletePriceListItem :exec
+DELETE FROM price_list_items
+WHERE price_list_id = $1 AND product_id = $2
+`
+
+type DeletePriceListItemParams struct {
+	PriceListID int32 `json:"price_list_id"`
+	ProductID   int32 `json:"product_id"`
+}
+
+func (q *Queries) DeletePriceListItem(ctx context.Context, arg DeletePriceListItemParams) error {
+	_, err := q.db.Exec(ctx, deletePriceListItem, arg.PriceListID, arg.ProductID)
+	return err
+}
+
+const findApplicablePrice = `-- name: FindApplicablePrice :one
+SELECT pl.id, pli.price
+FROM price_list_items pli
+JOIN price_lists pl ON pl.id = pli.price_list_id
+WHERE pli.product_id = $1
+  AND pl.currency = $2
+  AND pl.customer_group IN ('', $3::text)
+  AND pl.valid_from <= $4
+  AND (pl.valid_to IS NULL OR pl.valid_to > $4)
+ORDER BY pl.customer_group = $3::text DESC, pl.valid_from DESC, pl.id DESC
+LIMIT 1
+`
+
+type FindApplicablePriceParams struct {
+	ProductID     int32              `json:"product_id"`
+	Currency      string             `json:"currency"`
+	CustomerGroup string             `json:"customer_group"`
+	At            pgtype.Timestamptz `json:"at"`
+}
+
+type FindApplicablePriceRow struct {
+	ID    int32 `json:"id"`
+	Price int64 `json:"price"`
+}
+
+func (q *Queries) FindApplicablePrice(ctx context.Context, arg FindApplicablePriceParams) (FindApplicablePriceRow, error) {
+	row := q.db.QueryRow(ctx, findApplicablePrice,
+		arg.ProductID,
+		arg.Currency,
+		arg.CustomerGroup,
+		arg.At,
+	)
+	var i FindApplicablePriceRow
+	err := row.Scan(&i.ID, &i.Price)
+	return i, err
+}
+
+const getExchangeRate = `-- name: GetExchangeRate :one
+SELECT base_currency, quote_currency, rate, valid_from
+FROM exchange_rates
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND valid_from <= $3
+ORDER BY valid_from DESC
+LIMIT 1
+`
+
+type GetExchangeRateParams struct {
+	BaseCurrency  string             `json:"base_currency"`
+	QuoteCurrency string             `json:"quote_currency"`
+	At            pgtype.Timestamptz `json:"at"`
+}
+
+func (q *Queries) GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error) {
+	row := q.db.QueryRow(ctx, getExchangeRate, arg.BaseCurrency, arg.QuoteCurrency, arg.At)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.ValidFrom,
+	)
+	return i, err
+}
+
+const getPriceList = `-- name: GetPriceList :one
+SELECT id, name, currency, customer_group, valid_from, valid_to
+FROM price_lists
+WHERE id = $1
+`
+
+type GetPriceListRow struct {
+	ID            int32              `json:"id"`
+	Name          string             `json:"name"`
+	Currency      string             `json:"currency"`
+	CustomerGroup string             `json:"customer_group"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+	ValidTo       pgtype.Timestamptz `json:"valid_to"`
+}
+
+func (q *Queries) GetPriceList(ctx context.Context, id int32) (GetPriceListRow, error) {
+	row := q.db.QueryRow(ctx, getPriceList, id)
+	var i GetPriceListRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Currency,
+		&i.CustomerGroup,
+		&i.ValidFrom,
+		&i.ValidTo,
+	)
+	return i, err
+}
+
+const listExchangeRates = `-- name: ListExchangeRates :many
+SELECT base_currency, quote_currency, rate, valid_from
+FROM exchange_rates
+ORDER BY base_currency, quote_currency, valid_from DESC
+`
+
+func (q *Queries) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	rows, err := q.db.Query(ctx, listExchangeRates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangeRate{}
+	for rows.Next() {
+		var i ExchangeRate
+		if err := rows.Scan(
+			&i.BaseCurrency,
+			&i.QuoteCurrency,
+			&i.Rate,
+			&i.ValidFrom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceListItems = `-- name: ListPriceListItems :many
+SELECT price_list_id, product_id, price
+FROM price_list_items
+WHERE price_list_id = $1
+ORDER BY product_id
+`
+
+func (q *Queries) ListPriceListItems(ctx context.Context, priceListID int32) ([]PriceListItem, error) {
+	rows, err := q.db.Query(ctx, listPriceListItems, priceListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PriceListItem{}
+	for rows.Next() {
+		var i PriceListItem
+		if err := rows.Scan(&i.PriceListID, &i.ProductID, &i.Price); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceLists = `-- name: ListPriceLists :many
+SELECT id, name, currency, customer_group, valid_from, valid_to
+FROM price_lists
+ORDER BY id
+`
+
+type ListPriceListsRow struct {
+	ID            int32              `json:"id"`
+	Name          string             `json:"name"`
+	Currency      string             `json:"currency"`
+	CustomerGroup string             `json:"customer_group"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+	ValidTo       pgtype.Timestamptz `json:"valid_to"`
+}
+
+func (q *Queries) ListPriceLists(ctx context.Context) ([]ListPriceListsRow, error) {
+	rows, err := q.db.Query(ctx, listPriceLists)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPriceListsRow{}
+	for rows.Next() {
+		var i ListPriceListsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Currency,
+			&i.CustomerGroup,
+			&i.ValidFrom,
+			&i.ValidTo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertExchangeRate = `-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (
+    base_currency,
+    quote_currency,
+    rate,
+    valid_from
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (base_currency, quote_currency, valid_from) DO UPDATE
+SET rate = EXCLUDED.rate
+`
+
+type UpsertExchangeRateParams struct {
+	BaseCurrency  string             `json:"base_currency"`
+	QuoteCurrency string             `json:"quote_currency"`
+	Rate          pgtype.Numeric     `json:"rate"`
+	ValidFrom     pgtype.Timestamptz `json:"valid_from"`
+}
+
+func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) error {
+	_, err := q.db.Exec(ctx, upsertExchangeRate,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.Rate,
+		arg.ValidFrom,
+	)
+	return err
+}
+
+const upsertPriceListItem = `-- name: UpsertPriceListItem :exec
+INSERT INTO price_list_items (
+    price_list_id,
+    product_id,
+    price
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (price_list_id, product_id) DO UPDATE
+SET price = EXCLUDED.price
+`
+
+type UpsertPriceListItemParams struct {
+	PriceListID int32 `json:"price_list_id"`
+	ProductID   int32 `json:"product_id"`
+	Price       int64 `json:"price"`
+}
+
+func (q *Queries) UpsertPriceListItem(ctx context.Context, arg UpsertPriceListItemParams) error {
+	_, err := q.db.Exec(ctx, upsertPriceListItem, arg.PriceListID, arg.ProductID, arg.Price)
+	return err
+}
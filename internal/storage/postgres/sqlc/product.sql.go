@@ -7,25 +7,67 @@ package postgresdb
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countProducts = `-- name: CountProducts :one
+SELECT COUNT(*)::int
+FROM products
+WHERE ($1::text = '' OR name ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%')
+  AND ($2::text = '' OR category = $2)
+  AND ($3::text = '' OR price_currency = $3)
+  AND ($4::bool IS NULL OR (quantity > 0) = $4)
+`
+
+type CountProductsParams struct {
+	Search   string      `json:"search"`
+	Category string      `json:"category"`
+	Currency string      `json:"currency"`
+	InStock  pgtype.Bool `json:"in_stock"`
+}
+
+func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countProducts,
+		arg.Search,
+		arg.Category,
+		arg.Currency,
+		arg.InStock,
+	)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products (
     name,
     description,
     price,
-    quantity
+    price_currency,
+    quantity,
+    base_unit,
+    lot_tracked,
+    serialized,
+    bin_location,
+    category
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 )
 RETURNING id
 `
 
 type CreateProductParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int32  `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Price         int64  `json:"price"`
+	PriceCurrency string `json:"price_currency"`
+	Quantity      int32  `json:"quantity"`
+	BaseUnit      string `json:"base_unit"`
+	LotTracked    bool   `json:"lot_tracked"`
+	Serialized    bool   `json:"serialized"`
+	BinLocation   string `json:"bin_location"`
+	Category      string `json:"category"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (int32, error) {
@@ -33,35 +75,51 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (i
 		arg.Name,
 		arg.Description,
 		arg.Price,
+		arg.PriceCurrency,
 		arg.Quantity,
+		arg.BaseUnit,
+		arg.LotTracked,
+		arg.Serialized,
+		arg.BinLocation,
+		arg.Category,
 	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
-const deleteProduct = `-- name: DeleteProduct :exec
+const deleteProduct = `-- name: DeleteProduct :execrows
 DELETE FROM products
 WHERE id = $1
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteProduct, id)
-	return err
+func (q *Queries) DeleteProduct(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProduct, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getProductByID = `-- name: GetProductByID :one
-SELECT id, name, description, price, quantity
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
 FROM products
 WHERE id = $1
 `
 
 type GetProductByIDRow struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int32  `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	ID                  int32  `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	Price               int64  `json:"price"`
+	PriceCurrency       string `json:"price_currency"`
+	Quantity            int32  `json:"quantity"`
+	BaseUnit            string `json:"base_unit"`
+	LotTracked          bool   `json:"lot_tracked"`
+	Serialized          bool   `json:"serialized"`
+	BinLocation         string `json:"bin_location"`
+	QuarantinedQuantity int32  `json:"quarantined_quantity"`
+	Category            string `json:"category"`
 }
 
 func (q *Queries) GetProductByID(ctx context.Context, id int32) (GetProductByIDRow, error) {
@@ -72,23 +130,37 @@ func (q *Queries) GetProductByID(ctx context.Context, id int32) (GetProductByIDR
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.PriceCurrency,
 		&i.Quantity,
+		&i.BaseUnit,
+		&i.LotTracked,
+		&i.Serialized,
+		&i.BinLocation,
+		&i.QuarantinedQuantity,
+		&i.Category,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT id, name, description, price, quantity
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
 FROM products
 ORDER BY id
 `
 
 type ListProductsRow struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int32  `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	ID                  int32  `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	Price               int64  `json:"price"`
+	PriceCurrency       string `json:"price_currency"`
+	Quantity            int32  `json:"quantity"`
+	BaseUnit            string `json:"base_unit"`
+	LotTracked          bool   `json:"lot_tracked"`
+	Serialized          bool   `json:"serialized"`
+	BinLocation         string `json:"bin_location"`
+	QuarantinedQuantity int32  `json:"quarantined_quantity"`
+	Category            string `json:"category"`
 }
 
 func (q *Queries) ListProducts(ctx context.Context) ([]ListProductsRow, error) {
@@ -105,7 +177,89 @@ func (q *Queries) ListProducts(ctx context.Context) ([]ListProductsRow, error) {
 			&i.Name,
 			&i.Description,
 			&i.Price,
+			&i.PriceCurrency,
+			&i.Quantity,
+			&i.BaseUnit,
+			&i.LotTracked,
+			&i.Serialized,
+			&i.BinLocation,
+			&i.QuarantinedQuantity,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProducts = `-- name: SearchProducts :many
+SELECT id, name, description, price, price_currency, quantity, base_unit, lot_tracked, serialized, bin_location, quarantined_quantity, category
+FROM products
+WHERE ($1::text = '' OR name ILIKE '%' || $1 || '%' OR description ILIKE '%' || $1 || '%')
+  AND ($2::text = '' OR category = $2)
+  AND ($3::text = '' OR price_currency = $3)
+  AND ($4::bool IS NULL OR (quantity > 0) = $4)
+ORDER BY id
+LIMIT $6 OFFSET $5
+`
+
+type SearchProductsParams struct {
+	Search     string      `json:"search"`
+	Category   string      `json:"category"`
+	Currency   string      `json:"currency"`
+	InStock    pgtype.Bool `json:"in_stock"`
+	PageOffset int32       `json:"page_offset"`
+	PageLimit  int32       `json:"page_limit"`
+}
+
+type SearchProductsRow struct {
+	ID                  int32  `json:"id"`
+	Name                string `json:"name"`
+	Description         string `json:"description"`
+	Price               int64  `json:"price"`
+	PriceCurrency       string `json:"price_currency"`
+	Quantity            int32  `json:"quantity"`
+	BaseUnit            string `json:"base_unit"`
+	LotTracked          bool   `json:"lot_tracked"`
+	Serialized          bool   `json:"serialized"`
+	BinLocation         string `json:"bin_location"`
+	QuarantinedQuantity int32  `json:"quarantined_quantity"`
+	Category            string `json:"category"`
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.db.Query(ctx, searchProducts,
+		arg.Search,
+		arg.Category,
+		arg.Currency,
+		arg.InStock,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductsRow{}
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.PriceCurrency,
 			&i.Quantity,
+			&i.BaseUnit,
+			&i.LotTracked,
+			&i.Serialized,
+			&i.BinLocation,
+			&i.QuarantinedQuantity,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -123,16 +277,24 @@ SET
     name = $2,
     description = $3,
     price = $4,
-    quantity = $5
+    price_currency = $5,
+    lot_tracked = $6,
+    serialized = $7,
+    bin_location = $8,
+    category = $9
 WHERE id = $1
 `
 
 type UpdateProductParams struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int32  `json:"price"`
-	Quantity    int32  `json:"quantity"`
+	ID            int32  `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Price         int64  `json:"price"`
+	PriceCurrency string `json:"price_currency"`
+	LotTracked    bool   `json:"lot_tracked"`
+	Serialized    bool   `json:"serialized"`
+	BinLocation   string `json:"bin_location"`
+	Category      string `json:"category"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
@@ -141,7 +303,11 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 		arg.Name,
 		arg.Description,
 		arg.Price,
-		arg.Quantity,
+		arg.PriceCurrency,
+		arg.LotTracked,
+		arg.Serialized,
+		arg.BinLocation,
+		arg.Category,
 	)
 	return err
 }
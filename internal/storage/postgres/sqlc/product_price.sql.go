@@ -0,0 +1,284 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: product_price.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const activatePriceChange = `-- name: ActivatePriceChange :exec
+UPDATE product_prices
+SET status = 'active', activated_at = $2
+WHERE id = $1
+`
+
+type ActivatePriceChangeParams struct {
+	ID          int32              `json:"id"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
+func (q *Queries) ActivatePriceChange(ctx context.Context, arg ActivatePriceChangeParams) error {
+	_, err := q.db.Exec(ctx, activatePriceChange, arg.ID, arg.ActivatedAt)
+	return err
+}
+
+const closeOpenPrices = `-- name: CloseOpenPrices :exec
+UPDATE product_prices
+SET valid_to = $1::timestamptz
+WHERE product_id = $2
+  AND status IN ('active', 'superseded')
+  AND valid_from < $1::timestamptz
+  AND (valid_to IS NULL OR valid_to > $1::timestamptz)
+`
+
+type CloseOpenPricesParams struct {
+	Now       pgtype.Timestamptz `json:"now"`
+	ProductID int32              `json:"product_id"`
+}
+
+func (q *Queries) CloseOpenPrices(ctx context.Context, arg CloseOpenPricesParams) error {
+	_, err := q.db.Exec(ctx, closeOpenPrices, arg.Now, arg.ProductID)
+	return err
+}
+
+const createPriceChange = `-- name: CreatePriceChange :one
+INSERT INTO product_prices (
+    product_id,
+    price,
+    currency,
+    status,
+    valid_from,
+    valid_to,
+    created_by,
+    approved_by,
+    activated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id
+`
+
+type CreatePriceChangeParams struct {
+	ProductID   int32              `json:"product_id"`
+	Price       int64              `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ValidFrom   pgtype.Timestamptz `json:"valid_from"`
+	ValidTo     pgtype.Timestamptz `json:"valid_to"`
+	CreatedBy   string             `json:"created_by"`
+	ApprovedBy  string             `json:"approved_by"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
+func (q *Queries) CreatePriceChange(ctx context.Context, arg CreatePriceChangeParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createPriceChange,
+		arg.ProductID,
+		arg.Price,
+		arg.Currency,
+		arg.Status,
+		arg.ValidFrom,
+		arg.ValidTo,
+		arg.CreatedBy,
+		arg.ApprovedBy,
+		arg.ActivatedAt,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPriceChange = `-- name: GetPriceChange :one
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM product_prices
+WHERE id = $1
+`
+
+type GetPriceChangeRow struct {
+	ID          int32              `json:"id"`
+	ProductID   int32              `json:"product_id"`
+	Price       int64              `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ValidFrom   pgtype.Timestamptz `json:"valid_from"`
+	ValidTo     pgtype.Timestamptz `json:"valid_to"`
+	CreatedBy   string             `json:"created_by"`
+	ApprovedBy  string             `json:"approved_by"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
+func (q *Queries) GetPriceChange(ctx context.Context, id int32) (GetPriceChangeRow, error) {
+	row := q.db.QueryRow(ctx, getPriceChange, id)
+	var i GetPriceChangeRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Price,
+		&i.Currency,
+		&i.Status,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.CreatedBy,
+		&i.ApprovedBy,
+		&i.ActivatedAt,
+	)
+	return i, err
+}
+
+const listDuePriceChanges = `-- name: ListDuePriceChanges :many
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM (
+    SELECT DISTINCT ON (product_id) id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at, created_at
+    FROM product_prices
+    WHERE status <> 'cancelled'
+      AND valid_from <= $1
+      AND (valid_to IS NULL OR valid_to > $1)
+    ORDER BY product_id, valid_from DESC, id DESC
+) effective
+WHERE effective.status <> 'active'
+`
+
+type ListDuePriceChangesRow struct {
+	ID          int32              `json:"id"`
+	ProductID   int32              `json:"product_id"`
+	Price       int64              `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ValidFrom   pgtype.Timestamptz `json:"valid_from"`
+	ValidTo     pgtype.Timestamptz `json:"valid_to"`
+	CreatedBy   string             `json:"created_by"`
+	ApprovedBy  string             `json:"approved_by"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
+// Returns, per product, the price period that should be in effect at @now
+// when it is not the active one yet.
+func (q *Queries) ListDuePriceChanges(ctx context.Context, now pgtype.Timestamptz) ([]ListDuePriceChangesRow, error) {
+	rows, err := q.db.Query(ctx, listDuePriceChanges, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDuePriceChangesRow{}
+	for rows.Next() {
+		var i ListDuePriceChangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Price,
+			&i.Currency,
+			&i.Status,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.CreatedBy,
+			&i.ApprovedBy,
+			&i.ActivatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceChanges = `-- name: ListPriceChanges :many
+SELECT id, product_id, price, currency, status, valid_from, valid_to, created_by, approved_by, activated_at
+FROM product_prices
+WHERE product_id = $1
+ORDER BY valid_from DESC, id DESC
+`
+
+type ListPriceChangesRow struct {
+	ID          int32              `json:"id"`
+	ProductID   int32              `json:"product_id"`
+	Price       int64              `json:"price"`
+	Currency    string             `json:"currency"`
+	Status      string             `json:"status"`
+	ValidFrom   pgtype.Timestamptz `json:"valid_from"`
+	ValidTo     pgtype.Timestamptz `json:"valid_to"`
+	CreatedBy   string             `json:"created_by"`
+	ApprovedBy  string             `json:"approved_by"`
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
+func (q *Queries) ListPriceChanges(ctx context.Context, productID int32) ([]ListPriceChangesRow, error) {
+	rows, err := q.db.Query(ctx, listPriceChanges, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPriceChangesRow{}
+	for rows.Next() {
+		var i ListPriceChangesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Price,
+			&i.Currency,
+			&i.Status,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.CreatedBy,
+			&i.ApprovedBy,
+			&i.ActivatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setPriceChangeStatus = `-- name: SetPriceChangeStatus :exec
+UPDATE product_prices
+SET status = $2
+WHERE id = $1
+`
+
+type SetPriceChangeStatusParams struct {
+	ID     int32  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) SetPriceChangeStatus(ctx context.Context, arg SetPriceChangeStatusParams) error {
+	_, err := q.db.Exec(ctx, setPriceChangeStatus, arg.ID, arg.Status)
+	return err
+}
+
+const setProductPrice = `-- name: SetProductPrice :exec
+UPDATE products
+SET price = $2, price_currency = $3
+WHERE id = $1
+`
+
+type SetProductPriceParams struct {
+	ID            int32  `json:"id"`
+	Price         int64  `json:"price"`
+	PriceCurrency string `json:"price_currency"`
+}
+
+func (q *Queries) SetProductPrice(ctx context.Context, arg SetProductPriceParams) error {
+	_, err := q.db.Exec(ctx, setProductPrice, arg.ID, arg.Price, arg.PriceCurrency)
+	return err
+}
+
+const supersedeActivePrices = `-- name: SupersedeActivePrices :exec
+UPDATE product_prices
+SET status = 'superseded'
+WHERE product_id = $1 AND status = 'active'
+`
+
+func (q *Queries) SupersedeActivePrices(ctx context.Context, productID int32) error {
+	_, err := q.db.Exec(ctx, supersedeActivePrices, productID)
+	return err
+}
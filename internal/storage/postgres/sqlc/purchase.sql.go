@@ -0,0 +1,369 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: purchase.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const closePurchaseOrder = `-- name: ClosePurchaseOrder :one
+UPDATE purchase_orders
+SET status = 'closed', closed_at = $1
+WHERE id = $2 AND status IN ('partially_received', 'received')
+RETURNING id
+`
+
+type ClosePurchaseOrderParams struct {
+	Now pgtype.Timestamptz `json:"now"`
+	ID  int32              `json:"id"`
+}
+
+func (q *Queries) ClosePurchaseOrder(ctx context.Context, arg ClosePurchaseOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, closePurchaseOrder, arg.Now, arg.ID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPurchaseOrder = `-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+    supplier_id,
+    currency,
+    reference
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id
+`
+
+type CreatePurchaseOrderParams struct {
+	SupplierID int32  `json:"supplier_id"`
+	Currency   string `json:"currency"`
+	Reference  string `json:"reference"`
+}
+
+func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createPurchaseOrder, arg.SupplierID, arg.Currency, arg.Reference)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPurchaseOrderLine = `-- name: CreatePurchaseOrderLine :exec
+INSERT INTO purchase_order_lines (
+    order_id,
+    product_id,
+    quantity,
+    unit_cost
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type CreatePurchaseOrderLineParams struct {
+	OrderID   int32 `json:"order_id"`
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+	UnitCost  int64 `json:"unit_cost"`
+}
+
+func (q *Queries) CreatePurchaseOrderLine(ctx context.Context, arg CreatePurchaseOrderLineParams) error {
+	_, err := q.db.Exec(ctx, createPurchaseOrderLine,
+		arg.OrderID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.UnitCost,
+	)
+	return err
+}
+
+const deletePurchaseOrderLines = `-- name: DeletePurchaseOrderLines :exec
+DELETE FROM purchase_order_lines
+WHERE order_id = $1
+`
+
+func (q *Queries) DeletePurchaseOrderLines(ctx context.Context, orderID int32) error {
+	_, err := q.db.Exec(ctx, deletePurchaseOrderLines, orderID)
+	return err
+}
+
+const getPurchaseOrder = `-- name: GetPurchaseOrder :one
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE id = $1
+`
+
+func (q *Queries) GetPurchaseOrder(ctx context.Context, id int32) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, getPurchaseOrder, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.Status,
+		&i.Currency,
+		&i.Reference,
+		&i.ExpectedAt,
+		&i.CreatedAt,
+		&i.SubmittedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listPurchaseOrderLines = `-- name: ListPurchaseOrderLines :many
+SELECT id, order_id, product_id, quantity, received_quantity, unit_cost
+FROM purchase_order_lines
+WHERE order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListPurchaseOrderLines(ctx context.Context, orderID int32) ([]PurchaseOrderLine, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrderLines, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrderLine{}
+	for rows.Next() {
+		var i PurchaseOrderLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.ReceivedQuantity,
+			&i.UnitCost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseOrders = `-- name: ListPurchaseOrders :many
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE ($1::text = '' OR status = $1)
+  AND ($2::int IS NULL OR supplier_id = $2)
+ORDER BY created_at DESC, id DESC
+`
+
+type ListPurchaseOrdersParams struct {
+	Status     string      `json:"status"`
+	SupplierID pgtype.Int4 `json:"supplier_id"`
+}
+
+func (q *Queries) ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error) {
+	rows, err := q.db.Query(ctx, listPurchaseOrders, arg.Status, arg.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrder{}
+	for rows.Next() {
+		var i PurchaseOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.SupplierID,
+			&i.Status,
+			&i.Currency,
+			&i.Reference,
+			&i.ExpectedAt,
+			&i.CreatedAt,
+			&i.SubmittedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockPurchaseOrder = `-- name: LockPurchaseOrder :one
+SELECT id, supplier_id, status, currency, reference, expected_at, created_at, submitted_at, closed_at
+FROM purchase_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockPurchaseOrder(ctx context.Context, id int32) (PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, lockPurchaseOrder, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SupplierID,
+		&i.Status,
+		&i.Currency,
+		&i.Reference,
+		&i.ExpectedAt,
+		&i.CreatedAt,
+		&i.SubmittedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const purchaseOrderStatusReport = `-- name: PurchaseOrderStatusReport :many
+SELECT po.status, po.currency,
+       COUNT(DISTINCT po.id)::int AS orders,
+       COUNT(l.id)::int AS lines,
+       COALESCE(SUM(l.quantity), 0)::bigint AS ordered_quantity,
+       COALESCE(SUM(l.received_quantity), 0)::bigint AS received_quantity,
+       COALESCE(SUM(l.quantity::bigint * l.unit_cost), 0)::bigint AS ordered_value,
+       COALESCE(SUM(l.received_quantity::bigint * l.unit_cost), 0)::bigint AS received_value,
+       COUNT(DISTINCT po.id) FILTER (
+           WHERE po.expected_at < $1 AND po.status IN ('submitted', 'partially_received')
+       )::int AS overdue
+FROM purchase_orders po
+LEFT JOIN purchase_order_lines l ON l.order_id = po.id
+WHERE ($2::int IS NULL OR po.supplier_id = $2)
+  AND ($3::timestamptz IS NULL OR po.created_at >= $3)
+  AND ($4::timestamptz IS NULL OR po.created_at < $4)
+GROUP BY po.status, po.currency
+ORDER BY array_position(ARRAY['draft', 'submitted', 'partially_received', 'received', 'closed'], po.status), po.currency
+`
+
+type PurchaseOrderStatusReportParams struct {
+	Now        pgtype.Timestamptz `json:"now"`
+	SupplierID pgtype.Int4        `json:"supplier_id"`
+	From       pgtype.Timestamptz `json:"from"`
+	To         pgtype.Timestamptz `json:"to"`
+}
+
+type PurchaseOrderStatusReportRow struct {
+	Status           string `json:"status"`
+	Currency         string `json:"currency"`
+	Orders           int32  `json:"orders"`
+	Lines            int32  `json:"lines"`
+	OrderedQuantity  int64  `json:"ordered_quantity"`
+	ReceivedQuantity int64  `json:"received_quantity"`
+	OrderedValue     int64  `json:"ordered_value"`
+	ReceivedValue    int64  `json:"received_value"`
+	Overdue          int32  `json:"overdue"`
+}
+
+func (q *Queries) PurchaseOrderStatusReport(ctx context.Context, arg PurchaseOrderStatusReportParams) ([]PurchaseOrderStatusReportRow, error) {
+	rows, err := q.db.Query(ctx, purchaseOrderStatusReport,
+		arg.Now,
+		arg.SupplierID,
+		arg.From,
+		arg.To,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PurchaseOrderStatusReportRow{}
+	for rows.Next() {
+		var i PurchaseOrderStatusReportRow
+		if err := rows.Scan(
+			&i.Status,
+			&i.Currency,
+			&i.Orders,
+			&i.Lines,
+			&i.OrderedQuantity,
+			&i.ReceivedQuantity,
+			&i.OrderedValue,
+			&i.ReceivedValue,
+			&i.Overdue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setPurchaseOrderLineReceived = `-- name: SetPurchaseOrderLineReceived :exec
+UPDATE purchase_order_lines
+SET received_quantity = $2
+WHERE id = $1
+`
+
+type SetPurchaseOrderLineReceivedParams struct {
+	ID               int32 `json:"id"`
+	ReceivedQuantity int32 `json:"received_quantity"`
+}
+
+func (q *Queries) SetPurchaseOrderLineReceived(ctx context.Context, arg SetPurchaseOrderLineReceivedParams) error {
+	_, err := q.db.Exec(ctx, setPurchaseOrderLineReceived, arg.ID, arg.ReceivedQuantity)
+	return err
+}
+
+const setPurchaseOrderStatus = `-- name: SetPurchaseOrderStatus :exec
+UPDATE purchase_orders
+SET status = $2
+WHERE id = $1
+`
+
+type SetPurchaseOrderStatusParams struct {
+	ID     int32  `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) SetPurchaseOrderStatus(ctx context.Context, arg SetPurchaseOrderStatusParams) error {
+	_, err := q.db.Exec(ctx, setPurchaseOrderStatus, arg.ID, arg.Status)
+	return err
+}
+
+const submitPurchaseOrder = `-- name: SubmitPurchaseOrder :one
+UPDATE purchase_orders
+SET status = 'submitted', submitted_at = $1, expected_at = $2
+WHERE id = $3 AND status = 'draft'
+RETURNING id
+`
+
+type SubmitPurchaseOrderParams struct {
+	Now        pgtype.Timestamptz `json:"now"`
+	ExpectedAt pgtype.Timestamptz `json:"expected_at"`
+	ID         int32              `json:"id"`
+}
+
+func (q *Queries) SubmitPurchaseOrder(ctx context.Context, arg SubmitPurchaseOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, submitPurchaseOrder, arg.Now, arg.ExpectedAt, arg.ID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const updateDraftPurchaseOrder = `-- name: UpdateDraftPurchaseOrder :one
+UPDATE purchase_orders
+SET supplier_id = $2, currency = $3, reference = $4
+WHERE id = $1 AND status = 'draft'
+RETURNING id
+`
+
+type UpdateDraftPurchaseOrderParams struct {
+	ID         int32  `json:"id"`
+	SupplierID int32  `json:"supplier_id"`
+	Currency   string `json:"currency"`
+	Reference  string `json:"reference"`
+}
+
+func (q *Queries) UpdateDraftPurchaseOrder(ctx context.Context, arg UpdateDraftPurchaseOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateDraftPurchaseOrder,
+		arg.ID,
+		arg.SupplierID,
+		arg.Currency,
+		arg.Reference,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
@@ -0,0 +1,239 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: report.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const consumptionReport = `-- name: ConsumptionReport :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       COALESCE(e.currency, p.price_currency)::text AS currency,
+       (-SUM(m.quantity))::int AS issued_quantity,
+       (-SUM(COALESCE(e.value, m.quantity::bigint * p.price)))::bigint AS value
+FROM stock_movements m
+JOIN products p ON p.id = m.product_id
+LEFT JOIN cost_entries e ON e.movement_id = m.id
+WHERE m.type = 'issue' AND m.created_at >= $1 AND m.created_at < $2
+GROUP BY p.id, p.name, COALESCE(e.currency, p.price_currency)
+ORDER BY p.name, p.id
+`
+
+type ConsumptionReportParams struct {
+	FromTime pgtype.Timestamptz `json:"from_time"`
+	ToTime   pgtype.Timestamptz `json:"to_time"`
+}
+
+type ConsumptionReportRow struct {
+	ProductID      int32  `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	Currency       string `json:"currency"`
+	IssuedQuantity int32  `json:"issued_quantity"`
+	Value          int64  `json:"value"`
+}
+
+// ConsumptionReport values issues at their recorded cost, or at the current
+// price where no cost was recorded.
+func (q *Queries) ConsumptionReport(ctx context.Context, arg ConsumptionReportParams) ([]ConsumptionReportRow, error) {
+	rows, err := q.db.Query(ctx, consumptionReport, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConsumptionReportRow{}
+	for rows.Next() {
+		var i ConsumptionReportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Currency,
+			&i.IssuedQuantity,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deadStockReport = `-- name: DeadStockReport :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity,
+       MAX(m.created_at)::timestamptz AS last_movement_at
+FROM products p
+LEFT JOIN stock_movements m ON m.product_id = p.id
+WHERE p.quantity > 0
+GROUP BY p.id, p.name, p.quantity
+HAVING MAX(m.created_at) IS NULL OR MAX(m.created_at) < $1
+ORDER BY MAX(m.created_at) NULLS FIRST, p.name
+`
+
+type DeadStockReportRow struct {
+	ProductID      int32              `json:"product_id"`
+	ProductName    string             `json:"product_name"`
+	Quantity       int32              `json:"quantity"`
+	LastMovementAt pgtype.Timestamptz `json:"last_movement_at"`
+}
+
+func (q *Queries) DeadStockReport(ctx context.Context, since pgtype.Timestamptz) ([]DeadStockReportRow, error) {
+	rows, err := q.db.Query(ctx, deadStockReport, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DeadStockReportRow{}
+	for rows.Next() {
+		var i DeadStockReportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Quantity,
+			&i.LastMovementAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const stockAgingReport = `-- name: StockAgingReport :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       p.quantity,
+       COALESCE(width_bucket($1::date - a.created_at::date, $2::int[]), 0)::int AS bucket,
+       COALESCE(SUM(a.quantity), 0)::int AS bucket_quantity
+FROM products p
+LEFT JOIN (
+    SELECT i.product_id, i.created_at,
+           GREATEST(LEAST(i.quantity, pr.quantity - i.newer), 0) AS quantity
+    FROM (
+        SELECT m.product_id, m.created_at, m.quantity,
+               SUM(m.quantity) OVER (
+                   PARTITION BY m.product_id ORDER BY m.created_at DESC, m.id DESC
+               ) - m.quantity AS newer
+        FROM stock_movements m
+        WHERE m.quantity > 0 AND m.type IN ('receipt', 'return', 'adjustment')
+    ) i
+    JOIN products pr ON pr.id = i.product_id
+) a ON a.product_id = p.id AND a.quantity > 0
+WHERE p.quantity > 0
+GROUP BY p.id, p.name, p.quantity, 4
+ORDER BY p.name, p.id, 4
+`
+
+type StockAgingReportParams struct {
+	Now    pgtype.Date `json:"now"`
+	Bounds []int32     `json:"bounds"`
+}
+
+type StockAgingReportRow struct {
+	ProductID      int32  `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	Quantity       int32  `json:"quantity"`
+	Bucket         int32  `json:"bucket"`
+	BucketQuantity int32  `json:"bucket_quantity"`
+}
+
+// StockAgingReport matches the stock on hand to the newest incoming movements,
+// so the oldest stock is taken to have left first, and counts it per age band.
+func (q *Queries) StockAgingReport(ctx context.Context, arg StockAgingReportParams) ([]StockAgingReportRow, error) {
+	rows, err := q.db.Query(ctx, stockAgingReport, arg.Now, arg.Bounds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockAgingReportRow{}
+	for rows.Next() {
+		var i StockAgingReportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Quantity,
+			&i.Bucket,
+			&i.BucketQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const turnoverReport = `-- name: TurnoverReport :many
+WITH moves AS (
+    SELECT sm.product_id,
+           SUM(sm.quantity) AS since_from,
+           SUM(CASE WHEN sm.created_at >= $1 THEN sm.quantity ELSE 0 END) AS since_to,
+           SUM(CASE WHEN sm.type = 'issue' AND sm.created_at < $1 THEN -sm.quantity ELSE 0 END) AS issued
+    FROM stock_movements sm
+    WHERE sm.type <> 'quarantine' AND sm.created_at >= $2
+    GROUP BY sm.product_id
+)
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       (p.quantity - COALESCE(m.since_from, 0))::int AS opening_quantity,
+       (p.quantity - COALESCE(m.since_to, 0))::int AS closing_quantity,
+       COALESCE(m.issued, 0)::int AS issued_quantity
+FROM products p
+LEFT JOIN moves m ON m.product_id = p.id
+ORDER BY p.name, p.id
+`
+
+type TurnoverReportParams struct {
+	ToTime   pgtype.Timestamptz `json:"to_time"`
+	FromTime pgtype.Timestamptz `json:"from_time"`
+}
+
+type TurnoverReportRow struct {
+	ProductID       int32  `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	OpeningQuantity int32  `json:"opening_quantity"`
+	ClosingQuantity int32  `json:"closing_quantity"`
+	IssuedQuantity  int32  `json:"issued_quantity"`
+}
+
+// TurnoverReport works out the stock at a past time as the current stock less
+// every movement since.
+func (q *Queries) TurnoverReport(ctx context.Context, arg TurnoverReportParams) ([]TurnoverReportRow, error) {
+	rows, err := q.db.Query(ctx, turnoverReport, arg.ToTime, arg.FromTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TurnoverReportRow{}
+	for rows.Next() {
+		var i TurnoverReportRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.OpeningQuantity,
+			&i.ClosingQuantity,
+			&i.IssuedQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,268 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: rma.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createReturn = `-- name: CreateReturn :one
+INSERT INTO returns (
+    order_id,
+    reason
+) VALUES (
+    $1, $2
+)
+RETURNING id
+`
+
+type CreateReturnParams struct {
+	OrderID int32  `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+func (q *Queries) CreateReturn(ctx context.Context, arg CreateReturnParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createReturn, arg.OrderID, arg.Reason)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createReturnLine = `-- name: CreateReturnLine :exec
+INSERT INTO return_lines (
+    return_id,
+    order_line_id,
+    product_id,
+    quantity,
+    serials
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+`
+
+type CreateReturnLineParams struct {
+	ReturnID    int32    `json:"return_id"`
+	OrderLineID int32    `json:"order_line_id"`
+	ProductID   int32    `json:"product_id"`
+	Quantity    int32    `json:"quantity"`
+	Serials     []string `json:"serials"`
+}
+
+func (q *Queries) CreateReturnLine(ctx context.Context, arg CreateReturnLineParams) error {
+	_, err := q.db.Exec(ctx, createReturnLine,
+		arg.ReturnID,
+		arg.OrderLineID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.Serials,
+	)
+	return err
+}
+
+const getReturn = `-- name: GetReturn :one
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE id = $1
+`
+
+func (q *Queries) GetReturn(ctx context.Context, id int32) (Return, error) {
+	row := q.db.QueryRow(ctx, getReturn, id)
+	var i Return
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Status,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.InspectedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const listReturnLines = `-- name: ListReturnLines :many
+SELECT id, return_id, order_line_id, product_id, quantity, serials,
+       restocked_quantity, quarantined_quantity, scrapped_quantity
+FROM return_lines
+WHERE return_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListReturnLines(ctx context.Context, returnID int32) ([]ReturnLine, error) {
+	rows, err := q.db.Query(ctx, listReturnLines, returnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReturnLine{}
+	for rows.Next() {
+		var i ReturnLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReturnID,
+			&i.OrderLineID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.Serials,
+			&i.RestockedQuantity,
+			&i.QuarantinedQuantity,
+			&i.ScrappedQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReturnedLines = `-- name: ListReturnedLines :many
+SELECT l.order_line_id, l.quantity, l.serials
+FROM return_lines l
+JOIN returns r ON r.id = l.return_id
+WHERE r.order_id = $1 AND r.status <> 'cancelled'
+`
+
+type ListReturnedLinesRow struct {
+	OrderLineID int32    `json:"order_line_id"`
+	Quantity    int32    `json:"quantity"`
+	Serials     []string `json:"serials"`
+}
+
+// ListReturnedLines returns the lines of an order's returns that were not
+// cancelled.
+func (q *Queries) ListReturnedLines(ctx context.Context, orderID int32) ([]ListReturnedLinesRow, error) {
+	rows, err := q.db.Query(ctx, listReturnedLines, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReturnedLinesRow{}
+	for rows.Next() {
+		var i ListReturnedLinesRow
+		if err := rows.Scan(&i.OrderLineID, &i.Quantity, &i.Serials); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReturns = `-- name: ListReturns :many
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE ($1::text = '' OR status = $1)
+  AND ($2::int IS NULL OR order_id = $2)
+ORDER BY created_at DESC, id DESC
+`
+
+type ListReturnsParams struct {
+	Status  string      `json:"status"`
+	OrderID pgtype.Int4 `json:"order_id"`
+}
+
+func (q *Queries) ListReturns(ctx context.Context, arg ListReturnsParams) ([]Return, error) {
+	rows, err := q.db.Query(ctx, listReturns, arg.Status, arg.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Return{}
+	for rows.Next() {
+		var i Return
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Status,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.InspectedAt,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockReturn = `-- name: LockReturn :one
+SELECT id, order_id, status, reason, created_at, inspected_at, cancelled_at
+FROM returns
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockReturn(ctx context.Context, id int32) (Return, error) {
+	row := q.db.QueryRow(ctx, lockReturn, id)
+	var i Return
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Status,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.InspectedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const setReturnLineDisposition = `-- name: SetReturnLineDisposition :exec
+UPDATE return_lines
+SET restocked_quantity = $2, quarantined_quantity = $3, scrapped_quantity = $4
+WHERE id = $1
+`
+
+type SetReturnLineDispositionParams struct {
+	ID                  int32 `json:"id"`
+	RestockedQuantity   int32 `json:"restocked_quantity"`
+	QuarantinedQuantity int32 `json:"quarantined_quantity"`
+	ScrappedQuantity    int32 `json:"scrapped_quantity"`
+}
+
+func (q *Queries) SetReturnLineDisposition(ctx context.Context, arg SetReturnLineDispositionParams) error {
+	_, err := q.db.Exec(ctx, setReturnLineDisposition,
+		arg.ID,
+		arg.RestockedQuantity,
+		arg.QuarantinedQuantity,
+		arg.ScrappedQuantity,
+	)
+	return err
+}
+
+const updateReturnState = `-- name: UpdateReturnState :exec
+UPDATE returns
+SET status = $1, inspected_at = $2, cancelled_at = $3
+WHERE id = $4
+`
+
+type UpdateReturnStateParams struct {
+	Status      string             `json:"status"`
+	InspectedAt pgtype.Timestamptz `json:"inspected_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	ID          int32              `json:"id"`
+}
+
+func (q *Queries) UpdateReturnState(ctx context.Context, arg UpdateReturnStateParams) error {
+	_, err := q.db.Exec(ctx, updateReturnState,
+		arg.Status,
+		arg.InspectedAt,
+		arg.CancelledAt,
+		arg.ID,
+	)
+	return err
+}
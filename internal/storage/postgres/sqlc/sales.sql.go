@@ -0,0 +1,295 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: sales.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const allocatedQuantity = `-- name: AllocatedQuantity :one
+SELECT COALESCE(SUM(l.allocated_quantity), 0)::int AS allocated
+FROM sales_order_lines l
+JOIN sales_orders o ON o.id = l.order_id
+WHERE l.product_id = $1
+  AND l.order_id <> $2
+  AND o.status IN ('allocated', 'picking', 'packed')
+`
+
+type AllocatedQuantityParams struct {
+	ProductID int32 `json:"product_id"`
+	OrderID   int32 `json:"order_id"`
+}
+
+// AllocatedQuantity is the stock of a product reserved by open orders other
+// than the given one; order 0 counts all open orders.
+func (q *Queries) AllocatedQuantity(ctx context.Context, arg AllocatedQuantityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, allocatedQuantity, arg.ProductID, arg.OrderID)
+	var allocated int32
+	err := row.Scan(&allocated)
+	return allocated, err
+}
+
+const createSalesOrder = `-- name: CreateSalesOrder :one
+INSERT INTO sales_orders (
+    customer,
+    customer_group,
+    currency,
+    reference
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id
+`
+
+type CreateSalesOrderParams struct {
+	Customer      string `json:"customer"`
+	CustomerGroup string `json:"customer_group"`
+	Currency      string `json:"currency"`
+	Reference     string `json:"reference"`
+}
+
+func (q *Queries) CreateSalesOrder(ctx context.Context, arg CreateSalesOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createSalesOrder,
+		arg.Customer,
+		arg.CustomerGroup,
+		arg.Currency,
+		arg.Reference,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createSalesOrderLine = `-- name: CreateSalesOrderLine :exec
+INSERT INTO sales_order_lines (
+    order_id,
+    product_id,
+    quantity,
+    unit_price
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type CreateSalesOrderLineParams struct {
+	OrderID   int32 `json:"order_id"`
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+	UnitPrice int64 `json:"unit_price"`
+}
+
+func (q *Queries) CreateSalesOrderLine(ctx context.Context, arg CreateSalesOrderLineParams) error {
+	_, err := q.db.Exec(ctx, createSalesOrderLine,
+		arg.OrderID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.UnitPrice,
+	)
+	return err
+}
+
+const getSalesOrder = `-- name: GetSalesOrder :one
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE id = $1
+`
+
+func (q *Queries) GetSalesOrder(ctx context.Context, id int32) (SalesOrder, error) {
+	row := q.db.QueryRow(ctx, getSalesOrder, id)
+	var i SalesOrder
+	err := row.Scan(
+		&i.ID,
+		&i.Customer,
+		&i.CustomerGroup,
+		&i.Status,
+		&i.Currency,
+		&i.Reference,
+		&i.Packages,
+		&i.Carrier,
+		&i.TrackingNumber,
+		&i.CreatedAt,
+		&i.AllocatedAt,
+		&i.PackedAt,
+		&i.ShippedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const listSalesOrderLines = `-- name: ListSalesOrderLines :many
+SELECT id, order_id, product_id, quantity, allocated_quantity, shipped_quantity, unit_price, serials
+FROM sales_order_lines
+WHERE order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListSalesOrderLines(ctx context.Context, orderID int32) ([]SalesOrderLine, error) {
+	rows, err := q.db.Query(ctx, listSalesOrderLines, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SalesOrderLine{}
+	for rows.Next() {
+		var i SalesOrderLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.AllocatedQuantity,
+			&i.ShippedQuantity,
+			&i.UnitPrice,
+			&i.Serials,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSalesOrders = `-- name: ListSalesOrders :many
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE ($1::text = '' OR status = $1)
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListSalesOrders(ctx context.Context, status string) ([]SalesOrder, error) {
+	rows, err := q.db.Query(ctx, listSalesOrders, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SalesOrder{}
+	for rows.Next() {
+		var i SalesOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.Customer,
+			&i.CustomerGroup,
+			&i.Status,
+			&i.Currency,
+			&i.Reference,
+			&i.Packages,
+			&i.Carrier,
+			&i.TrackingNumber,
+			&i.CreatedAt,
+			&i.AllocatedAt,
+			&i.PackedAt,
+			&i.ShippedAt,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockSalesOrder = `-- name: LockSalesOrder :one
+SELECT id, customer, customer_group, status, currency, reference, packages, carrier, tracking_number,
+       created_at, allocated_at, packed_at, shipped_at, cancelled_at
+FROM sales_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockSalesOrder(ctx context.Context, id int32) (SalesOrder, error) {
+	row := q.db.QueryRow(ctx, lockSalesOrder, id)
+	var i SalesOrder
+	err := row.Scan(
+		&i.ID,
+		&i.Customer,
+		&i.CustomerGroup,
+		&i.Status,
+		&i.Currency,
+		&i.Reference,
+		&i.Packages,
+		&i.Carrier,
+		&i.TrackingNumber,
+		&i.CreatedAt,
+		&i.AllocatedAt,
+		&i.PackedAt,
+		&i.ShippedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const updateSalesOrderLine = `-- name: UpdateSalesOrderLine :exec
+UPDATE sales_order_lines
+SET allocated_quantity = $2, shipped_quantity = $3, serials = $4
+WHERE id = $1
+`
+
+type UpdateSalesOrderLineParams struct {
+	ID                int32    `json:"id"`
+	AllocatedQuantity int32    `json:"allocated_quantity"`
+	ShippedQuantity   int32    `json:"shipped_quantity"`
+	Serials           []string `json:"serials"`
+}
+
+func (q *Queries) UpdateSalesOrderLine(ctx context.Context, arg UpdateSalesOrderLineParams) error {
+	_, err := q.db.Exec(ctx, updateSalesOrderLine,
+		arg.ID,
+		arg.AllocatedQuantity,
+		arg.ShippedQuantity,
+		arg.Serials,
+	)
+	return err
+}
+
+const updateSalesOrderState = `-- name: UpdateSalesOrderState :exec
+UPDATE sales_orders
+SET status = $1,
+    packages = $2,
+    carrier = $3,
+    tracking_number = $4,
+    allocated_at = $5,
+    packed_at = $6,
+    shipped_at = $7,
+    cancelled_at = $8
+WHERE id = $9
+`
+
+type UpdateSalesOrderStateParams struct {
+	Status         string             `json:"status"`
+	Packages       int32              `json:"packages"`
+	Carrier        string             `json:"carrier"`
+	TrackingNumber string             `json:"tracking_number"`
+	AllocatedAt    pgtype.Timestamptz `json:"allocated_at"`
+	PackedAt       pgtype.Timestamptz `json:"packed_at"`
+	ShippedAt      pgtype.Timestamptz `json:"shipped_at"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
+	ID             int32              `json:"id"`
+}
+
+func (q *Queries) UpdateSalesOrderState(ctx context.Context, arg UpdateSalesOrderStateParams) error {
+	_, err := q.db.Exec(ctx, updateSalesOrderState,
+		arg.Status,
+		arg.Packages,
+		arg.Carrier,
+		arg.TrackingNumber,
+		arg.AllocatedAt,
+		arg.PackedAt,
+		arg.ShippedAt,
+		arg.CancelledAt,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,214 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: serial.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSerial = `-- name: CreateSerial :one
+INSERT INTO serial_numbers (
+    product_id,
+    serial_number,
+    status
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (serial_number) DO NOTHING
+RETURNING id
+`
+
+type CreateSerialParams struct {
+	ProductID    int32  `json:"product_id"`
+	SerialNumber string `json:"serial_number"`
+	Status       string `json:"status"`
+}
+
+func (q *Queries) CreateSerial(ctx context.Context, arg CreateSerialParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createSerial, arg.ProductID, arg.SerialNumber, arg.Status)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createStockMovementSerial = `-- name: CreateStockMovementSerial :exec
+INSERT INTO stock_movement_serials (
+    movement_id,
+    serial_id
+) VALUES (
+    $1, $2
+)
+`
+
+type CreateStockMovementSerialParams struct {
+	MovementID int32 `json:"movement_id"`
+	SerialID   int32 `json:"serial_id"`
+}
+
+func (q *Queries) CreateStockMovementSerial(ctx context.Context, arg CreateStockMovementSerialParams) error {
+	_, err := q.db.Exec(ctx, createStockMovementSerial, arg.MovementID, arg.SerialID)
+	return err
+}
+
+const getSerialByNumber = `-- name: GetSerialByNumber :one
+SELECT id, product_id, serial_number, status, updated_at
+FROM serial_numbers
+WHERE serial_number = $1
+`
+
+type GetSerialByNumberRow struct {
+	ID           int32              `json:"id"`
+	ProductID    int32              `json:"product_id"`
+	SerialNumber string             `json:"serial_number"`
+	Status       string             `json:"status"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) GetSerialByNumber(ctx context.Context, serialNumber string) (GetSerialByNumberRow, error) {
+	row := q.db.QueryRow(ctx, getSerialByNumber, serialNumber)
+	var i GetSerialByNumberRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.SerialNumber,
+		&i.Status,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listSerialMovements = `-- name: ListSerialMovements :many
+SELECT m.id, m.product_id, m.type, m.quantity, m.entered_quantity, m.entered_unit, m.reference, m.created_at, m.warehouse_id
+FROM stock_movements m
+JOIN stock_movement_serials sms ON sms.movement_id = m.id
+WHERE sms.serial_id = $1
+ORDER BY m.created_at, m.id
+`
+
+func (q *Queries) ListSerialMovements(ctx context.Context, serialID int32) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listSerialMovements, serialID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockMovement{}
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Type,
+			&i.Quantity,
+			&i.EnteredQuantity,
+			&i.EnteredUnit,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSerialsByNumber = `-- name: ListSerialsByNumber :many
+SELECT id, product_id, serial_number, status, updated_at
+FROM serial_numbers
+WHERE serial_number = ANY($1::text[])
+`
+
+type ListSerialsByNumberRow struct {
+	ID           int32              `json:"id"`
+	ProductID    int32              `json:"product_id"`
+	SerialNumber string             `json:"serial_number"`
+	Status       string             `json:"status"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ListSerialsByNumber(ctx context.Context, numbers []string) ([]ListSerialsByNumberRow, error) {
+	rows, err := q.db.Query(ctx, listSerialsByNumber, numbers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSerialsByNumberRow{}
+	for rows.Next() {
+		var i ListSerialsByNumberRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SerialNumber,
+			&i.Status,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovementSerials = `-- name: ListStockMovementSerials :many
+SELECT sms.movement_id, s.serial_number
+FROM stock_movement_serials sms
+JOIN serial_numbers s ON s.id = sms.serial_id
+WHERE s.product_id = $1
+ORDER BY sms.movement_id, s.serial_number
+`
+
+type ListStockMovementSerialsRow struct {
+	MovementID   int32  `json:"movement_id"`
+	SerialNumber string `json:"serial_number"`
+}
+
+func (q *Queries) ListStockMovementSerials(ctx context.Context, productID int32) ([]ListStockMovementSerialsRow, error) {
+	rows, err := q.db.Query(ctx, listStockMovementSerials, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStockMovementSerialsRow{}
+	for rows.Next() {
+		var i ListStockMovementSerialsRow
+		if err := rows.Scan(&i.MovementID, &i.SerialNumber); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setSerialStatus = `-- name: SetSerialStatus :one
+UPDATE serial_numbers
+SET status = $1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND status = $3
+RETURNING id
+`
+
+type SetSerialStatusParams struct {
+	Status     string `json:"status"`
+	ID         int32  `json:"id"`
+	FromStatus string `json:"from_status"`
+}
+
+func (q *Queries) SetSerialStatus(ctx context.Context, arg SetSerialStatusParams) (int32, error) {
+	row := q.db.QueryRow(ctx, setSerialStatus, arg.Status, arg.ID, arg.FromStatus)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
@@ -0,0 +1,273 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: stock.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addProductQuantity = `-- name: AddProductQuantity :exec
+UPDATE products
+SET quantity = quantity + $2
+WHERE id = $1
+`
+
+type AddProductQuantityParams struct {
+	ID       int32 `json:"id"`
+	Quantity int32 `json:"quantity"`
+}
+
+func (q *Queries) AddProductQuantity(ctx context.Context, arg AddProductQuantityParams) error {
+	_, err := q.db.Exec(ctx, addProductQuantity, arg.ID, arg.Quantity)
+	return err
+}
+
+const addProductQuarantinedQuantity = `-- name: AddProductQuarantinedQuantity :one
+UPDATE products
+SET quarantined_quantity = quarantined_quantity + $2
+WHERE id = $1 AND quarantined_quantity + $2 >= 0
+RETURNING id
+`
+
+type AddProductQuarantinedQuantityParams struct {
+	ID                  int32 `json:"id"`
+	QuarantinedQuantity int32 `json:"quarantined_quantity"`
+}
+
+func (q *Queries) AddProductQuarantinedQuantity(ctx context.Context, arg AddProductQuarantinedQuantityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addProductQuarantinedQuantity, arg.ID, arg.QuarantinedQuantity)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createStockMovement = `-- name: CreateStockMovement :one
+INSERT INTO stock_movements (
+    product_id,
+    type,
+    quantity,
+    entered_quantity,
+    entered_unit,
+    reference,
+    warehouse_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, created_at
+`
+
+type CreateStockMovementParams struct {
+	ProductID       int32       `json:"product_id"`
+	Type            string      `json:"type"`
+	Quantity        int32       `json:"quantity"`
+	EnteredQuantity string      `json:"entered_quantity"`
+	EnteredUnit     string      `json:"entered_unit"`
+	Reference       string      `json:"reference"`
+	WarehouseID     pgtype.Int4 `json:"warehouse_id"`
+}
+
+type CreateStockMovementRow struct {
+	ID        int32              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (CreateStockMovementRow, error) {
+	row := q.db.QueryRow(ctx, createStockMovement,
+		arg.ProductID,
+		arg.Type,
+		arg.Quantity,
+		arg.EnteredQuantity,
+		arg.EnteredUnit,
+		arg.Reference,
+		arg.WarehouseID,
+	)
+	var i CreateStockMovementRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const deleteProductUnit = `-- name: DeleteProductUnit :exec
+DELETE FROM product_units
+WHERE product_id = $1 AND code = $2
+`
+
+type DeleteProductUnitParams struct {
+	ProductID int32  `json:"product_id"`
+	Code      string `json:"code"`
+}
+
+func (q *Queries) DeleteProductUnit(ctx context.Context, arg DeleteProductUnitParams) error {
+	_, err := q.db.Exec(ctx, deleteProductUnit, arg.ProductID, arg.Code)
+	return err
+}
+
+const listProductUnits = `-- name: ListProductUnits :many
+SELECT product_id, code, factor_num, factor_den, rounding
+FROM product_units
+WHERE product_id = $1
+ORDER BY code
+`
+
+func (q *Queries) ListProductUnits(ctx context.Context, productID int32) ([]ProductUnit, error) {
+	rows, err := q.db.Query(ctx, listProductUnits, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductUnit{}
+	for rows.Next() {
+		var i ProductUnit
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Code,
+			&i.FactorNum,
+			&i.FactorDen,
+			&i.Rounding,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentStockMovements = `-- name: ListRecentStockMovements :many
+SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id
+FROM (
+    SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id,
+           ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY created_at DESC, id DESC) AS position
+    FROM stock_movements
+    WHERE product_id = ANY($1::int[])
+) m
+WHERE position <= $2::int
+ORDER BY product_id, created_at DESC, id DESC
+`
+
+type ListRecentStockMovementsParams struct {
+	ProductIds []int32 `json:"product_ids"`
+	PerProduct int32   `json:"per_product"`
+}
+
+func (q *Queries) ListRecentStockMovements(ctx context.Context, arg ListRecentStockMovementsParams) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listRecentStockMovements, arg.ProductIds, arg.PerProduct)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockMovement{}
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Type,
+			&i.Quantity,
+			&i.EnteredQuantity,
+			&i.EnteredUnit,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovements = `-- name: ListStockMovements :many
+SELECT id, product_id, type, quantity, entered_quantity, entered_unit, reference, created_at, warehouse_id
+FROM stock_movements
+WHERE product_id = $1
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListStockMovements(ctx context.Context, productID int32) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listStockMovements, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockMovement{}
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Type,
+			&i.Quantity,
+			&i.EnteredQuantity,
+			&i.EnteredUnit,
+			&i.Reference,
+			&i.CreatedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProductQuantity = `-- name: LockProductQuantity :one
+SELECT quantity
+FROM products
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockProductQuantity(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRow(ctx, lockProductQuantity, id)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const upsertProductUnit = `-- name: UpsertProductUnit :exec
+INSERT INTO product_units (
+    product_id,
+    code,
+    factor_num,
+    factor_den,
+    rounding
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (product_id, code) DO UPDATE
+SET factor_num = EXCLUDED.factor_num,
+    factor_den = EXCLUDED.factor_den,
+    rounding = EXCLUDED.rounding
+`
+
+type UpsertProductUnitParams struct {
+	ProductID int32  `json:"product_id"`
+	Code      string `json:"code"`
+	FactorNum int64  `json:"factor_num"`
+	FactorDen int64  `json:"factor_den"`
+	Rounding  string `json:"rounding"`
+}
+
+func (q *Queries) UpsertProductUnit(ctx context.Context, arg UpsertProductUnitParams) error {
+	_, err := q.db.Exec(ctx, upsertProductUnit,
+		arg.ProductID,
+		arg.Code,
+		arg.FactorNum,
+		arg.FactorDen,
+		arg.Rounding,
+	)
+	return err
+}
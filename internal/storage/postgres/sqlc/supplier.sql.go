@@ -0,0 +1,331 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: supplier.sql
+
+package postgresdb
+
+import (
+	"context"
+)
+
+const createSupplier = `-- name: CreateSupplier :one
+INSERT INTO suppliers (
+    name,
+    contact_name,
+    email,
+    phone,
+    address,
+    lead_time_days,
+    payment_terms
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id
+`
+
+type CreateSupplierParams struct {
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	PaymentTerms string `json:"payment_terms"`
+}
+
+func (q *Queries) CreateSupplier(ctx context.Context, arg CreateSupplierParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createSupplier,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Address,
+		arg.LeadTimeDays,
+		arg.PaymentTerms,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteProductSupplier = `-- name: DeleteProductSupplier :exec
+DELETE FROM product_suppliers
+WHERE product_id = $1 AND supplier_id = $2
+`
+
+type DeleteProductSupplierParams struct {
+	ProductID  int32 `json:"product_id"`
+	SupplierID int32 `json:"supplier_id"`
+}
+
+func (q *Queries) DeleteProductSupplier(ctx context.Context, arg DeleteProductSupplierParams) error {
+	_, err := q.db.Exec(ctx, deleteProductSupplier, arg.ProductID, arg.SupplierID)
+	return err
+}
+
+const deleteSupplier = `-- name: DeleteSupplier :exec
+DELETE FROM suppliers
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSupplier(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, deleteSupplier, id)
+	return err
+}
+
+const getSupplier = `-- name: GetSupplier :one
+SELECT id, name, contact_name, email, phone, address, lead_time_days, payment_terms
+FROM suppliers
+WHERE id = $1
+`
+
+type GetSupplierRow struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	PaymentTerms string `json:"payment_terms"`
+}
+
+func (q *Queries) GetSupplier(ctx context.Context, id int32) (GetSupplierRow, error) {
+	row := q.db.QueryRow(ctx, getSupplier, id)
+	var i GetSupplierRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ContactName,
+		&i.Email,
+		&i.Phone,
+		&i.Address,
+		&i.LeadTimeDays,
+		&i.PaymentTerms,
+	)
+	return i, err
+}
+
+const listAllProductSuppliers = `-- name: ListAllProductSuppliers :many
+SELECT ps.product_id, ps.supplier_id, s.name AS supplier_name, ps.supplier_sku,
+       ps.cost_price, ps.cost_currency, ps.min_order_quantity, s.lead_time_days
+FROM product_suppliers ps
+JOIN suppliers s ON s.id = ps.supplier_id
+ORDER BY ps.product_id, ps.cost_currency, ps.cost_price, s.lead_time_days, s.id
+`
+
+type ListAllProductSuppliersRow struct {
+	ProductID        int32  `json:"product_id"`
+	SupplierID       int32  `json:"supplier_id"`
+	SupplierName     string `json:"supplier_name"`
+	SupplierSku      string `json:"supplier_sku"`
+	CostPrice        int64  `json:"cost_price"`
+	CostCurrency     string `json:"cost_currency"`
+	MinOrderQuantity int32  `json:"min_order_quantity"`
+	LeadTimeDays     int32  `json:"lead_time_days"`
+}
+
+func (q *Queries) ListAllProductSuppliers(ctx context.Context) ([]ListAllProductSuppliersRow, error) {
+	rows, err := q.db.Query(ctx, listAllProductSuppliers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAllProductSuppliersRow{}
+	for rows.Next() {
+		var i ListAllProductSuppliersRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SupplierID,
+			&i.SupplierName,
+			&i.SupplierSku,
+			&i.CostPrice,
+			&i.CostCurrency,
+			&i.MinOrderQuantity,
+			&i.LeadTimeDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductSuppliers = `-- name: ListProductSuppliers :many
+SELECT ps.product_id, ps.supplier_id, s.name AS supplier_name, ps.supplier_sku,
+       ps.cost_price, ps.cost_currency, ps.min_order_quantity, s.lead_time_days
+FROM product_suppliers ps
+JOIN suppliers s ON s.id = ps.supplier_id
+WHERE ps.product_id = $1
+ORDER BY ps.cost_currency, ps.cost_price, s.lead_time_days, s.id
+`
+
+type ListProductSuppliersRow struct {
+	ProductID        int32  `json:"product_id"`
+	SupplierID       int32  `json:"supplier_id"`
+	SupplierName     string `json:"supplier_name"`
+	SupplierSku      string `json:"supplier_sku"`
+	CostPrice        int64  `json:"cost_price"`
+	CostCurrency     string `json:"cost_currency"`
+	MinOrderQuantity int32  `json:"min_order_quantity"`
+	LeadTimeDays     int32  `json:"lead_time_days"`
+}
+
+func (q *Queries) ListProductSuppliers(ctx context.Context, productID int32) ([]ListProductSuppliersRow, error) {
+	rows, err := q.db.Query(ctx, listProductSuppliers, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductSuppliersRow{}
+	for rows.Next() {
+		var i ListProductSuppliersRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.SupplierID,
+			&i.SupplierName,
+			&i.SupplierSku,
+			&i.CostPrice,
+			&i.CostCurrency,
+			&i.MinOrderQuantity,
+			&i.LeadTimeDays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSuppliers = `-- name: ListSuppliers :many
+SELECT id, name, contact_name, email, phone, address, lead_time_days, payment_terms
+FROM suppliers
+ORDER BY name, id
+`
+
+type ListSuppliersRow struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	PaymentTerms string `json:"payment_terms"`
+}
+
+func (q *Queries) ListSuppliers(ctx context.Context) ([]ListSuppliersRow, error) {
+	rows, err := q.db.Query(ctx, listSuppliers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSuppliersRow{}
+	for rows.Next() {
+		var i ListSuppliersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ContactName,
+			&i.Email,
+			&i.Phone,
+			&i.Address,
+			&i.LeadTimeDays,
+			&i.PaymentTerms,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSupplier = `-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+    name = $2,
+    contact_name = $3,
+    email = $4,
+    phone = $5,
+    address = $6,
+    lead_time_days = $7,
+    payment_terms = $8
+WHERE id = $1
+RETURNING id
+`
+
+type UpdateSupplierParams struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	PaymentTerms string `json:"payment_terms"`
+}
+
+func (q *Queries) UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateSupplier,
+		arg.ID,
+		arg.Name,
+		arg.ContactName,
+		arg.Email,
+		arg.Phone,
+		arg.Address,
+		arg.LeadTimeDays,
+		arg.PaymentTerms,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertProductSupplier = `-- name: UpsertProductSupplier :exec
+INSERT INTO product_suppliers (
+    product_id,
+    supplier_id,
+    supplier_sku,
+    cost_price,
+    cost_currency,
+    min_order_quantity
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (product_id, supplier_id) DO UPDATE
+SET supplier_sku = EXCLUDED.supplier_sku,
+    cost_price = EXCLUDED.cost_price,
+    cost_currency = EXCLUDED.cost_currency,
+    min_order_quantity = EXCLUDED.min_order_quantity
+`
+
+type UpsertProductSupplierParams struct {
+	ProductID        int32  `json:"product_id"`
+	SupplierID       int32  `json:"supplier_id"`
+	SupplierSku      string `json:"supplier_sku"`
+	CostPrice        int64  `json:"cost_price"`
+	CostCurrency     string `json:"cost_currency"`
+	MinOrderQuantity int32  `json:"min_order_quantity"`
+}
+
+func (q *Queries) UpsertProductSupplier(ctx context.Context, arg UpsertProductSupplierParams) error {
+	_, err := q.db.Exec(ctx, upsertProductSupplier,
+		arg.ProductID,
+		arg.SupplierID,
+		arg.SupplierSku,
+		arg.CostPrice,
+		arg.CostCurrency,
+		arg.MinOrderQuantity,
+	)
+	return err
+}
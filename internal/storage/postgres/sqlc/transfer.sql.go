@@ -0,0 +1,296 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO transfer_orders (
+    from_warehouse_id,
+    to_warehouse_id,
+    reference
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id
+`
+
+type CreateTransferParams struct {
+	FromWarehouseID int32  `json:"from_warehouse_id"`
+	ToWarehouseID   int32  `json:"to_warehouse_id"`
+	Reference       string `json:"reference"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createTransfer, arg.FromWarehouseID, arg.ToWarehouseID, arg.Reference)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createTransferLine = `-- name: CreateTransferLine :exec
+INSERT INTO transfer_lines (
+    transfer_id,
+    product_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+)
+`
+
+type CreateTransferLineParams struct {
+	TransferID int32 `json:"transfer_id"`
+	ProductID  int32 `json:"product_id"`
+	Quantity   int32 `json:"quantity"`
+}
+
+func (q *Queries) CreateTransferLine(ctx context.Context, arg CreateTransferLineParams) error {
+	_, err := q.db.Exec(ctx, createTransferLine, arg.TransferID, arg.ProductID, arg.Quantity)
+	return err
+}
+
+const getTransfer = `-- name: GetTransfer :one
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE id = $1
+`
+
+func (q *Queries) GetTransfer(ctx context.Context, id int32) (TransferOrder, error) {
+	row := q.db.QueryRow(ctx, getTransfer, id)
+	var i TransferOrder
+	err := row.Scan(
+		&i.ID,
+		&i.FromWarehouseID,
+		&i.ToWarehouseID,
+		&i.Status,
+		&i.Reference,
+		&i.Carrier,
+		&i.TrackingNumber,
+		&i.CreatedAt,
+		&i.ShippedAt,
+		&i.ReceivedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const listOpenTransfers = `-- name: ListOpenTransfers :many
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE status IN ('draft', 'in_transit')
+  AND ($1::int IS NULL
+       OR from_warehouse_id = $1
+       OR to_warehouse_id = $1)
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListOpenTransfers(ctx context.Context, warehouseID pgtype.Int4) ([]TransferOrder, error) {
+	rows, err := q.db.Query(ctx, listOpenTransfers, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferOrder{}
+	for rows.Next() {
+		var i TransferOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromWarehouseID,
+			&i.ToWarehouseID,
+			&i.Status,
+			&i.Reference,
+			&i.Carrier,
+			&i.TrackingNumber,
+			&i.CreatedAt,
+			&i.ShippedAt,
+			&i.ReceivedAt,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferLines = `-- name: ListTransferLines :many
+SELECT id, transfer_id, product_id, quantity, shipped_quantity, received_quantity, discrepancy, discrepancy_reason
+FROM transfer_lines
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListTransferLines(ctx context.Context, transferID int32) ([]TransferLine, error) {
+	rows, err := q.db.Query(ctx, listTransferLines, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferLine{}
+	for rows.Next() {
+		var i TransferLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.ShippedQuantity,
+			&i.ReceivedQuantity,
+			&i.Discrepancy,
+			&i.DiscrepancyReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfers = `-- name: ListTransfers :many
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE ($1::text = '' OR status = $1)
+  AND ($2::int IS NULL
+       OR from_warehouse_id = $2
+       OR to_warehouse_id = $2)
+ORDER BY created_at DESC, id DESC
+`
+
+type ListTransfersParams struct {
+	Status      string      `json:"status"`
+	WarehouseID pgtype.Int4 `json:"warehouse_id"`
+}
+
+func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]TransferOrder, error) {
+	rows, err := q.db.Query(ctx, listTransfers, arg.Status, arg.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferOrder{}
+	for rows.Next() {
+		var i TransferOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromWarehouseID,
+			&i.ToWarehouseID,
+			&i.Status,
+			&i.Reference,
+			&i.Carrier,
+			&i.TrackingNumber,
+			&i.CreatedAt,
+			&i.ShippedAt,
+			&i.ReceivedAt,
+			&i.CancelledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTransfer = `-- name: LockTransfer :one
+SELECT id, from_warehouse_id, to_warehouse_id, status, reference, carrier, tracking_number,
+       created_at, shipped_at, received_at, cancelled_at
+FROM transfer_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockTransfer(ctx context.Context, id int32) (TransferOrder, error) {
+	row := q.db.QueryRow(ctx, lockTransfer, id)
+	var i TransferOrder
+	err := row.Scan(
+		&i.ID,
+		&i.FromWarehouseID,
+		&i.ToWarehouseID,
+		&i.Status,
+		&i.Reference,
+		&i.Carrier,
+		&i.TrackingNumber,
+		&i.CreatedAt,
+		&i.ShippedAt,
+		&i.ReceivedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const setTransferLineQuantities = `-- name: SetTransferLineQuantities :exec
+UPDATE transfer_lines
+SET shipped_quantity = $2, received_quantity = $3, discrepancy = $4, discrepancy_reason = $5
+WHERE id = $1
+`
+
+type SetTransferLineQuantitiesParams struct {
+	ID                int32  `json:"id"`
+	ShippedQuantity   int32  `json:"shipped_quantity"`
+	ReceivedQuantity  int32  `json:"received_quantity"`
+	Discrepancy       int32  `json:"discrepancy"`
+	DiscrepancyReason string `json:"discrepancy_reason"`
+}
+
+func (q *Queries) SetTransferLineQuantities(ctx context.Context, arg SetTransferLineQuantitiesParams) error {
+	_, err := q.db.Exec(ctx, setTransferLineQuantities,
+		arg.ID,
+		arg.ShippedQuantity,
+		arg.ReceivedQuantity,
+		arg.Discrepancy,
+		arg.DiscrepancyReason,
+	)
+	return err
+}
+
+const updateTransferState = `-- name: UpdateTransferState :exec
+UPDATE transfer_orders
+SET status = $1,
+    carrier = $2,
+    tracking_number = $3,
+    shipped_at = $4,
+    received_at = $5,
+    cancelled_at = $6
+WHERE id = $7
+`
+
+type UpdateTransferStateParams struct {
+	Status         string             `json:"status"`
+	Carrier        string             `json:"carrier"`
+	TrackingNumber string             `json:"tracking_number"`
+	ShippedAt      pgtype.Timestamptz `json:"shipped_at"`
+	ReceivedAt     pgtype.Timestamptz `json:"received_at"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
+	ID             int32              `json:"id"`
+}
+
+func (q *Queries) UpdateTransferState(ctx context.Context, arg UpdateTransferStateParams) error {
+	_, err := q.db.Exec(ctx, updateTransferState,
+		arg.Status,
+		arg.Carrier,
+		arg.TrackingNumber,
+		arg.ShippedAt,
+		arg.ReceivedAt,
+		arg.CancelledAt,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,191 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: valuation.sql
+
+package postgresdb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const consumeCostLayer = `-- name: ConsumeCostLayer :exec
+UPDATE cost_layers
+SET remaining = remaining - $1
+WHERE id = $2
+`
+
+type ConsumeCostLayerParams struct {
+	Quantity int32 `json:"quantity"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) ConsumeCostLayer(ctx context.Context, arg ConsumeCostLayerParams) error {
+	_, err := q.db.Exec(ctx, consumeCostLayer, arg.Quantity, arg.ID)
+	return err
+}
+
+const createCostEntry = `-- name: CreateCostEntry :exec
+INSERT INTO cost_entries (
+    movement_id,
+    product_id,
+    quantity,
+    value,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+`
+
+type CreateCostEntryParams struct {
+	MovementID pgtype.Int4 `json:"movement_id"`
+	ProductID  int32       `json:"product_id"`
+	Quantity   int32       `json:"quantity"`
+	Value      int64       `json:"value"`
+	Currency   string      `json:"currency"`
+}
+
+func (q *Queries) CreateCostEntry(ctx context.Context, arg CreateCostEntryParams) error {
+	_, err := q.db.Exec(ctx, createCostEntry,
+		arg.MovementID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.Value,
+		arg.Currency,
+	)
+	return err
+}
+
+const createCostLayer = `-- name: CreateCostLayer :exec
+INSERT INTO cost_layers (
+    product_id,
+    movement_id,
+    unit_cost,
+    currency,
+    quantity,
+    remaining
+) VALUES (
+    $1, $2, $3, $4, $5, $5
+)
+`
+
+type CreateCostLayerParams struct {
+	ProductID  int32       `json:"product_id"`
+	MovementID pgtype.Int4 `json:"movement_id"`
+	UnitCost   int64       `json:"unit_cost"`
+	Currency   string      `json:"currency"`
+	Quantity   int32       `json:"quantity"`
+}
+
+func (q *Queries) CreateCostLayer(ctx context.Context, arg CreateCostLayerParams) error {
+	_, err := q.db.Exec(ctx, createCostLayer,
+		arg.ProductID,
+		arg.MovementID,
+		arg.UnitCost,
+		arg.Currency,
+		arg.Quantity,
+	)
+	return err
+}
+
+const getCostTotals = `-- name: GetCostTotals :one
+SELECT COALESCE(SUM(quantity), 0)::int AS quantity,
+       COALESCE(SUM(value), 0)::bigint AS value
+FROM cost_entries
+WHERE product_id = $1
+`
+
+type GetCostTotalsRow struct {
+	Quantity int32 `json:"quantity"`
+	Value    int64 `json:"value"`
+}
+
+func (q *Queries) GetCostTotals(ctx context.Context, productID int32) (GetCostTotalsRow, error) {
+	row := q.db.QueryRow(ctx, getCostTotals, productID)
+	var i GetCostTotalsRow
+	err := row.Scan(&i.Quantity, &i.Value)
+	return i, err
+}
+
+const listOpenCostLayers = `-- name: ListOpenCostLayers :many
+SELECT id, unit_cost, remaining
+FROM cost_layers
+WHERE product_id = $1 AND remaining > 0
+ORDER BY created_at, id
+`
+
+type ListOpenCostLayersRow struct {
+	ID        int32 `json:"id"`
+	UnitCost  int64 `json:"unit_cost"`
+	Remaining int32 `json:"remaining"`
+}
+
+func (q *Queries) ListOpenCostLayers(ctx context.Context, productID int32) ([]ListOpenCostLayersRow, error) {
+	rows, err := q.db.Query(ctx, listOpenCostLayers, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOpenCostLayersRow{}
+	for rows.Next() {
+		var i ListOpenCostLayersRow
+		if err := rows.Scan(&i.ID, &i.UnitCost, &i.Remaining); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductValues = `-- name: ListProductValues :many
+SELECT e.product_id,
+       p.name AS product_name,
+       e.currency,
+       SUM(e.quantity)::int AS quantity,
+       SUM(e.value)::bigint AS value
+FROM cost_entries e
+JOIN products p ON p.id = e.product_id
+WHERE e.created_at <= $1
+GROUP BY e.product_id, p.name, e.currency
+HAVING SUM(e.quantity) <> 0 OR SUM(e.value) <> 0
+ORDER BY p.name, e.product_id
+`
+
+type ListProductValuesRow struct {
+	ProductID   int32  `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Currency    string `json:"currency"`
+	Quantity    int32  `json:"quantity"`
+	Value       int64  `json:"value"`
+}
+
+func (q *Queries) ListProductValues(ctx context.Context, asOf pgtype.Timestamptz) ([]ListProductValuesRow, error) {
+	rows, err := q.db.Query(ctx, listProductValues, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductValuesRow{}
+	for rows.Next() {
+		var i ListProductValuesRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Currency,
+			&i.Quantity,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
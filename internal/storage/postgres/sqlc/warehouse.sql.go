@@ -0,0 +1,200 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: warehouse.sql
+
+package postgresdb
+
+import (
+	"context"
+)
+
+const addWarehouseQuantity = `-- name: AddWarehouseQuantity :one
+INSERT INTO warehouse_stock (
+    warehouse_id,
+    product_id,
+    quantity
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (warehouse_id, product_id) DO UPDATE
+SET quantity = warehouse_stock.quantity + EXCLUDED.quantity
+RETURNING quantity
+`
+
+type AddWarehouseQuantityParams struct {
+	WarehouseID int32 `json:"warehouse_id"`
+	ProductID   int32 `json:"product_id"`
+	Quantity    int32 `json:"quantity"`
+}
+
+// AddWarehouseQuantity changes the stock of a product at a warehouse; the
+// check constraint rejects a negative result.
+func (q *Queries) AddWarehouseQuantity(ctx context.Context, arg AddWarehouseQuantityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, addWarehouseQuantity, arg.WarehouseID, arg.ProductID, arg.Quantity)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const createWarehouse = `-- name: CreateWarehouse :one
+INSERT INTO warehouses (
+    code,
+    name
+) VALUES (
+    $1, $2
+)
+RETURNING id
+`
+
+type CreateWarehouseParams struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createWarehouse, arg.Code, arg.Name)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getDefaultWarehouseID = `-- name: GetDefaultWarehouseID :one
+SELECT id
+FROM warehouses
+WHERE is_default
+`
+
+func (q *Queries) GetDefaultWarehouseID(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, getDefaultWarehouseID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getWarehouse = `-- name: GetWarehouse :one
+SELECT id, code, name, is_default, created_at
+FROM warehouses
+WHERE id = $1
+`
+
+func (q *Queries) GetWarehouse(ctx context.Context, id int32) (Warehouse, error) {
+	row := q.db.QueryRow(ctx, getWarehouse, id)
+	var i Warehouse
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.IsDefault,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWarehouseQuantity = `-- name: GetWarehouseQuantity :one
+SELECT COALESCE((
+    SELECT quantity
+    FROM warehouse_stock
+    WHERE warehouse_id = $1 AND product_id = $2
+), 0)::int AS quantity
+`
+
+type GetWarehouseQuantityParams struct {
+	WarehouseID int32 `json:"warehouse_id"`
+	ProductID   int32 `json:"product_id"`
+}
+
+func (q *Queries) GetWarehouseQuantity(ctx context.Context, arg GetWarehouseQuantityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getWarehouseQuantity, arg.WarehouseID, arg.ProductID)
+	var quantity int32
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const listWarehouseStock = `-- name: ListWarehouseStock :many
+SELECT p.id AS product_id,
+       p.name AS product_name,
+       COALESCE(ws.quantity, 0)::int AS quantity,
+       COALESCE((
+           SELECT SUM(l.shipped_quantity)
+           FROM transfer_lines l
+           JOIN transfer_orders t ON t.id = l.transfer_id
+           WHERE t.to_warehouse_id = $1 AND t.status = 'in_transit' AND l.product_id = p.id
+       ), 0)::int AS inbound_quantity
+FROM products p
+LEFT JOIN warehouse_stock ws ON ws.product_id = p.id AND ws.warehouse_id = $1
+WHERE ws.quantity > 0
+   OR EXISTS (
+       SELECT 1
+       FROM transfer_lines l
+       JOIN transfer_orders t ON t.id = l.transfer_id
+       WHERE t.to_warehouse_id = $1 AND t.status = 'in_transit' AND l.product_id = p.id
+   )
+ORDER BY p.id
+`
+
+type ListWarehouseStockRow struct {
+	ProductID       int32  `json:"product_id"`
+	ProductName     string `json:"product_name"`
+	Quantity        int32  `json:"quantity"`
+	InboundQuantity int32  `json:"inbound_quantity"`
+}
+
+// ListWarehouseStock returns the products on hand at a warehouse or in transit
+// to it.
+func (q *Queries) ListWarehouseStock(ctx context.Context, warehouseID int32) ([]ListWarehouseStockRow, error) {
+	rows, err := q.db.Query(ctx, listWarehouseStock, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWarehouseStockRow{}
+	for rows.Next() {
+		var i ListWarehouseStockRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Quantity,
+			&i.InboundQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWarehouses = `-- name: ListWarehouses :many
+SELECT id, code, name, is_default, created_at
+FROM warehouses
+ORDER BY id
+`
+
+func (q *Queries) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
+	rows, err := q.db.Query(ctx, listWarehouses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Warehouse{}
+	for rows.Next() {
+		var i Warehouse
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.IsDefault,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
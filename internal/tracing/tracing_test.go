@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "ListProducts", queryName("-- name: ListProducts :many\nSELECT id FROM products"))
+	assert.Equal(t, "BEGIN", queryName("begin"))
+	assert.Equal(t, "query", queryName("  "))
+}
+
+func TestQueryTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	Install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	ctx, parent := tracer.Start(context.Background(), "ProductUseCase.List")
+	var qt QueryTracer
+	qctx := qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "-- name: ListProducts :many\nSELECT id FROM products"})
+	qt.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{})
+	qctx = qt.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "-- name: DeleteProduct :exec\nDELETE FROM products"})
+	qt.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 3) {
+		list := spans[0]
+		assert.Equal(t, "db ListProducts", list.Name)
+		assert.Equal(t, trace.SpanKindClient, list.SpanKind)
+		assert.Equal(t, parent.SpanContext().SpanID(), list.Parent.SpanID())
+		assert.Contains(t, list.Attributes, attribute.String("db.system", "postgresql"))
+		assert.Contains(t, list.Attributes, attribute.String("db.operation.name", "ListProducts"))
+		assert.Equal(t, codes.Unset, list.Status.Code)
+
+		failed := spans[1]
+		assert.Equal(t, "db DeleteProduct", failed.Name)
+		assert.Equal(t, codes.Error, failed.Status.Code)
+		assert.Equal(t, "connection reset", failed.Status.Description)
+	}
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	_, err := Setup(context.Background(), config.Tracing{Exporter: "jaeger", SampleRatio: 1})
+	assert.EqualError(t, err, "exporter must be one of none, stdout, otlp")
+
+	_, err = Setup(context.Background(), config.Tracing{Exporter: ExporterNone, SampleRatio: 2})
+	assert.EqualError(t, err, "sample ratio must be between 0 and 1")
+
+	shutdown, err := Setup(context.Background(), config.Tracing{Exporter: ExporterNone, SampleRatio: 1})
+	if assert.NoError(t, err) {
+		assert.NoError(t, shutdown(context.Background()))
+	}
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+)
+
+// abcPeriod is how far back issues are valued to classify products for counts.
+const abcPeriod = 365 * 24 * time.Hour
+
+type CountUseCase struct {
+	repo       cyclecount.Repository
+	warehouses warehouse.Repository
+	stock      *StockUseCase
+	reports    *ReportUseCase
+	// thresholdPct is the variance, in percent of the system quantity, above
+	// which a count needs approval before it is posted.
+	thresholdPct float64
+}
+
+func NewCountUseCase(r cyclecount.Repository, warehouses warehouse.Repository, stock *StockUseCase, reports *ReportUseCase, thresholdPct float64) *CountUseCase {
+	return &CountUseCase{repo: r, warehouses: warehouses, stock: stock, reports: reports, thresholdPct: thresholdPct}
+}
+
+var (
+	ErrInvalidCountStatus = errors.New("unknown count status")
+	ErrInvalidABCClass    = errors.New("ABC class must be one of A, B, C")
+	ErrNothingToCount     = errors.New("no products match the count filters")
+	ErrUncountedLines     = errors.New("every line must be counted before the count is submitted")
+	ErrApproverRequired   = errors.New("approver is required")
+	ErrTrackedCount       = errors.New("lot-tracked and serialized products cannot be counted")
+)
+
+// Create generates the count sheet of a session: the products of the warehouse
+// (the default one if none is given) whose bin location starts with the session
+// bin location, in its category and, if an ABC class is set, in that class of
+// the ABC report over the past year. Each line takes the current
+// warehouse stock as its system quantity. Lot-tracked and serialized products
+// are not counted.
+func (u *CountUseCase) Create(ctx context.Context, s cyclecount.Session) (int32, error) {
+	if s.ABCClass != "" && !s.ABCClass.Valid() {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidABCClass, s.ABCClass)
+	}
+
+	if s.WarehouseID == 0 {
+		warehouses, err := u.warehouses.List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, w := range warehouses {
+			if w.Default {
+				s.WarehouseID = w.ID
+			}
+		}
+	}
+	if _, err := u.warehouses.Get(ctx, s.WarehouseID); err != nil {
+		return 0, err
+	}
+
+	candidates, err := u.repo.Candidates(ctx, s.WarehouseID)
+	if err != nil {
+		return 0, err
+	}
+	var classes map[int32]stock.ABCClass
+	if s.ABCClass != "" {
+		classes, err = u.classes(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, c := range candidates {
+		if !strings.HasPrefix(c.BinLocation, s.BinLocation) ||
+			(s.Category != "" && !strings.EqualFold(c.Category, s.Category)) ||
+			(classes != nil && classOf(classes, c.ProductID) != s.ABCClass) {
+			continue
+		}
+		quantity := c.Quantity
+		s.Lines = append(s.Lines, cyclecount.Line{
+			ProductID:      c.ProductID,
+			ProductName:    c.ProductName,
+			BinLocation:    c.BinLocation,
+			SystemQuantity: &quantity,
+		})
+	}
+	if len(s.Lines) == 0 {
+		return 0, ErrNothingToCount
+	}
+	return u.repo.Create(ctx, s)
+}
+
+// classes returns the ABC class of the products issued over the past year.
+func (u *CountUseCase) classes(ctx context.Context) (map[int32]stock.ABCClass, error) {
+	now := time.Now()
+	rows, err := u.reports.ABC(ctx, now.Add(-abcPeriod), now)
+	if err != nil {
+		return nil, err
+	}
+	classes := make(map[int32]stock.ABCClass, len(rows))
+	for _, row := range rows {
+		classes[row.ProductID] = row.Class
+	}
+	return classes, nil
+}
+
+// classOf returns the class of a product; products that were not issued are
+// class C.
+func classOf(classes map[int32]stock.ABCClass, productID int32) stock.ABCClass {
+	if c, ok := classes[productID]; ok {
+		return c
+	}
+	return stock.ClassC
+}
+
+// Get returns the session with its count sheet; system quantities and variances
+// of an open blind count are left out.
+func (u *CountUseCase) Get(ctx context.Context, id int32) (cyclecount.Session, error) {
+	s, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	return hideSystemQuantities(s), nil
+}
+
+func (u *CountUseCase) List(ctx context.Context, status cyclecount.Status) ([]cyclecount.Session, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCountStatus, status)
+	}
+	return u.repo.List(ctx, status)
+}
+
+// RecordCounts saves counted quantities of an open session. A line can be
+// counted again until the session is submitted. Each counted line takes the
+// warehouse stock at that moment as its system quantity, so its variance is
+// what approval adjusts the stock by, whatever moves before or after.
+func (u *CountUseCase) RecordCounts(ctx context.Context, id int32, counts []cyclecount.Count) (cyclecount.Session, error) {
+	// The stock is read before the session is locked, as the adjustments are in
+	// Submit.
+	s, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	candidates, err := u.repo.Candidates(ctx, s.WarehouseID)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	onHand := make(map[int32]int32, len(candidates))
+	for _, c := range candidates {
+		onHand[c.ProductID] = c.Quantity
+	}
+
+	s, err = u.repo.Update(ctx, id, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		if s.Status != cyclecount.StatusOpen {
+			return nil, fmt.Errorf("%w: count is %s", cyclecount.ErrStatus, s.Status)
+		}
+		return nil, recordCounts(s, counts, onHand)
+	})
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	return hideSystemQuantities(s), nil
+}
+
+// recordCounts sets the counted quantities of the lines, with the warehouse
+// stock by product as their system quantities.
+func recordCounts(s *cyclecount.Session, counts []cyclecount.Count, onHand map[int32]int32) error {
+	for _, c := range counts {
+		line := findCountLine(s.Lines, c.LineID)
+		if line == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, c.LineID)
+		}
+		if c.Quantity < 0 {
+			return fmt.Errorf("%w: must not be negative", stock.ErrInvalidQuantity)
+		}
+		quantity, system := c.Quantity, onHand[line.ProductID]
+		variance := quantity - system
+		line.CountedQuantity = &quantity
+		line.SystemQuantity = &system
+		line.Variance = &variance
+	}
+	return nil
+}
+
+// Submit closes counting. If no line varies by more than the approval
+// threshold, the count is approved and its adjustments are posted at once;
+// otherwise it waits for approval.
+func (u *CountUseCase) Submit(ctx context.Context, id int32) (cyclecount.Session, error) {
+	// The adjustments are prepared before the session is locked, so the
+	// transaction runs no other queries; the lines of a session never change.
+	now := time.Now()
+	s, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	if err := u.submit(&s, now); err != nil {
+		return cyclecount.Session{}, err
+	}
+	adjustments, err := u.prepareAdjustments(ctx, s)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+
+	return u.repo.Update(ctx, id, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		if err := u.submit(s, now); err != nil {
+			return nil, err
+		}
+		if s.Status == cyclecount.StatusPendingApproval {
+			return nil, nil
+		}
+		return approve(s, "", now, adjustments), nil
+	})
+}
+
+// submit closes counting on an open session and marks it pending approval if a
+// line varies by more than the approval threshold.
+func (u *CountUseCase) submit(s *cyclecount.Session, now time.Time) error {
+	if s.Status != cyclecount.StatusOpen {
+		return fmt.Errorf("%w: count is %s", cyclecount.ErrStatus, s.Status)
+	}
+	s.SubmittedAt = &now
+	for _, line := range s.Lines {
+		if line.CountedQuantity == nil {
+			return fmt.Errorf("%w: line %d", ErrUncountedLines, line.ID)
+		}
+		if line.NeedsApproval(u.thresholdPct) {
+			s.Status = cyclecount.StatusPendingApproval
+		}
+	}
+	return nil
+}
+
+// Approve accepts a count waiting for approval and posts its adjustments.
+func (u *CountUseCase) Approve(ctx context.Context, id int32, approvedBy string) (cyclecount.Session, error) {
+	if approvedBy == "" {
+		return cyclecount.Session{}, ErrApproverRequired
+	}
+
+	// The adjustments are prepared before the session is locked, as in Submit.
+	s, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	if s.Status != cyclecount.StatusPendingApproval {
+		return cyclecount.Session{}, fmt.Errorf("%w: count is %s", cyclecount.ErrStatus, s.Status)
+	}
+	adjustments, err := u.prepareAdjustments(ctx, s)
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+
+	return u.repo.Update(ctx, id, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		if s.Status != cyclecount.StatusPendingApproval {
+			return nil, fmt.Errorf("%w: count is %s", cyclecount.ErrStatus, s.Status)
+		}
+		return approve(s, approvedBy, time.Now(), adjustments), nil
+	})
+}
+
+// prepareAdjustments prepares an adjustment per line of the session, by line ID.
+func (u *CountUseCase) prepareAdjustments(ctx context.Context, s cyclecount.Session) (map[int32]stock.Movement, error) {
+	reference := "CNT-" + strconv.Itoa(int(s.ID))
+	adjustments := make(map[int32]stock.Movement, len(s.Lines))
+	for _, line := range s.Lines {
+		m, err := u.stock.PrepareCount(ctx, line.ProductID, s.WarehouseID, reference)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		adjustments[line.ID] = m
+	}
+	return adjustments, nil
+}
+
+// approve marks the session approved and returns the adjustments that post the
+// variance of each line. The variance is against the stock when the line was
+// counted, so stock moved before or after the count is not adjusted again.
+func approve(s *cyclecount.Session, approvedBy string, now time.Time, adjustments map[int32]stock.Movement) []stock.Movement {
+	var movements []stock.Movement
+	for _, line := range s.Lines {
+		if *line.Variance == 0 {
+			continue
+		}
+		m := adjustments[line.ID]
+		m.Quantity = *line.Variance
+		m.EnteredQuantity = strconv.Itoa(int(m.Quantity))
+		movements = append(movements, m)
+	}
+
+	s.Status = cyclecount.StatusApproved
+	s.ApprovedBy = approvedBy
+	s.ApprovedAt = &now
+	return movements
+}
+
+// Cancel drops a count that has not been approved; stock is not changed.
+func (u *CountUseCase) Cancel(ctx context.Context, id int32) (cyclecount.Session, error) {
+	s, err := u.repo.Update(ctx, id, func(s *cyclecount.Session) ([]stock.Movement, error) {
+		if s.Status != cyclecount.StatusOpen && s.Status != cyclecount.StatusPendingApproval {
+			return nil, fmt.Errorf("%w: count is %s", cyclecount.ErrStatus, s.Status)
+		}
+		now := time.Now()
+		s.Status = cyclecount.StatusCancelled
+		s.CancelledAt = &now
+		return nil, nil
+	})
+	if err != nil {
+		return cyclecount.Session{}, err
+	}
+	return hideSystemQuantities(s), nil
+}
+
+func hideSystemQuantities(s cyclecount.Session) cyclecount.Session {
+	if !s.Hidden() {
+		return s
+	}
+	lines := make([]cyclecount.Line, len(s.Lines))
+	for i, line := range s.Lines {
+		line.SystemQuantity = nil
+		line.Variance = nil
+		lines[i] = line
+	}
+	s.Lines = lines
+	return s
+}
+
+func findCountLine(lines []cyclecount.Line, id int32) *cyclecount.Line {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/stretchr/testify/assert"
+)
+
+func countSession() cyclecount.Session {
+	quantity := func(q int32) *int32 { return &q }
+	return cyclecount.Session{
+		ID:     1,
+		Status: cyclecount.StatusOpen,
+		Lines: []cyclecount.Line{
+			{ID: 1, ProductID: 10, SystemQuantity: quantity(100)},
+			{ID: 2, ProductID: 20, SystemQuantity: quantity(20)},
+		},
+	}
+}
+
+func TestRecordCounts_StockAtCount(t *testing.T) {
+	s := countSession()
+
+	// 4 of product 10 were issued after the sheet was generated.
+	err := recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: 95}, {LineID: 2, Quantity: 20}}, map[int32]int32{10: 96, 20: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(96), *s.Lines[0].SystemQuantity)
+	assert.Equal(t, int32(-1), *s.Lines[0].Variance)
+	assert.Equal(t, int32(0), *s.Lines[1].Variance)
+
+	// A product without stock at the warehouse has a system quantity of 0.
+	s = countSession()
+	err = recordCounts(&s, []cyclecount.Count{{LineID: 2, Quantity: 3}}, map[int32]int32{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *s.Lines[1].SystemQuantity)
+	assert.Equal(t, int32(3), *s.Lines[1].Variance)
+
+	err = recordCounts(&s, []cyclecount.Count{{LineID: 9, Quantity: 1}}, nil)
+	assert.ErrorIs(t, err, ErrUnknownOrderLine)
+	err = recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: -1}}, nil)
+	assert.ErrorIs(t, err, stock.ErrInvalidQuantity)
+}
+
+func TestCountSubmit_Threshold(t *testing.T) {
+	u := &CountUseCase{thresholdPct: 5}
+	now := time.Now()
+
+	// The sheet variance of -8 is above the threshold, but 4 of it was issued
+	// before the count; the -4 left is within it.
+	s := countSession()
+	err := recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: 92}, {LineID: 2, Quantity: 20}}, map[int32]int32{10: 96, 20: 20})
+	assert.NoError(t, err)
+	assert.NoError(t, u.submit(&s, now))
+	assert.Equal(t, cyclecount.StatusOpen, s.Status)
+
+	s = countSession()
+	err = recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: 90}, {LineID: 2, Quantity: 20}}, map[int32]int32{10: 96, 20: 20})
+	assert.NoError(t, err)
+	assert.NoError(t, u.submit(&s, now))
+	assert.Equal(t, cyclecount.StatusPendingApproval, s.Status)
+
+	s = countSession()
+	err = recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: 90}}, map[int32]int32{10: 96})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, u.submit(&s, now), ErrUncountedLines)
+}
+
+func TestApprove_PostsVariance(t *testing.T) {
+	s := countSession()
+	err := recordCounts(&s, []cyclecount.Count{{LineID: 1, Quantity: 90}, {LineID: 2, Quantity: 20}}, map[int32]int32{10: 100, 20: 20})
+	assert.NoError(t, err)
+	adjustments := map[int32]stock.Movement{
+		1: {ProductID: 10, Type: stock.MovementAdjustment, Reference: "CNT-1"},
+		2: {ProductID: 20, Type: stock.MovementAdjustment, Reference: "CNT-1"},
+	}
+
+	now := time.Now()
+	movements := approve(&s, "j.doe", now, adjustments)
+
+	// The line without a variance posts nothing; stock moved since the count
+	// does not change what the other line posts.
+	if assert.Len(t, movements, 1) {
+		assert.Equal(t, int32(10), movements[0].ProductID)
+		assert.Equal(t, int32(-10), movements[0].Quantity)
+		assert.Equal(t, "-10", movements[0].EnteredQuantity)
+		assert.Equal(t, "CNT-1", movements[0].Reference)
+	}
+	assert.Equal(t, cyclecount.StatusApproved, s.Status)
+	assert.Equal(t, "j.doe", s.ApprovedBy)
+	assert.Equal(t, &now, s.ApprovedAt)
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+)
+
+// businessErrors are rejections caused by the request itself rather than by the system.
+var businessErrors = []error{
+	ErrPriceLimit,
+	ErrInvalidPrice,
+	ErrUnsupportedCurrency,
+	ErrNameIsReserved,
+	ErrQuantityLimit,
+	ErrInvalidValidity,
+	ErrCurrencyMismatch,
+	ErrNoExchangeRate,
+	ErrInvalidRatesFile,
+	ErrScheduleInPast,
+	ErrPriceChangeNotPending,
+	ErrUnknownUnit,
+	ErrBaseUnitConflict,
+	ErrInvalidRounding,
+	ErrLotExpired,
+	ErrLotRequired,
+	ErrNotLotTracked,
+	ErrLotDatesMismatch,
+	ErrTrackingChange,
+	ErrCurrencyChange,
+	ErrQuantityManaged,
+	ErrInvalidPage,
+	ErrSerialCount,
+	ErrDuplicateSerial,
+	ErrSerialProduct,
+	ErrNotSerialized,
+	stock.ErrSerialStatus,
+	ErrInvalidLeadTime,
+	ErrInvalidCost,
+	ErrInvalidMOQ,
+	supplier.ErrInUse,
+	product.ErrInUse,
+	ErrEmptyOrder,
+	ErrDuplicateLine,
+	ErrMixedCurrencies,
+	ErrNoSupplierCost,
+	ErrBelowMOQ,
+	ErrUnknownOrderLine,
+	ErrOverReceipt,
+	ErrInvalidStatus,
+	ErrInvalidPeriod,
+	purchase.ErrStatus,
+	ErrInvalidSalesStatus,
+	ErrNoPackages,
+	ErrPackMismatch,
+	ErrTrackingRequired,
+	sales.ErrStatus,
+	ErrInvalidReturnStatus,
+	ErrReturnExceedsShipped,
+	ErrInvalidDisposition,
+	ErrDispositionMismatch,
+	ErrSerialNotReturned,
+	ErrSerialNotShipped,
+	ErrSerialReturned,
+	ErrNothingToRelease,
+	ErrReleaseDisposition,
+	ErrExceedsQuarantined,
+	rma.ErrStatus,
+	ErrInvalidTransferStatus,
+	ErrSameWarehouse,
+	ErrTrackedTransfer,
+	ErrDiscrepancyReason,
+	transfer.ErrStatus,
+	warehouse.ErrCodeTaken,
+	ErrInvalidCountStatus,
+	ErrInvalidABCClass,
+	ErrNothingToCount,
+	ErrUncountedLines,
+	ErrApproverRequired,
+	ErrTrackedCount,
+	ErrUnitCostOutgoing,
+	ErrCostCurrency,
+	ErrInvalidUnitCost,
+	ErrInvalidAgingBounds,
+	ErrInvalidDays,
+	ErrInvalidForecastMethod,
+	cyclecount.ErrStatus,
+	stock.ErrInvalidFactor,
+	stock.ErrInvalidQuantity,
+	stock.ErrFractionalQuantity,
+	stock.ErrInsufficientStock,
+}
+
+var notFoundErrors = []error{
+	product.ErrNotFound,
+	pricing.ErrNotFound,
+	stock.ErrLotNotFound,
+	stock.ErrSerialNotFound,
+	supplier.ErrNotFound,
+	purchase.ErrNotFound,
+	sales.ErrNotFound,
+	rma.ErrNotFound,
+	warehouse.ErrNotFound,
+	transfer.ErrNotFound,
+	cyclecount.ErrNotFound,
+}
+
+func IsBusinessError(err error) bool {
+	return isAny(err, businessErrors)
+}
+
+// BusinessRule returns the business error that err wraps, which names the
+// broken rule without the details of the request.
+func BusinessRule(err error) (error, bool) {
+	for _, target := range businessErrors {
+		if errors.Is(err, target) {
+			return target, true
+		}
+	}
+	return nil, false
+}
+
+func IsNotFound(err error) bool {
+	return isAny(err, notFoundErrors)
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
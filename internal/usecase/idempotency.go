@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/idempotency"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+)
+
+// IdempotencyUseCase remembers the responses of requests sent with an
+// idempotency key for ttl, so that retries of them are not served twice.
+type IdempotencyUseCase struct {
+	repo idempotency.Repository
+	ttl  time.Duration
+	// lockTimeout is how long a key stays reserved for a request that never
+	// completes, e.g. because the server stopped while serving it.
+	lockTimeout time.Duration
+}
+
+func NewIdempotencyUseCase(r idempotency.Repository, ttl, lockTimeout time.Duration) *IdempotencyUseCase {
+	return &IdempotencyUseCase{repo: r, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+// Begin reserves the key for a request with the given hash. It returns the
+// stored response if the request was served before; otherwise the request is
+// to be served now and its reservation completed with Complete or Release. A
+// key sent with another request fails with idempotency.ErrKeyReused, and one
+// whose request is still being served with idempotency.ErrInProgress.
+func (u *IdempotencyUseCase) Begin(ctx context.Context, key, requestHash string) (*idempotency.Response, idempotency.Reservation, error) {
+	now := time.Now()
+	rec, reserved, err := u.repo.Reserve(ctx, idempotency.Record{
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(u.ttl),
+	}, now, now.Add(-u.lockTimeout))
+	switch {
+	case err != nil:
+		return nil, idempotency.Reservation{}, err
+	case reserved:
+		return nil, idempotency.Reservation{Key: rec.Key, CreatedAt: rec.CreatedAt}, nil
+	case rec.RequestHash != requestHash:
+		return nil, idempotency.Reservation{}, idempotency.ErrKeyReused
+	case rec.Response == nil:
+		return nil, idempotency.Reservation{}, idempotency.ErrInProgress
+	}
+	return rec.Response, idempotency.Reservation{}, nil
+}
+
+// Complete stores the response of a request begun with Begin, unless its key
+// was taken over after the lock timeout.
+func (u *IdempotencyUseCase) Complete(ctx context.Context, res idempotency.Reservation, resp idempotency.Response) error {
+	return u.repo.Complete(ctx, res, resp)
+}
+
+// Release frees the key of a request that failed, so that a retry is served
+// again, unless the key was taken over after the lock timeout.
+func (u *IdempotencyUseCase) Release(ctx context.Context, res idempotency.Reservation) error {
+	return u.repo.Release(ctx, res)
+}
+
+// RunCleanup deletes expired keys every interval until ctx is cancelled.
+func (u *IdempotencyUseCase) RunCleanup(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	const op = "usecase.idempotency.cleanup"
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := u.repo.DeleteExpired(ctx, now)
+			if err != nil {
+				log.Error(fmt.Sprintf("%s | Failed to delete expired keys: ", op), sl.Err(err))
+				continue
+			}
+			if n > 0 {
+				log.Info(fmt.Sprintf("%s | Expired keys deleted", op), slog.Int64("count", n))
+			}
+		}
+	}
+}
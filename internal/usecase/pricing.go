@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+)
+
+type PricingUseCase struct {
+	repo     pricing.Repository
+	products product.Repository
+	rounding money.RoundingMode
+}
+
+func NewPricingUseCase(r pricing.Repository, products product.Repository, rounding money.RoundingMode) *PricingUseCase {
+	return &PricingUseCase{repo: r, products: products, rounding: rounding}
+}
+
+var (
+	ErrInvalidValidity  = errors.New("valid_to must be after valid_from")
+	ErrCurrencyMismatch = errors.New("price currency does not match price list currency")
+	ErrNoExchangeRate   = errors.New("no exchange rate available")
+	ErrInvalidRatesFile = errors.New("invalid exchange rates file")
+
+	ErrScheduleInPast        = errors.New("valid_from must not be in the past")
+	ErrPriceChangeNotPending = errors.New("only pending price changes can be cancelled")
+)
+
+func (u *PricingUseCase) CreatePriceList(ctx context.Context, l pricing.PriceList) (int32, error) {
+	if !money.IsSupported(l.Currency) {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, l.Currency)
+	}
+	if l.ValidFrom.IsZero() {
+		l.ValidFrom = time.Now()
+	}
+	if l.ValidTo != nil && !l.ValidTo.After(l.ValidFrom) {
+		return 0, ErrInvalidValidity
+	}
+
+	return u.repo.CreatePriceList(ctx, l)
+}
+
+func (u *PricingUseCase) GetPriceList(ctx context.Context, id int32) (pricing.PriceList, error) {
+	return u.repo.GetPriceList(ctx, id)
+}
+
+func (u *PricingUseCase) ListPriceLists(ctx context.Context) ([]pricing.PriceList, error) {
+	return u.repo.ListPriceLists(ctx)
+}
+
+func (u *PricingUseCase) DeletePriceList(ctx context.Context, id int32) error {
+	return u.repo.DeletePriceList(ctx, id)
+}
+
+func (u *PricingUseCase) SetItem(ctx context.Context, item pricing.Item) error {
+	list, err := u.repo.GetPriceList(ctx, item.PriceListID)
+	if err != nil {
+		return err
+	}
+	if item.Price.Currency != list.Currency {
+		return fmt.Errorf("%w: expected %s", ErrCurrencyMismatch, list.Currency)
+	}
+	if item.Price.Amount <= 0 {
+		return ErrInvalidPrice
+	}
+	if _, err := u.products.GetByID(ctx, item.ProductID); err != nil {
+		return err
+	}
+
+	return u.repo.SetItem(ctx, item)
+}
+
+func (u *PricingUseCase) DeleteItem(ctx context.Context, priceListID, productID int32) error {
+	return u.repo.DeleteItem(ctx, priceListID, productID)
+}
+
+// Resolve returns the price of a product in currency at the given time. A matching
+// price list wins (customer group specific lists before general ones); otherwise the
+// base price is used, converted with the exchange rate valid at that time.
+func (u *PricingUseCase) Resolve(ctx context.Context, productID int32, currency, customerGroup string, at time.Time) (pricing.ResolvedPrice, error) {
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		return pricing.ResolvedPrice{}, err
+	}
+	if currency == "" {
+		currency = p.Price.Currency
+	}
+	if !money.IsSupported(currency) {
+		return pricing.ResolvedPrice{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+
+	resolved := pricing.ResolvedPrice{ProductID: productID, At: at}
+
+	item, err := u.repo.FindPrice(ctx, productID, currency, customerGroup, at)
+	switch {
+	case err == nil:
+		resolved.Price = item.Price
+		resolved.Source = pricing.SourcePriceList
+		resolved.PriceListID = &item.PriceListID
+		return resolved, nil
+	case !errors.Is(err, pricing.ErrNotFound):
+		return pricing.ResolvedPrice{}, err
+	}
+
+	if currency == p.Price.Currency {
+		resolved.Price = p.Price
+		resolved.Source = pricing.SourceBase
+		return resolved, nil
+	}
+
+	rate, err := u.rate(ctx, p.Price.Currency, currency, at)
+	if err != nil {
+		return pricing.ResolvedPrice{}, err
+	}
+	converted, err := p.Price.Convert(rate, currency, u.rounding)
+	if err != nil {
+		return pricing.ResolvedPrice{}, err
+	}
+
+	resolved.Price = converted
+	resolved.Source = pricing.SourceConverted
+	resolved.Rate = formatRate(rate)
+	return resolved, nil
+}
+
+// Convert converts an amount to another currency with the exchange rate valid at
+// the given time.
+func (u *PricingUseCase) Convert(ctx context.Context, m money.Money, to string, at time.Time) (money.Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	rate, err := u.rate(ctx, m.Currency, to, at)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return m.Convert(rate, to, u.rounding)
+}
+
+// rate looks up base->quote, falling back to the inverse of quote->base.
+func (u *PricingUseCase) rate(ctx context.Context, base, quote string, at time.Time) (*big.Rat, error) {
+	direct, err := u.repo.GetExchangeRate(ctx, base, quote, at)
+	if err == nil {
+		return money.ParseRate(direct.Rate)
+	}
+	if !errors.Is(err, pricing.ErrNotFound) {
+		return nil, err
+	}
+
+	inverse, err := u.repo.GetExchangeRate(ctx, quote, base, at)
+	if errors.Is(err, pricing.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s/%s at %s", ErrNoExchangeRate, base, quote, at.Format(time.RFC3339))
+	}
+	if err != nil {
+		return nil, err
+	}
+	r, err := money.ParseRate(inverse.Rate)
+	if err != nil {
+		return nil, err
+	}
+	return r.Inv(r), nil
+}
+
+func (u *PricingUseCase) ListExchangeRates(ctx context.Context) ([]pricing.ExchangeRate, error) {
+	return u.repo.ListExchangeRates(ctx)
+}
+
+// LoadExchangeRates imports rates from CSV with the columns base,quote,rate,valid_from.
+// valid_from is either RFC 3339 or a date (YYYY-MM-DD); a header row is optional.
+func (u *PricingUseCase) LoadExchangeRates(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rates []pricing.ExchangeRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidRatesFile, err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+
+		rate, err := parseRateRecord(record)
+		if err != nil {
+			return 0, fmt.Errorf("%w: line %d: %v", ErrInvalidRatesFile, line, err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := u.repo.SaveExchangeRates(ctx, rates); err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func parseRateRecord(record []string) (pricing.ExchangeRate, error) {
+	base, quote := strings.ToUpper(record[0]), strings.ToUpper(record[1])
+	for _, c := range []string{base, quote} {
+		if !money.IsSupported(c) {
+			return pricing.ExchangeRate{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, c)
+		}
+	}
+	if _, err := money.ParseRate(record[2]); err != nil || strings.Contains(record[2], "/") {
+		return pricing.ExchangeRate{}, fmt.Errorf("invalid rate %q", record[2])
+	}
+
+	validFrom, err := time.Parse(time.RFC3339, record[3])
+	if err != nil {
+		validFrom, err = time.Parse(time.DateOnly, record[3])
+		if err != nil {
+			return pricing.ExchangeRate{}, fmt.Errorf("invalid valid_from %q", record[3])
+		}
+	}
+
+	return pricing.ExchangeRate{Base: base, Quote: quote, Rate: record[2], ValidFrom: validFrom}, nil
+}
+
+func (u *PricingUseCase) PriceHistory(ctx context.Context, productID int32) ([]pricing.PriceChange, error) {
+	if _, err := u.products.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListPriceChanges(ctx, productID)
+}
+
+// SchedulePrice records an approved price change. A change without ValidFrom, or
+// one that is already due, is activated immediately. The price currency of a
+// product with stock cannot change; a change that finds stock or stock value
+// when it comes due is cancelled.
+func (u *PricingUseCase) SchedulePrice(ctx context.Context, pc pricing.PriceChange) (int32, error) {
+	now := time.Now()
+	if pc.ValidFrom.IsZero() {
+		pc.ValidFrom = now
+	}
+	if pc.ValidFrom.Before(now.Add(-time.Minute)) {
+		return 0, ErrScheduleInPast
+	}
+	if pc.ValidTo != nil && !pc.ValidTo.After(pc.ValidFrom) {
+		return 0, ErrInvalidValidity
+	}
+	if err := validatePrice(pc.Price); err != nil {
+		return 0, err
+	}
+	p, err := u.products.GetByID(ctx, pc.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	if pc.Price.Currency != p.Price.Currency && p.Quantity != 0 {
+		return 0, fmt.Errorf("%w: %s to %s", ErrCurrencyChange, p.Price.Currency, pc.Price.Currency)
+	}
+
+	id, err := u.repo.SchedulePriceChange(ctx, pc)
+	if err != nil {
+		return 0, err
+	}
+
+	if !pc.ValidFrom.After(now) {
+		changes, err := u.repo.ActivateDuePriceChanges(ctx, now)
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range changes {
+			if c.ID == id && c.Status == pricing.ChangeCancelled {
+				return 0, fmt.Errorf("%w: %s to %s", ErrCurrencyChange, p.Price.Currency, pc.Price.Currency)
+			}
+		}
+	}
+	return id, nil
+}
+
+func (u *PricingUseCase) CancelPriceChange(ctx context.Context, productID, id int32) error {
+	pc, err := u.repo.GetPriceChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	if pc.ProductID != productID {
+		return pricing.ErrNotFound
+	}
+	if pc.Status != pricing.ChangePending {
+		return ErrPriceChangeNotPending
+	}
+
+	return u.repo.CancelPriceChange(ctx, id)
+}
+
+func (u *PricingUseCase) ActivateDuePrices(ctx context.Context, now time.Time) ([]pricing.PriceChange, error) {
+	return u.repo.ActivateDuePriceChanges(ctx, now)
+}
+
+// RunScheduler activates due price changes every interval until ctx is cancelled.
+func (u *PricingUseCase) RunScheduler(ctx context.Context, interval time.Duration, log *slog.Logger) {
+	const op = "usecase.pricing.scheduler"
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			changes, err := u.ActivateDuePrices(ctx, now)
+			if err != nil {
+				log.Error(fmt.Sprintf("%s | Failed to activate prices: ", op), sl.Err(err))
+				continue
+			}
+			for _, pc := range changes {
+				if pc.Status == pricing.ChangeCancelled {
+					log.Warn(fmt.Sprintf("%s | Price change cancelled: ", op),
+						sl.Err(ErrCurrencyChange),
+						slog.Int("product_id", int(pc.ProductID)),
+						slog.Int("price_change_id", int(pc.ID)),
+						slog.String("price", pc.Price.String()),
+					)
+					continue
+				}
+				log.Info(fmt.Sprintf("%s | Price activated", op),
+					slog.Int("product_id", int(pc.ProductID)),
+					slog.String("price", pc.Price.String()),
+					slog.String("approved_by", pc.ApprovedBy),
+				)
+			}
+		}
+	}
+}
+
+func formatRate(r *big.Rat) string {
+	s := r.FloatString(12)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
@@ -3,9 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"strings"
 
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
 	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
 )
 
 type ProductUseCase struct {
@@ -17,15 +21,27 @@ func NewProductUseCase(r product.Repository) *ProductUseCase {
 }
 
 var (
-	ErrPriceLimit     = errors.New("price exceeds maximum allowed value of $10,000")
-	ErrNameIsReserved = errors.New("product name is reserved")
-	ErrQuantityLimit  = errors.New("quantity exceeds maximum allowed value of 1000 units")
+	ErrPriceLimit          = errors.New("price exceeds maximum allowed value")
+	ErrInvalidPrice        = errors.New("price must be greater than zero")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrNameIsReserved      = errors.New("product name is reserved")
+	ErrQuantityLimit       = errors.New("quantity exceeds maximum allowed value of 1000 units")
+	ErrTrackingChange      = errors.New("stock tracking can only change while the product has no stock")
+	ErrCurrencyChange      = product.ErrCurrencyChange
+	ErrQuantityManaged     = errors.New("quantity of tracked products can only change through stock movements")
+	ErrInvalidPage         = errors.New("page size must be between 1 and 100 and offset must not be negative")
 )
 
-func IsBusinessError(err error) bool {
-	return errors.Is(err, ErrPriceLimit) ||
-		errors.Is(err, ErrNameIsReserved) ||
-		errors.Is(err, ErrQuantityLimit)
+// priceLimits holds the maximum product price for every currency in the money
+// package, in minor units. The limits are roughly USD 10,000 in each currency.
+var priceLimits = map[string]money.Money{
+	"USD": money.MustParse("10000", "USD"),
+	"EUR": money.MustParse("10000", "EUR"),
+	"GBP": money.MustParse("8000", "GBP"),
+	"RUB": money.MustParse("1000000", "RUB"),
+	"KZT": money.MustParse("5000000", "KZT"),
+	"UZS": money.MustParse("125000000", "UZS"),
+	"JPY": money.MustParse("1500000", "JPY"),
 }
 
 func validateProduct(p product.Product) error {
@@ -33,37 +49,134 @@ func validateProduct(p product.Product) error {
 		return ErrNameIsReserved
 	}
 
-	if p.Price > 10000 {
-		return ErrPriceLimit
+	return validatePrice(p.Price)
+}
+
+func validatePrice(price money.Money) error {
+	if price.Amount <= 0 {
+		return ErrInvalidPrice
 	}
 
-	if p.Quantity > 1000 {
-		return ErrQuantityLimit
+	limit, ok := priceLimits[price.Currency]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, price.Currency)
+	}
+	if price.Amount > limit.Amount {
+		return fmt.Errorf("%w of %s", ErrPriceLimit, limit)
 	}
 
 	return nil
 }
 
-func (u *ProductUseCase) Create(ctx context.Context, p product.Product) (int32, error) {
+// DefaultBaseUnit is used for products created without a unit of measure.
+const DefaultBaseUnit = "pcs"
+
+func (u *ProductUseCase) Create(ctx context.Context, p product.Product) (id int32, err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.Create")
+	defer func() { endSpan(span, err) }()
+
 	if err := validateProduct(p); err != nil {
 		return 0, err
 	}
+	if p.Quantity > 1000 {
+		return 0, ErrQuantityLimit
+	}
+	if p.Tracked() && p.Quantity != 0 {
+		return 0, ErrQuantityManaged
+	}
+	if c := p.OpeningUnitCost; c != nil {
+		switch {
+		case c.Currency != p.Price.Currency:
+			return 0, fmt.Errorf("%w %s", ErrCostCurrency, p.Price.Currency)
+		case c.Amount < 0:
+			return 0, ErrInvalidUnitCost
+		}
+	}
+	if p.BaseUnit == "" {
+		p.BaseUnit = DefaultBaseUnit
+	}
 
-	return u.repo.Create(ctx, p)
+	id, err = u.repo.Create(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	sl.FromContext(ctx, slog.Default()).Info("Product created", slog.Any("product_id", id))
+	return id, nil
 }
 
-func (u *ProductUseCase) GetByID(ctx context.Context, id int32) (product.Product, error) {
+func (u *ProductUseCase) GetByID(ctx context.Context, id int32) (p product.Product, err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetByID")
+	defer func() { endSpan(span, err) }()
+
 	return u.repo.GetByID(ctx, id)
 }
 
-func (u *ProductUseCase) Update(ctx context.Context, p product.Product) error {
-	return u.repo.Update(ctx, p)
+func (u *ProductUseCase) Update(ctx context.Context, p product.Product) (err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.Update")
+	defer func() { endSpan(span, err) }()
+
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+
+	err = u.repo.Update(ctx, p.ID, func(current *product.Product) error {
+		if (current.LotTracked != p.LotTracked || current.Serialized != p.Serialized) && current.Quantity != 0 {
+			return ErrTrackingChange
+		}
+		// The repository also checks the stock value, which can remain after
+		// the last unit is gone.
+		if current.Price.Currency != p.Price.Currency && current.Quantity != 0 {
+			return fmt.Errorf("%w: %s to %s", ErrCurrencyChange, current.Price.Currency, p.Price.Currency)
+		}
+		current.Name = p.Name
+		current.Description = p.Description
+		current.Price = p.Price
+		current.LotTracked = p.LotTracked
+		current.Serialized = p.Serialized
+		current.BinLocation = p.BinLocation
+		current.Category = p.Category
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sl.FromContext(ctx, slog.Default()).Info("Product updated", slog.Any("product_id", p.ID))
+	return nil
 }
 
-func (u *ProductUseCase) Delete(ctx context.Context, id int32) error {
-	return u.repo.Delete(ctx, id)
+func (u *ProductUseCase) Delete(ctx context.Context, id int32) (err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.Delete")
+	defer func() { endSpan(span, err) }()
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	sl.FromContext(ctx, slog.Default()).Info("Product deleted", slog.Any("product_id", id))
+	return nil
 }
 
-func (u *ProductUseCase) List(ctx context.Context) ([]product.Product, error) {
+func (u *ProductUseCase) List(ctx context.Context) (list []product.Product, err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.List")
+	defer func() { endSpan(span, err) }()
+
 	return u.repo.List(ctx)
 }
+
+// DefaultPageSize is the number of products a search returns without a limit.
+const DefaultPageSize = 20
+
+// Search returns a page of the products matching the filter and the number of
+// matching products on all pages.
+func (u *ProductUseCase) Search(ctx context.Context, f product.Filter) (page []product.Product, total int32, err error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.Search")
+	defer func() { endSpan(span, err) }()
+
+	if f.Limit == 0 {
+		f.Limit = DefaultPageSize
+	}
+	if f.Limit < 0 || f.Limit > 100 || f.Offset < 0 {
+		return nil, 0, ErrInvalidPage
+	}
+	f.Currency = strings.ToUpper(f.Currency)
+	return u.repo.Search(ctx, f)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/stretchr/testify/assert"
+)
+
+// createStub records the product it is asked to create.
+type createStub struct {
+	product.Repository
+	created *product.Product
+}
+
+func (s createStub) Create(ctx context.Context, p product.Product) (int32, error) {
+	*s.created = p
+	return 1, nil
+}
+
+func TestProductUseCase_CreateOpeningCost(t *testing.T) {
+	usd := money.MustParse("20.00", "USD")
+	eur := money.MustParse("20.00", "EUR")
+	negative := money.Money{Amount: -1, Currency: "USD"}
+
+	tests := []struct {
+		name     string
+		unitCost *money.Money
+		err      error
+	}{
+		{"at the price", nil, nil},
+		{"at a unit cost", &usd, nil},
+		{"other currency", &eur, ErrCostCurrency},
+		{"negative unit cost", &negative, ErrInvalidUnitCost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created product.Product
+			u := NewProductUseCase(createStub{created: &created})
+			_, err := u.Create(context.Background(), product.Product{
+				Name:            "Chair",
+				Price:           money.MustParse("49.90", "USD"),
+				Quantity:        3,
+				OpeningUnitCost: tt.unitCost,
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.unitCost, created.OpeningUnitCost)
+		})
+	}
+}
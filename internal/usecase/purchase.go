@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+)
+
+type PurchaseUseCase struct {
+	repo      purchase.Repository
+	suppliers supplier.Repository
+	products  product.Repository
+	stock     *StockUseCase
+	pricing   *PricingUseCase
+	tolerance purchase.Tolerance
+}
+
+func NewPurchaseUseCase(r purchase.Repository, suppliers supplier.Repository, products product.Repository, stock *StockUseCase, pricing *PricingUseCase, tolerance purchase.Tolerance) *PurchaseUseCase {
+	return &PurchaseUseCase{repo: r, suppliers: suppliers, products: products, stock: stock, pricing: pricing, tolerance: tolerance}
+}
+
+var (
+	ErrEmptyOrder       = errors.New("order must have at least one line")
+	ErrDuplicateLine    = errors.New("product is listed more than once")
+	ErrMixedCurrencies  = errors.New("all lines of an order must use the order currency")
+	ErrNoSupplierCost   = errors.New("unit cost is required for products without a cost from this supplier")
+	ErrBelowMOQ         = errors.New("quantity is below the supplier minimum order quantity")
+	ErrUnknownOrderLine = errors.New("line does not belong to this order")
+	ErrOverReceipt      = errors.New("received quantity exceeds the over-receipt tolerance")
+	ErrInvalidStatus    = errors.New("unknown purchase order status")
+	ErrInvalidPeriod    = errors.New("end of period must be after its start")
+)
+
+// Create saves a draft order. Lines without a unit cost take the cost price of the
+// supplier link, which also sets the minimum order quantity.
+func (u *PurchaseUseCase) Create(ctx context.Context, o purchase.Order) (int32, error) {
+	o, err := u.prepare(ctx, o)
+	if err != nil {
+		return 0, err
+	}
+	return u.repo.Create(ctx, o)
+}
+
+func (u *PurchaseUseCase) Get(ctx context.Context, id int32) (purchase.Order, error) {
+	return u.repo.Get(ctx, id)
+}
+
+func (u *PurchaseUseCase) List(ctx context.Context, status purchase.Status, supplierID *int32) ([]purchase.Order, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+	return u.repo.List(ctx, status, supplierID)
+}
+
+// UpdateDraft replaces a draft order; submitted orders cannot change.
+func (u *PurchaseUseCase) UpdateDraft(ctx context.Context, o purchase.Order) error {
+	current, err := u.repo.Get(ctx, o.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status != purchase.StatusDraft {
+		return fmt.Errorf("%w: order is %s", purchase.ErrStatus, current.Status)
+	}
+
+	o, err = u.prepare(ctx, o)
+	if err != nil {
+		return err
+	}
+	return u.repo.UpdateDraft(ctx, o)
+}
+
+// prepare checks the supplier and the products of an order and fills in unit costs
+// and the order currency from the supplier links.
+func (u *PurchaseUseCase) prepare(ctx context.Context, o purchase.Order) (purchase.Order, error) {
+	if len(o.Lines) == 0 {
+		return purchase.Order{}, ErrEmptyOrder
+	}
+	if _, err := u.suppliers.GetByID(ctx, o.SupplierID); err != nil {
+		return purchase.Order{}, err
+	}
+
+	seen := make(map[int32]bool, len(o.Lines))
+	for i := range o.Lines {
+		line := &o.Lines[i]
+		if seen[line.ProductID] {
+			return purchase.Order{}, fmt.Errorf("%w: product %d", ErrDuplicateLine, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if _, err := u.products.GetByID(ctx, line.ProductID); err != nil {
+			return purchase.Order{}, err
+		}
+		if line.Quantity <= 0 {
+			return purchase.Order{}, fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+
+		link, err := u.supplierLink(ctx, line.ProductID, o.SupplierID)
+		if err != nil {
+			return purchase.Order{}, err
+		}
+		if link != nil && line.Quantity < link.MinOrderQty {
+			return purchase.Order{}, fmt.Errorf("%w of %d for product %d", ErrBelowMOQ, link.MinOrderQty, line.ProductID)
+		}
+		if line.UnitCost.IsZero() {
+			if link == nil {
+				return purchase.Order{}, fmt.Errorf("%w: product %d", ErrNoSupplierCost, line.ProductID)
+			}
+			line.UnitCost = link.CostPrice
+		}
+		if line.UnitCost.Amount <= 0 {
+			return purchase.Order{}, ErrInvalidCost
+		}
+
+		if o.Currency == "" {
+			o.Currency = line.UnitCost.Currency
+		}
+		if line.UnitCost.Currency != o.Currency {
+			return purchase.Order{}, fmt.Errorf("%w %s", ErrMixedCurrencies, o.Currency)
+		}
+	}
+
+	if !money.IsSupported(o.Currency) {
+		return purchase.Order{}, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, o.Currency)
+	}
+	return o, nil
+}
+
+func (u *PurchaseUseCase) supplierLink(ctx context.Context, productID, supplierID int32) (*supplier.ProductSupplier, error) {
+	links, err := u.suppliers.ListProductSuppliers(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.SupplierID == supplierID {
+			return &link, nil
+		}
+	}
+	return nil, nil
+}
+
+// Submit sends a draft order to the supplier. Without an expected date the order
+// is expected after the supplier lead time.
+func (u *PurchaseUseCase) Submit(ctx context.Context, id int32, expectedAt *time.Time) error {
+	o, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if o.Status != purchase.StatusDraft {
+		return fmt.Errorf("%w: order is %s", purchase.ErrStatus, o.Status)
+	}
+
+	if expectedAt == nil {
+		s, err := u.suppliers.GetByID(ctx, o.SupplierID)
+		if err != nil {
+			return err
+		}
+		at := time.Now().AddDate(0, 0, int(s.LeadTimeDays))
+		expectedAt = &at
+	}
+	return u.repo.Submit(ctx, id, *expectedAt)
+}
+
+// Receive records delivered quantities and adds them to stock in one transaction.
+// A line may not exceed the over-receipt tolerance; the order is received once
+// every line is within the under-receipt tolerance of its ordered quantity.
+// Received stock is valued at the line cost converted to the product currency.
+func (u *PurchaseUseCase) Receive(ctx context.Context, id int32, r purchase.Receipt) (purchase.Order, error) {
+	if len(r.Lines) == 0 {
+		return purchase.Order{}, ErrEmptyOrder
+	}
+
+	// The movements are prepared before the order is locked, so the transaction
+	// runs no other queries; the locked order is checked again below.
+	o, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return purchase.Order{}, err
+	}
+	if err := u.receive(&o, r); err != nil {
+		return purchase.Order{}, err
+	}
+
+	reference := "PO-" + strconv.Itoa(int(o.ID))
+	if r.Reference != "" {
+		reference += " " + r.Reference
+	}
+	movements := make([]stock.Movement, 0, len(r.Lines))
+	for _, received := range r.Lines {
+		line := findLine(o.Lines, received.LineID)
+		cost, err := u.unitCost(ctx, *line)
+		if err != nil {
+			return purchase.Order{}, err
+		}
+		m, err := u.stock.Prepare(ctx, stock.Movement{
+			ProductID:       line.ProductID,
+			Type:            stock.MovementReceipt,
+			EnteredQuantity: strconv.Itoa(int(received.Quantity)),
+			Reference:       reference,
+			Lot:             received.Lot,
+			Serials:         received.Serials,
+			UnitCost:        &cost,
+		})
+		if err != nil {
+			return purchase.Order{}, err
+		}
+		movements = append(movements, m)
+	}
+
+	return u.repo.Receive(ctx, id, func(o *purchase.Order) ([]stock.Movement, error) {
+		if err := u.receive(o, r); err != nil {
+			return nil, err
+		}
+		return movements, nil
+	})
+}
+
+// receive adds the received quantities to the lines of the order and updates its
+// status.
+func (u *PurchaseUseCase) receive(o *purchase.Order, r purchase.Receipt) error {
+	if !o.Status.Receivable() {
+		return fmt.Errorf("%w: order is %s", purchase.ErrStatus, o.Status)
+	}
+
+	for _, received := range r.Lines {
+		line := findLine(o.Lines, received.LineID)
+		if line == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, received.LineID)
+		}
+		if received.Quantity <= 0 {
+			return fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+		limit := u.tolerance.MaxReceivable(line.Quantity)
+		if line.ReceivedQuantity+received.Quantity > limit {
+			return fmt.Errorf("%w: line %d allows %d more", ErrOverReceipt, line.ID, max(limit-line.ReceivedQuantity, 0))
+		}
+		line.ReceivedQuantity += received.Quantity
+	}
+
+	o.Status = purchase.StatusReceived
+	for _, line := range o.Lines {
+		if !u.tolerance.Complete(line) {
+			o.Status = purchase.StatusPartiallyReceived
+		}
+	}
+	return nil
+}
+
+// unitCost converts the cost of an order line to the product currency with the
+// current exchange rate.
+func (u *PurchaseUseCase) unitCost(ctx context.Context, line purchase.Line) (money.Money, error) {
+	p, err := u.products.GetByID(ctx, line.ProductID)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return u.pricing.Convert(ctx, line.UnitCost, p.Price.Currency, time.Now())
+}
+
+// Close ends an order that was received, or short-closes a partially received one.
+func (u *PurchaseUseCase) Close(ctx context.Context, id int32) error {
+	o, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if o.Status != purchase.StatusReceived && o.Status != purchase.StatusPartiallyReceived {
+		return fmt.Errorf("%w: order is %s", purchase.ErrStatus, o.Status)
+	}
+	return u.repo.Close(ctx, id)
+}
+
+// StatusReport summarizes orders per status and currency.
+func (u *PurchaseUseCase) StatusReport(ctx context.Context, f purchase.ReportFilter) ([]purchase.StatusSummary, error) {
+	if f.From != nil && f.To != nil && !f.To.After(*f.From) {
+		return nil, ErrInvalidPeriod
+	}
+	return u.repo.StatusReport(ctx, f)
+}
+
+func findLine(lines []purchase.Line, id int32) *purchase.Line {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+)
+
+type ReplenishmentUseCase struct {
+	repo      forecast.Repository
+	suppliers *SupplierUseCase
+	settings  forecast.Settings
+}
+
+func NewReplenishmentUseCase(r forecast.Repository, suppliers *SupplierUseCase, settings forecast.Settings) *ReplenishmentUseCase {
+	return &ReplenishmentUseCase{repo: r, suppliers: suppliers, settings: settings}
+}
+
+var ErrInvalidForecastMethod = errors.New("invalid forecast method")
+
+// ProductForecast forecasts the demand of one product with the given method, or
+// the configured one when empty, and works out whether to reorder it.
+func (u *ReplenishmentUseCase) ProductForecast(ctx context.Context, productID int32, method forecast.Method) (forecast.Suggestion, error) {
+	s, err := u.withMethod(method)
+	if err != nil {
+		return forecast.Suggestion{}, err
+	}
+	positions, err := u.repo.Positions(ctx, &productID)
+	if err != nil {
+		return forecast.Suggestion{}, err
+	}
+	if len(positions) == 0 {
+		return forecast.Suggestion{}, product.ErrNotFound
+	}
+	history, err := u.history(ctx, &productID, s)
+	if err != nil {
+		return forecast.Suggestion{}, err
+	}
+	links, err := u.suppliers.ProductSuppliers(ctx, productID)
+	if err != nil {
+		return forecast.Suggestion{}, err
+	}
+	var link *supplier.ProductSupplier
+	if len(links) > 0 {
+		link = &links[0]
+	}
+	sg := u.suggest(positions[0], history[productID], link, s)
+	sg.History = history[productID]
+	return sg, nil
+}
+
+// Suggestions lists the products whose available stock has fallen to their
+// reorder point with the quantity to order, and groups those that have a
+// supplier into draft purchase orders with the preferred supplier: the
+// cheapest, then the fastest. A supplierID keeps only that supplier's products.
+// Draft purchase orders are not counted as on order, so suggestions already
+// ordered that way come back until the order is submitted.
+func (u *ReplenishmentUseCase) Suggestions(ctx context.Context, method forecast.Method, supplierID *int32) (forecast.Plan, error) {
+	s, err := u.withMethod(method)
+	if err != nil {
+		return forecast.Plan{}, err
+	}
+	positions, err := u.repo.Positions(ctx, nil)
+	if err != nil {
+		return forecast.Plan{}, err
+	}
+	history, err := u.history(ctx, nil, s)
+	if err != nil {
+		return forecast.Plan{}, err
+	}
+	preferred, err := u.suppliers.PreferredSuppliers(ctx)
+	if err != nil {
+		return forecast.Plan{}, err
+	}
+
+	plan := forecast.Plan{Method: s.Method, Suggestions: []forecast.Suggestion{}, Orders: []forecast.DraftOrder{}}
+	orders := make(map[string]int)
+	for _, p := range positions {
+		var link *supplier.ProductSupplier
+		if l, ok := preferred[p.ProductID]; ok {
+			link = &l
+		}
+		sg := u.suggest(p, history[p.ProductID], link, s)
+		if sg.SuggestedQuantity <= 0 || (supplierID != nil && (sg.SupplierID == nil || *sg.SupplierID != *supplierID)) {
+			continue
+		}
+		sg.Forecast = nil
+		plan.Suggestions = append(plan.Suggestions, sg)
+		if sg.SupplierID == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", *sg.SupplierID, sg.UnitCost.Currency)
+		i, ok := orders[key]
+		if !ok {
+			i = len(plan.Orders)
+			orders[key] = i
+			plan.Orders = append(plan.Orders, forecast.DraftOrder{
+				SupplierID:   *sg.SupplierID,
+				SupplierName: sg.SupplierName,
+				Currency:     sg.UnitCost.Currency,
+			})
+		}
+		plan.Orders[i].Lines = append(plan.Orders[i].Lines, forecast.DraftLine{
+			ProductID: sg.ProductID,
+			Quantity:  sg.SuggestedQuantity,
+			UnitCost:  *sg.UnitCost,
+		})
+	}
+	return plan, nil
+}
+
+func (u *ReplenishmentUseCase) withMethod(method forecast.Method) (forecast.Settings, error) {
+	s := u.settings
+	if method == "" {
+		return s, nil
+	}
+	if !method.Valid() {
+		return s, fmt.Errorf("%w: %s", ErrInvalidForecastMethod, method)
+	}
+	s.Method = method
+	return s, nil
+}
+
+// history returns the demand per product in each of the configured periods
+// before today, oldest first.
+func (u *ReplenishmentUseCase) history(ctx context.Context, productID *int32, s forecast.Settings) (map[int32][]float64, error) {
+	y, m, d := time.Now().Date()
+	to := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -s.PeriodDays*s.HistoryPeriods)
+
+	days, err := u.repo.Demand(ctx, productID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	history := make(map[int32][]float64)
+	for _, dd := range days {
+		i := int(dd.Day.Sub(from).Hours()/24) / s.PeriodDays
+		if i < 0 || i >= s.HistoryPeriods {
+			continue
+		}
+		if history[dd.ProductID] == nil {
+			history[dd.ProductID] = make([]float64, s.HistoryPeriods)
+		}
+		history[dd.ProductID][i] += float64(dd.Quantity)
+	}
+	if productID != nil && history[*productID] == nil {
+		history[*productID] = make([]float64, s.HistoryPeriods)
+	}
+	return history, nil
+}
+
+// suggest forecasts the product with the lead time and minimum order quantity
+// of its preferred supplier, or the default lead time when it has none.
+func (u *ReplenishmentUseCase) suggest(p forecast.Position, history []float64, link *supplier.ProductSupplier, s forecast.Settings) forecast.Suggestion {
+	if history == nil {
+		history = make([]float64, s.HistoryPeriods)
+	}
+	if link == nil {
+		return forecast.Suggest(p, history, int32(s.DefaultLeadTimeDays), 0, s)
+	}
+
+	sg := forecast.Suggest(p, history, link.LeadTimeDays, link.MinOrderQty, s)
+	sg.SupplierID = &link.SupplierID
+	sg.SupplierName = link.SupplierName
+	sg.UnitCost = &link.CostPrice
+	return sg
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/report"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+// DefaultAgingBounds are the age bands, in days, of the aging report.
+var DefaultAgingBounds = []int32{30, 60, 90, 180}
+
+type ReportUseCase struct {
+	repo    report.Repository
+	pricing *PricingUseCase
+	// currency is what values of different currencies are converted to before
+	// they are compared.
+	currency string
+}
+
+func NewReportUseCase(r report.Repository, pricing *PricingUseCase, currency string) *ReportUseCase {
+	return &ReportUseCase{repo: r, pricing: pricing, currency: currency}
+}
+
+var (
+	ErrInvalidAgingBounds = errors.New("aging bounds must be positive and ascending")
+	ErrInvalidDays        = errors.New("number of days must be positive")
+)
+
+// Turnover reports, per product, the quantity issued in the period against the
+// average of its opening and closing stock, and how many days that stock lasts.
+func (u *ReportUseCase) Turnover(ctx context.Context, from, to time.Time) ([]report.Turnover, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidPeriod
+	}
+	rows, err := u.repo.Turnover(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	days := to.Sub(from).Hours() / 24
+	for i := range rows {
+		rows[i].Ratios(days)
+	}
+	return rows, nil
+}
+
+// Aging splits the stock on hand by age bands; stock whose receipt is not on
+// record counts as the oldest.
+func (u *ReportUseCase) Aging(ctx context.Context, bounds []int32) ([]report.Aging, error) {
+	if len(bounds) == 0 {
+		bounds = DefaultAgingBounds
+	}
+	for i, b := range bounds {
+		if b <= 0 || (i > 0 && b <= bounds[i-1]) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAgingBounds, bounds)
+		}
+	}
+
+	rows, err := u.repo.Aging(ctx, time.Now(), bounds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		unexplained := rows[i].Quantity
+		for j := range rows[i].Buckets {
+			rows[i].Buckets[j] = agingBucket(bounds, j, rows[i].Buckets[j].Quantity)
+			unexplained -= rows[i].Buckets[j].Quantity
+		}
+		rows[i].Buckets[len(bounds)].Quantity += max(unexplained, 0)
+	}
+	return rows, nil
+}
+
+func agingBucket(bounds []int32, i int, quantity int32) report.AgingBucket {
+	b := report.AgingBucket{Quantity: quantity}
+	if i > 0 {
+		b.MinDays = bounds[i-1]
+	}
+	if i == len(bounds) {
+		b.Label = strconv.Itoa(int(b.MinDays)) + "+"
+		return b
+	}
+	maxDays := bounds[i] - 1
+	b.MaxDays = &maxDays
+	b.Label = strconv.Itoa(int(b.MinDays)) + "-" + strconv.Itoa(int(maxDays))
+	return b
+}
+
+// DeadStock lists products with stock on hand that have not moved for the given
+// number of days.
+func (u *ReportUseCase) DeadStock(ctx context.Context, days int) ([]report.DeadStock, error) {
+	if days <= 0 {
+		return nil, ErrInvalidDays
+	}
+	return u.repo.DeadStock(ctx, time.Now().AddDate(0, 0, -days))
+}
+
+// ABC classifies the products issued in the period by their consumption value
+// in the reporting currency: A covers the first 80 percent of the total, B the
+// next 15 and C the rest. Products are listed by value, highest first.
+func (u *ReportUseCase) ABC(ctx context.Context, from, to time.Time) ([]report.ABC, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidPeriod
+	}
+	consumption, err := u.consumption(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[int32]int64, len(consumption))
+	var total int64
+	for _, c := range consumption {
+		usage[c.ProductID] = c.Value.Amount
+		total += c.Value.Amount
+	}
+	classes := stock.ClassifyABC(usage)
+
+	sort.SliceStable(consumption, func(i, j int) bool {
+		if consumption[i].Value.Amount != consumption[j].Value.Amount {
+			return consumption[i].Value.Amount > consumption[j].Value.Amount
+		}
+		return consumption[i].ProductID < consumption[j].ProductID
+	})
+
+	rows := make([]report.ABC, 0, len(consumption))
+	var covered int64
+	for _, c := range consumption {
+		covered += c.Value.Amount
+		row := report.ABC{Consumption: c, Class: classes[c.ProductID]}
+		if total > 0 {
+			row.SharePct = percent(c.Value.Amount, total)
+			row.CumulativePct = percent(covered, total)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// consumption returns one row per product issued in the period, with the value
+// of its issues converted to the reporting currency at the rates valid at the
+// end of the period, or now if it has not ended yet.
+func (u *ReportUseCase) consumption(ctx context.Context, from, to time.Time) ([]report.Consumption, error) {
+	rows, err := u.repo.Consumption(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	at := to
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
+	var result []report.Consumption
+	index := make(map[int32]int, len(rows))
+	for _, row := range rows {
+		value, err := u.pricing.Convert(ctx, row.Value, u.currency, at)
+		if err != nil {
+			return nil, fmt.Errorf("product %d: %w", row.ProductID, err)
+		}
+		i, ok := index[row.ProductID]
+		if !ok {
+			index[row.ProductID] = len(result)
+			result = append(result, report.Consumption{ProductID: row.ProductID, ProductName: row.ProductName, Value: money.Money{Currency: u.currency}})
+			i = len(result) - 1
+		}
+		result[i].IssuedQuantity += row.IssuedQuantity
+		result[i].Value.Amount += value.Amount
+	}
+	return result, nil
+}
+
+// percent returns part of total in percent, rounded to two decimals.
+func percent(part, total int64) float64 {
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
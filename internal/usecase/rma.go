@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type ReturnUseCase struct {
+	repo  rma.Repository
+	stock *StockUseCase
+}
+
+func NewReturnUseCase(r rma.Repository, stock *StockUseCase) *ReturnUseCase {
+	return &ReturnUseCase{repo: r, stock: stock}
+}
+
+var (
+	ErrInvalidReturnStatus  = errors.New("unknown return status")
+	ErrReturnExceedsShipped = errors.New("returned quantity exceeds the shipped quantity")
+	ErrInvalidDisposition   = errors.New("disposition must be one of restock, quarantine, scrap")
+	ErrDispositionMismatch  = errors.New("dispositions must account for every returned unit")
+	ErrSerialNotReturned    = errors.New("serial number is not part of this return line")
+	ErrSerialNotShipped     = errors.New("serial number was not shipped on this order line")
+	ErrSerialReturned       = errors.New("serial number is already returned")
+	ErrNothingToRelease     = errors.New("no quarantined goods are listed")
+	ErrReleaseDisposition   = errors.New("quarantined goods can only be restocked or scrapped")
+	ErrExceedsQuarantined   = errors.New("quantity exceeds what is left in quarantine")
+)
+
+// Create opens a return against lines of a shipped sales order. Each line may
+// return at most what was shipped less what earlier returns already cover;
+// serialized lines list the returned units, which must have been shipped on the
+// line and not returned yet.
+func (u *ReturnUseCase) Create(ctx context.Context, r rma.Return) (int32, error) {
+	if len(r.Lines) == 0 {
+		return 0, ErrEmptyOrder
+	}
+
+	return u.repo.Create(ctx, r, func(r *rma.Return, o sales.Order, returned map[int32]rma.Returned) error {
+		if o.Status != sales.StatusShipped {
+			return fmt.Errorf("%w: order is %s", sales.ErrStatus, o.Status)
+		}
+
+		seen := make(map[int32]bool, len(r.Lines))
+		for i := range r.Lines {
+			line := &r.Lines[i]
+			if seen[line.OrderLineID] {
+				return fmt.Errorf("%w: order line %d", ErrDuplicateLine, line.OrderLineID)
+			}
+			seen[line.OrderLineID] = true
+
+			shipped := findSalesLine(o.Lines, line.OrderLineID)
+			if shipped == nil {
+				return fmt.Errorf("%w: %d", ErrUnknownOrderLine, line.OrderLineID)
+			}
+			if line.Quantity <= 0 {
+				return fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+			}
+			earlier := returned[shipped.ID]
+			if left := shipped.ShippedQuantity - earlier.Quantity; line.Quantity > left {
+				return fmt.Errorf("%w: order line %d has %d left to return", ErrReturnExceedsShipped, shipped.ID, max(left, 0))
+			}
+			line.ProductID = shipped.ProductID
+
+			if err := checkSerials(*line, *shipped, earlier); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkSerials makes sure a line returned against a serialized order line lists
+// exactly one unit per returned quantity, each shipped on the order line and not
+// returned before, and that other lines list none.
+func checkSerials(line rma.Line, shipped sales.Line, earlier rma.Returned) error {
+	if len(shipped.Serials) == 0 {
+		if len(line.Serials) > 0 {
+			return ErrNotSerialized
+		}
+		return nil
+	}
+	if len(line.Serials) != int(line.Quantity) {
+		return fmt.Errorf("%w: %d listed for a quantity of %d", ErrSerialCount, len(line.Serials), line.Quantity)
+	}
+	for i, number := range line.Serials {
+		switch {
+		case slices.Contains(line.Serials[:i], number):
+			return fmt.Errorf("%w: %s", ErrDuplicateSerial, number)
+		case !slices.Contains(shipped.Serials, number):
+			return fmt.Errorf("%w: %s", ErrSerialNotShipped, number)
+		case slices.Contains(earlier.Serials, number):
+			return fmt.Errorf("%w: %s", ErrSerialReturned, number)
+		}
+	}
+	return nil
+}
+
+func (u *ReturnUseCase) Get(ctx context.Context, id int32) (rma.Return, error) {
+	return u.repo.Get(ctx, id)
+}
+
+func (u *ReturnUseCase) List(ctx context.Context, status rma.Status, orderID *int32) ([]rma.Return, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidReturnStatus, status)
+	}
+	return u.repo.List(ctx, status, orderID)
+}
+
+// Inspect records the disposition of every returned unit and posts the stock
+// changes: restocked goods are returned to sellable stock, quarantined goods are
+// added to the quarantined quantity, and scrapped goods never re-enter stock
+// (scrapped serial numbers are written off).
+func (u *ReturnUseCase) Inspect(ctx context.Context, id int32, insp rma.Inspection) (rma.Return, error) {
+	if len(insp.Lines) == 0 {
+		return rma.Return{}, ErrDispositionMismatch
+	}
+
+	// The stock changes are prepared before the return is locked, so the
+	// transaction runs no other queries; the locked return is checked again below.
+	now := time.Now()
+	r, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return rma.Return{}, err
+	}
+	if err := inspect(&r, insp, now); err != nil {
+		return rma.Return{}, err
+	}
+
+	reference := "RMA-" + strconv.Itoa(int(r.ID))
+	var movements []stock.Movement
+	var writeOffs []stock.SerialUpdate
+	for _, entry := range insp.Lines {
+		line := findReturnLine(r.Lines, entry.LineID)
+		m := stock.Movement{
+			ProductID:       line.ProductID,
+			EnteredQuantity: strconv.Itoa(int(entry.Quantity)),
+			Reference:       reference,
+			Lot:             entry.Lot,
+			Serials:         entry.Serials,
+		}
+		switch entry.Disposition {
+		case rma.DispositionRestock:
+			m.Type = stock.MovementReturn
+		case rma.DispositionQuarantine:
+			m.Type = stock.MovementQuarantine
+		case rma.DispositionScrap:
+			updates, err := u.scrapSerials(ctx, *line, entry, stock.SerialShipped)
+			if err != nil {
+				return rma.Return{}, err
+			}
+			writeOffs = append(writeOffs, updates...)
+			continue
+		}
+
+		m, err := u.stock.Prepare(ctx, m)
+		if err != nil {
+			return rma.Return{}, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		movements = append(movements, m)
+	}
+
+	return u.repo.Update(ctx, id, func(r *rma.Return) ([]stock.Movement, []stock.SerialUpdate, error) {
+		if err := inspect(r, insp, now); err != nil {
+			return nil, nil, err
+		}
+		return movements, writeOffs, nil
+	})
+}
+
+// inspect adds the inspected quantities to the dispositions of the return lines
+// and marks the return inspected once every returned unit is accounted for.
+func inspect(r *rma.Return, insp rma.Inspection, now time.Time) error {
+	if r.Status != rma.StatusOpen {
+		return fmt.Errorf("%w: return is %s", rma.ErrStatus, r.Status)
+	}
+
+	inspected := make(map[int32][]string)
+	for _, entry := range insp.Lines {
+		line := findReturnLine(r.Lines, entry.LineID)
+		if line == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, entry.LineID)
+		}
+		if !entry.Disposition.Valid() {
+			return fmt.Errorf("%w: %q", ErrInvalidDisposition, entry.Disposition)
+		}
+		if entry.Quantity <= 0 {
+			return fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+		for _, number := range entry.Serials {
+			if !slices.Contains(line.Serials, number) || slices.Contains(inspected[line.ID], number) {
+				return fmt.Errorf("%w: %s", ErrSerialNotReturned, number)
+			}
+			inspected[line.ID] = append(inspected[line.ID], number)
+		}
+
+		switch entry.Disposition {
+		case rma.DispositionRestock:
+			line.RestockedQuantity += entry.Quantity
+		case rma.DispositionQuarantine:
+			line.QuarantinedQuantity += entry.Quantity
+		case rma.DispositionScrap:
+			line.ScrappedQuantity += entry.Quantity
+		}
+	}
+
+	for _, line := range r.Lines {
+		if sorted := line.RestockedQuantity + line.QuarantinedQuantity + line.ScrappedQuantity; sorted != line.Quantity {
+			return fmt.Errorf("%w: line %d returned %d, inspected %d", ErrDispositionMismatch, line.ID, line.Quantity, sorted)
+		}
+	}
+
+	r.Status = rma.StatusInspected
+	r.InspectedAt = &now
+	return nil
+}
+
+// scrapSerials writes off the scrapped units of a serialized line, which must
+// all have the given status.
+func (u *ReturnUseCase) scrapSerials(ctx context.Context, line rma.Line, entry rma.InspectedLine, from stock.SerialStatus) ([]stock.SerialUpdate, error) {
+	if len(line.Serials) == 0 {
+		if len(entry.Serials) > 0 {
+			return nil, ErrNotSerialized
+		}
+		return nil, nil
+	}
+	if len(entry.Serials) != int(entry.Quantity) {
+		return nil, fmt.Errorf("%w: %d listed for a quantity of %d", ErrSerialCount, len(entry.Serials), entry.Quantity)
+	}
+
+	serials, err := u.stock.serialsWithStatus(ctx, line.ProductID, from, entry.Serials)
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]stock.SerialUpdate, 0, len(serials))
+	for _, s := range serials {
+		updates = append(updates, stock.SerialUpdate{ID: s.ID, Number: s.Number, From: s.Status, To: stock.SerialWrittenOff})
+	}
+	return updates, nil
+}
+
+// ReleaseQuarantine takes quarantined goods of an inspected return out of
+// quarantine. Restocked goods become sellable and are valued at the current
+// average cost, as restocked returns are; scrapped goods leave stock and their
+// serial numbers are written off. Quarantined goods carry no stock value, so
+// scrapping them changes none.
+func (u *ReturnUseCase) ReleaseQuarantine(ctx context.Context, id int32, rel rma.Release) (rma.Return, error) {
+	if len(rel.Lines) == 0 {
+		return rma.Return{}, ErrNothingToRelease
+	}
+
+	// The stock changes are prepared before the return is locked, as in Inspect.
+	r, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return rma.Return{}, err
+	}
+	if err := releaseQuarantine(&r, rel); err != nil {
+		return rma.Return{}, err
+	}
+
+	reference := "RMA-" + strconv.Itoa(int(r.ID))
+	var movements []stock.Movement
+	var writeOffs []stock.SerialUpdate
+	for _, entry := range rel.Lines {
+		line := findReturnLine(r.Lines, entry.LineID)
+		out, err := u.stock.PrepareRelease(ctx, line.ProductID, entry.Quantity, reference)
+		if err != nil {
+			return rma.Return{}, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		movements = append(movements, out)
+
+		if entry.Disposition == rma.DispositionScrap {
+			updates, err := u.scrapSerials(ctx, *line, entry, stock.SerialQuarantined)
+			if err != nil {
+				return rma.Return{}, err
+			}
+			writeOffs = append(writeOffs, updates...)
+			continue
+		}
+		in, err := u.stock.Prepare(ctx, stock.Movement{
+			ProductID:       line.ProductID,
+			Type:            stock.MovementReturn,
+			EnteredQuantity: strconv.Itoa(int(entry.Quantity)),
+			Reference:       reference,
+			Lot:             entry.Lot,
+			Serials:         entry.Serials,
+		})
+		if err != nil {
+			return rma.Return{}, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		movements = append(movements, in)
+	}
+
+	return u.repo.Update(ctx, id, func(r *rma.Return) ([]stock.Movement, []stock.SerialUpdate, error) {
+		if err := releaseQuarantine(r, rel); err != nil {
+			return nil, nil, err
+		}
+		return movements, writeOffs, nil
+	})
+}
+
+// releaseQuarantine moves the released quantities of an inspected return from
+// the quarantined quantity of its lines to the restocked or scrapped one.
+func releaseQuarantine(r *rma.Return, rel rma.Release) error {
+	if r.Status != rma.StatusInspected {
+		return fmt.Errorf("%w: return is %s", rma.ErrStatus, r.Status)
+	}
+
+	released := make(map[int32][]string)
+	for _, entry := range rel.Lines {
+		line := findReturnLine(r.Lines, entry.LineID)
+		if line == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, entry.LineID)
+		}
+		if entry.Disposition != rma.DispositionRestock && entry.Disposition != rma.DispositionScrap {
+			return fmt.Errorf("%w: %q", ErrReleaseDisposition, entry.Disposition)
+		}
+		if entry.Quantity <= 0 {
+			return fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+		if entry.Quantity > line.QuarantinedQuantity {
+			return fmt.Errorf("%w: line %d has %d quarantined", ErrExceedsQuarantined, line.ID, line.QuarantinedQuantity)
+		}
+		for _, number := range entry.Serials {
+			if !slices.Contains(line.Serials, number) || slices.Contains(released[line.ID], number) {
+				return fmt.Errorf("%w: %s", ErrSerialNotReturned, number)
+			}
+			released[line.ID] = append(released[line.ID], number)
+		}
+
+		line.QuarantinedQuantity -= entry.Quantity
+		if entry.Disposition == rma.DispositionRestock {
+			line.RestockedQuantity += entry.Quantity
+		} else {
+			line.ScrappedQuantity += entry.Quantity
+		}
+	}
+	return nil
+}
+
+// Cancel closes an open return without touching stock.
+func (u *ReturnUseCase) Cancel(ctx context.Context, id int32) (rma.Return, error) {
+	return u.repo.Update(ctx, id, func(r *rma.Return) ([]stock.Movement, []stock.SerialUpdate, error) {
+		if r.Status != rma.StatusOpen {
+			return nil, nil, fmt.Errorf("%w: return is %s", rma.ErrStatus, r.Status)
+		}
+		now := time.Now()
+		r.Status = rma.StatusCancelled
+		r.CancelledAt = &now
+		return nil, nil, nil
+	})
+}
+
+func findReturnLine(lines []rma.Line, id int32) *rma.Line {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/stretchr/testify/assert"
+)
+
+func inspectedReturn() rma.Return {
+	return rma.Return{
+		ID:     1,
+		Status: rma.StatusInspected,
+		Lines: []rma.Line{
+			{ID: 1, ProductID: 10, Quantity: 4, RestockedQuantity: 1, QuarantinedQuantity: 3},
+			{ID: 2, ProductID: 20, Quantity: 2, Serials: []string{"SN-1", "SN-2"}, QuarantinedQuantity: 2},
+		},
+	}
+}
+
+func TestReleaseQuarantine_Dispositions(t *testing.T) {
+	r := inspectedReturn()
+	err := releaseQuarantine(&r, rma.Release{Lines: []rma.InspectedLine{
+		{LineID: 1, Disposition: rma.DispositionRestock, Quantity: 2},
+		{LineID: 1, Disposition: rma.DispositionScrap, Quantity: 1},
+		{LineID: 2, Disposition: rma.DispositionScrap, Quantity: 1, Serials: []string{"SN-2"}},
+	}})
+	assert.NoError(t, err)
+
+	// The dispositions still add up to the returned quantity.
+	assert.Equal(t, rma.Line{ID: 1, ProductID: 10, Quantity: 4, RestockedQuantity: 3, ScrappedQuantity: 1}, r.Lines[0])
+	assert.Equal(t, int32(1), r.Lines[1].QuarantinedQuantity)
+	assert.Equal(t, int32(1), r.Lines[1].ScrappedQuantity)
+	assert.Equal(t, rma.StatusInspected, r.Status)
+}
+
+func TestReleaseQuarantine_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []rma.InspectedLine
+		err   error
+	}{
+		{"quarantine", []rma.InspectedLine{{LineID: 1, Disposition: rma.DispositionQuarantine, Quantity: 1}}, ErrReleaseDisposition},
+		{"more than quarantined", []rma.InspectedLine{{LineID: 1, Disposition: rma.DispositionRestock, Quantity: 4}}, ErrExceedsQuarantined},
+		{"more than quarantined over two entries", []rma.InspectedLine{
+			{LineID: 1, Disposition: rma.DispositionRestock, Quantity: 2},
+			{LineID: 1, Disposition: rma.DispositionScrap, Quantity: 2},
+		}, ErrExceedsQuarantined},
+		{"unknown line", []rma.InspectedLine{{LineID: 9, Disposition: rma.DispositionScrap, Quantity: 1}}, ErrUnknownOrderLine},
+		{"zero quantity", []rma.InspectedLine{{LineID: 1, Disposition: rma.DispositionScrap}}, stock.ErrInvalidQuantity},
+		{"serial of another line", []rma.InspectedLine{{LineID: 2, Disposition: rma.DispositionScrap, Quantity: 1, Serials: []string{"SN-3"}}}, ErrSerialNotReturned},
+		{"serial released twice", []rma.InspectedLine{
+			{LineID: 2, Disposition: rma.DispositionRestock, Quantity: 1, Serials: []string{"SN-1"}},
+			{LineID: 2, Disposition: rma.DispositionScrap, Quantity: 1, Serials: []string{"SN-1"}},
+		}, ErrSerialNotReturned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := inspectedReturn()
+			err := releaseQuarantine(&r, rma.Release{Lines: tt.lines})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	r := inspectedReturn()
+	r.Status = rma.StatusOpen
+	err := releaseQuarantine(&r, rma.Release{Lines: []rma.InspectedLine{{LineID: 1, Disposition: rma.DispositionScrap, Quantity: 1}}})
+	assert.ErrorIs(t, err, rma.ErrStatus)
+}
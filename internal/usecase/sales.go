@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type SalesUseCase struct {
+	repo     sales.Repository
+	products product.Repository
+	pricing  *PricingUseCase
+	stock    *StockUseCase
+}
+
+func NewSalesUseCase(r sales.Repository, products product.Repository, pricing *PricingUseCase, stock *StockUseCase) *SalesUseCase {
+	return &SalesUseCase{repo: r, products: products, pricing: pricing, stock: stock}
+}
+
+var (
+	ErrInvalidSalesStatus = errors.New("unknown sales order status")
+	ErrNoPackages         = errors.New("packed order must have at least one package")
+	ErrPackMismatch       = errors.New("packed quantity must match the allocated quantity")
+	ErrTrackingRequired   = errors.New("tracking number is required to ship an order")
+)
+
+// salesTransitions is the order state machine: the statuses each status may move
+// to. Shipped and cancelled orders are final.
+var salesTransitions = map[sales.Status][]sales.Status{
+	sales.StatusDraft:     {sales.StatusAllocated, sales.StatusCancelled},
+	sales.StatusAllocated: {sales.StatusPicking, sales.StatusCancelled},
+	sales.StatusPicking:   {sales.StatusPacked, sales.StatusCancelled},
+	sales.StatusPacked:    {sales.StatusShipped, sales.StatusCancelled},
+}
+
+// transition moves the order to status if the state machine allows it and stamps
+// the time of the step.
+func transition(o *sales.Order, to sales.Status, now time.Time) error {
+	if !slices.Contains(salesTransitions[o.Status], to) {
+		return fmt.Errorf("%w: order is %s and cannot become %s", sales.ErrStatus, o.Status, to)
+	}
+	o.Status = to
+	switch to {
+	case sales.StatusAllocated:
+		o.AllocatedAt = &now
+	case sales.StatusPacked:
+		o.PackedAt = &now
+	case sales.StatusShipped:
+		o.ShippedAt = &now
+	case sales.StatusCancelled:
+		o.CancelledAt = &now
+	}
+	return nil
+}
+
+// Create saves a draft order. Lines without a unit price take the price resolved
+// for the customer group in the order currency; an order without a currency uses
+// the currency of the first product.
+func (u *SalesUseCase) Create(ctx context.Context, o sales.Order) (int32, error) {
+	if len(o.Lines) == 0 {
+		return 0, ErrEmptyOrder
+	}
+
+	now := time.Now()
+	seen := make(map[int32]bool, len(o.Lines))
+	for i := range o.Lines {
+		line := &o.Lines[i]
+		if seen[line.ProductID] {
+			return 0, fmt.Errorf("%w: product %d", ErrDuplicateLine, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return 0, fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+		if line.UnitPrice.IsZero() {
+			resolved, err := u.pricing.Resolve(ctx, line.ProductID, o.Currency, o.CustomerGroup, now)
+			if err != nil {
+				return 0, err
+			}
+			line.UnitPrice = resolved.Price
+		} else if _, err := u.products.GetByID(ctx, line.ProductID); err != nil {
+			return 0, err
+		}
+		if line.UnitPrice.Amount < 0 {
+			return 0, ErrInvalidPrice
+		}
+
+		if o.Currency == "" {
+			o.Currency = line.UnitPrice.Currency
+		}
+		if line.UnitPrice.Currency != o.Currency {
+			return 0, fmt.Errorf("%w %s", ErrMixedCurrencies, o.Currency)
+		}
+	}
+
+	if !money.IsSupported(o.Currency) {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedCurrency, o.Currency)
+	}
+	return u.repo.Create(ctx, o)
+}
+
+func (u *SalesUseCase) Get(ctx context.Context, id int32) (sales.Order, error) {
+	return u.repo.Get(ctx, id)
+}
+
+func (u *SalesUseCase) List(ctx context.Context, status sales.Status) ([]sales.Order, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSalesStatus, status)
+	}
+	return u.repo.List(ctx, status)
+}
+
+// Allocate reserves the full quantity of every line. Stock is available when it is
+// on hand and not allocated to another open order; a short line fails the whole
+// allocation.
+func (u *SalesUseCase) Allocate(ctx context.Context, id int32) (sales.Order, error) {
+	return u.repo.Update(ctx, id, func(o *sales.Order, available sales.Available) ([]stock.Movement, error) {
+		if err := transition(o, sales.StatusAllocated, time.Now()); err != nil {
+			return nil, err
+		}
+		for i := range o.Lines {
+			line := &o.Lines[i]
+			if free := available[line.ProductID]; free < line.Quantity {
+				return nil, fmt.Errorf("%w: product %d has %d available, line %d needs %d",
+					stock.ErrInsufficientStock, line.ProductID, max(free, 0), line.ID, line.Quantity)
+			}
+			line.AllocatedQuantity = line.Quantity
+		}
+		return nil, nil
+	})
+}
+
+// StartPicking releases an allocated order to the floor and returns its pick list.
+func (u *SalesUseCase) StartPicking(ctx context.Context, id int32) (sales.PickList, error) {
+	o, err := u.repo.Update(ctx, id, func(o *sales.Order, _ sales.Available) ([]stock.Movement, error) {
+		return nil, transition(o, sales.StatusPicking, time.Now())
+	})
+	if err != nil {
+		return sales.PickList{}, err
+	}
+	return u.pickList(ctx, o)
+}
+
+// PickList returns the pick list of an order that holds an allocation.
+func (u *SalesUseCase) PickList(ctx context.Context, id int32) (sales.PickList, error) {
+	o, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return sales.PickList{}, err
+	}
+	switch o.Status {
+	case sales.StatusAllocated, sales.StatusPicking, sales.StatusPacked:
+	default:
+		return sales.PickList{}, fmt.Errorf("%w: order is %s", sales.ErrStatus, o.Status)
+	}
+	return u.pickList(ctx, o)
+}
+
+// pickList groups the allocated lines by bin location. Bins are sorted so pickers
+// walk the aisles in order, with products without a bin last.
+func (u *SalesUseCase) pickList(ctx context.Context, o sales.Order) (sales.PickList, error) {
+	bins := make(map[string][]sales.PickLine)
+	for _, line := range o.Lines {
+		if line.AllocatedQuantity == 0 {
+			continue
+		}
+		p, err := u.products.GetByID(ctx, line.ProductID)
+		if err != nil {
+			return sales.PickList{}, err
+		}
+		bins[p.BinLocation] = append(bins[p.BinLocation], sales.PickLine{
+			LineID:      line.ID,
+			ProductID:   p.ID,
+			ProductName: p.Name,
+			Quantity:    line.AllocatedQuantity,
+			Unit:        p.BaseUnit,
+		})
+	}
+
+	list := sales.PickList{OrderID: o.ID, Bins: []sales.PickBin{}}
+	for bin, lines := range bins {
+		slices.SortFunc(lines, func(a, b sales.PickLine) int {
+			return cmp.Or(cmp.Compare(a.ProductName, b.ProductName), cmp.Compare(a.LineID, b.LineID))
+		})
+		list.Bins = append(list.Bins, sales.PickBin{BinLocation: bin, Lines: lines})
+	}
+	slices.SortFunc(list.Bins, func(a, b sales.PickBin) int {
+		if (a.BinLocation == "") != (b.BinLocation == "") {
+			if a.BinLocation == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.BinLocation, b.BinLocation)
+	})
+	return list, nil
+}
+
+// Pack confirms that every line was packed in full.
+func (u *SalesUseCase) Pack(ctx context.Context, id int32, p sales.Packing) (sales.Order, error) {
+	if p.Packages <= 0 {
+		return sales.Order{}, ErrNoPackages
+	}
+
+	return u.repo.Update(ctx, id, func(o *sales.Order, _ sales.Available) ([]stock.Movement, error) {
+		if err := transition(o, sales.StatusPacked, time.Now()); err != nil {
+			return nil, err
+		}
+
+		packed := make(map[int32]int32, len(p.Lines))
+		for _, line := range p.Lines {
+			if findSalesLine(o.Lines, line.LineID) == nil {
+				return nil, fmt.Errorf("%w: %d", ErrUnknownOrderLine, line.LineID)
+			}
+			if _, ok := packed[line.LineID]; ok {
+				return nil, fmt.Errorf("%w: line %d is confirmed more than once", ErrPackMismatch, line.LineID)
+			}
+			packed[line.LineID] = line.Quantity
+		}
+		for _, line := range o.Lines {
+			if packed[line.ID] != line.AllocatedQuantity {
+				return nil, fmt.Errorf("%w: line %d has %d allocated, %d packed",
+					ErrPackMismatch, line.ID, line.AllocatedQuantity, packed[line.ID])
+			}
+		}
+
+		o.Packages = p.Packages
+		return nil, nil
+	})
+}
+
+// Ship hands a packed order to the carrier and issues the allocated stock in the
+// same transaction; this is the only step that changes stock.
+func (u *SalesUseCase) Ship(ctx context.Context, id int32, s sales.Shipment) (sales.Order, error) {
+	if s.TrackingNumber == "" {
+		return sales.Order{}, ErrTrackingRequired
+	}
+
+	// The movements are prepared before the order is locked, so the transaction
+	// runs no other queries. A packed order keeps its allocation until it ships or
+	// is cancelled, which ship checks again on the locked order.
+	now := time.Now()
+	o, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	if err := ship(&o, s, now); err != nil {
+		return sales.Order{}, err
+	}
+
+	reference := "SO-" + strconv.Itoa(int(o.ID))
+	if o.Reference != "" {
+		reference += " " + o.Reference
+	}
+	details := make(map[int32]sales.ShipmentLine, len(s.Lines))
+	for _, line := range s.Lines {
+		details[line.LineID] = line
+	}
+
+	movements := make([]stock.Movement, 0, len(o.Lines))
+	for _, line := range o.Lines {
+		m, err := u.stock.Prepare(ctx, stock.Movement{
+			ProductID:       line.ProductID,
+			Type:            stock.MovementIssue,
+			EnteredQuantity: strconv.Itoa(int(line.ShippedQuantity)),
+			Reference:       reference,
+			Lot:             details[line.ID].Lot,
+			Serials:         details[line.ID].Serials,
+		})
+		if err != nil {
+			return sales.Order{}, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		movements = append(movements, m)
+	}
+
+	return u.repo.Update(ctx, id, func(o *sales.Order, _ sales.Available) ([]stock.Movement, error) {
+		if err := ship(o, s, now); err != nil {
+			return nil, err
+		}
+		return movements, nil
+	})
+}
+
+// ship marks the order shipped with the carrier details and every line shipped
+// in full, recording the serial numbers of each line.
+func ship(o *sales.Order, s sales.Shipment, now time.Time) error {
+	if err := transition(o, sales.StatusShipped, now); err != nil {
+		return err
+	}
+	o.Carrier = s.Carrier
+	o.TrackingNumber = s.TrackingNumber
+
+	for _, line := range s.Lines {
+		shipped := findSalesLine(o.Lines, line.LineID)
+		if shipped == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, line.LineID)
+		}
+		shipped.Serials = line.Serials
+	}
+	for i := range o.Lines {
+		o.Lines[i].ShippedQuantity = o.Lines[i].AllocatedQuantity
+	}
+	return nil
+}
+
+// Cancel ends an order that has not shipped and releases its allocation.
+func (u *SalesUseCase) Cancel(ctx context.Context, id int32) (sales.Order, error) {
+	return u.repo.Update(ctx, id, func(o *sales.Order, _ sales.Available) ([]stock.Movement, error) {
+		if err := transition(o, sales.StatusCancelled, time.Now()); err != nil {
+			return nil, err
+		}
+		for i := range o.Lines {
+			o.Lines[i].AllocatedQuantity = 0
+		}
+		return nil, nil
+	})
+}
+
+func findSalesLine(lines []sales.Line, id int32) *sales.Line {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,484 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+)
+
+type StockUseCase struct {
+	repo     stock.Repository
+	products product.Repository
+	// method values the stock taken out by movements.
+	method stock.CostMethod
+}
+
+func NewStockUseCase(r stock.Repository, products product.Repository, method stock.CostMethod) *StockUseCase {
+	return &StockUseCase{repo: r, products: products, method: method}
+}
+
+var (
+	ErrUnknownUnit      = errors.New("unit is not configured for this product")
+	ErrBaseUnitConflict = errors.New("alternate unit cannot reuse the base unit code")
+	ErrInvalidRounding  = errors.New("rounding must be one of reject, down, up, half_up")
+
+	ErrLotExpired       = errors.New("lot is expired")
+	ErrLotRequired      = errors.New("lot number is required for lot-tracked products")
+	ErrNotLotTracked    = errors.New("product is not lot-tracked")
+	ErrLotDatesMismatch = stock.ErrLotDatesMismatch
+
+	ErrSerialCount     = errors.New("serial numbers must match the quantity")
+	ErrDuplicateSerial = errors.New("serial number is listed more than once")
+	ErrSerialProduct   = errors.New("serial number belongs to another product")
+	ErrNotSerialized   = errors.New("product is not serialized")
+
+	ErrUnitCostOutgoing = errors.New("unit cost can only be given for incoming stock")
+	ErrCostCurrency     = errors.New("unit cost must be in the product currency")
+	ErrInvalidUnitCost  = errors.New("unit cost must not be negative")
+)
+
+// Units returns the base unit of the product followed by its alternate units.
+func (u *StockUseCase) Units(ctx context.Context, productID int32) ([]stock.Unit, error) {
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	alternates, err := u.repo.ListUnits(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]stock.Unit{stock.BaseUnit(p.ID, p.BaseUnit)}, alternates...), nil
+}
+
+func (u *StockUseCase) SetUnit(ctx context.Context, unit stock.Unit) error {
+	p, err := u.products.GetByID(ctx, unit.ProductID)
+	if err != nil {
+		return err
+	}
+	if unit.Code == p.BaseUnit {
+		return ErrBaseUnitConflict
+	}
+	if unit.Rounding == "" {
+		unit.Rounding = stock.RoundReject
+	}
+	if !unit.Rounding.Valid() {
+		return ErrInvalidRounding
+	}
+	if unit.Factor.Num <= 0 || unit.Factor.Den <= 0 {
+		return stock.ErrInvalidFactor
+	}
+
+	return u.repo.SetUnit(ctx, unit)
+}
+
+func (u *StockUseCase) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	return u.repo.DeleteUnit(ctx, productID, code)
+}
+
+// ToBase converts a quantity entered in any configured unit of the product to base units.
+// An empty unit means the base unit.
+func (u *StockUseCase) ToBase(ctx context.Context, p product.Product, quantity, unit string) (int32, error) {
+	if unit == "" || unit == p.BaseUnit {
+		return stock.BaseUnit(p.ID, p.BaseUnit).ToBase(quantity)
+	}
+
+	units, err := u.repo.ListUnits(ctx, p.ID)
+	if err != nil {
+		return 0, err
+	}
+	for _, candidate := range units {
+		if candidate.Code == unit {
+			return candidate.ToBase(quantity)
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+}
+
+// Receive adds stock; the entered quantity must be positive.
+func (u *StockUseCase) Receive(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	m.Type = stock.MovementReceipt
+	return u.record(ctx, m)
+}
+
+// Issue removes stock; the entered quantity must be positive.
+func (u *StockUseCase) Issue(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	m.Type = stock.MovementIssue
+	return u.record(ctx, m)
+}
+
+// Adjust corrects stock by a signed quantity.
+func (u *StockUseCase) Adjust(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	m.Type = stock.MovementAdjustment
+	return u.record(ctx, m)
+}
+
+func (u *StockUseCase) Movements(ctx context.Context, productID int32) ([]stock.Movement, error) {
+	if _, err := u.products.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListMovements(ctx, productID)
+}
+
+// RecentMovements returns up to limit of the latest movements of each product,
+// newest first, keyed by product ID, in one query for all products.
+func (u *StockUseCase) RecentMovements(ctx context.Context, productIDs []int32, limit int32) (map[int32][]stock.Movement, error) {
+	result := make(map[int32][]stock.Movement, len(productIDs))
+	if len(productIDs) == 0 || limit <= 0 {
+		return result, nil
+	}
+	movements, err := u.repo.ListRecentMovements(ctx, productIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range movements {
+		result[m.ProductID] = append(result[m.ProductID], m)
+	}
+	return result, nil
+}
+
+func (u *StockUseCase) record(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	m, err := u.Prepare(ctx, m)
+	if err != nil {
+		return stock.Movement{}, err
+	}
+	return u.repo.ApplyMovement(ctx, m)
+}
+
+// Prepare validates a movement without applying it: it converts the entered
+// quantity to base units with the sign of the movement type, checks its unit
+// cost and resolves the lots and serials it touches. Other use cases apply prepared movements together with
+// their own documents.
+func (u *StockUseCase) Prepare(ctx context.Context, m stock.Movement) (stock.Movement, error) {
+	sign := m.Type.Sign()
+
+	p, err := u.products.GetByID(ctx, m.ProductID)
+	if err != nil {
+		return stock.Movement{}, err
+	}
+
+	qty, err := u.ToBase(ctx, p, m.EnteredQuantity, m.EnteredUnit)
+	if err != nil {
+		return stock.Movement{}, err
+	}
+	if qty == 0 {
+		return stock.Movement{}, fmt.Errorf("%w: must not be zero", stock.ErrInvalidQuantity)
+	}
+	if sign != 0 && qty < 0 {
+		return stock.Movement{}, fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+	}
+	if sign != 0 {
+		qty *= sign
+	}
+
+	if m.EnteredUnit == "" {
+		m.EnteredUnit = p.BaseUnit
+	}
+	m.Quantity = qty
+	m.CostMethod = u.method
+
+	if m.UnitCost != nil {
+		switch {
+		case qty < 0 || !m.Type.Costed():
+			return stock.Movement{}, ErrUnitCostOutgoing
+		case m.UnitCost.Currency != p.Price.Currency:
+			return stock.Movement{}, fmt.Errorf("%w %s", ErrCostCurrency, p.Price.Currency)
+		case m.UnitCost.Amount < 0:
+			return stock.Movement{}, ErrInvalidUnitCost
+		}
+	}
+
+	switch {
+	case m.Type == stock.MovementQuarantine:
+		// Quarantined stock is not kept per lot.
+		m.Lot = nil
+	case p.LotTracked:
+		m.Lots, err = u.allocateLots(ctx, m, time.Now())
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	case m.Lot != nil:
+		return stock.Movement{}, ErrNotLotTracked
+	}
+
+	switch {
+	case p.Serialized:
+		m.SerialUpdates, err = u.serialUpdates(ctx, m)
+		if err != nil {
+			return stock.Movement{}, err
+		}
+	case len(m.Serials) > 0:
+		return stock.Movement{}, ErrNotSerialized
+	}
+
+	return m, nil
+}
+
+// PrepareCount prepares an adjustment of the stock of a product at a warehouse
+// for a count; the caller fills in the variance as its quantity. Stock kept per
+// lot or serial cannot be counted this way.
+func (u *StockUseCase) PrepareCount(ctx context.Context, productID, warehouseID int32, reference string) (stock.Movement, error) {
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		return stock.Movement{}, err
+	}
+	if p.Tracked() {
+		return stock.Movement{}, fmt.Errorf("%w: product %d", ErrTrackedCount, p.ID)
+	}
+	return stock.Movement{
+		ProductID:   p.ID,
+		Type:        stock.MovementAdjustment,
+		WarehouseID: warehouseID,
+		EnteredUnit: p.BaseUnit,
+		Reference:   reference,
+		CostMethod:  u.method,
+	}, nil
+}
+
+// PrepareRelease prepares a movement that takes a quantity of a product out of
+// quarantine. Quarantined stock is not kept per lot, and the units of serialized
+// products change status with the movement or write-off that takes them on, so
+// the movement lists neither.
+func (u *StockUseCase) PrepareRelease(ctx context.Context, productID, quantity int32, reference string) (stock.Movement, error) {
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		return stock.Movement{}, err
+	}
+	return stock.Movement{
+		ProductID:       p.ID,
+		Type:            stock.MovementQuarantine,
+		Quantity:        -quantity,
+		EnteredQuantity: strconv.Itoa(int(-quantity)),
+		EnteredUnit:     p.BaseUnit,
+		Reference:       reference,
+		CostMethod:      u.method,
+	}, nil
+}
+
+// serialUpdates checks that a movement of a serialized product lists exactly one
+// serial per unit and works out the status each of them moves to.
+func (u *StockUseCase) serialUpdates(ctx context.Context, m stock.Movement) ([]stock.SerialUpdate, error) {
+	count := m.Quantity
+	if count < 0 {
+		count = -count
+	}
+	if len(m.Serials) != int(count) {
+		return nil, fmt.Errorf("%w: %d listed for a quantity of %d", ErrSerialCount, len(m.Serials), count)
+	}
+
+	seen := make(map[string]bool, len(m.Serials))
+	for _, number := range m.Serials {
+		if seen[number] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSerial, number)
+		}
+		seen[number] = true
+	}
+
+	found, err := u.repo.FindSerials(ctx, m.Serials)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]stock.Serial, len(found))
+	for _, s := range found {
+		existing[s.Number] = s
+	}
+
+	updates := make([]stock.SerialUpdate, 0, len(m.Serials))
+	for _, number := range m.Serials {
+		s, ok := existing[number]
+		if ok && s.ProductID != m.ProductID {
+			return nil, fmt.Errorf("%w: %s", ErrSerialProduct, number)
+		}
+		if !ok && m.Quantity < 0 {
+			return nil, fmt.Errorf("%w: %s", stock.ErrSerialNotFound, number)
+		}
+
+		to, allowed := nextSerialStatus(m, s.Status)
+		if !allowed {
+			return nil, fmt.Errorf("%w: %s is %s", stock.ErrSerialStatus, number, s.Status)
+		}
+		updates = append(updates, stock.SerialUpdate{ID: s.ID, Number: number, From: s.Status, To: to})
+	}
+	return updates, nil
+}
+
+// nextSerialStatus returns the status a unit gets from the movement. Incoming
+// units must be new or out of stock; a shipped unit coming back is returned, or
+// quarantined by a quarantine movement, and a quarantined unit released by a
+// return movement is returned. Issues ship units and negative adjustments write
+// them off.
+func nextSerialStatus(m stock.Movement, current stock.SerialStatus) (stock.SerialStatus, bool) {
+	if m.Type == stock.MovementQuarantine {
+		if m.Quantity > 0 && current == stock.SerialShipped {
+			return stock.SerialQuarantined, true
+		}
+		return "", false
+	}
+
+	if m.Quantity > 0 {
+		switch current {
+		case "", stock.SerialWrittenOff:
+			return stock.SerialInStock, true
+		case stock.SerialShipped:
+			return stock.SerialReturned, true
+		case stock.SerialQuarantined:
+			return stock.SerialReturned, m.Type == stock.MovementReturn
+		}
+		return "", false
+	}
+
+	if !current.OnHand() {
+		return "", false
+	}
+	if m.Type == stock.MovementIssue {
+		return stock.SerialShipped, true
+	}
+	return stock.SerialWrittenOff, true
+}
+
+// allocateLots decides which lots a movement of a lot-tracked product touches.
+// Incoming stock goes to the requested lot, which the repository creates with the
+// movement on first receipt.
+// Outgoing stock comes from the requested lot or, for issues without one, from
+// the lots that expire first (FEFO). Expired lots are never issued.
+func (u *StockUseCase) allocateLots(ctx context.Context, m stock.Movement, now time.Time) ([]stock.LotAllocation, error) {
+	if m.Lot == nil || m.Lot.Number == "" {
+		if m.Quantity > 0 || m.Type != stock.MovementIssue {
+			return nil, ErrLotRequired
+		}
+		lots, err := u.repo.ListLots(ctx, m.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		return allocateFEFO(lots, -m.Quantity, now)
+	}
+
+	lot, err := u.repo.FindLot(ctx, m.ProductID, m.Lot.Number)
+	switch {
+	case errors.Is(err, stock.ErrLotNotFound) && m.Quantity > 0:
+		lot = *m.Lot
+	case err != nil:
+		return nil, err
+	case !lot.Matches(*m.Lot):
+		return nil, fmt.Errorf("%w: %s", ErrLotDatesMismatch, lot.Number)
+	}
+
+	if m.Quantity < 0 {
+		if m.Type == stock.MovementIssue && lot.Expired(now) {
+			return nil, fmt.Errorf("%w: %s expired on %s", ErrLotExpired, lot.Number, lot.ExpiresAt.Format(time.DateOnly))
+		}
+		if lot.Quantity < -m.Quantity {
+			return nil, fmt.Errorf("%w: lot %s has %d", stock.ErrInsufficientStock, lot.Number, lot.Quantity)
+		}
+	}
+
+	return []stock.LotAllocation{{
+		LotID:     lot.ID,
+		LotNumber: lot.Number,
+		ExpiresAt: lot.ExpiresAt,
+		Quantity:  m.Quantity,
+	}}, nil
+}
+
+// allocateFEFO takes need units from the non-expired lots that expire first;
+// lots without an expiry date go last.
+func allocateFEFO(lots []stock.Lot, need int32, now time.Time) ([]stock.LotAllocation, error) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		a, b := lots[i].ExpiresAt, lots[j].ExpiresAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	var allocations []stock.LotAllocation
+	var expired int32
+	for _, lot := range lots {
+		if need == 0 {
+			break
+		}
+		if lot.Quantity <= 0 {
+			continue
+		}
+		if lot.Expired(now) {
+			expired += lot.Quantity
+			continue
+		}
+
+		take := min(lot.Quantity, need)
+		allocations = append(allocations, stock.LotAllocation{
+			LotID:     lot.ID,
+			LotNumber: lot.Number,
+			ExpiresAt: lot.ExpiresAt,
+			Quantity:  -take,
+		})
+		need -= take
+	}
+
+	if need > 0 {
+		if expired >= need {
+			return nil, fmt.Errorf("%w: only expired stock is available", ErrLotExpired)
+		}
+		return nil, stock.ErrInsufficientStock
+	}
+	return allocations, nil
+}
+
+func (u *StockUseCase) Lots(ctx context.Context, productID int32) ([]stock.Lot, error) {
+	if _, err := u.products.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListLots(ctx, productID)
+}
+
+// ExpiringLots returns lots with stock that expire within the given period from
+// today, including lots that have already expired.
+func (u *StockUseCase) ExpiringLots(ctx context.Context, within time.Duration) ([]stock.Lot, error) {
+	return u.repo.ListExpiringLots(ctx, time.Now().Add(within))
+}
+
+// Serial returns a unit of a serialized product with its movement trail.
+func (u *StockUseCase) Serial(ctx context.Context, number string) (stock.SerialTrail, error) {
+	s, err := u.repo.GetSerial(ctx, number)
+	if err != nil {
+		return stock.SerialTrail{}, err
+	}
+	movements, err := u.repo.ListSerialMovements(ctx, s.ID)
+	if err != nil {
+		return stock.SerialTrail{}, err
+	}
+	return stock.SerialTrail{Serial: s, Movements: movements}, nil
+}
+
+// serialsWithStatus returns the listed units of a product; every one must have
+// the given status.
+func (u *StockUseCase) serialsWithStatus(ctx context.Context, productID int32, status stock.SerialStatus, numbers []string) ([]stock.Serial, error) {
+	found, err := u.repo.FindSerials(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+	byNumber := make(map[string]stock.Serial, len(found))
+	for _, s := range found {
+		byNumber[s.Number] = s
+	}
+
+	serials := make([]stock.Serial, 0, len(numbers))
+	for _, number := range numbers {
+		s, ok := byNumber[number]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: %s", stock.ErrSerialNotFound, number)
+		case s.ProductID != productID:
+			return nil, fmt.Errorf("%w: %s", ErrSerialProduct, number)
+		case s.Status != status:
+			return nil, fmt.Errorf("%w: %s is %s", stock.ErrSerialStatus, number, s.Status)
+		}
+		serials = append(serials, s)
+	}
+	return serials, nil
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/stretchr/testify/assert"
+)
+
+// productStub serves fixed products. The use case tests cover the rules the use
+// cases apply before anything is stored; how movements change stock and its
+// value is tested against Postgres in the repo package.
+type productStub struct {
+	product.Repository
+	products map[int32]product.Product
+}
+
+func (s productStub) GetByID(ctx context.Context, id int32) (product.Product, error) {
+	p, ok := s.products[id]
+	if !ok {
+		return product.Product{}, product.ErrNotFound
+	}
+	return p, nil
+}
+
+func newProductStub(products ...product.Product) productStub {
+	s := productStub{products: make(map[int32]product.Product)}
+	for _, p := range products {
+		s.products[p.ID] = p
+	}
+	return s
+}
+
+func TestStockUseCase_PrepareCost(t *testing.T) {
+	chair := product.Product{ID: 1, Name: "Chair", Price: money.MustParse("49.90", "USD"), BaseUnit: "pcs"}
+	u := NewStockUseCase(nil, newProductStub(chair), stock.CostLIFO)
+	usd := money.MustParse("20.00", "USD")
+	eur := money.MustParse("20.00", "EUR")
+	negative := money.Money{Amount: -1, Currency: "USD"}
+
+	tests := []struct {
+		name     string
+		typ      stock.MovementType
+		quantity string
+		unitCost *money.Money
+		want     int32
+		err      error
+	}{
+		{"receipt at a unit cost", stock.MovementReceipt, "3", &usd, 3, nil},
+		{"receipt at the average cost", stock.MovementReceipt, "3", nil, 3, nil},
+		{"issue", stock.MovementIssue, "3", nil, -3, nil},
+		{"positive adjustment", stock.MovementAdjustment, "2", &usd, 2, nil},
+		{"issue at a unit cost", stock.MovementIssue, "3", &usd, 0, ErrUnitCostOutgoing},
+		{"negative adjustment at a unit cost", stock.MovementAdjustment, "-2", &usd, 0, ErrUnitCostOutgoing},
+		{"transfer at a unit cost", stock.MovementTransferIn, "3", &usd, 0, ErrUnitCostOutgoing},
+		{"other currency", stock.MovementReceipt, "3", &eur, 0, ErrCostCurrency},
+		{"negative unit cost", stock.MovementReceipt, "3", &negative, 0, ErrInvalidUnitCost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := u.Prepare(context.Background(), stock.Movement{
+				ProductID:       chair.ID,
+				Type:            tt.typ,
+				EnteredQuantity: tt.quantity,
+				UnitCost:        tt.unitCost,
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, m.Quantity)
+			assert.Equal(t, stock.CostLIFO, m.CostMethod)
+			assert.Equal(t, "pcs", m.EnteredUnit)
+		})
+	}
+}
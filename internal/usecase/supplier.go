@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+)
+
+type SupplierUseCase struct {
+	repo     supplier.Repository
+	products product.Repository
+	pricing  *PricingUseCase
+}
+
+func NewSupplierUseCase(r supplier.Repository, products product.Repository, pricing *PricingUseCase) *SupplierUseCase {
+	return &SupplierUseCase{repo: r, products: products, pricing: pricing}
+}
+
+var (
+	ErrInvalidLeadTime = errors.New("lead time must not be negative")
+	ErrInvalidCost     = errors.New("cost price must be greater than zero")
+	ErrInvalidMOQ      = errors.New("minimum order quantity must be at least 1")
+)
+
+func validateSupplier(s supplier.Supplier) error {
+	if s.LeadTimeDays < 0 {
+		return ErrInvalidLeadTime
+	}
+	return nil
+}
+
+func (u *SupplierUseCase) Create(ctx context.Context, s supplier.Supplier) (int32, error) {
+	if err := validateSupplier(s); err != nil {
+		return 0, err
+	}
+	return u.repo.Create(ctx, s)
+}
+
+func (u *SupplierUseCase) GetByID(ctx context.Context, id int32) (supplier.Supplier, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *SupplierUseCase) Update(ctx context.Context, s supplier.Supplier) error {
+	if err := validateSupplier(s); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, s)
+}
+
+func (u *SupplierUseCase) Delete(ctx context.Context, id int32) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *SupplierUseCase) List(ctx context.Context) ([]supplier.Supplier, error) {
+	return u.repo.List(ctx)
+}
+
+// SetProductSupplier creates or replaces the link between a product and a supplier.
+func (u *SupplierUseCase) SetProductSupplier(ctx context.Context, ps supplier.ProductSupplier) error {
+	if _, err := u.products.GetByID(ctx, ps.ProductID); err != nil {
+		return err
+	}
+	if _, err := u.repo.GetByID(ctx, ps.SupplierID); err != nil {
+		return err
+	}
+	if ps.CostPrice.Amount <= 0 {
+		return ErrInvalidCost
+	}
+	if !money.IsSupported(ps.CostPrice.Currency) {
+		return fmt.Errorf("%w: %q", ErrUnsupportedCurrency, ps.CostPrice.Currency)
+	}
+	if ps.MinOrderQty == 0 {
+		ps.MinOrderQty = 1
+	}
+	if ps.MinOrderQty < 0 {
+		return ErrInvalidMOQ
+	}
+
+	return u.repo.SetProductSupplier(ctx, ps)
+}
+
+func (u *SupplierUseCase) DeleteProductSupplier(ctx context.Context, productID, supplierID int32) error {
+	return u.repo.DeleteProductSupplier(ctx, productID, supplierID)
+}
+
+// ProductSuppliers returns the suppliers of a product, cheapest first and then by
+// lead time. Costs in other currencies are compared after conversion to the product
+// currency; suppliers whose cost cannot be converted go last.
+func (u *SupplierUseCase) ProductSuppliers(ctx context.Context, productID int32) ([]supplier.ProductSupplier, error) {
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := u.repo.ListProductSuppliers(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.rank(ctx, links, p.Price.Currency, newRateCache(time.Now())); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// PreferredSuppliers returns the first supplier of every product that has one,
+// ranked as in ProductSuppliers, by product ID. The links are loaded at once and
+// each exchange rate is looked up once.
+func (u *SupplierUseCase) PreferredSuppliers(ctx context.Context) (map[int32]supplier.ProductSupplier, error) {
+	products, err := u.products.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	links, err := u.repo.ListAllProductSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byProduct := make(map[int32][]supplier.ProductSupplier)
+	for _, link := range links {
+		byProduct[link.ProductID] = append(byProduct[link.ProductID], link)
+	}
+	rates := newRateCache(time.Now())
+	preferred := make(map[int32]supplier.ProductSupplier, len(byProduct))
+	for _, p := range products {
+		links := byProduct[p.ID]
+		if len(links) == 0 {
+			continue
+		}
+		if err := u.rank(ctx, links, p.Price.Currency, rates); err != nil {
+			return nil, err
+		}
+		preferred[p.ID] = links[0]
+	}
+	return preferred, nil
+}
+
+// rank sorts the supplier links of a product, cheapest first and then by lead
+// time, comparing costs in the product currency.
+func (u *SupplierUseCase) rank(ctx context.Context, links []supplier.ProductSupplier, currency string, rates *rateCache) error {
+	for i := range links {
+		cost, err := rates.convert(ctx, u.pricing, links[i].CostPrice, currency)
+		switch {
+		case err == nil:
+			links[i].ComparableCost = &cost
+		case !errors.Is(err, ErrNoExchangeRate):
+			return err
+		}
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		a, b := links[i].ComparableCost, links[j].ComparableCost
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		if a.Amount != b.Amount {
+			return a.Amount < b.Amount
+		}
+		return links[i].LeadTimeDays < links[j].LeadTimeDays
+	})
+	return nil
+}
+
+// rateCache converts amounts with the exchange rates valid at one time, looking
+// up each currency pair once.
+type rateCache struct {
+	at    time.Time
+	rates map[[2]string]*big.Rat
+	errs  map[[2]string]error
+}
+
+func newRateCache(at time.Time) *rateCache {
+	return &rateCache{at: at, rates: make(map[[2]string]*big.Rat), errs: make(map[[2]string]error)}
+}
+
+func (c *rateCache) convert(ctx context.Context, pricing *PricingUseCase, m money.Money, to string) (money.Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+	pair := [2]string{m.Currency, to}
+	if err, ok := c.errs[pair]; ok {
+		return money.Money{}, err
+	}
+	rate, ok := c.rates[pair]
+	if !ok {
+		var err error
+		rate, err = pricing.rate(ctx, m.Currency, to, c.at)
+		if err != nil {
+			c.errs[pair] = err
+			return money.Money{}, err
+		}
+		c.rates[pair] = rate
+	}
+	return m.Convert(rate, to, pricing.rounding)
+}
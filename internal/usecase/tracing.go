@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/Gen1usBruh/warehouse-api/internal/usecase")
+
+// startSpan starts the span of a use case method, named e.g.
+// "ProductUseCase.Create"; the repository calls it makes become its children.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name)
+}
+
+// endSpan ends the span, marking it failed when err is set. Business rule
+// rejections are recorded as events only, since the use case worked as intended.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !IsBusinessError(err) && !IsNotFound(err) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+)
+
+type TransferUseCase struct {
+	repo       transfer.Repository
+	warehouses warehouse.Repository
+	products   product.Repository
+	stock      *StockUseCase
+}
+
+func NewTransferUseCase(r transfer.Repository, warehouses warehouse.Repository, products product.Repository, stock *StockUseCase) *TransferUseCase {
+	return &TransferUseCase{repo: r, warehouses: warehouses, products: products, stock: stock}
+}
+
+var (
+	ErrInvalidTransferStatus = errors.New("unknown transfer status")
+	ErrSameWarehouse         = errors.New("transfer must go to another warehouse")
+	ErrTrackedTransfer       = errors.New("lot-tracked and serialized products cannot be transferred")
+	ErrDiscrepancyReason     = errors.New("a reason is required when the received quantity differs from the shipped one")
+)
+
+// Create saves a draft transfer between two existing warehouses.
+func (u *TransferUseCase) Create(ctx context.Context, t transfer.Transfer) (int32, error) {
+	if len(t.Lines) == 0 {
+		return 0, ErrEmptyOrder
+	}
+	if t.FromWarehouseID == t.ToWarehouseID {
+		return 0, ErrSameWarehouse
+	}
+	for _, id := range []int32{t.FromWarehouseID, t.ToWarehouseID} {
+		if _, err := u.warehouses.Get(ctx, id); err != nil {
+			return 0, fmt.Errorf("%w: %d", err, id)
+		}
+	}
+
+	seen := make(map[int32]bool, len(t.Lines))
+	for _, line := range t.Lines {
+		if seen[line.ProductID] {
+			return 0, fmt.Errorf("%w: product %d", ErrDuplicateLine, line.ProductID)
+		}
+		seen[line.ProductID] = true
+
+		if line.Quantity <= 0 {
+			return 0, fmt.Errorf("%w: must be positive", stock.ErrInvalidQuantity)
+		}
+		p, err := u.products.GetByID(ctx, line.ProductID)
+		if err != nil {
+			return 0, err
+		}
+		if p.Tracked() {
+			return 0, fmt.Errorf("%w: product %d", ErrTrackedTransfer, p.ID)
+		}
+	}
+	return u.repo.Create(ctx, t)
+}
+
+func (u *TransferUseCase) Get(ctx context.Context, id int32) (transfer.Transfer, error) {
+	return u.repo.Get(ctx, id)
+}
+
+func (u *TransferUseCase) List(ctx context.Context, status transfer.Status, warehouseID *int32) ([]transfer.Transfer, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTransferStatus, status)
+	}
+	return u.repo.List(ctx, status, warehouseID)
+}
+
+// Open returns the transfers still to be shipped or received, from or to the
+// warehouse if one is given.
+func (u *TransferUseCase) Open(ctx context.Context, warehouseID *int32) ([]transfer.Transfer, error) {
+	return u.repo.ListOpen(ctx, warehouseID)
+}
+
+// Ship sends every line of a draft transfer: the stock leaves the source
+// warehouse and stays in transit until the transfer is received.
+func (u *TransferUseCase) Ship(ctx context.Context, id int32, s transfer.Shipment) (transfer.Transfer, error) {
+	// The movements are prepared before the transfer is locked, so the
+	// transaction runs no other queries. The lines of a transfer never change;
+	// its status is checked again on the locked transfer.
+	now := time.Now()
+	t, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	if err := shipTransfer(&t, s, now); err != nil {
+		return transfer.Transfer{}, err
+	}
+
+	movements := make([]stock.Movement, 0, len(t.Lines))
+	for _, line := range t.Lines {
+		m, err := u.stock.Prepare(ctx, stock.Movement{
+			ProductID:       line.ProductID,
+			Type:            stock.MovementTransferOut,
+			WarehouseID:     t.FromWarehouseID,
+			EnteredQuantity: strconv.Itoa(int(line.ShippedQuantity)),
+			Reference:       transferReference(t),
+		})
+		if err != nil {
+			return transfer.Transfer{}, fmt.Errorf("line %d: %w", line.ID, err)
+		}
+		movements = append(movements, m)
+	}
+
+	return u.repo.Update(ctx, id, func(t *transfer.Transfer) ([]stock.Movement, error) {
+		if err := shipTransfer(t, s, now); err != nil {
+			return nil, err
+		}
+		return movements, nil
+	})
+}
+
+// shipTransfer marks a draft transfer in transit with every line shipped in full.
+func shipTransfer(t *transfer.Transfer, s transfer.Shipment, now time.Time) error {
+	if t.Status != transfer.StatusDraft {
+		return fmt.Errorf("%w: transfer is %s", transfer.ErrStatus, t.Status)
+	}
+	for i := range t.Lines {
+		t.Lines[i].ShippedQuantity = t.Lines[i].Quantity
+	}
+	t.Status = transfer.StatusInTransit
+	t.Carrier = s.Carrier
+	t.TrackingNumber = s.TrackingNumber
+	t.ShippedAt = &now
+	return nil
+}
+
+// Receive books what arrived into the destination warehouse and completes the
+// transfer. A line received short or in excess keeps the difference as its
+// discrepancy, which needs a reason. The discrepancy is posted as an adjustment
+// at the destination, so goods lost in transit leave stock at their cost and
+// goods found in excess are valued at the current average cost.
+func (u *TransferUseCase) Receive(ctx context.Context, id int32, r transfer.Receipt) (transfer.Transfer, error) {
+	// The movements are prepared before the transfer is locked, as in Ship.
+	now := time.Now()
+	t, err := u.repo.Get(ctx, id)
+	if err != nil {
+		return transfer.Transfer{}, err
+	}
+	if err := receiveTransfer(&t, r, now); err != nil {
+		return transfer.Transfer{}, err
+	}
+
+	movements := receiptMovements(t)
+	for i, m := range movements {
+		if movements[i], err = u.stock.Prepare(ctx, m); err != nil {
+			return transfer.Transfer{}, fmt.Errorf("product %d: %w", m.ProductID, err)
+		}
+	}
+
+	return u.repo.Update(ctx, id, func(t *transfer.Transfer) ([]stock.Movement, error) {
+		if err := receiveTransfer(t, r, now); err != nil {
+			return nil, err
+		}
+		return movements, nil
+	})
+}
+
+// receiptMovements returns the movements that book a received transfer into
+// its destination: the shipped quantity of each line comes out of transit as it
+// left the source, and its discrepancy is adjusted at the destination.
+func receiptMovements(t transfer.Transfer) []stock.Movement {
+	var movements []stock.Movement
+	for _, line := range t.Lines {
+		movements = append(movements, stock.Movement{
+			ProductID:       line.ProductID,
+			Type:            stock.MovementTransferIn,
+			WarehouseID:     t.ToWarehouseID,
+			EnteredQuantity: strconv.Itoa(int(line.ShippedQuantity)),
+			Reference:       transferReference(t),
+		})
+		if line.Discrepancy != 0 {
+			movements = append(movements, stock.Movement{
+				ProductID:       line.ProductID,
+				Type:            stock.MovementAdjustment,
+				WarehouseID:     t.ToWarehouseID,
+				EnteredQuantity: strconv.Itoa(int(line.Discrepancy)),
+				Reference:       transferReference(t),
+			})
+		}
+	}
+	return movements
+}
+
+// receiveTransfer records the received quantities and discrepancies of an
+// in-transit transfer and marks it received.
+func receiveTransfer(t *transfer.Transfer, r transfer.Receipt, now time.Time) error {
+	if t.Status != transfer.StatusInTransit {
+		return fmt.Errorf("%w: transfer is %s", transfer.ErrStatus, t.Status)
+	}
+
+	reasons := make(map[int32]string, len(r.Lines))
+	seen := make(map[int32]bool, len(r.Lines))
+	for _, entry := range r.Lines {
+		if seen[entry.LineID] {
+			return fmt.Errorf("%w: line %d", ErrDuplicateLine, entry.LineID)
+		}
+		seen[entry.LineID] = true
+
+		line := findTransferLine(t.Lines, entry.LineID)
+		if line == nil {
+			return fmt.Errorf("%w: %d", ErrUnknownOrderLine, entry.LineID)
+		}
+		if entry.Quantity < 0 {
+			return fmt.Errorf("%w: must not be negative", stock.ErrInvalidQuantity)
+		}
+		line.ReceivedQuantity = entry.Quantity
+		reasons[line.ID] = entry.Reason
+	}
+
+	for i := range t.Lines {
+		line := &t.Lines[i]
+		line.Discrepancy = line.ReceivedQuantity - line.ShippedQuantity
+		if line.Discrepancy != 0 {
+			if reasons[line.ID] == "" {
+				return fmt.Errorf("%w: line %d shipped %d, received %d", ErrDiscrepancyReason, line.ID, line.ShippedQuantity, line.ReceivedQuantity)
+			}
+			line.DiscrepancyReason = reasons[line.ID]
+		}
+	}
+
+	t.Status = transfer.StatusReceived
+	t.ReceivedAt = &now
+	return nil
+}
+
+// Cancel drops a transfer that has not been shipped yet.
+func (u *TransferUseCase) Cancel(ctx context.Context, id int32) (transfer.Transfer, error) {
+	return u.repo.Update(ctx, id, func(t *transfer.Transfer) ([]stock.Movement, error) {
+		if t.Status != transfer.StatusDraft {
+			return nil, fmt.Errorf("%w: transfer is %s", transfer.ErrStatus, t.Status)
+		}
+		now := time.Now()
+		t.Status = transfer.StatusCancelled
+		t.CancelledAt = &now
+		return nil, nil
+	})
+}
+
+func transferReference(t transfer.Transfer) string {
+	ref := "TR-" + strconv.Itoa(int(t.ID))
+	if t.Reference != "" {
+		ref += " " + t.Reference
+	}
+	return ref
+}
+
+func findTransferLine(lines []transfer.Line, id int32) *transfer.Line {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
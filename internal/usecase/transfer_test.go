@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+func inTransit() transfer.Transfer {
+	return transfer.Transfer{
+		ID:              1,
+		FromWarehouseID: 1,
+		ToWarehouseID:   2,
+		Status:          transfer.StatusInTransit,
+		Lines: []transfer.Line{
+			{ID: 1, ProductID: 10, Quantity: 6, ShippedQuantity: 6},
+			{ID: 2, ProductID: 20, Quantity: 4, ShippedQuantity: 4},
+			{ID: 3, ProductID: 30, Quantity: 2, ShippedQuantity: 2},
+		},
+	}
+}
+
+func TestReceiveTransfer_Discrepancies(t *testing.T) {
+	tr := inTransit()
+	err := receiveTransfer(&tr, transfer.Receipt{Lines: []transfer.ReceivedLine{
+		{LineID: 1, Quantity: 5, Reason: "One broken"},
+		{LineID: 2, Quantity: 4},
+		{LineID: 3, Quantity: 3, Reason: "Picked one too many"},
+	}}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, transfer.StatusReceived, tr.Status)
+
+	type posted struct {
+		product  int32
+		typ      stock.MovementType
+		quantity string
+	}
+	var got []posted
+	for _, m := range receiptMovements(tr) {
+		assert.Equal(t, int32(2), m.WarehouseID)
+		assert.Equal(t, "TR-1", m.Reference)
+		got = append(got, posted{m.ProductID, m.Type, m.EnteredQuantity})
+	}
+	// The shipped quantities come out of transit; the chair lost in transit
+	// leaves stock and the extra lamp enters it.
+	assert.Equal(t, []posted{
+		{10, stock.MovementTransferIn, "6"},
+		{10, stock.MovementAdjustment, "-1"},
+		{20, stock.MovementTransferIn, "4"},
+		{30, stock.MovementTransferIn, "2"},
+		{30, stock.MovementAdjustment, "1"},
+	}, got)
+}
+
+func TestReceiveTransfer_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []transfer.ReceivedLine
+		err   error
+	}{
+		{"discrepancy without a reason", []transfer.ReceivedLine{{LineID: 1, Quantity: 6}, {LineID: 2, Quantity: 4}}, ErrDiscrepancyReason},
+		{"unknown line", []transfer.ReceivedLine{{LineID: 9, Quantity: 1}}, ErrUnknownOrderLine},
+		{"duplicate line", []transfer.ReceivedLine{{LineID: 1, Quantity: 1}, {LineID: 1, Quantity: 1}}, ErrDuplicateLine},
+		{"negative quantity", []transfer.ReceivedLine{{LineID: 1, Quantity: -1}}, stock.ErrInvalidQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := inTransit()
+			err := receiveTransfer(&tr, transfer.Receipt{Lines: tt.lines}, time.Now())
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	tr := inTransit()
+	tr.Status = transfer.StatusReceived
+	err := receiveTransfer(&tr, transfer.Receipt{}, time.Now())
+	assert.ErrorIs(t, err, transfer.ErrStatus)
+}
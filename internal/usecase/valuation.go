@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/valuation"
+)
+
+type ValuationUseCase struct {
+	repo   valuation.Repository
+	method stock.CostMethod
+}
+
+func NewValuationUseCase(r valuation.Repository, method stock.CostMethod) *ValuationUseCase {
+	return &ValuationUseCase{repo: r, method: method}
+}
+
+// Report values the stock of every product as of the given time and totals the
+// values per currency. Products are valued in their own currency.
+func (u *ValuationUseCase) Report(ctx context.Context, asOf time.Time) (valuation.Report, error) {
+	products, err := u.repo.ProductValues(ctx, asOf)
+	if err != nil {
+		return valuation.Report{}, err
+	}
+
+	totals := make(map[string]int64)
+	for i, p := range products {
+		products[i].UnitCost = money.Money{Amount: stock.AverageCost(p.Quantity, p.Value.Amount), Currency: p.Value.Currency}
+		totals[p.Value.Currency] += p.Value.Amount
+	}
+
+	r := valuation.Report{AsOf: asOf, Method: u.method, Totals: []money.Money{}, Products: products}
+	for currency, amount := range totals {
+		r.Totals = append(r.Totals, money.Money{Amount: amount, Currency: currency})
+	}
+	sort.Slice(r.Totals, func(i, j int) bool { return r.Totals[i].Currency < r.Totals[j].Currency })
+	if r.Products == nil {
+		r.Products = []valuation.ProductValue{}
+	}
+	return r, nil
+}
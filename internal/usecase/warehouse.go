@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+)
+
+type WarehouseUseCase struct {
+	repo warehouse.Repository
+}
+
+func NewWarehouseUseCase(r warehouse.Repository) *WarehouseUseCase {
+	return &WarehouseUseCase{repo: r}
+}
+
+func (u *WarehouseUseCase) Create(ctx context.Context, w warehouse.Warehouse) (int32, error) {
+	return u.repo.Create(ctx, w)
+}
+
+func (u *WarehouseUseCase) Get(ctx context.Context, id int32) (warehouse.Warehouse, error) {
+	return u.repo.Get(ctx, id)
+}
+
+func (u *WarehouseUseCase) List(ctx context.Context) ([]warehouse.Warehouse, error) {
+	return u.repo.List(ctx)
+}
+
+// Stock returns what a warehouse has on hand and what is in transit to it.
+func (u *WarehouseUseCase) Stock(ctx context.Context, id int32) ([]warehouse.Stock, error) {
+	if _, err := u.repo.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.repo.Stock(ctx, id)
+}
@@ -3,48 +3,190 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Gen1usBruh/warehouse-api/internal/app"
 	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/graphqlapi"
+	"github.com/Gen1usBruh/warehouse-api/internal/grpcapi"
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
 	"github.com/Gen1usBruh/warehouse-api/internal/logger/sl"
+	"github.com/Gen1usBruh/warehouse-api/internal/metrics"
 	"github.com/Gen1usBruh/warehouse-api/internal/rest"
 	"github.com/Gen1usBruh/warehouse-api/internal/scope"
 	"github.com/Gen1usBruh/warehouse-api/internal/storage/postgres"
-	postgresdb "github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/sqlc"
 	"github.com/Gen1usBruh/warehouse-api/internal/storage/postgres/repo"
+	"github.com/Gen1usBruh/warehouse-api/internal/tracing"
 	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 )
 
 func main() {
 	if err := godotenv.Load(); err != nil {
-        log.Fatalf("No .env file found: %v\n", err)
-    }
+		log.Fatalf("No .env file found: %v\n", err)
+	}
 	conf, err := config.New()
 	if err != nil {
 		log.Fatalf("Could not create config: %v\n", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(&conf.Database, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v\n", err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), conf.Tracing)
+	if err != nil {
+		log.Fatalf("Invalid tracing config: %v\n", err)
+	}
+
+	if conf.Database.AutoMigrate {
+		if err := autoMigrate(&conf.Database); err != nil {
+			log.Fatalf("Could not migrate postgres: %v\n", err)
+		}
+	}
+
 	conn, err := postgres.ConnectDB(&conf.Database)
 	if err != nil {
 		log.Fatalf("Could not connect to postgres: %v\n", err)
 	}
 
-	qConn := postgresdb.New(conn)
-	productRepo := repo.NewProductRepo(qConn)
+	rounding, err := money.ParseRoundingMode(conf.Pricing.Rounding)
+	if err != nil {
+		log.Fatalf("Invalid pricing config: %v\n", err)
+	}
+
+	tolerance := purchase.Tolerance{
+		OverPct:  conf.Purchasing.OverReceiptTolerance,
+		UnderPct: conf.Purchasing.UnderReceiptTolerance,
+	}
+	if tolerance.OverPct < 0 || tolerance.UnderPct < 0 || tolerance.UnderPct > 100 {
+		log.Fatalf("Invalid purchasing config: receipt tolerances must not be negative and under-receipt tolerance must not exceed 100 percent\n")
+	}
+
+	costMethod := stock.CostMethod(conf.Valuation.Method)
+	if !costMethod.Valid() {
+		log.Fatalf("Invalid valuation config: method must be one of fifo, lifo, average\n")
+	}
+
+	forecasting := forecast.Settings{
+		Method:              forecast.Method(conf.Forecasting.Method),
+		PeriodDays:          conf.Forecasting.PeriodDays,
+		HistoryPeriods:      conf.Forecasting.HistoryPeriods,
+		Window:              conf.Forecasting.Window,
+		Alpha:               conf.Forecasting.Alpha,
+		SeasonLength:        conf.Forecasting.SeasonLength,
+		ServiceLevel:        conf.Forecasting.ServiceLevel,
+		ReviewDays:          conf.Forecasting.ReviewDays,
+		DefaultLeadTimeDays: conf.Forecasting.DefaultLeadTimeDays,
+	}
+	if err := forecasting.Validate(); err != nil {
+		log.Fatalf("Invalid forecasting config: %v\n", err)
+	}
+
+	if conf.GraphQL.MaxComplexity <= 0 || conf.GraphQL.MaxDepth <= 0 {
+		log.Fatalf("Invalid GraphQL config: max complexity and max depth must be positive\n")
+	}
+
+	if conf.Counting.ApprovalThreshold < 0 {
+		log.Fatalf("Invalid counting config: approval threshold must not be negative\n")
+	}
+
+	if !money.IsSupported(conf.Reporting.Currency) {
+		log.Fatalf("Invalid reporting config: currency must be one of %s\n", strings.Join(money.Currencies(), ", "))
+	}
+
+	if conf.Idempotency.TTL <= 0 || conf.Idempotency.LockTimeout <= 0 || conf.Idempotency.CleanupInterval <= 0 {
+		log.Fatalf("Invalid idempotency config: TTL, lock timeout and cleanup interval must be positive\n")
+	}
+
+	productRepo := repo.NewProductRepo(conn)
 	productUC := usecase.NewProductUseCase(productRepo)
+	pricingUC := usecase.NewPricingUseCase(repo.NewPricingRepo(conn), productRepo, rounding)
+	stockUC := usecase.NewStockUseCase(repo.NewStockRepo(conn), productRepo, costMethod)
+	supplierRepo := repo.NewSupplierRepo(conn)
+	supplierUC := usecase.NewSupplierUseCase(supplierRepo, productRepo, pricingUC)
+	purchaseUC := usecase.NewPurchaseUseCase(repo.NewPurchaseRepo(conn), supplierRepo, productRepo, stockUC, pricingUC, tolerance)
+	salesUC := usecase.NewSalesUseCase(repo.NewSalesRepo(conn), productRepo, pricingUC, stockUC)
+	returnUC := usecase.NewReturnUseCase(repo.NewReturnRepo(conn), stockUC)
+	warehouseRepo := repo.NewWarehouseRepo(conn)
+	warehouseUC := usecase.NewWarehouseUseCase(warehouseRepo)
+	transferUC := usecase.NewTransferUseCase(repo.NewTransferRepo(conn), warehouseRepo, productRepo, stockUC)
+	reportUC := usecase.NewReportUseCase(repo.NewReportRepo(conn), pricingUC, conf.Reporting.Currency)
+	countUC := usecase.NewCountUseCase(repo.NewCountRepo(conn), warehouseRepo, stockUC, reportUC, conf.Counting.ApprovalThreshold)
+	valuationUC := usecase.NewValuationUseCase(repo.NewValuationRepo(conn), costMethod)
+	replenishmentUC := usecase.NewReplenishmentUseCase(repo.NewForecastRepo(conn), supplierUC, forecasting)
+	idempotencyUC := usecase.NewIdempotencyUseCase(repo.NewIdempotencyRepo(conn), conf.Idempotency.TTL, conf.Idempotency.LockTimeout)
+
+	if conf.Pricing.ExchangeRatesFile != "" {
+		if err := loadExchangeRates(pricingUC, conf.Pricing.ExchangeRatesFile); err != nil {
+			log.Fatalf("Could not load exchange rates: %v\n", err)
+		}
+	}
+
+	logger, err := sl.SetupLogger(&conf.Logger)
+	if err != nil {
+		log.Fatalf("Invalid logger config: %v\n", err)
+	}
+	slog.SetDefault(logger)
 
-	restServer := rest.NewHandler(rest.HandlerConfig{
-		Dep: &scope.Dependencies{
-			Sl: sl.SetupLogger(&conf.Logger),
-			Product: productUC,
-		},
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go pricingUC.RunScheduler(schedulerCtx, conf.Pricing.SchedulerInterval, logger)
+	go idempotencyUC.RunCleanup(schedulerCtx, conf.Idempotency.CleanupInterval, logger)
+
+	appMetrics := metrics.New()
+	if err := appMetrics.Register(metrics.NewPoolCollector(conn)); err != nil {
+		log.Fatalf("Could not register pool metrics: %v\n", err)
+	}
+
+	dep := &scope.Dependencies{
+		Sl:            logger,
+		Metrics:       appMetrics,
+		Product:       productUC,
+		Pricing:       pricingUC,
+		Stock:         stockUC,
+		Supplier:      supplierUC,
+		Purchase:      purchaseUC,
+		Sales:         salesUC,
+		Return:        returnUC,
+		Warehouse:     warehouseUC,
+		Transfer:      transferUC,
+		Count:         countUC,
+		Valuation:     valuationUC,
+		Report:        reportUC,
+		Replenishment: replenishmentUC,
+		Idempotency:   idempotencyUC,
+	}
+	graphqlServer, err := graphqlapi.NewServer(dep, graphqlapi.Limits{
+		MaxComplexity: conf.GraphQL.MaxComplexity,
+		MaxDepth:      conf.GraphQL.MaxDepth,
 	})
+	if err != nil {
+		log.Fatalf("Could not build GraphQL schema: %v\n", err)
+	}
+	checker := health.New(2*time.Second,
+		health.Check{Name: "postgres", Run: conn.Ping},
+		health.Check{Name: "migrations", Run: func(ctx context.Context) error {
+			return postgres.CheckMigrations(ctx, conn)
+		}},
+	)
+	restServer := rest.NewHandler(rest.HandlerConfig{Dep: dep, GraphQL: graphqlServer, Health: checker})
 
 	server, err := app.NewApp(conf.Server, restServer)
 	if err != nil {
@@ -57,18 +199,83 @@ func main() {
 		}
 	}()
 
+	var adminServer *http.Server
+	if conf.Admin.Address != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", appMetrics.Handler())
+		adminServer = &http.Server{Addr: conf.Admin.Address, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Admin server ListenAndServe: %v", err)
+			}
+		}()
+	}
+
+	var grpcServer *grpc.Server
+	if conf.GRPC.Address != "" {
+		lis, err := net.Listen("tcp", conf.GRPC.Address)
+		if err != nil {
+			log.Fatalf("Unable to start gRPC server: %v\n", err)
+		}
+		grpcServer = grpcapi.NewServer(dep)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server Serve: %v", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	<-quit
 	log.Println("Shutting down server gracefully...")
+	// Fail readiness first so load balancers stop sending traffic before the
+	// server stops accepting it.
+	checker.Drain()
+	time.Sleep(conf.Server.DrainDelay)
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	var wg sync.WaitGroup
+	if grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			grpcapi.GracefulStop(ctx, grpcServer)
+		}()
+	}
+	if adminServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			adminServer.Shutdown(ctx)
+		}()
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	wg.Wait()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Could not flush traces: %v\n", err)
+	}
 
 	log.Println("Server exited gracefully")
 }
+
+func loadExchangeRates(uc *usecase.PricingUseCase, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	n, err := uc.LoadExchangeRates(context.Background(), f)
+	if err != nil {
+		return err
+	}
+	log.Printf("Loaded %d exchange rates from %s\n", n, path)
+	return nil
+}
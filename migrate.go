@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/config"
+	"github.com/Gen1usBruh/warehouse-api/internal/storage/postgres"
+)
+
+const migrateUsage = "usage: warehouse-api migrate up | down [N] | status | goto N"
+
+// runMigrate handles the migrate subcommand against the configured database.
+func runMigrate(dbConfig *config.Database, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	m, err := postgres.NewMigrator(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+		if err := m.Up(); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		} else if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+		if err := m.Down(steps); err != nil {
+			return err
+		}
+	case "goto":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := m.Goto(uint(version)); err != nil {
+			return err
+		}
+	case "status":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version %d of %d", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Print(" (dirty: the last migration failed, fix it and goto a clean version)")
+	}
+	fmt.Println()
+	return nil
+}
+
+func autoMigrate(dbConfig *config.Database) error {
+	m, err := postgres.NewMigrator(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Up()
+}
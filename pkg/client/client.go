@@ -0,0 +1,281 @@
+// Package client is a Go client for the warehouse REST API, with a typed method
+// for every route.
+//
+// Failed calls return an *Error; business rule failures match the rule errors
+// of this package with errors.Is. Calls are retried with exponential backoff on
+// network errors and 429, 502, 503 and 504 responses. POST calls send an
+// Idempotency-Key header, so a retry of a request that was already served gets
+// the first response instead of being served twice.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the warehouse REST API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	userAgent  string
+	retry      Retry
+}
+
+// Retry configures how calls are retried.
+type Retry struct {
+	// MaxAttempts is the number of tries including the first; 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on every retry,
+	// with jitter, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetry tries calls up to three times.
+var DefaultRetry = Retry{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken sends the token as a bearer token to the authenticating proxy in
+// front of the API.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+func WithRetry(r Retry) Option {
+	return func(c *Client) {
+		c.retry = r
+	}
+}
+
+// New returns a client for the API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  "warehouse-api-client",
+		retry:      DefaultRetry,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.retry.MaxAttempts = max(c.retry.MaxAttempts, 1)
+	return c, nil
+}
+
+// dataResponse is the {"data": ...} envelope of successful responses.
+type dataResponse[T any] struct {
+	Data T `json:"data"`
+}
+
+// idResponse is the response of create routes.
+type idResponse struct {
+	ID int32 `json:"id"`
+}
+
+// rawBody is a request body sent as is.
+type rawBody struct {
+	contentType string
+	r           io.Reader
+}
+
+// do sends a request and decodes a successful response into out, which may be
+// nil. A rawBody is sent as is, any other body as JSON.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// send sends a request, retrying it unless its body is a stream that cannot be
+// sent again, and returns the last response.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	attempts := c.retry.MaxAttempts
+	if _, raw := body.(rawBody); raw {
+		attempts = 1
+	}
+	return c.sendAttempts(ctx, attempts, method, path, query, body)
+}
+
+// sendAttempts sends a request up to attempts times while it fails for a
+// retryable reason.
+func (c *Client) sendAttempts(ctx context.Context, attempts int, method, path string, query url.Values, body any) (*http.Response, error) {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var (
+		data        []byte
+		stream      io.Reader
+		contentType string
+	)
+	switch b := body.(type) {
+	case nil:
+	case rawBody:
+		stream, contentType = b.r, b.contentType
+	default:
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("encoding %s %s request: %w", method, path, err)
+		}
+		contentType = "application/json"
+	}
+	// Every try of a POST request carries the same key, so the server serves
+	// it once however many tries reach it.
+	var idempotencyKey string
+	if method == http.MethodPost {
+		idempotencyKey = newIdempotencyKey()
+	}
+
+	for attempt := 1; ; attempt++ {
+		reader := stream
+		if data != nil {
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if attempt == attempts || !retryable(ctx, resp, err, idempotencyKey != "") {
+			return resp, err
+		}
+		delay := c.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether a call failed for a reason a later try may not
+// hit: a network error, throttling, an unavailable server or, for a request
+// with an idempotency key, an earlier try of it still being served.
+func retryable(ctx context.Context, resp *http.Response, err error, keyed bool) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		return keyed
+	}
+	return false
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// backoff returns the wait before the next try: the Retry-After the server
+// asked for, or an exponential delay with full jitter.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+			return min(time.Duration(s)*time.Second, c.retry.MaxDelay)
+		}
+	}
+	d := c.retry.BaseDelay << (attempt - 1)
+	if d <= 0 || d > c.retry.MaxDelay {
+		d = c.retry.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(mathrand.Int64N(int64(d))) + d/2
+}
+
+// pathf formats a path with escaped string arguments.
+func pathf(format string, args ...any) string {
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			args[i] = url.PathEscape(s)
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// setID sets an optional ID query parameter.
+func setID(query url.Values, key string, id *int32) {
+	if id != nil {
+		query.Set(key, strconv.Itoa(int(*id)))
+	}
+}
+
+// setTime sets an optional time query parameter.
+func setTime(query url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		query.Set(key, t.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/rest"
+	"github.com/Gen1usBruh/warehouse-api/internal/scope"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockProductRepo struct {
+	products map[int32]product.Product
+	nextID   int32
+}
+
+func (m *mockProductRepo) Create(ctx context.Context, p product.Product) (int32, error) {
+	m.nextID++
+	p.ID = m.nextID
+	m.products[p.ID] = p
+	return p.ID, nil
+}
+
+func (m *mockProductRepo) GetByID(ctx context.Context, id int32) (product.Product, error) {
+	p, ok := m.products[id]
+	if !ok {
+		return product.Product{}, product.ErrNotFound
+	}
+	return p, nil
+}
+
+func (m *mockProductRepo) Update(ctx context.Context, id int32, fn func(p *product.Product) error) error {
+	p, ok := m.products[id]
+	if !ok {
+		return product.ErrNotFound
+	}
+	if err := fn(&p); err != nil {
+		return err
+	}
+	m.products[id] = p
+	return nil
+}
+
+func (m *mockProductRepo) Delete(ctx context.Context, id int32) error {
+	if _, ok := m.products[id]; !ok {
+		return product.ErrNotFound
+	}
+	delete(m.products, id)
+	return nil
+}
+
+func (m *mockProductRepo) List(ctx context.Context) ([]product.Product, error) {
+	var list []product.Product
+	for _, p := range m.products {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (m *mockProductRepo) Search(ctx context.Context, f product.Filter) ([]product.Product, int32, error) {
+	list, _ := m.List(ctx)
+	total := int32(len(list))
+	return list[min(f.Offset, total):min(f.Offset+f.Limit, total)], total, nil
+}
+
+// mockStockRepo keeps movements of untracked products in base units only.
+type mockStockRepo struct {
+	products  *mockProductRepo
+	movements []stock.Movement
+}
+
+func (m *mockStockRepo) ListUnits(ctx context.Context, productID int32) ([]stock.Unit, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) SetUnit(ctx context.Context, u stock.Unit) error { return nil }
+func (m *mockStockRepo) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	return nil
+}
+func (m *mockStockRepo) ListLots(ctx context.Context, productID int32) ([]stock.Lot, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) FindLot(ctx context.Context, productID int32, number string) (stock.Lot, error) {
+	return stock.Lot{}, stock.ErrLotNotFound
+}
+func (m *mockStockRepo) CreateLot(ctx context.Context, l stock.Lot) (stock.Lot, error) {
+	return l, nil
+}
+func (m *mockStockRepo) ListExpiringLots(ctx context.Context, before time.Time) ([]stock.Lot, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) FindSerials(ctx context.Context, numbers []string) ([]stock.Serial, error) {
+	return nil, nil
+}
+func (m *mockStockRepo) GetSerial(ctx context.Context, number string) (stock.Serial, error) {
+	return stock.Serial{}, stock.ErrSerialNotFound
+}
+func (m *mockStockRepo) ListSerialMovements(ctx context.Context, serialID int32) ([]stock.Movement, error) {
+	return nil, nil
+}
+
+func (m *mockStockRepo) ApplyMovement(ctx context.Context, mv stock.Movement) (stock.Movement, error) {
+	p := m.products.products[mv.ProductID]
+	if p.Quantity+mv.Quantity < 0 {
+		return stock.Movement{}, stock.ErrInsufficientStock
+	}
+	p.Quantity += mv.Quantity
+	m.products.products[p.ID] = p
+	mv.ID = int32(len(m.movements) + 1)
+	mv.CreatedAt = time.Now()
+	m.movements = append(m.movements, mv)
+	return mv, nil
+}
+
+func (m *mockStockRepo) ListMovements(ctx context.Context, productID int32) ([]stock.Movement, error) {
+	var list []stock.Movement
+	for i := len(m.movements) - 1; i >= 0; i-- {
+		if m.movements[i].ProductID == productID {
+			list = append(list, m.movements[i])
+		}
+	}
+	return list, nil
+}
+
+func (m *mockStockRepo) ListRecentMovements(ctx context.Context, productIDs []int32, limit int32) ([]stock.Movement, error) {
+	return nil, nil
+}
+
+// setupServer serves the REST API over HTTP, with handler wrapping the Gin
+// engine when it is not nil, and returns a client for it.
+func setupServer(t *testing.T, handler func(http.Handler) http.Handler, opts ...Option) *Client {
+	gin.SetMode(gin.TestMode)
+	products := &mockProductRepo{products: make(map[int32]product.Product)}
+	var h http.Handler = rest.NewHandler(rest.HandlerConfig{Dep: &scope.Dependencies{
+		Product: usecase.NewProductUseCase(products),
+		Stock:   usecase.NewStockUseCase(&mockStockRepo{products: products}, products, stock.CostFIFO),
+		Sl:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}})
+	if handler != nil {
+		h = handler(h)
+	}
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, append([]Option{WithRetry(Retry{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})}, opts...)...)
+	assert.NoError(t, err)
+	return c
+}
+
+func chair(t *testing.T) ProductInput {
+	price, err := ParseMoney("129.90", "USD")
+	assert.NoError(t, err)
+	return ProductInput{Name: "Office chair", Description: "Black", Price: price, Quantity: 4}
+}
+
+func TestProducts(t *testing.T) {
+	c := setupServer(t, nil)
+	ctx := context.TODO()
+
+	id, err := c.CreateProduct(ctx, chair(t))
+	assert.NoError(t, err)
+
+	p, err := c.GetProduct(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Office chair", p.Name)
+	assert.Equal(t, "129.90 USD", p.Price.String())
+	assert.Equal(t, "pcs", p.BaseUnit)
+
+	in := chair(t)
+	in.Name = "Desk chair"
+	assert.NoError(t, c.UpdateProduct(ctx, id, in))
+
+	list, err := c.ListProducts(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "Desk chair", list[0].Name)
+	}
+
+	assert.NoError(t, c.DeleteProduct(ctx, id))
+	_, err = c.GetProduct(ctx, id)
+	assert.True(t, IsNotFound(err))
+	var apiErr *Error
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+		assert.NotEmpty(t, apiErr.RequestID)
+	}
+}
+
+func TestProducts_BusinessRule(t *testing.T) {
+	c := setupServer(t, nil)
+
+	in := chair(t)
+	in.Price, _ = ParseMoney("20000", "USD")
+	_, err := c.CreateProduct(context.TODO(), in)
+	assert.ErrorIs(t, err, ErrPriceLimit)
+	rule, ok := BusinessRule(err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrPriceLimit, rule)
+	assert.False(t, IsNotFound(err))
+}
+
+func TestStock(t *testing.T) {
+	c := setupServer(t, nil)
+	ctx := context.TODO()
+
+	id, err := c.CreateProduct(ctx, chair(t))
+	assert.NoError(t, err)
+
+	m, err := c.ReceiveStock(ctx, id, MovementInput{Quantity: "6", Reference: "DN-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), m.Quantity)
+
+	_, err = c.IssueStock(ctx, id, MovementInput{Quantity: "11"})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	rule, ok := BusinessRule(err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrInsufficientStock, rule)
+
+	movements, err := c.ListStockMovements(ctx, id)
+	assert.NoError(t, err)
+	assert.Len(t, movements, 1)
+
+	_, err = c.GetSerial(ctx, "SN 1/2")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestErrorIs(t *testing.T) {
+	err := &Error{StatusCode: http.StatusBadRequest, Message: "line 2: cost price must be greater than zero"}
+	assert.ErrorIs(t, err, ErrInvalidCost)
+	assert.NotErrorIs(t, err, ErrInvalidPrice)
+
+	err = &Error{StatusCode: http.StatusBadRequest, Message: "invalid quantity: must not be negative"}
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+
+	err = &Error{StatusCode: http.StatusInternalServerError, Message: "insufficient stock"}
+	assert.NotErrorIs(t, err, ErrInsufficientStock)
+	_, ok := BusinessRule(err)
+	assert.False(t, ok)
+}
+
+// unavailable answers the first n requests with 503 and counts all requests.
+// keys records the Idempotency-Key header of every request when it is not nil.
+func unavailable(n int32, calls *atomic.Int32, keys *[]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if keys != nil {
+				*keys = append(*keys, r.Header.Get("Idempotency-Key"))
+			}
+			if calls.Add(1) <= n {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+	var keys []string
+	c := setupServer(t, unavailable(2, &calls, &keys))
+
+	_, err := c.ListProducts(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []string{"", "", ""}, keys)
+
+	calls.Store(0)
+	keys = nil
+	c = setupServer(t, unavailable(2, &calls, &keys))
+	_, err = c.CreateProduct(context.TODO(), chair(t))
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 3) {
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1], "every try sends the same key")
+		assert.Equal(t, keys[0], keys[2])
+	}
+
+	first := keys[0]
+	calls.Store(0)
+	keys = nil
+	_, err = c.CreateProduct(context.TODO(), chair(t))
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, keys[0], "every call has its own key")
+
+	var apiErr *Error
+	calls.Store(0)
+	c = setupServer(t, unavailable(100, &calls, nil))
+	_, err = c.GetProduct(context.TODO(), 1)
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	}
+	assert.Equal(t, int32(3), calls.Load(), "gives up after MaxAttempts")
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	var calls atomic.Int32
+	c := setupServer(t, unavailable(100, &calls, nil), WithRetry(Retry{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: time.Minute}))
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.ListProducts(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(1), calls.Load())
+}
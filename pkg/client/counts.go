@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CountInput selects the products of a cycle count; zero fields match all
+// products of the warehouse.
+type CountInput struct {
+	// WarehouseID is the default warehouse if zero.
+	WarehouseID int32 `json:"warehouse_id,omitempty"`
+	// BinLocation is a bin location prefix, e.g. "A-03".
+	BinLocation string `json:"bin_location,omitempty"`
+	Category    string `json:"category,omitempty"`
+	ABCClass    string `json:"abc_class,omitempty"`
+	// Blind hides system quantities from counters while the count is open.
+	Blind bool `json:"blind"`
+}
+
+// CountLineInput is the quantity counted for a line of a count sheet.
+type CountLineInput struct {
+	LineID          int32 `json:"line_id"`
+	CountedQuantity int32 `json:"counted_quantity"`
+}
+
+// CreateCount generates a count sheet and returns the ID of the count session.
+func (c *Client) CreateCount(ctx context.Context, in CountInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/counts", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListCounts returns count sessions without lines, newest first; an empty
+// status matches all sessions.
+func (c *Client) ListCounts(ctx context.Context, status string) ([]CountSession, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", status)
+	}
+	var resp dataResponse[[]CountSession]
+	err := c.do(ctx, http.MethodGet, "/counts", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetCount returns a count session with its count sheet ordered by bin location.
+func (c *Client) GetCount(ctx context.Context, id int32) (CountSession, error) {
+	var resp dataResponse[CountSession]
+	err := c.do(ctx, http.MethodGet, pathf("/counts/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// RecordCounts saves counted quantities; a line can be counted again until the
+// session is submitted.
+func (c *Client) RecordCounts(ctx context.Context, id int32, lines []CountLineInput) (CountSession, error) {
+	body := struct {
+		Lines []CountLineInput `json:"lines"`
+	}{lines}
+	var resp dataResponse[CountSession]
+	err := c.do(ctx, http.MethodPut, pathf("/counts/%d/lines", id), nil, body, &resp)
+	return resp.Data, err
+}
+
+// SubmitCount closes counting. Without variances above the approval threshold
+// the adjustments are posted at once; otherwise the count waits for approval.
+func (c *Client) SubmitCount(ctx context.Context, id int32) (CountSession, error) {
+	return c.countAction(ctx, id, "submit", nil)
+}
+
+// ApproveCount approves a count waiting for approval and posts its adjustments.
+func (c *Client) ApproveCount(ctx context.Context, id int32, approvedBy string) (CountSession, error) {
+	body := struct {
+		ApprovedBy string `json:"approved_by"`
+	}{approvedBy}
+	return c.countAction(ctx, id, "approve", body)
+}
+
+// CancelCount cancels a count that has not been approved; stock is not changed.
+func (c *Client) CancelCount(ctx context.Context, id int32) (CountSession, error) {
+	return c.countAction(ctx, id, "cancel", nil)
+}
+
+func (c *Client) countAction(ctx context.Context, id int32, action string, body any) (CountSession, error) {
+	var resp dataResponse[CountSession]
+	err := c.do(ctx, http.MethodPost, pathf("/counts/%d/%s", id, action), nil, body, &resp)
+	return resp.Data, err
+}
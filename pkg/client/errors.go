@@ -0,0 +1,223 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/usecase"
+)
+
+// Error is a request the API rejected, decoded from its BaseResponse body.
+// Business rule failures match the rule errors below with errors.Is, e.g.
+// errors.Is(err, client.ErrInsufficientStock), and 404 responses match
+// ErrNotFound.
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Message is the error the API returned; for business rule failures it
+	// names the rule, e.g. "insufficient stock".
+	Message string
+	// Code is the errorCode of the response, which repeats the status.
+	Code int
+	// RequestID correlates the call with the server logs.
+	RequestID string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("warehouse api: %d %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the response is a 404 for ErrNotFound, or a 400 or 409
+// naming the business rule target.
+func (e *Error) Is(target error) bool {
+	if target == ErrNotFound {
+		return e.StatusCode == http.StatusNotFound
+	}
+	return (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusConflict) && hasRule(e.Message, target.Error())
+}
+
+// hasRule reports whether one of the ": "-separated parts the server wraps rule
+// errors in starts with rule, such as "line 3: invalid quantity: must not be
+// negative" or "price exceeds maximum allowed value of 10000.00 USD".
+func hasRule(msg, rule string) bool {
+	for {
+		if rest, ok := strings.CutPrefix(msg, rule); ok && (rest == "" || rest[0] == ':' || rest[0] == ' ') {
+			return true
+		}
+		var ok bool
+		if _, msg, ok = strings.Cut(msg, ": "); !ok {
+			return false
+		}
+	}
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// BusinessRule returns the rule error a 400 or 409 response names, if any.
+func BusinessRule(err error) (error, bool) {
+	for _, rule := range businessRules {
+		if errors.Is(err, rule) {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// ErrNotFound matches responses for records that do not exist.
+var ErrNotFound = errors.New("not found")
+
+// Business rules the API enforces; they are the errors of the server itself.
+var (
+	// Products and pricing.
+	ErrPriceLimit            = usecase.ErrPriceLimit
+	ErrInvalidPrice          = usecase.ErrInvalidPrice
+	ErrUnsupportedCurrency   = usecase.ErrUnsupportedCurrency
+	ErrNameIsReserved        = usecase.ErrNameIsReserved
+	ErrQuantityLimit         = usecase.ErrQuantityLimit
+	ErrTrackingChange        = usecase.ErrTrackingChange
+	ErrCurrencyChange        = usecase.ErrCurrencyChange
+	ErrQuantityManaged       = usecase.ErrQuantityManaged
+	ErrProductInUse          = product.ErrInUse
+	ErrInvalidPage           = usecase.ErrInvalidPage
+	ErrInvalidValidity       = usecase.ErrInvalidValidity
+	ErrCurrencyMismatch      = usecase.ErrCurrencyMismatch
+	ErrNoExchangeRate        = usecase.ErrNoExchangeRate
+	ErrInvalidRatesFile      = usecase.ErrInvalidRatesFile
+	ErrScheduleInPast        = usecase.ErrScheduleInPast
+	ErrPriceChangeNotPending = usecase.ErrPriceChangeNotPending
+
+	// Stock, units, lots and serial numbers.
+	ErrInsufficientStock  = stock.ErrInsufficientStock
+	ErrInvalidQuantity    = stock.ErrInvalidQuantity
+	ErrFractionalQuantity = stock.ErrFractionalQuantity
+	ErrInvalidFactor      = stock.ErrInvalidFactor
+	ErrUnknownUnit        = usecase.ErrUnknownUnit
+	ErrBaseUnitConflict   = usecase.ErrBaseUnitConflict
+	ErrInvalidRounding    = usecase.ErrInvalidRounding
+	ErrLotExpired         = usecase.ErrLotExpired
+	ErrLotRequired        = usecase.ErrLotRequired
+	ErrNotLotTracked      = usecase.ErrNotLotTracked
+	ErrLotDatesMismatch   = usecase.ErrLotDatesMismatch
+	ErrSerialCount        = usecase.ErrSerialCount
+	ErrDuplicateSerial    = usecase.ErrDuplicateSerial
+	ErrSerialProduct      = usecase.ErrSerialProduct
+	ErrNotSerialized      = usecase.ErrNotSerialized
+	ErrSerialStatus       = stock.ErrSerialStatus
+	ErrUnitCostOutgoing   = usecase.ErrUnitCostOutgoing
+	ErrCostCurrency       = usecase.ErrCostCurrency
+	ErrInvalidUnitCost    = usecase.ErrInvalidUnitCost
+
+	// Suppliers and purchasing.
+	ErrInvalidLeadTime     = usecase.ErrInvalidLeadTime
+	ErrInvalidCost         = usecase.ErrInvalidCost
+	ErrInvalidMOQ          = usecase.ErrInvalidMOQ
+	ErrSupplierInUse       = supplier.ErrInUse
+	ErrEmptyOrder          = usecase.ErrEmptyOrder
+	ErrDuplicateLine       = usecase.ErrDuplicateLine
+	ErrMixedCurrencies     = usecase.ErrMixedCurrencies
+	ErrNoSupplierCost      = usecase.ErrNoSupplierCost
+	ErrBelowMOQ            = usecase.ErrBelowMOQ
+	ErrUnknownOrderLine    = usecase.ErrUnknownOrderLine
+	ErrOverReceipt         = usecase.ErrOverReceipt
+	ErrInvalidStatus       = usecase.ErrInvalidStatus
+	ErrInvalidPeriod       = usecase.ErrInvalidPeriod
+	ErrPurchaseOrderStatus = purchase.ErrStatus
+
+	// Sales orders and returns.
+	ErrInvalidSalesStatus   = usecase.ErrInvalidSalesStatus
+	ErrNoPackages           = usecase.ErrNoPackages
+	ErrPackMismatch         = usecase.ErrPackMismatch
+	ErrTrackingRequired     = usecase.ErrTrackingRequired
+	ErrSalesOrderStatus     = sales.ErrStatus
+	ErrInvalidReturnStatus  = usecase.ErrInvalidReturnStatus
+	ErrReturnExceedsShipped = usecase.ErrReturnExceedsShipped
+	ErrInvalidDisposition   = usecase.ErrInvalidDisposition
+	ErrDispositionMismatch  = usecase.ErrDispositionMismatch
+	ErrSerialNotReturned    = usecase.ErrSerialNotReturned
+	ErrSerialNotShipped     = usecase.ErrSerialNotShipped
+	ErrSerialReturned       = usecase.ErrSerialReturned
+	ErrNothingToRelease     = usecase.ErrNothingToRelease
+	ErrReleaseDisposition   = usecase.ErrReleaseDisposition
+	ErrExceedsQuarantined   = usecase.ErrExceedsQuarantined
+	ErrReturnStatus         = rma.ErrStatus
+
+	// Warehouses, transfers and cycle counts.
+	ErrWarehouseCodeTaken    = warehouse.ErrCodeTaken
+	ErrInvalidTransferStatus = usecase.ErrInvalidTransferStatus
+	ErrSameWarehouse         = usecase.ErrSameWarehouse
+	ErrTrackedTransfer       = usecase.ErrTrackedTransfer
+	ErrDiscrepancyReason     = usecase.ErrDiscrepancyReason
+	ErrTransferStatus        = transfer.ErrStatus
+	ErrInvalidCountStatus    = usecase.ErrInvalidCountStatus
+	ErrInvalidABCClass       = usecase.ErrInvalidABCClass
+	ErrNothingToCount        = usecase.ErrNothingToCount
+	ErrUncountedLines        = usecase.ErrUncountedLines
+	ErrApproverRequired      = usecase.ErrApproverRequired
+	ErrTrackedCount          = usecase.ErrTrackedCount
+	ErrCountStatus           = cyclecount.ErrStatus
+
+	// Reports and forecasts.
+	ErrInvalidAgingBounds    = usecase.ErrInvalidAgingBounds
+	ErrInvalidDays           = usecase.ErrInvalidDays
+	ErrInvalidForecastMethod = usecase.ErrInvalidForecastMethod
+)
+
+var businessRules = []error{
+	ErrPriceLimit, ErrInvalidPrice, ErrUnsupportedCurrency, ErrNameIsReserved, ErrQuantityLimit,
+	ErrTrackingChange, ErrCurrencyChange, ErrQuantityManaged, ErrProductInUse, ErrInvalidPage, ErrInvalidValidity,
+	ErrCurrencyMismatch, ErrNoExchangeRate, ErrInvalidRatesFile, ErrScheduleInPast, ErrPriceChangeNotPending,
+
+	ErrInsufficientStock, ErrInvalidQuantity, ErrFractionalQuantity, ErrInvalidFactor, ErrUnknownUnit,
+	ErrBaseUnitConflict, ErrInvalidRounding, ErrLotExpired, ErrLotRequired, ErrNotLotTracked,
+	ErrLotDatesMismatch, ErrSerialCount, ErrDuplicateSerial, ErrSerialProduct, ErrNotSerialized,
+	ErrSerialStatus, ErrUnitCostOutgoing, ErrCostCurrency, ErrInvalidUnitCost,
+
+	ErrInvalidLeadTime, ErrInvalidCost, ErrInvalidMOQ, ErrSupplierInUse, ErrEmptyOrder, ErrDuplicateLine,
+	ErrMixedCurrencies, ErrNoSupplierCost, ErrBelowMOQ, ErrUnknownOrderLine, ErrOverReceipt,
+	ErrInvalidStatus, ErrInvalidPeriod, ErrPurchaseOrderStatus,
+
+	ErrInvalidSalesStatus, ErrNoPackages, ErrPackMismatch, ErrTrackingRequired, ErrSalesOrderStatus,
+	ErrInvalidReturnStatus, ErrReturnExceedsShipped, ErrInvalidDisposition, ErrDispositionMismatch,
+	ErrSerialNotReturned, ErrSerialNotShipped, ErrSerialReturned, ErrNothingToRelease,
+	ErrReleaseDisposition, ErrExceedsQuarantined, ErrReturnStatus,
+
+	ErrWarehouseCodeTaken, ErrInvalidTransferStatus, ErrSameWarehouse, ErrTrackedTransfer,
+	ErrDiscrepancyReason, ErrTransferStatus, ErrInvalidCountStatus, ErrInvalidABCClass,
+	ErrNothingToCount, ErrUncountedLines, ErrApproverRequired, ErrTrackedCount, ErrCountStatus,
+
+	ErrInvalidAgingBounds, ErrInvalidDays, ErrInvalidForecastMethod,
+}
+
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	var body struct {
+		Error     string `json:"error"`
+		ErrorCode int    `json:"errorCode"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(data, &body); err == nil && body.Error != "" {
+		apiErr.Message, apiErr.Code = body.Error, body.ErrorCode
+	} else {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// GraphQLRequest is a GraphQL operation.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLResponse is the result of a GraphQL operation. Errors of the
+// operation itself, such as exceeding the query limits, are reported in
+// Errors rather than returned.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQL runs an operation against the GraphQL endpoint, which is only served
+// when the server enables it.
+func (c *Client) GraphQL(ctx context.Context, req GraphQLRequest) (GraphQLResponse, error) {
+	var resp GraphQLResponse
+	err := c.do(ctx, http.MethodPost, "/graphql", nil, req, &resp)
+	return resp, err
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
+)
+
+// Health returns the status of the server and of each of its dependencies. A
+// server that is down or shutting down is reported, not returned as an error.
+func (c *Client) Health(ctx context.Context) (HealthReport, error) {
+	var report HealthReport
+	err := c.probe(ctx, "/health", &report)
+	return report, err
+}
+
+// Live returns nil if the server process is up.
+func (c *Client) Live(ctx context.Context) error {
+	var status struct {
+		Status string `json:"status"`
+	}
+	return c.probe(ctx, "/healthz", &status)
+}
+
+// Ready reports whether the server and its dependencies can take traffic; a
+// server that is draining on shutdown is not ready.
+func (c *Client) Ready(ctx context.Context) (bool, error) {
+	var status struct {
+		Status string `json:"status"`
+	}
+	err := c.probe(ctx, "/readyz", &status)
+	return err == nil && status.Status == health.StatusUp, err
+}
+
+// probe gets a health route, which answers 503 with a body when the server is
+// not up. It is never retried: the answer is the current state of the server.
+func (c *Client) probe(ctx context.Context, path string, out any) error {
+	resp, err := c.sendAttempts(ctx, 1, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return decodeError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s response: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PriceListInput is the body of price list create requests.
+type PriceListInput struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	// CustomerGroup limits the list to one group; the list applies to everyone if empty.
+	CustomerGroup string     `json:"customer_group,omitempty"`
+	ValidFrom     *time.Time `json:"valid_from,omitempty"`
+	ValidTo       *time.Time `json:"valid_to,omitempty"`
+}
+
+// PriceQuery selects the price GetProductPrice resolves. Empty fields mean the
+// product currency, no customer group and now.
+type PriceQuery struct {
+	Currency      string
+	CustomerGroup string
+	At            time.Time
+}
+
+// SchedulePriceInput is the body of price change requests.
+type SchedulePriceInput struct {
+	Price Money `json:"price"`
+	// ValidFrom is when the price applies; immediately if nil.
+	ValidFrom   *time.Time `json:"valid_from,omitempty"`
+	ValidTo     *time.Time `json:"valid_to,omitempty"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	ApprovedBy  string     `json:"approved_by"`
+}
+
+// CreatePriceList creates a price list and returns its ID.
+func (c *Client) CreatePriceList(ctx context.Context, in PriceListInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/price-lists", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListPriceLists returns all price lists without their items.
+func (c *Client) ListPriceLists(ctx context.Context) ([]PriceList, error) {
+	var resp dataResponse[[]PriceList]
+	err := c.do(ctx, http.MethodGet, "/price-lists", nil, nil, &resp)
+	return resp.Data, err
+}
+
+// GetPriceList returns a price list with its product prices.
+func (c *Client) GetPriceList(ctx context.Context, id int32) (PriceList, error) {
+	var resp dataResponse[PriceList]
+	err := c.do(ctx, http.MethodGet, pathf("/price-lists/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// DeletePriceList deletes a price list and all of its product prices.
+func (c *Client) DeletePriceList(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/price-lists/%d", id), nil, nil, nil)
+}
+
+// SetPriceListItem creates or replaces the price of a product in a price list;
+// the currency must match the list.
+func (c *Client) SetPriceListItem(ctx context.Context, listID, productID int32, price Money) error {
+	body := struct {
+		Price Money `json:"price"`
+	}{price}
+	return c.do(ctx, http.MethodPut, pathf("/price-lists/%d/items/%d", listID, productID), nil, body, nil)
+}
+
+func (c *Client) DeletePriceListItem(ctx context.Context, listID, productID int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/price-lists/%d/items/%d", listID, productID), nil, nil, nil)
+}
+
+// GetProductPrice resolves the price of a product from price lists, falling
+// back to its converted base price.
+func (c *Client) GetProductPrice(ctx context.Context, productID int32, q PriceQuery) (ResolvedPrice, error) {
+	query := url.Values{}
+	if q.Currency != "" {
+		query.Set("currency", q.Currency)
+	}
+	if q.CustomerGroup != "" {
+		query.Set("group", q.CustomerGroup)
+	}
+	if !q.At.IsZero() {
+		query.Set("at", q.At.Format(time.RFC3339))
+	}
+	var resp dataResponse[ResolvedPrice]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/price", productID), query, nil, &resp)
+	return resp.Data, err
+}
+
+// ListProductPrices returns the past, current and scheduled prices of a
+// product, newest first.
+func (c *Client) ListProductPrices(ctx context.Context, productID int32) ([]PriceChange, error) {
+	var resp dataResponse[[]PriceChange]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/prices", productID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// ScheduleProductPrice schedules an approved price change and returns its ID.
+func (c *Client) ScheduleProductPrice(ctx context.Context, productID int32, in SchedulePriceInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, pathf("/products/%d/prices", productID), nil, in, &resp)
+	return resp.ID, err
+}
+
+// CancelProductPrice cancels a price change that has not been activated yet.
+func (c *Client) CancelProductPrice(ctx context.Context, productID, priceID int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/products/%d/prices/%d", productID, priceID), nil, nil, nil)
+}
+
+// ListExchangeRates returns all exchange rates, newest first per currency pair.
+func (c *Client) ListExchangeRates(ctx context.Context) ([]ExchangeRate, error) {
+	var resp dataResponse[[]ExchangeRate]
+	err := c.do(ctx, http.MethodGet, "/exchange-rates", nil, nil, &resp)
+	return resp.Data, err
+}
+
+// ImportExchangeRates loads exchange rates from CSV rows with the columns
+// base,quote,rate,valid_from and returns the number imported.
+func (c *Client) ImportExchangeRates(ctx context.Context, csv io.Reader) (int, error) {
+	var resp struct {
+		Imported int `json:"imported"`
+	}
+	err := c.do(ctx, http.MethodPost, "/exchange-rates/import", nil, rawBody{contentType: "text/csv", r: csv}, &resp)
+	return resp.Imported, err
+}
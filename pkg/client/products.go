@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// ProductInput is the body of product create and update requests.
+type ProductInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       Money  `json:"price"`
+	// Quantity is only used on create, as opening stock.
+	Quantity int32 `json:"quantity"`
+	// BaseUnit is only used on create; it cannot change once stock is kept in it.
+	BaseUnit    string `json:"base_unit,omitempty"`
+	LotTracked  bool   `json:"lot_tracked"`
+	Serialized  bool   `json:"serialized"`
+	BinLocation string `json:"bin_location,omitempty"`
+	Category    string `json:"category,omitempty"`
+}
+
+// CreateProduct creates a product and returns its ID.
+func (c *Client) CreateProduct(ctx context.Context, in ProductInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/products", nil, in, &resp)
+	return resp.ID, err
+}
+
+func (c *Client) GetProduct(ctx context.Context, id int32) (Product, error) {
+	var resp dataResponse[Product]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// UpdateProduct replaces the fields of a product, except its base unit and quantity.
+func (c *Client) UpdateProduct(ctx context.Context, id int32, in ProductInput) error {
+	return c.do(ctx, http.MethodPut, pathf("/products/%d", id), nil, in, nil)
+}
+
+func (c *Client) DeleteProduct(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/products/%d", id), nil, nil, nil)
+}
+
+func (c *Client) ListProducts(ctx context.Context) ([]Product, error) {
+	var resp dataResponse[[]Product]
+	err := c.do(ctx, http.MethodGet, "/products", nil, nil, &resp)
+	return resp.Data, err
+}
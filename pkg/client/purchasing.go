@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PurchaseOrderInput is the body of purchase order create and update requests.
+type PurchaseOrderInput struct {
+	SupplierID int32 `json:"supplier_id"`
+	// Currency is the currency of the supplier costs if empty.
+	Currency  string                   `json:"currency,omitempty"`
+	Reference string                   `json:"reference,omitempty"`
+	Lines     []PurchaseOrderLineInput `json:"lines"`
+}
+
+type PurchaseOrderLineInput struct {
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+	// UnitCost is the supplier cost price if zero.
+	UnitCost Money `json:"unit_cost"`
+}
+
+// PurchaseReceiptInput is the body of purchase order receipt requests.
+type PurchaseReceiptInput struct {
+	Reference string                     `json:"reference,omitempty"`
+	Lines     []PurchaseReceiptLineInput `json:"lines"`
+}
+
+type PurchaseReceiptLineInput struct {
+	LineID   int32     `json:"line_id"`
+	Quantity int32     `json:"quantity"`
+	Lot      *LotInput `json:"lot,omitempty"`
+	Serials  []string  `json:"serials,omitempty"`
+}
+
+// PurchaseOrderFilter narrows ListPurchaseOrders; zero fields match all orders.
+type PurchaseOrderFilter struct {
+	Status     string
+	SupplierID *int32
+}
+
+// PurchaseOrderReportFilter narrows PurchaseOrderReport to a supplier and to
+// orders created in [From, To); zero fields match all orders.
+type PurchaseOrderReportFilter struct {
+	SupplierID *int32
+	From, To   time.Time
+}
+
+// CreatePurchaseOrder creates a draft purchase order and returns its ID.
+func (c *Client) CreatePurchaseOrder(ctx context.Context, in PurchaseOrderInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/purchase-orders", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListPurchaseOrders returns purchase orders without lines, newest first.
+func (c *Client) ListPurchaseOrders(ctx context.Context, f PurchaseOrderFilter) ([]PurchaseOrder, error) {
+	query := url.Values{}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	setID(query, "supplier_id", f.SupplierID)
+	var resp dataResponse[[]PurchaseOrder]
+	err := c.do(ctx, http.MethodGet, "/purchase-orders", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetPurchaseOrder returns a purchase order with its lines and received quantities.
+func (c *Client) GetPurchaseOrder(ctx context.Context, id int32) (PurchaseOrder, error) {
+	var resp dataResponse[PurchaseOrder]
+	err := c.do(ctx, http.MethodGet, pathf("/purchase-orders/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// UpdatePurchaseOrder replaces the supplier and lines of a draft order.
+func (c *Client) UpdatePurchaseOrder(ctx context.Context, id int32, in PurchaseOrderInput) error {
+	return c.do(ctx, http.MethodPut, pathf("/purchase-orders/%d", id), nil, in, nil)
+}
+
+// SubmitPurchaseOrder sends a draft order to the supplier. Without expectedAt
+// the order is expected after the supplier lead time.
+func (c *Client) SubmitPurchaseOrder(ctx context.Context, id int32, expectedAt *time.Time) error {
+	var body any
+	if expectedAt != nil {
+		body = struct {
+			ExpectedAt *time.Time `json:"expected_at"`
+		}{expectedAt}
+	}
+	return c.do(ctx, http.MethodPost, pathf("/purchase-orders/%d/submit", id), nil, body, nil)
+}
+
+// ReceivePurchaseOrder adds delivered quantities to stock and returns the
+// updated order.
+func (c *Client) ReceivePurchaseOrder(ctx context.Context, id int32, in PurchaseReceiptInput) (PurchaseOrder, error) {
+	var resp dataResponse[PurchaseOrder]
+	err := c.do(ctx, http.MethodPost, pathf("/purchase-orders/%d/receipts", id), nil, in, &resp)
+	return resp.Data, err
+}
+
+// ClosePurchaseOrder closes a received order, or short-closes a partially
+// received one.
+func (c *Client) ClosePurchaseOrder(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodPost, pathf("/purchase-orders/%d/close", id), nil, nil, nil)
+}
+
+// PurchaseOrderReport counts orders, quantities and values per status and currency.
+func (c *Client) PurchaseOrderReport(ctx context.Context, f PurchaseOrderReportFilter) ([]PurchaseOrderSummary, error) {
+	query := url.Values{}
+	setID(query, "supplier_id", f.SupplierID)
+	setTime(query, "from", f.From)
+	setTime(query, "to", f.To)
+	var resp dataResponse[[]PurchaseOrderSummary]
+	err := c.do(ctx, http.MethodGet, "/reports/purchase-orders", query, nil, &resp)
+	return resp.Data, err
+}
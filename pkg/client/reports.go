@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportPeriod bounds the turnover and ABC reports. A zero To means now and a
+// zero From a year before To.
+type ReportPeriod struct {
+	From, To time.Time
+}
+
+func (p ReportPeriod) query() url.Values {
+	query := url.Values{}
+	setTime(query, "from", p.From)
+	setTime(query, "to", p.To)
+	return query
+}
+
+// ValuationReport values the stock of every product as of a time with the
+// configured cost method; a zero asOf means now.
+func (c *Client) ValuationReport(ctx context.Context, asOf time.Time) (ValuationReport, error) {
+	query := url.Values{}
+	setTime(query, "as_of", asOf)
+	var resp dataResponse[ValuationReport]
+	err := c.do(ctx, http.MethodGet, "/reports/valuation", query, nil, &resp)
+	return resp.Data, err
+}
+
+// TurnoverReport returns, per product, the turnover ratio and days of
+// inventory over a period.
+func (c *Client) TurnoverReport(ctx context.Context, p ReportPeriod) ([]TurnoverRow, error) {
+	var resp dataResponse[[]TurnoverRow]
+	err := c.do(ctx, http.MethodGet, "/reports/turnover", p.query(), nil, &resp)
+	return resp.Data, err
+}
+
+// AgingReport splits the stock on hand of every product into age bands with
+// the given ascending bounds in days; 30, 60, 90 and 180 if none.
+func (c *Client) AgingReport(ctx context.Context, bounds ...int32) ([]AgingRow, error) {
+	var resp dataResponse[[]AgingRow]
+	err := c.do(ctx, http.MethodGet, "/reports/aging", agingQuery(bounds), nil, &resp)
+	return resp.Data, err
+}
+
+// DeadStockReport lists products with stock and no movement in the given
+// number of days, least recently moved first; 90 days if zero.
+func (c *Client) DeadStockReport(ctx context.Context, days int) ([]DeadStockRow, error) {
+	var resp dataResponse[[]DeadStockRow]
+	err := c.do(ctx, http.MethodGet, "/reports/dead-stock", deadStockQuery(days), nil, &resp)
+	return resp.Data, err
+}
+
+// ABCReport classifies the products issued in a period by consumption value.
+func (c *Client) ABCReport(ctx context.Context, p ReportPeriod) ([]ABCRow, error) {
+	var resp dataResponse[[]ABCRow]
+	err := c.do(ctx, http.MethodGet, "/reports/abc", p.query(), nil, &resp)
+	return resp.Data, err
+}
+
+// TurnoverReportCSV writes the turnover report to w as CSV.
+func (c *Client) TurnoverReportCSV(ctx context.Context, w io.Writer, p ReportPeriod) error {
+	return c.reportCSV(ctx, w, "/reports/turnover", p.query())
+}
+
+// AgingReportCSV writes the aging report to w as CSV, one row per age band.
+func (c *Client) AgingReportCSV(ctx context.Context, w io.Writer, bounds ...int32) error {
+	return c.reportCSV(ctx, w, "/reports/aging", agingQuery(bounds))
+}
+
+// DeadStockReportCSV writes the dead stock report to w as CSV.
+func (c *Client) DeadStockReportCSV(ctx context.Context, w io.Writer, days int) error {
+	return c.reportCSV(ctx, w, "/reports/dead-stock", deadStockQuery(days))
+}
+
+// ABCReportCSV writes the ABC report to w as CSV.
+func (c *Client) ABCReportCSV(ctx context.Context, w io.Writer, p ReportPeriod) error {
+	return c.reportCSV(ctx, w, "/reports/abc", p.query())
+}
+
+func (c *Client) reportCSV(ctx context.Context, w io.Writer, path string, query url.Values) error {
+	query.Set("format", "csv")
+	resp, err := c.send(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return decodeError(resp)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+func agingQuery(bounds []int32) url.Values {
+	query := url.Values{}
+	if len(bounds) > 0 {
+		parts := make([]string, len(bounds))
+		for i, b := range bounds {
+			parts[i] = strconv.Itoa(int(b))
+		}
+		query.Set("bounds", strings.Join(parts, ","))
+	}
+	return query
+}
+
+func deadStockQuery(days int) url.Values {
+	query := url.Values{}
+	if days != 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	return query
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ReturnInput is the body of return create requests.
+type ReturnInput struct {
+	OrderID int32             `json:"order_id"`
+	Reason  string            `json:"reason,omitempty"`
+	Lines   []ReturnLineInput `json:"lines"`
+}
+
+type ReturnLineInput struct {
+	OrderLineID int32    `json:"order_line_id"`
+	Quantity    int32    `json:"quantity"`
+	Serials     []string `json:"serials,omitempty"`
+}
+
+// InspectionLineInput sorts returned units of a line into a disposition:
+// restock, quarantine or scrap. Goods released from quarantine are restocked or
+// scrapped.
+type InspectionLineInput struct {
+	LineID      int32     `json:"line_id"`
+	Disposition string    `json:"disposition"`
+	Quantity    int32     `json:"quantity"`
+	Lot         *LotInput `json:"lot,omitempty"`
+	Serials     []string  `json:"serials,omitempty"`
+}
+
+// ReturnFilter narrows ListReturns; zero fields match all returns.
+type ReturnFilter struct {
+	Status  string
+	OrderID *int32
+}
+
+// CreateReturn opens a return against lines of a shipped sales order and
+// returns its ID.
+func (c *Client) CreateReturn(ctx context.Context, in ReturnInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/returns", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListReturns returns returns without lines, newest first.
+func (c *Client) ListReturns(ctx context.Context, f ReturnFilter) ([]Return, error) {
+	query := url.Values{}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	setID(query, "order_id", f.OrderID)
+	var resp dataResponse[[]Return]
+	err := c.do(ctx, http.MethodGet, "/returns", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetReturn returns a return with its lines and dispositions.
+func (c *Client) GetReturn(ctx context.Context, id int32) (Return, error) {
+	var resp dataResponse[Return]
+	err := c.do(ctx, http.MethodGet, pathf("/returns/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// InspectReturn sorts every returned unit into a disposition and posts the
+// stock movements.
+func (c *Client) InspectReturn(ctx context.Context, id int32, lines []InspectionLineInput) (Return, error) {
+	body := struct {
+		Lines []InspectionLineInput `json:"lines"`
+	}{lines}
+	var resp dataResponse[Return]
+	err := c.do(ctx, http.MethodPost, pathf("/returns/%d/inspection", id), nil, body, &resp)
+	return resp.Data, err
+}
+
+// ReleaseQuarantine takes quarantined goods of an inspected return out of
+// quarantine; each line is restocked or scrapped.
+func (c *Client) ReleaseQuarantine(ctx context.Context, id int32, lines []InspectionLineInput) (Return, error) {
+	body := struct {
+		Lines []InspectionLineInput `json:"lines"`
+	}{lines}
+	var resp dataResponse[Return]
+	err := c.do(ctx, http.MethodPost, pathf("/returns/%d/quarantine/release", id), nil, body, &resp)
+	return resp.Data, err
+}
+
+// CancelReturn cancels an open return; its quantities can be returned again.
+func (c *Client) CancelReturn(ctx context.Context, id int32) (Return, error) {
+	var resp dataResponse[Return]
+	err := c.do(ctx, http.MethodPost, pathf("/returns/%d/cancel", id), nil, nil, &resp)
+	return resp.Data, err
+}
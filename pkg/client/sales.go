@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SalesOrderInput is the body of sales order create requests.
+type SalesOrderInput struct {
+	Customer      string `json:"customer"`
+	CustomerGroup string `json:"customer_group,omitempty"`
+	// Currency is the currency of the resolved prices if empty.
+	Currency  string                `json:"currency,omitempty"`
+	Reference string                `json:"reference,omitempty"`
+	Lines     []SalesOrderLineInput `json:"lines"`
+}
+
+type SalesOrderLineInput struct {
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+	// UnitPrice is the price resolved for the customer group if zero.
+	UnitPrice Money `json:"unit_price"`
+}
+
+// PackInput is the body of sales order pack requests.
+type PackInput struct {
+	Packages int32           `json:"packages"`
+	Lines    []PackLineInput `json:"lines"`
+}
+
+type PackLineInput struct {
+	LineID   int32 `json:"line_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+// ShipInput is the body of sales order ship requests.
+type ShipInput struct {
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number"`
+	// Lines names the lots and serial numbers shipped; lot-tracked lines
+	// without a lot are issued first-expiry-first-out.
+	Lines []ShipLineInput `json:"lines,omitempty"`
+}
+
+type ShipLineInput struct {
+	LineID  int32     `json:"line_id"`
+	Lot     *LotInput `json:"lot,omitempty"`
+	Serials []string  `json:"serials,omitempty"`
+}
+
+// CreateSalesOrder creates a draft sales order and returns its ID.
+func (c *Client) CreateSalesOrder(ctx context.Context, in SalesOrderInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/sales-orders", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListSalesOrders returns sales orders without lines, newest first; an empty
+// status matches all orders.
+func (c *Client) ListSalesOrders(ctx context.Context, status string) ([]SalesOrder, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", status)
+	}
+	var resp dataResponse[[]SalesOrder]
+	err := c.do(ctx, http.MethodGet, "/sales-orders", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetSalesOrder returns a sales order with its lines and allocated and shipped quantities.
+func (c *Client) GetSalesOrder(ctx context.Context, id int32) (SalesOrder, error) {
+	var resp dataResponse[SalesOrder]
+	err := c.do(ctx, http.MethodGet, pathf("/sales-orders/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// AllocateSalesOrder reserves stock for every line of a draft order.
+func (c *Client) AllocateSalesOrder(ctx context.Context, id int32) (SalesOrder, error) {
+	return c.salesOrderAction(ctx, id, "allocate", nil)
+}
+
+// StartPicking releases an allocated order for picking and returns its pick list.
+func (c *Client) StartPicking(ctx context.Context, id int32) (PickList, error) {
+	var resp dataResponse[PickList]
+	err := c.do(ctx, http.MethodPost, pathf("/sales-orders/%d/pick", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// GetPickList returns the allocated lines of an order grouped by bin location.
+func (c *Client) GetPickList(ctx context.Context, id int32) (PickList, error) {
+	var resp dataResponse[PickList]
+	err := c.do(ctx, http.MethodGet, pathf("/sales-orders/%d/pick-list", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// PackSalesOrder confirms the packed quantity of every line and the number of packages.
+func (c *Client) PackSalesOrder(ctx context.Context, id int32, in PackInput) (SalesOrder, error) {
+	return c.salesOrderAction(ctx, id, "pack", in)
+}
+
+// ShipSalesOrder hands a packed order to the carrier and issues its stock.
+func (c *Client) ShipSalesOrder(ctx context.Context, id int32, in ShipInput) (SalesOrder, error) {
+	return c.salesOrderAction(ctx, id, "ship", in)
+}
+
+// CancelSalesOrder cancels an order that has not shipped and releases its allocation.
+func (c *Client) CancelSalesOrder(ctx context.Context, id int32) (SalesOrder, error) {
+	return c.salesOrderAction(ctx, id, "cancel", nil)
+}
+
+func (c *Client) salesOrderAction(ctx context.Context, id int32, action string, body any) (SalesOrder, error) {
+	var resp dataResponse[SalesOrder]
+	err := c.do(ctx, http.MethodPost, pathf("/sales-orders/%d/%s", id, action), nil, body, &resp)
+	return resp.Data, err
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// MovementInput is the body of stock receipt, issue and adjustment requests.
+type MovementInput struct {
+	// Quantity is a decimal in Unit, e.g. "2.5"; adjustments carry a sign.
+	Quantity string `json:"quantity"`
+	// Unit is the product base unit if empty.
+	Unit      string    `json:"unit,omitempty"`
+	Reference string    `json:"reference,omitempty"`
+	Lot       *LotInput `json:"lot,omitempty"`
+	// WarehouseID is the default warehouse if zero.
+	WarehouseID int32 `json:"warehouse_id,omitempty"`
+	// Serials lists every unit moved; required for serialized products.
+	Serials []string `json:"serials,omitempty"`
+	// UnitCost is the cost of one base unit of incoming stock.
+	UnitCost *Money `json:"unit_cost,omitempty"`
+}
+
+// LotInput names the lot of a lot-tracked product; dates (YYYY-MM-DD) are only
+// used when the lot is received for the first time.
+type LotInput struct {
+	Number         string `json:"lot_number"`
+	ManufacturedAt string `json:"manufactured_at,omitempty"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+}
+
+func (c *Client) ReceiveStock(ctx context.Context, productID int32, in MovementInput) (StockMovement, error) {
+	return c.recordMovement(ctx, pathf("/products/%d/stock/receipts", productID), in)
+}
+
+func (c *Client) IssueStock(ctx context.Context, productID int32, in MovementInput) (StockMovement, error) {
+	return c.recordMovement(ctx, pathf("/products/%d/stock/issues", productID), in)
+}
+
+// AdjustStock corrects the stock of a product by a signed quantity.
+func (c *Client) AdjustStock(ctx context.Context, productID int32, in MovementInput) (StockMovement, error) {
+	return c.recordMovement(ctx, pathf("/products/%d/stock/adjustments", productID), in)
+}
+
+// ListStockMovements returns the stock movements of a product, newest first.
+func (c *Client) ListStockMovements(ctx context.Context, productID int32) ([]StockMovement, error) {
+	var resp dataResponse[[]StockMovement]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/stock/movements", productID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+func (c *Client) recordMovement(ctx context.Context, path string, in MovementInput) (StockMovement, error) {
+	var resp dataResponse[StockMovement]
+	err := c.do(ctx, http.MethodPost, path, nil, in, &resp)
+	return resp.Data, err
+}
+
+// UnitInput is the body of unit requests.
+type UnitInput struct {
+	// Factor is the number of base units in one unit, e.g. "12" or "0.5".
+	Factor string `json:"factor"`
+	// Rounding is one of reject, down, up and half_up; reject if empty.
+	Rounding string `json:"rounding,omitempty"`
+}
+
+// ListUnits returns the units a product can be moved in, base unit first.
+func (c *Client) ListUnits(ctx context.Context, productID int32) ([]Unit, error) {
+	var resp dataResponse[[]Unit]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/units", productID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// SetUnit creates or replaces a unit of a product.
+func (c *Client) SetUnit(ctx context.Context, productID int32, code string, in UnitInput) error {
+	return c.do(ctx, http.MethodPut, pathf("/products/%d/units/%s", productID, code), nil, in, nil)
+}
+
+func (c *Client) DeleteUnit(ctx context.Context, productID int32, code string) error {
+	return c.do(ctx, http.MethodDelete, pathf("/products/%d/units/%s", productID, code), nil, nil, nil)
+}
+
+// ListLots returns the lots of a product, first to expire first.
+func (c *Client) ListLots(ctx context.Context, productID int32) ([]Lot, error) {
+	var resp dataResponse[[]Lot]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/lots", productID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// ListExpiringLots returns lots with stock that expire within a period such as
+// "30d", "2w" or "72h", including already expired ones; 30 days if empty.
+func (c *Client) ListExpiringLots(ctx context.Context, within string) ([]Lot, error) {
+	query := url.Values{}
+	if within != "" {
+		query.Set("within", within)
+	}
+	var resp dataResponse[[]Lot]
+	err := c.do(ctx, http.MethodGet, "/lots/expiring", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetSerial returns a serialized unit with all its stock movements, oldest first.
+func (c *Client) GetSerial(ctx context.Context, serial string) (SerialTrail, error) {
+	var resp dataResponse[SerialTrail]
+	err := c.do(ctx, http.MethodGet, pathf("/serials/%s", serial), nil, nil, &resp)
+	return resp.Data, err
+}
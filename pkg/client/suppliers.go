@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SupplierInput is the body of supplier create and update requests.
+type SupplierInput struct {
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Address      string `json:"address,omitempty"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+	PaymentTerms string `json:"payment_terms,omitempty"`
+}
+
+// ProductSupplierInput is the body of product supplier requests.
+type ProductSupplierInput struct {
+	SupplierSKU string `json:"supplier_sku,omitempty"`
+	CostPrice   Money  `json:"cost_price"`
+	MinOrderQty int32  `json:"min_order_quantity"`
+}
+
+// CreateSupplier creates a supplier and returns its ID.
+func (c *Client) CreateSupplier(ctx context.Context, in SupplierInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/suppliers", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListSuppliers returns all suppliers ordered by name.
+func (c *Client) ListSuppliers(ctx context.Context) ([]Supplier, error) {
+	var resp dataResponse[[]Supplier]
+	err := c.do(ctx, http.MethodGet, "/suppliers", nil, nil, &resp)
+	return resp.Data, err
+}
+
+func (c *Client) GetSupplier(ctx context.Context, id int32) (Supplier, error) {
+	var resp dataResponse[Supplier]
+	err := c.do(ctx, http.MethodGet, pathf("/suppliers/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+func (c *Client) UpdateSupplier(ctx context.Context, id int32, in SupplierInput) error {
+	return c.do(ctx, http.MethodPut, pathf("/suppliers/%d", id), nil, in, nil)
+}
+
+// DeleteSupplier deletes a supplier together with its product links.
+func (c *Client) DeleteSupplier(ctx context.Context, id int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/suppliers/%d", id), nil, nil, nil)
+}
+
+// ListProductSuppliers returns the suppliers of a product, cheapest first and
+// then by lead time.
+func (c *Client) ListProductSuppliers(ctx context.Context, productID int32) ([]ProductSupplier, error) {
+	var resp dataResponse[[]ProductSupplier]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/suppliers", productID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// SetProductSupplier creates or replaces the terms a supplier sells a product on.
+func (c *Client) SetProductSupplier(ctx context.Context, productID, supplierID int32, in ProductSupplierInput) error {
+	return c.do(ctx, http.MethodPut, pathf("/products/%d/suppliers/%d", productID, supplierID), nil, in, nil)
+}
+
+func (c *Client) DeleteProductSupplier(ctx context.Context, productID, supplierID int32) error {
+	return c.do(ctx, http.MethodDelete, pathf("/products/%d/suppliers/%d", productID, supplierID), nil, nil, nil)
+}
+
+// GetProductForecast returns the demand forecast and reorder point of a
+// product. method is one of moving_average, exponential_smoothing and
+// seasonal_naive; the configured one if empty.
+func (c *Client) GetProductForecast(ctx context.Context, productID int32, method string) (ForecastSuggestion, error) {
+	query := url.Values{}
+	if method != "" {
+		query.Set("method", method)
+	}
+	var resp dataResponse[ForecastSuggestion]
+	err := c.do(ctx, http.MethodGet, pathf("/products/%d/forecast", productID), query, nil, &resp)
+	return resp.Data, err
+}
+
+// ReplenishmentSuggestions returns the products due for reordering, grouped
+// into draft purchase orders per supplier. A nil supplierID means all suppliers.
+func (c *Client) ReplenishmentSuggestions(ctx context.Context, method string, supplierID *int32) (ReplenishmentPlan, error) {
+	query := url.Values{}
+	if method != "" {
+		query.Set("method", method)
+	}
+	setID(query, "supplier_id", supplierID)
+	var resp dataResponse[ReplenishmentPlan]
+	err := c.do(ctx, http.MethodGet, "/replenishment/suggestions", query, nil, &resp)
+	return resp.Data, err
+}
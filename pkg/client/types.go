@@ -0,0 +1,75 @@
+package client
+
+import (
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/cyclecount"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/forecast"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/money"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/pricing"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/product"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/purchase"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/report"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/rma"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/sales"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/stock"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/supplier"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/transfer"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/valuation"
+	"github.com/Gen1usBruh/warehouse-api/internal/domain/warehouse"
+	"github.com/Gen1usBruh/warehouse-api/internal/health"
+)
+
+// The API returns the domain types of the server, so they are shared rather
+// than copied and cannot drift from what the server sends.
+type (
+	Money   = money.Money
+	Product = product.Product
+
+	Unit          = stock.Unit
+	StockMovement = stock.Movement
+	Lot           = stock.Lot
+	LotAllocation = stock.LotAllocation
+	Serial        = stock.Serial
+	SerialTrail   = stock.SerialTrail
+
+	PriceList     = pricing.PriceList
+	ResolvedPrice = pricing.ResolvedPrice
+	PriceChange   = pricing.PriceChange
+	ExchangeRate  = pricing.ExchangeRate
+
+	Supplier        = supplier.Supplier
+	ProductSupplier = supplier.ProductSupplier
+
+	PurchaseOrder          = purchase.Order
+	PurchaseOrderLine      = purchase.Line
+	PurchaseOrderSummary   = purchase.StatusSummary
+	SalesOrder             = sales.Order
+	SalesOrderLine         = sales.Line
+	PickList               = sales.PickList
+	Return                 = rma.Return
+	ReturnLine             = rma.Line
+	Warehouse              = warehouse.Warehouse
+	WarehouseStock         = warehouse.Stock
+	Transfer               = transfer.Transfer
+	TransferLine           = transfer.Line
+	CountSession           = cyclecount.Session
+	CountLine              = cyclecount.Line
+	ValuationReport        = valuation.Report
+	ProductValue           = valuation.ProductValue
+	TurnoverRow            = report.Turnover
+	AgingRow               = report.Aging
+	AgingBucket            = report.AgingBucket
+	DeadStockRow           = report.DeadStock
+	ABCRow                 = report.ABC
+	ForecastSuggestion     = forecast.Suggestion
+	ReplenishmentPlan      = forecast.Plan
+	ReplenishmentDraft     = forecast.DraftOrder
+	ReplenishmentDraftLine = forecast.DraftLine
+
+	HealthReport = health.Report
+	HealthResult = health.Result
+)
+
+// ParseMoney parses a decimal amount such as "12.50" in an ISO 4217 currency.
+func ParseMoney(amount, currency string) (Money, error) {
+	return money.Parse(amount, currency)
+}
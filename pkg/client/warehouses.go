@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// TransferInput is the body of transfer create requests.
+type TransferInput struct {
+	FromWarehouseID int32               `json:"from_warehouse_id"`
+	ToWarehouseID   int32               `json:"to_warehouse_id"`
+	Reference       string              `json:"reference,omitempty"`
+	Lines           []TransferLineInput `json:"lines"`
+}
+
+type TransferLineInput struct {
+	ProductID int32 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+// TransferReceiptLineInput is the quantity of a line received. A quantity
+// other than the one shipped needs a reason.
+type TransferReceiptLineInput struct {
+	LineID   int32  `json:"line_id"`
+	Quantity int32  `json:"quantity"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// TransferFilter narrows ListTransfers; zero fields match all transfers.
+type TransferFilter struct {
+	Status string
+	// WarehouseID matches the source or destination warehouse.
+	WarehouseID *int32
+}
+
+// CreateWarehouse creates a warehouse with a unique code and returns its ID.
+func (c *Client) CreateWarehouse(ctx context.Context, code, name string) (int32, error) {
+	body := struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}{code, name}
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/warehouses", nil, body, &resp)
+	return resp.ID, err
+}
+
+func (c *Client) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
+	var resp dataResponse[[]Warehouse]
+	err := c.do(ctx, http.MethodGet, "/warehouses", nil, nil, &resp)
+	return resp.Data, err
+}
+
+// GetWarehouseStock returns the quantity of every product on hand at a
+// warehouse and the quantity in transit to it.
+func (c *Client) GetWarehouseStock(ctx context.Context, id int32) ([]WarehouseStock, error) {
+	var resp dataResponse[[]WarehouseStock]
+	err := c.do(ctx, http.MethodGet, pathf("/warehouses/%d/stock", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// CreateTransfer creates a draft transfer between warehouses and returns its ID.
+func (c *Client) CreateTransfer(ctx context.Context, in TransferInput) (int32, error) {
+	var resp idResponse
+	err := c.do(ctx, http.MethodPost, "/transfers", nil, in, &resp)
+	return resp.ID, err
+}
+
+// ListTransfers returns transfers without lines, newest first.
+func (c *Client) ListTransfers(ctx context.Context, f TransferFilter) ([]Transfer, error) {
+	query := url.Values{}
+	if f.Status != "" {
+		query.Set("status", f.Status)
+	}
+	setID(query, "warehouse_id", f.WarehouseID)
+	var resp dataResponse[[]Transfer]
+	err := c.do(ctx, http.MethodGet, "/transfers", query, nil, &resp)
+	return resp.Data, err
+}
+
+// ListOpenTransfers returns draft and in-transit transfers with their lines,
+// oldest first. A nil warehouseID means all warehouses.
+func (c *Client) ListOpenTransfers(ctx context.Context, warehouseID *int32) ([]Transfer, error) {
+	query := url.Values{}
+	setID(query, "warehouse_id", warehouseID)
+	var resp dataResponse[[]Transfer]
+	err := c.do(ctx, http.MethodGet, "/transfers/open", query, nil, &resp)
+	return resp.Data, err
+}
+
+// GetTransfer returns a transfer with its lines and discrepancies.
+func (c *Client) GetTransfer(ctx context.Context, id int32) (Transfer, error) {
+	var resp dataResponse[Transfer]
+	err := c.do(ctx, http.MethodGet, pathf("/transfers/%d", id), nil, nil, &resp)
+	return resp.Data, err
+}
+
+// ShipTransfer issues every line from the source warehouse; the goods are in
+// transit until received.
+func (c *Client) ShipTransfer(ctx context.Context, id int32, carrier, trackingNumber string) (Transfer, error) {
+	body := struct {
+		Carrier        string `json:"carrier,omitempty"`
+		TrackingNumber string `json:"tracking_number,omitempty"`
+	}{carrier, trackingNumber}
+	return c.transferAction(ctx, id, "ship", body)
+}
+
+// ReceiveTransfer books the received quantities into the destination warehouse
+// and completes the transfer. Lines left out were not received at all.
+func (c *Client) ReceiveTransfer(ctx context.Context, id int32, lines []TransferReceiptLineInput) (Transfer, error) {
+	body := struct {
+		Lines []TransferReceiptLineInput `json:"lines,omitempty"`
+	}{lines}
+	return c.transferAction(ctx, id, "receipts", body)
+}
+
+// CancelTransfer cancels a transfer that has not been shipped.
+func (c *Client) CancelTransfer(ctx context.Context, id int32) (Transfer, error) {
+	return c.transferAction(ctx, id, "cancel", nil)
+}
+
+func (c *Client) transferAction(ctx context.Context, id int32, action string, body any) (Transfer, error) {
+	var resp dataResponse[Transfer]
+	err := c.do(ctx, http.MethodPost, pathf("/transfers/%d/%s", id, action), nil, body, &resp)
+	return resp.Data, err
+}